@@ -70,11 +70,11 @@ func TestAirgapListImages(t *testing.T) {
 					defaults.Pause.URI(),
 					defaults.KubeRouter.CNI.URI(),
 					defaults.Calico.CNI.URI(),
-					defaults.PushGateway.URI(),
 					defaultEnvoyImage,
 				},
 				notContained: []string{
 					defaults.Windows.KubeProxy.URI(),
+					defaults.PushGateway.URI(),
 				},
 			},
 			{
@@ -82,9 +82,9 @@ func TestAirgapListImages(t *testing.T) {
 				args: []string{"--all", "--platform=linux/arm/v7"},
 				contained: []string{
 					defaults.KubeProxy.URI(),
-					defaults.PushGateway.URI(),
 				},
 				notContained: []string{
+					defaults.PushGateway.URI(),
 					defaultEnvoyImage,
 				},
 			},
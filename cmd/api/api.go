@@ -13,6 +13,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -22,7 +23,10 @@ import (
 
 	"github.com/k0sproject/k0s/cmd/internal"
 	mw "github.com/k0sproject/k0s/internal/pkg/middleware"
+	etcdv1beta1 "github.com/k0sproject/k0s/pkg/apis/etcd/v1beta1"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	k0sclientset "github.com/k0sproject/k0s/pkg/client/clientset"
+	etcdclient "github.com/k0sproject/k0s/pkg/client/clientset/typed/etcd/v1beta1"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/etcd"
@@ -31,8 +35,10 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	tokenutil "k8s.io/cluster-bootstrap/token/util"
+	nodeutil "k8s.io/component-helpers/node/util"
 	bootstraptokenv1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/bootstraptoken/v1"
 
 	"github.com/sirupsen/logrus"
@@ -134,7 +140,15 @@ func loadRuntimeConfig(log logrus.FieldLogger, stdin io.Reader) (*config.Runtime
 
 func buildServer(log logrus.FieldLogger, k0sVars *config.CfgVars, nodeConfig *v1beta1.ClusterConfig) (*http.Server, error) {
 	// Single kube client for whole lifetime of the API
-	client, err := kubeutil.NewClientFromFile(k0sVars.AdminKubeConfigPath)
+	restConfig, err := kubeutil.ClientConfig(kubeutil.KubeconfigFromFile(k0sVars.AdminKubeConfigPath))
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	k0sClient, err := k0sclientset.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +162,7 @@ func buildServer(log logrus.FieldLogger, k0sVars *config.CfgVars, nodeConfig *v1
 		// Only mount the etcd handler if we're running on internal etcd storage
 		// by default the mux will return 404 back which the caller should handle
 		mux.Handle(prefix+"/etcd/members", mw.AllowMethods(http.MethodPost)(
-			authMiddleware(etcdHandler(log, k0sVars.CertRootDir, k0sVars.EtcdCertDir), log, secrets, "controller-join")))
+			authMiddleware(etcdHandler(log, k0sVars.CertRootDir, k0sVars.EtcdCertDir, k0sClient.EtcdV1beta1().EtcdMembers()), log, secrets, "controller-join")))
 	}
 
 	if storage.IsJoinable() {
@@ -182,7 +196,7 @@ func buildServer(log logrus.FieldLogger, k0sVars *config.CfgVars, nodeConfig *v1
 	}, nil
 }
 
-func etcdHandler(log logrus.FieldLogger, certRootDir, etcdCertDir string) http.Handler {
+func etcdHandler(log logrus.FieldLogger, certRootDir, etcdCertDir string, etcdMembers etcdclient.EtcdMemberInterface) http.Handler {
 	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 		ctx := req.Context()
 		var etcdReq v1beta1.EtcdRequest
@@ -205,12 +219,25 @@ func etcdHandler(log logrus.FieldLogger, certRootDir, etcdCertDir string) http.H
 		}
 		defer etcdClient.Close()
 
-		memberList, err := etcdClient.AddMemberAsLearner(ctx, etcdReq.Node, etcdReq.PeerAddress)
+		members, err := etcdClient.ListMembers(ctx)
+		if err != nil {
+			sendError(err, resp)
+			return
+		}
+		if err := checkMemberName(ctx, etcdMembers, members, &etcdReq); err != nil {
+			sendError(err, resp, http.StatusConflict)
+			return
+		}
+
+		memberID, memberList, err := etcdClient.AddMemberAsLearner(ctx, etcdReq.Node, etcdReq.PeerAddress)
 		if err != nil {
 			sendError(err, resp)
 			return
 		}
 
+		// The joining controller must not wait for the Kubernetes API.
+		go recordLearner(context.WithoutCancel(ctx), log, etcdMembers, &etcdReq, memberID)
+
 		etcdResp := v1beta1.EtcdResponse{
 			InitialCluster: memberList,
 		}
@@ -239,6 +266,84 @@ func etcdHandler(log logrus.FieldLogger, certRootDir, etcdCertDir string) http.H
 	})
 }
 
+// Checks that there's no other etcd member with the same name, but a different
+// peer address. This is the case when a failed controller is replaced by a new
+// one. The new controller can only join after the old member has been removed,
+// which the EtcdMemberReconciler does for members marked for replacement. The
+// joining controller keeps on retrying in the meantime.
+func checkMemberName(ctx context.Context, client etcdclient.EtcdMemberInterface, members []etcd.Member, req *v1beta1.EtcdRequest) error {
+	idx := slices.IndexFunc(members, func(m etcd.Member) bool {
+		return m.Name == req.Node && m.PeerURL != req.PeerAddress
+	})
+	if idx < 0 {
+		return nil
+	}
+	existing := &members[idx]
+
+	if name, err := nodeutil.GetHostname(req.Node); err == nil {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if member, err := client.Get(ctx, name, metav1.GetOptions{}); err == nil && member.Spec.Replace {
+			return fmt.Errorf("etcd member %s with peer address %s is being replaced, try again later", req.Node, existing.PeerURL)
+		}
+	}
+
+	return fmt.Errorf("etcd member %s already exists with peer address %s, mark its EtcdMember object for replacement in order to replace it", req.Node, existing.PeerURL)
+}
+
+// Records a freshly added learner in its EtcdMember object, so that the join
+// progress is visible even before the joining controller is up and running.
+// The EtcdMemberReconciler takes over from there and promotes the learner to a
+// voting member as soon as it has caught up with the leader. This is
+// best-effort only: it runs in the background, so that a slow or unavailable
+// Kubernetes API doesn't hold up the join request, and failures are only
+// logged, as the joining controller creates the object on its own, too.
+func recordLearner(ctx context.Context, log logrus.FieldLogger, client etcdclient.EtcdMemberInterface, req *v1beta1.EtcdRequest, memberID uint64) {
+	name, err := nodeutil.GetHostname(req.Node)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get name for EtcdMember object")
+		return
+	}
+	log = log.WithField("name", name)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	member, err := client.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		member, err = client.Create(ctx, &etcdv1beta1.EtcdMember{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		}, metav1.CreateOptions{})
+	case err == nil && (member.Spec.Leave || member.Spec.Replace):
+		// Don't let a stale leave or replace request remove the new member
+		// again. The object's history is carried over to the new member.
+		member.Spec.Leave, member.Spec.Replace = false, false
+		member, err = client.Update(ctx, member, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		log.WithError(err).Warn("Failed to record learner in EtcdMember object")
+		return
+	}
+
+	// Store the bare address, like the joining controller does.
+	peerAddress := req.PeerAddress
+	if u, err := url.Parse(peerAddress); err == nil && u.Hostname() != "" {
+		peerAddress = u.Hostname()
+	}
+
+	now := time.Now()
+	member.Status.PeerAddress = peerAddress
+	member.Status.MemberID = strconv.FormatUint(memberID, 16)
+	member.Status.ReconcileStatus = ""
+	member.Status.Message = ""
+	member.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionFalse, "Member added as learner", now)
+	member.Status.SetCondition(etcdv1beta1.ConditionTypeCaughtUp, etcdv1beta1.ConditionFalse, "Waiting for the learner to start", now)
+	if _, err := client.UpdateStatus(ctx, member, metav1.UpdateOptions{}); err != nil {
+		log.WithError(err).Warn("Failed to record learner in EtcdMember status")
+	}
+}
+
 func caHandler(certRootDir string) http.Handler {
 	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
 		caResp := v1beta1.CaResponse{}
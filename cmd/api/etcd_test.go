@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	etcdv1beta1 "github.com/k0sproject/k0s/pkg/apis/etcd/v1beta1"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordLearner(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	req := &v1beta1.EtcdRequest{Node: "Controller-2", PeerAddress: "https://10.0.0.2:2380"}
+
+	assertLearner := func(t *testing.T, member *etcdv1beta1.EtcdMember) {
+		assert.Equal(t, "10.0.0.2", member.Status.PeerAddress)
+		assert.Equal(t, "2a", member.Status.MemberID)
+		for _, conditionType := range []etcdv1beta1.ConditionType{etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionTypeCaughtUp} {
+			if condition := member.Status.GetCondition(conditionType); assert.NotNil(t, condition, "%s", conditionType) {
+				assert.Equal(t, etcdv1beta1.ConditionFalse, condition.Status, "%s", conditionType)
+			}
+		}
+	}
+
+	t.Run("CreatesObject", func(t *testing.T) {
+		client := testutil.NewFakeClientFactory().K0sClient.EtcdV1beta1().EtcdMembers()
+
+		recordLearner(t.Context(), log, client, req, 0x2a)
+
+		member, err := client.Get(t.Context(), "controller-2", metav1.GetOptions{})
+		require.NoError(t, err)
+		assertLearner(t, member)
+	})
+
+	t.Run("ResetsStaleObject", func(t *testing.T) {
+		stale := &etcdv1beta1.EtcdMember{
+			ObjectMeta: metav1.ObjectMeta{Name: "controller-2"},
+			Spec:       etcdv1beta1.EtcdMemberSpec{Leave: true, Replace: true},
+			Status: etcdv1beta1.Status{
+				PeerAddress:     "10.0.0.3",
+				MemberID:        "3c",
+				ReconcileStatus: "Success",
+				Message:         "Member removed from cluster",
+			},
+		}
+		stale.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionFalse, "Member removed", stale.CreationTimestamp.Time)
+		client := testutil.NewFakeClientFactory(stale).K0sClient.EtcdV1beta1().EtcdMembers()
+
+		recordLearner(t.Context(), log, client, req, 0x2a)
+
+		member, err := client.Get(t.Context(), "controller-2", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.False(t, member.Spec.Leave, "stale leave request should have been reset")
+		assert.False(t, member.Spec.Replace, "stale replace request should have been reset")
+		assert.Empty(t, member.Status.ReconcileStatus)
+		assert.Empty(t, member.Status.Message)
+		assertLearner(t, member)
+	})
+}
+
+func TestCheckMemberName(t *testing.T) {
+	req := &v1beta1.EtcdRequest{Node: "Controller-2", PeerAddress: "https://10.0.0.4:2380"}
+	members := []etcd.Member{
+		{ID: 0x1f, Name: "controller-1", PeerURL: "https://10.0.0.1:2380"},
+		{ID: 0x2a, Name: "Controller-2", PeerURL: "https://10.0.0.2:2380"},
+		{ID: 0x3c, PeerURL: "https://10.0.0.3:2380"}, // unstarted
+	}
+
+	t.Run("NoConflict", func(t *testing.T) {
+		client := testutil.NewFakeClientFactory().K0sClient.EtcdV1beta1().EtcdMembers()
+		assert.NoError(t, checkMemberName(t.Context(), client, members[:1], req))
+		assert.NoError(t, checkMemberName(t.Context(), client, members, &v1beta1.EtcdRequest{
+			Node: "Controller-2", PeerAddress: "https://10.0.0.2:2380",
+		}), "same peer address should be left to etcd")
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		client := testutil.NewFakeClientFactory().K0sClient.EtcdV1beta1().EtcdMembers()
+		assert.EqualError(t, checkMemberName(t.Context(), client, members, req),
+			"etcd member Controller-2 already exists with peer address https://10.0.0.2:2380, mark its EtcdMember object for replacement in order to replace it")
+	})
+
+	t.Run("BeingReplaced", func(t *testing.T) {
+		client := testutil.NewFakeClientFactory(&etcdv1beta1.EtcdMember{
+			ObjectMeta: metav1.ObjectMeta{Name: "controller-2"},
+			Spec:       etcdv1beta1.EtcdMemberSpec{Replace: true},
+		}).K0sClient.EtcdV1beta1().EtcdMembers()
+		assert.EqualError(t, checkMemberName(t.Context(), client, members, req),
+			"etcd member Controller-2 with peer address https://10.0.0.2:2380 is being replaced, try again later")
+	})
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewAutopilotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "autopilot",
+		Short: "Tooling for autopilot",
+		Args:  cobra.NoArgs,
+		RunE:  func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
+	}
+
+	cmd.AddCommand(newCheckCmd())
+	cmd.AddCommand(newUpdateServerCmd())
+
+	return cmd
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k0sproject/k0s/pkg/autopilot/checks"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/rest"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+)
+
+func newCheckCmd() *cobra.Command {
+	var toVersion string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check if the cluster can be updated to a given Kubernetes version",
+		Long: `Check if the cluster can be updated to a given Kubernetes version.
+
+Lists all objects that use APIs which are removed in the given version, along
+with the API version that replaces them. The following places are scanned:
+
+  - objects stored in the cluster
+  - stored versions of CustomResourceDefinitions
+  - manifests of Helm releases managed by k0s Chart resources
+  - manifest files in k0s's manifests directory
+
+Exits with an error if any such object has been found.`,
+		Example: `  k0s autopilot check --to-version v1.36.0`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			version := toVersion
+			if !strings.HasPrefix(version, "v") {
+				version = "v" + version
+			}
+			if !semver.IsValid(version) {
+				return fmt.Errorf("invalid version: %q", toVersion)
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			log := k0scontext.ValueOrElse(ctx, func() logrus.FieldLogger {
+				return logrus.StandardLogger()
+			})
+
+			scanner := checks.RemovedAPIScanner{
+				Log: log,
+				ClientFactory: &kubernetes.ClientFactory{LoadRESTConfig: func() (*rest.Config, error) {
+					return kubernetes.ClientConfig(kubernetes.KubeconfigFromFile(opts.K0sVars.AdminKubeConfigPath))
+				}},
+				ManifestsDir: opts.K0sVars.ManifestsDir,
+			}
+
+			usages, err := scanner.Scan(ctx, version)
+			if err != nil {
+				return err
+			}
+
+			if len(usages) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No usages of APIs that are removed in Kubernetes", version, "found")
+				return nil
+			}
+
+			printRemovedAPIUsages(cmd.OutOrStdout(), usages)
+			return fmt.Errorf("found %d objects using APIs that are removed in Kubernetes %s", len(usages), version)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.StringVar(&toVersion, "to-version", "", "The Kubernetes version to check against")
+	_ = cmd.MarkFlagRequired("to-version")
+
+	return cmd
+}
+
+func printRemovedAPIUsages(writer io.Writer, usages []checks.RemovedAPIUsage) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Source", Type: "string", Description: "Where the object has been found"},
+			{Name: "Kind", Type: "string", Description: "Object kind"},
+			{Name: "Object", Type: "string", Description: "Object namespace and name"},
+			{Name: "API Version", Type: "string", Description: "Removed API version"},
+			{Name: "Removed In", Type: "string", Description: "Kubernetes version in which the API has been removed"},
+			{Name: "Replacement", Type: "string", Description: "API version that replaces the removed one"},
+		},
+	}
+
+	for _, u := range usages {
+		source := u.Origin
+		if source == "" {
+			source = string(u.Source)
+		}
+		object := u.Name
+		if u.Namespace != "" {
+			object = u.Namespace + "/" + u.Name
+		}
+		replacement := u.Replacement
+		if replacement == "" {
+			replacement = "<none>"
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{source, u.GroupVersionKind.Kind, object, u.GroupVersionKind.GroupVersion().String(), u.RemovedIn, replacement},
+		})
+	}
+
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	defer tabWriter.Flush()
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	if err := printer.PrintObj(table, tabWriter); err != nil {
+		fmt.Fprintf(writer, "Error printing table: %v\n", err)
+	}
+}
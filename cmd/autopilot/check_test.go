@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/autopilot/checks"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintRemovedAPIUsages(t *testing.T) {
+	var output bytes.Buffer
+	printRemovedAPIUsages(&output, []checks.RemovedAPIUsage{{
+		Source:           checks.RemovedAPISourceCluster,
+		GroupVersionKind: schema.GroupVersionKind{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema"},
+		Name:             "legacy",
+		RemovedIn:        "v1.32.0",
+		Replacement:      "flowcontrol.apiserver.k8s.io/v1",
+	}, {
+		Source:           checks.RemovedAPISourceHelmRelease,
+		Origin:           "Helm release ops/backup",
+		GroupVersionKind: schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy"},
+		Name:             "restricted",
+		RemovedIn:        "v1.25.0",
+	}})
+
+	assert.Equal(t, ""+
+		"SOURCE                    KIND                OBJECT       API VERSION                            REMOVED IN   REPLACEMENT\n"+
+		"Cluster                   FlowSchema          legacy       flowcontrol.apiserver.k8s.io/v1beta3   v1.32.0      flowcontrol.apiserver.k8s.io/v1\n"+
+		"Helm release ops/backup   PodSecurityPolicy   restricted   policy/v1beta1                         v1.25.0      <none>\n",
+		output.String())
+}
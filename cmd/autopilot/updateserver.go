@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autopilot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/autopilot/updateserver"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type updateServerFlags struct {
+	listen    string
+	dataDir   string
+	baseURL   string
+	tokenFile string
+	tlsCert   string
+	tlsKey    string
+}
+
+func newUpdateServerCmd() *cobra.Command {
+	var (
+		debugFlags internal.DebugFlags
+		flags      updateServerFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update-server",
+		Short: "Serve autopilot update channels from a local directory",
+		Long: `Serve autopilot update channels from a local directory.
+
+The data directory contains one subdirectory per channel. Each channel
+directory holds an index.yaml file describing the channel's latest version,
+along with any artifacts that it references via relative URLs:
+
+    stable/
+      index.yaml
+      k0s-v1.35.1+k0s.0-amd64
+      k0s-airgap-bundle-v1.35.1+k0s.0-amd64
+
+An index.yaml file looks like this:
+
+    version: v1.35.1+k0s.0
+    downloadURLs:
+      - os: linux
+        arch: amd64
+        k0s: k0s-v1.35.1+k0s.0-amd64
+        k0sSha256: <sha256>
+        airgapBundle: k0s-airgap-bundle-v1.35.1+k0s.0-amd64
+        airgapSha256: <sha256>
+
+Point the updateServer field of an UpdateConfig to this server in order to use
+it for automatic updates.`,
+		Example:          `  k0s autopilot update-server --data-dir /srv/k0s-updates --base-url https://updates.example.com/`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			log := k0scontext.ValueOrElse(ctx, func() logrus.FieldLogger {
+				return logrus.StandardLogger()
+			})
+
+			server, err := flags.buildServer(log)
+			if err != nil {
+				return err
+			}
+
+			return runUpdateServer(ctx, log, server, flags.tlsCert, flags.tlsKey)
+		},
+	}
+
+	debugFlags.LongRunning().AddToFlagSet(cmd.PersistentFlags())
+
+	f := cmd.Flags()
+	f.StringVar(&flags.listen, "listen", ":8080", "Address to listen on")
+	f.StringVar(&flags.dataDir, "data-dir", "", "Directory from which to serve the update channels")
+	f.StringVar(&flags.baseURL, "base-url", "", "Externally reachable URL of the update server, used to resolve relative download URLs (defaults to the URL of the request)")
+	f.StringVar(&flags.tokenFile, "token-file", "", "Path to a file containing a bearer token that clients need to present to fetch channel metadata")
+	f.StringVar(&flags.tlsCert, "tls-cert", "", "Path to a TLS certificate file; enables HTTPS if set")
+	f.StringVar(&flags.tlsKey, "tls-key", "", "Path to the TLS private key file")
+	_ = cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
+
+	return cmd
+}
+
+func (f *updateServerFlags) buildServer(log logrus.FieldLogger) (*http.Server, error) {
+	if stat, err := os.Stat(f.dataDir); err != nil {
+		return nil, fmt.Errorf("invalid data directory: %w", err)
+	} else if !stat.IsDir() {
+		return nil, fmt.Errorf("invalid data directory: %s is not a directory", f.dataDir)
+	}
+
+	config := updateserver.Config{DataDir: f.dataDir}
+
+	if f.baseURL != "" {
+		baseURL, err := url.Parse(f.baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		if baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, fmt.Errorf("invalid base URL: %s is not absolute", f.baseURL)
+		}
+		config.BaseURL = baseURL
+	}
+
+	if f.tokenFile != "" {
+		token, err := os.ReadFile(f.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		config.Token = strings.TrimSpace(string(token))
+		if config.Token == "" {
+			return nil, fmt.Errorf("token file %s is empty", f.tokenFile)
+		}
+	}
+
+	return &http.Server{
+		Addr:              f.listen,
+		Handler:           updateserver.NewHandler(log.WithField("component", "update-server"), config),
+		ReadHeaderTimeout: 10 * time.Second,
+	}, nil
+}
+
+func runUpdateServer(ctx context.Context, log logrus.FieldLogger, server *http.Server, tlsCert, tlsKey string) error {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+
+	log.Info("Listening on ", listener.Addr(), ", start serving")
+
+	doneServing := make(chan struct{})
+	go func() {
+		defer close(doneServing)
+		if tlsCert != "" {
+			err = server.ServeTLS(listener, tlsCert, tlsKey)
+		} else {
+			err = server.Serve(listener)
+		}
+	}()
+
+	select {
+	case <-doneServing:
+		return fmt.Errorf("unexpected server error: %w", err)
+
+	case <-ctx.Done():
+		log.Info("Shutting down server: ", context.Cause(ctx))
+
+		ctx, cancel := context.WithTimeout(context.TODO(), 3*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			return fmt.Errorf("while shutting down server: %w", err)
+		}
+
+		<-doneServing
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("unexpected error after server shutdown: %w", err)
+		}
+
+		log.Info("Good bye")
+		return nil
+	}
+}
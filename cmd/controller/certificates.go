@@ -252,15 +252,26 @@ func (c *Certificates) generateSANList(ctx context.Context) ([]string, error) {
 
 	// Add to SANs the IPs from the control plane load balancer
 	cplb := c.ClusterSpec.Network.ControlPlaneLoadBalancing
-	if cplb != nil && cplb.Enabled && cplb.Keepalived != nil {
-		for _, v := range cplb.Keepalived.VRRPInstances {
-			for _, vip := range v.VirtualIPs {
-				ip, _, err := net.ParseCIDR(vip)
-				if err != nil {
-					return nil, fmt.Errorf("error parsing virtualIP %s: %w", vip, err)
-				}
-				hostnames = append(hostnames, ip.String())
+	if cplb != nil && cplb.Enabled {
+		var vips []string
+		if cplb.Keepalived != nil {
+			for _, v := range cplb.Keepalived.VRRPInstances {
+				vips = append(vips, v.VirtualIPs...)
+			}
+		}
+		if cplb.LeaseVIP != nil {
+			vips = append(vips, cplb.LeaseVIP.VirtualIPs...)
+		}
+		for _, vip := range vips {
+			ip, _, err := net.ParseCIDR(vip)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing virtualIP %s: %w", vip, err)
 			}
+			hostnames = append(hostnames, ip.String())
+		}
+		if cplb.BGP != nil {
+			// The BGP virtual IPs are plain IP addresses, not CIDRs.
+			hostnames = append(hostnames, cplb.BGP.VirtualIPs...)
 		}
 	}
 
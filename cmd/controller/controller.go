@@ -29,6 +29,7 @@ import (
 	"github.com/k0sproject/k0s/internal/sync/value"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/applier"
+	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
 	"github.com/k0sproject/k0s/pkg/autopilot/controller/updates"
 	"github.com/k0sproject/k0s/pkg/build"
 	"github.com/k0sproject/k0s/pkg/certificate"
@@ -46,9 +47,11 @@ import (
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/leaderelection"
+	"github.com/k0sproject/k0s/pkg/metrics"
 	"github.com/k0sproject/k0s/pkg/performance"
 	"github.com/k0sproject/k0s/pkg/telemetry"
 	"github.com/k0sproject/k0s/pkg/token"
+	"github.com/k0sproject/k0s/pkg/tracing"
 
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -57,6 +60,7 @@ import (
 	"github.com/avast/retry-go"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
 )
 
 type command config.CLIOptions
@@ -89,6 +93,12 @@ func NewControllerCmd() *cobra.Command {
 				return err
 			}
 
+			closeLogs, err := internal.ConfigureLogging(&opts.WorkerOptions, opts.K0sVars.DataDir)
+			if err != nil {
+				return fmt.Errorf("failed to configure logging: %w", err)
+			}
+			defer closeLogs()
+
 			c := (*command)(opts)
 
 			if len(args) > 0 {
@@ -198,6 +208,26 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 	ctx, cancel := context.WithCancelCause(ctx)
 	defer cancel(nil)
 
+	shutdownTracing, err := tracing.Setup(ctx, "controller", tracing.Options{
+		Endpoint: flags.TracingEndpoint,
+		File:     flags.TracingFile,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logrus.WithError(err).Warn("Failed to shut down tracing")
+		}
+	}()
+
+	// The span covering the controller startup. Components are initialized and
+	// started using startCtx, so that their spans end up in the same trace.
+	startCtx, startSpan := otel.Tracer("github.com/k0sproject/k0s/cmd/controller").Start(ctx, "Start controller")
+	defer startSpan.End()
+
 	perfTimer := performance.NewTimer("controller-start").Buffer().Start()
 
 	nodeComponents := manager.New(prober.DefaultProber)
@@ -289,6 +319,7 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 	enableK0sEndpointReconciler := nodeConfig.Spec.API.ExternalAddress != "" &&
 		!slices.Contains(flags.DisableComponents, constant.APIEndpointReconcilerComponentName)
 
+	var cplbStatus cplb.StatusSource
 	if cplbCfg := nodeConfig.Spec.Network.ControlPlaneLoadBalancing; cplbCfg != nil && cplbCfg.Enabled {
 		if controllerMode == config.SingleNodeMode {
 			return errors.New("control plane load balancing cannot be used in a single-node cluster")
@@ -305,17 +336,42 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 			BinDir:       c.K0sVars.BinDir,
 		})
 
-		nodeComponents.Add(ctx, &cplb.Keepalived{
-			K0sVars:         c.K0sVars,
-			Config:          cplbCfg.Keepalived,
-			DetailedLogging: debug,
-			LogConfig:       debug,
-			KubeConfigPath:  c.K0sVars.AdminKubeConfigPath,
-			APIPort:         nodeConfig.Spec.API.Port,
-		})
+		switch cplbCfg.Type {
+		case v1beta1.CPLBTypeLeaseVIP:
+			leaseVIP := &cplb.LeaseVIP{
+				K0sVars:           c.K0sVars,
+				Config:            cplbCfg.LeaseVIP,
+				APIPort:           nodeConfig.Spec.API.Port,
+				KubeConfigPath:    c.K0sVars.AdminKubeConfigPath,
+				KubeClientFactory: adminClientFactory,
+			}
+			nodeComponents.Add(ctx, leaseVIP)
+			cplbStatus = leaseVIP
+		case v1beta1.CPLBTypeBGP:
+			bgp := &cplb.BGP{
+				Config:         cplbCfg.BGP,
+				APIAddress:     nodeConfig.Spec.API.Address,
+				KubeConfigPath: c.K0sVars.AdminKubeConfigPath,
+			}
+			nodeComponents.Add(ctx, bgp)
+			cplbStatus = bgp
+		default:
+			keepalived := &cplb.Keepalived{
+				K0sVars:         c.K0sVars,
+				Config:          cplbCfg.Keepalived,
+				DetailedLogging: debug,
+				LogConfig:       debug,
+				KubeConfigPath:  c.K0sVars.AdminKubeConfigPath,
+				APIPort:         nodeConfig.Spec.API.Port,
+			}
+			nodeComponents.Add(ctx, keepalived)
+			cplbStatus = keepalived
+		}
 	}
 
-	enableKonnectivity := controllerMode != config.SingleNodeMode && !slices.Contains(flags.DisableComponents, constant.KonnectivityServerComponentName)
+	enableKonnectivity := controllerMode != config.SingleNodeMode &&
+		!slices.Contains(flags.DisableComponents, constant.KonnectivityServerComponentName) &&
+		nodeConfig.Spec.Konnectivity.IsEnabled()
 
 	if enableKonnectivity {
 		nodeComponents.Add(ctx, &controller.Konnectivity{
@@ -422,8 +478,32 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 		statusComponent.StatusInformation.Workloads = true
 		statusComponent.CertManager = worker.NewCertificateManager(worker.DirectKubeletKubeconfigPath(c.K0sVars))
 	}
+	if cplbStatus != nil {
+		statusComponent.CPLBStatus = cplbStatus.Status
+	}
+	if enableKonnectivity {
+		statusComponent.KonnectivityAgents = func(ctx context.Context) *status.KonnectivityAgentsStatus {
+			return controller.KonnectivityAgentsStatus(ctx, adminClientFactory, nodeConfig.Spec.API.Address)
+		}
+	}
 	nodeComponents.Add(ctx, &statusComponent)
 
+	if cplbStatus != nil {
+		controlNodeName, err := apcomm.FindEffectiveHostname()
+		if err != nil {
+			return fmt.Errorf("failed to determine ControlNode name: %w", err)
+		}
+		nodeComponents.Add(ctx, &cplb.StatusReporter{
+			Source:            cplbStatus,
+			KubeClientFactory: adminClientFactory,
+			ControlNodeName:   controlNodeName,
+		})
+	}
+
+	if c.MetricsBindAddress != "" {
+		nodeComponents.Add(ctx, &metrics.Server{BindAddress: c.MetricsBindAddress})
+	}
+
 	perfTimer.Checkpoint("starting-certificates-init")
 	certs := &Certificates{
 		ClusterSpec:         nodeConfig.Spec,
@@ -437,7 +517,7 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 
 	perfTimer.Checkpoint("starting-node-component-init")
 	// init Node components
-	if err := nodeComponents.Init(ctx); err != nil {
+	if err := nodeComponents.Init(startCtx); err != nil {
 		return err
 	}
 	perfTimer.Checkpoint("finished-node-component-init")
@@ -449,7 +529,7 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 	}
 
 	// Start components
-	err = nodeComponents.Start(ctx)
+	err = nodeComponents.Start(startCtx)
 	perfTimer.Checkpoint("finished-starting-node-components")
 	if err != nil {
 		return fmt.Errorf("failed to start controller node components: %w", err)
@@ -539,9 +619,25 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 			return fmt.Errorf("failed to create Calico component: %w", err)
 		}
 		clusterComponents.Add(ctx, calico)
+		cilium, err := controller.NewCilium(c.K0sVars, nodeConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create Cilium component: %w", err)
+		}
+		clusterComponents.Add(ctx, cilium)
 		clusterComponents.Add(ctx, controller.NewKubeRouter(c.K0sVars, nodeConfig.Spec.PrimaryAddressFamily(), nodeConfig.Spec.Network.BuildServiceCIDR(nodeConfig.Spec.PrimaryAddressFamily())))
+
+		// Network provider migrations are driven by changes to the dynamic config.
+		if flags.EnableDynamicConfig {
+			clusterComponents.Add(ctx, &controller.NetworkProviderMigration{
+				ClientFactory: adminClientFactory,
+				LeaderElector: leaderElector,
+				ManifestsDir:  c.K0sVars.ManifestsDir,
+			})
+		}
 	}
 
+	clusterComponents.Add(ctx, controller.NewNetworkPolicies(adminClientFactory, c.K0sVars.ManifestsDir))
+
 	if !slices.Contains(flags.DisableComponents, constant.MetricsServerComponentName) {
 		if !enableKonnectivity && !flags.Mode().WorkloadsEnabled() {
 			logrus.Warn("In order to run metrics-server without konnectivity, this controller must be able to connect to the cluster network")
@@ -550,11 +646,15 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 	}
 
 	if flags.EnableMetricsScraper {
-		metrics, err := controller.NewMetrics(c.K0sVars, adminClientFactory, nodeConfig.Spec.Storage.Type)
-		if err != nil {
-			return fmt.Errorf("failed to create metrics reconciler: %w", err)
-		}
-		clusterComponents.Add(ctx, metrics)
+		clusterComponents.Add(ctx, &controller.Metrics{
+			K0sVars:       c.K0sVars,
+			ClientFactory: adminClientFactory,
+			Storage:       nodeConfig.Spec.Storage,
+			NodeName:      nodeName,
+			Address:       nodeConfig.Spec.API.Address,
+			Port:          flags.MetricsEndpointPort,
+			Insecure:      flags.MetricsEndpointInsecure,
+		})
 	}
 
 	disableAutopilot := slices.Contains(flags.DisableComponents, constant.AutopilotComponentName)
@@ -590,7 +690,20 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 			KonnectivityServerHost: cmp.Or(nodeConfig.Spec.API.ExternalHost(), nodeConfig.Spec.API.Address),
 			EventEmitter:           prober.NewEventEmitter(),
 			ServerCount:            numActiveControllers.Peek,
+			KubeClientFactory:      adminClientFactory,
+			HasExternalAPIAddress:  nodeConfig.Spec.API.ExternalAddress != "",
 		})
+	} else if !nodeConfig.Spec.Konnectivity.IsEnabled() {
+		// Konnectivity has been disabled for the whole cluster. Remove the
+		// agents, in case it has been enabled before.
+		clusterComponents.Add(ctx, &controller.KonnectivityAgentRemoval{
+			ManifestsDir:      c.K0sVars.ManifestsDir,
+			KubeClientFactory: adminClientFactory,
+			LeaderElector:     leaderElector,
+		})
+		if controllerMode != config.SingleNodeMode {
+			clusterComponents.Add(ctx, &controller.KubeletReachability{ClientFactory: adminClientFactory})
+		}
 	}
 
 	if !slices.Contains(flags.DisableComponents, constant.KubeSchedulerComponentName) {
@@ -632,6 +745,11 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 
 		clusterComponents.Add(ctx, controller.NewCRDStack(adminClientFactory, leaderElector, "etcd", controller.WithStackName(controller.EtcdMemberStackName)))
 		clusterComponents.Add(ctx, etcdReconciler)
+		clusterComponents.Add(ctx, &controller.EtcdMaintenance{
+			K0sVars:       c.K0sVars,
+			Config:        nodeConfig.Spec.Storage.Etcd,
+			LeaderElector: leaderElector,
+		})
 	}
 
 	if telemetry.IsEnabled() {
@@ -684,12 +802,12 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 
 	perfTimer.Checkpoint("starting-cluster-components-init")
 	// init Cluster components
-	if err := clusterComponents.Init(ctx); err != nil {
+	if err := clusterComponents.Init(startCtx); err != nil {
 		return err
 	}
 	perfTimer.Checkpoint("finished cluster-component-init")
 
-	err = clusterComponents.Start(ctx)
+	err = clusterComponents.Start(startCtx)
 	if err != nil {
 		return fmt.Errorf("failed to start cluster components: %w", err)
 	}
@@ -704,6 +822,7 @@ func (c *command) start(ctx context.Context, runtimeConfig *config.RuntimeConfig
 	}()
 
 	perfTimer.Output()
+	startSpan.End()
 
 	if controllerMode.WorkloadsEnabled() {
 		return c.startWorker(ctx, nodeName, kubeletExtraArgs, &workerInterface)
@@ -0,0 +1,414 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"cmp"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	etcdv1beta1 "github.com/k0sproject/k0s/pkg/apis/etcd/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/rest"
+
+	"github.com/spf13/cobra"
+)
+
+type etcdCheckClient interface {
+	ListMembers(context.Context) ([]etcd.Member, error)
+	LocalMemberStatus(context.Context) (*etcd.MemberStatus, error)
+	LocalMemberMetrics(context.Context) (*etcd.MemberMetrics, error)
+	MemberReports(context.Context) (map[uint64]*etcd.MemberReport, error)
+	PeerClockOffset(ctx context.Context, peerURL string) (*etcd.ClockOffset, error)
+	PeerCertificate(ctx context.Context, peerURL string) (*x509.Certificate, error)
+	Close() error
+}
+
+const (
+	// Etcd itself starts complaining about clock drift above one second.
+	maxClockSkew = 1 * time.Second
+
+	// The latencies recommended by the etcd tuning guide.
+	maxWALFsyncP99      = 10 * time.Millisecond
+	maxBackendCommitP99 = 25 * time.Millisecond
+
+	// The time to wait for each single query.
+	checkTimeout = 10 * time.Second
+)
+
+type checkStatus string
+
+const (
+	checkOK        checkStatus = "OK"
+	checkWarning   checkStatus = "WARN"
+	checkFailed    checkStatus = "FAIL"
+	checkUnchecked checkStatus = "UNCHECKED" // Counts as a failure.
+)
+
+// The outcome of a single check for a single etcd member.
+type checkResult struct {
+	Check   string
+	Member  string
+	Status  checkStatus
+	Details string
+}
+
+func etcdCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Diagnose common problems of the etcd cluster",
+		Long: `Diagnose common problems of the etcd cluster.
+
+Performs the following checks for every etcd cluster member:
+
+  - health: the member doesn't report any errors or alarms
+  - clock skew: the member's clock doesn't deviate from the local clock
+  - disk latency: WAL fsyncs and backend commits are fast enough
+  - peer certificate: the member's peer certificate is valid for its peer URL
+  - member object: the member's EtcdMember object has the right member ID
+
+K0s-managed etcd members only serve clients on the loopback interface. Hence,
+the health and disk latency of the local member are queried directly, whereas
+those of the other members are taken from the reports that their controllers
+publish periodically. Members without a recent report are marked as UNCHECKED.
+The clock skew is measured via the members' peer URLs.
+
+Exits with an error if any of the checks failed or couldn't be performed.
+Warnings don't cause an error.`,
+		Example: `  k0s etcd check`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			etcdClient := k0scontext.Value[etcdCheckClient](ctx)
+			if etcdClient == nil {
+				etcdClient, err = etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+				if err != nil {
+					return fmt.Errorf("can't connect to etcd: %w", err)
+				}
+			}
+			defer etcdClient.Close()
+
+			clientFactory := k0scontext.ValueOrElse(ctx, func() kubernetes.ClientFactoryInterface {
+				return &kubernetes.ClientFactory{LoadRESTConfig: func() (*rest.Config, error) {
+					return kubernetes.ClientConfig(kubernetes.KubeconfigFromFile(opts.K0sVars.AdminKubeConfigPath))
+				}}
+			})
+
+			checker := etcdChecker{
+				client: etcdClient,
+				listEtcdMembers: func(ctx context.Context) ([]etcdv1beta1.EtcdMember, error) {
+					client, err := clientFactory.GetK0sClient()
+					if err != nil {
+						return nil, err
+					}
+					list, err := client.EtcdV1beta1().EtcdMembers().List(ctx, metav1.ListOptions{})
+					if err != nil {
+						return nil, err
+					}
+					return list.Items, nil
+				},
+			}
+
+			results, err := checker.run(ctx)
+			if err != nil {
+				return err
+			}
+
+			printCheckResults(cmd.OutOrStdout(), results)
+
+			var failed int
+			for _, result := range results {
+				if result.Status == checkFailed || result.Status == checkUnchecked {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d etcd checks failed", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(config.GetPersistentFlagSet())
+
+	return cmd
+}
+
+type etcdChecker struct {
+	client          etcdCheckClient
+	listEtcdMembers func(context.Context) ([]etcdv1beta1.EtcdMember, error)
+}
+
+// Runs all the checks. The results are grouped by check, then by member name.
+func (c *etcdChecker) run(ctx context.Context) ([]checkResult, error) {
+	members, reports, err := func() ([]etcd.Member, map[uint64]*etcd.MemberReport, error) {
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		members, err := c.client.ListMembers(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't list etcd cluster members: %w", err)
+		}
+		reports, err := c.client.MemberReports(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't get etcd member reports: %w", err)
+		}
+		return members, reports, nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(members, func(l, r etcd.Member) int { return cmp.Compare(l.Name, r.Name) })
+
+	// Prefer up-to-date information about the local member over its report.
+	if local := c.queryLocalMember(ctx); local != nil {
+		reports[local.Status.ID] = local
+	}
+
+	var health, clock, disk, certs []checkResult
+	for _, member := range members {
+		clock = append(clock, c.checkClockSkew(ctx, member))
+		certs = append(certs, c.checkPeerCertificate(ctx, member))
+
+		report, ok := reports[member.ID]
+		switch {
+		case ok:
+			health = append(health, checkHealth(member, &report.Status))
+			disk = append(disk, checkDiskLatency(member, report))
+		case member.ClientURL == "":
+			details := "member has not been started yet"
+			health = append(health, checkResult{"health", member.Name, checkWarning, details})
+		default:
+			details := "no recent status report from this member"
+			health = append(health, checkResult{"health", member.Name, checkUnchecked, details})
+			disk = append(disk, checkResult{"disk latency", member.Name, checkUnchecked, details})
+		}
+	}
+
+	return slices.Concat(health, clock, disk, certs, c.checkEtcdMemberObjects(ctx, members)), nil
+}
+
+// Queries the local member directly. Returns nil if it can't be queried, in
+// which case its report is used instead, if any.
+func (c *etcdChecker) queryLocalMember(ctx context.Context) *etcd.MemberReport {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	status, err := c.client.LocalMemberStatus(ctx)
+	if err != nil {
+		return nil
+	}
+
+	report := etcd.MemberReport{Status: *status, WALFsyncP99: -1, BackendCommitP99: -1, ReportedAt: time.Now()}
+	if metrics, err := c.client.LocalMemberMetrics(ctx); err == nil {
+		report.WALFsyncP99 = metrics.WALFsyncP99
+		report.BackendCommitP99 = metrics.BackendCommitP99
+	}
+	return &report
+}
+
+func checkHealth(member etcd.Member, status *etcd.MemberStatus) checkResult {
+	result := checkResult{Check: "health", Member: member.Name}
+
+	if len(status.Errors) > 0 {
+		result.Status, result.Details = checkFailed, strings.Join(status.Errors, ", ")
+		return result
+	}
+
+	role := "follower"
+	if status.IsLeader() {
+		role = "leader"
+	} else if status.IsLearner {
+		role = "learner"
+	}
+	result.Status, result.Details = checkOK, fmt.Sprintf("%s, version %s", role, status.Version)
+	return result
+}
+
+func (c *etcdChecker) checkClockSkew(ctx context.Context, member etcd.Member) checkResult {
+	result := checkResult{Check: "clock skew", Member: member.Name}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	clock, err := c.client.PeerClockOffset(ctx, member.PeerURL)
+	if err != nil {
+		result.Status, result.Details = checkWarning, "can't determine the member's clock: "+err.Error()
+		return result
+	}
+
+	offset := clock.Offset.Abs()
+	direction := "ahead of"
+	if clock.Offset < 0 {
+		direction = "behind"
+	}
+	result.Status = checkOK
+	result.Details = fmt.Sprintf("clock is %s (±%s) %s the local clock", offset, clock.Uncertainty, direction)
+
+	// Only warn if the skew is certain, i.e. exceeds the maximum even when
+	// taking the uncertainty into account.
+	if offset-clock.Uncertainty > maxClockSkew {
+		result.Status = checkWarning
+	}
+
+	return result
+}
+
+func checkDiskLatency(member etcd.Member, report *etcd.MemberReport) checkResult {
+	result := checkResult{Check: "disk latency", Member: member.Name, Status: checkOK}
+
+	var details []string
+	for _, latency := range []struct {
+		name       string
+		p99, limit time.Duration
+	}{
+		{"WAL fsync", report.WALFsyncP99, maxWALFsyncP99},
+		{"backend commit", report.BackendCommitP99, maxBackendCommitP99},
+	} {
+		switch {
+		case latency.p99 < 0:
+			details = append(details, latency.name+" p99 unknown")
+		case latency.p99 > latency.limit:
+			result.Status = checkWarning
+			details = append(details, fmt.Sprintf("%s p99 is %s (should be below %s)", latency.name, latency.p99.Round(time.Microsecond), latency.limit))
+		default:
+			details = append(details, fmt.Sprintf("%s p99 is %s", latency.name, latency.p99.Round(time.Microsecond)))
+		}
+	}
+	result.Details = strings.Join(details, ", ")
+
+	return result
+}
+
+func (c *etcdChecker) checkPeerCertificate(ctx context.Context, member etcd.Member) checkResult {
+	result := checkResult{Check: "peer certificate", Member: member.Name}
+
+	peerURL, err := url.Parse(member.PeerURL)
+	if err != nil {
+		result.Status, result.Details = checkFailed, "invalid peer URL: "+err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	cert, err := c.client.PeerCertificate(ctx, member.PeerURL)
+	switch {
+	case err != nil:
+		result.Status, result.Details = checkFailed, "can't connect to peer URL: "+err.Error()
+	case cert == nil:
+		result.Status, result.Details = checkOK, "peer URL doesn't use TLS"
+	case time.Now().After(cert.NotAfter):
+		result.Status, result.Details = checkFailed, fmt.Sprintf("certificate expired at %s", cert.NotAfter.Format(time.RFC3339))
+	default:
+		if err := cert.VerifyHostname(peerURL.Hostname()); err != nil {
+			sans := slices.Clone(cert.DNSNames)
+			for _, ip := range cert.IPAddresses {
+				sans = append(sans, ip.String())
+			}
+			result.Status = checkFailed
+			result.Details = fmt.Sprintf("certificate isn't valid for %s, subject alternative names are %s", peerURL.Hostname(), strings.Join(sans, ", "))
+		} else {
+			result.Status, result.Details = checkOK, "certificate is valid for "+peerURL.Hostname()
+		}
+	}
+
+	return result
+}
+
+// Checks that the EtcdMember objects match the actual etcd cluster members.
+func (c *etcdChecker) checkEtcdMemberObjects(ctx context.Context, members []etcd.Member) []checkResult {
+	const check = "member object"
+
+	objects, err := func() ([]etcdv1beta1.EtcdMember, error) {
+		ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+		defer cancel()
+		return c.listEtcdMembers(ctx)
+	}()
+	if err != nil {
+		return []checkResult{{check, "-", checkWarning, "can't list EtcdMember objects: " + err.Error()}}
+	}
+
+	var results []checkResult
+	for _, member := range members {
+		idx := slices.IndexFunc(objects, func(o etcdv1beta1.EtcdMember) bool { return o.Name == member.Name })
+		if idx < 0 {
+			results = append(results, checkResult{check, member.Name, checkWarning, "no EtcdMember object found"})
+			continue
+		}
+
+		object := &objects[idx]
+		if id, err := strconv.ParseUint(object.Status.MemberID, 16, 64); err != nil || id != member.ID {
+			results = append(results, checkResult{check, member.Name, checkFailed, fmt.Sprintf(
+				"EtcdMember object has member ID %q, but the etcd cluster reports %x",
+				object.Status.MemberID, member.ID,
+			)})
+			continue
+		}
+
+		results = append(results, checkResult{check, member.Name, checkOK, "member ID " + object.Status.MemberID})
+	}
+
+	// Look for objects that claim to be joined, but aren't part of the cluster.
+	for _, object := range objects {
+		if slices.ContainsFunc(members, func(m etcd.Member) bool { return m.Name == object.Name }) {
+			continue
+		}
+		joined := object.Status.GetCondition(etcdv1beta1.ConditionTypeJoined)
+		if joined != nil && joined.Status == etcdv1beta1.ConditionTrue && !object.Spec.Leave {
+			results = append(results, checkResult{check, object.Name, checkFailed, fmt.Sprintf(
+				"EtcdMember object with member ID %q is marked as joined, but it's not an etcd cluster member",
+				object.Status.MemberID,
+			)})
+		}
+	}
+
+	return results
+}
+
+func printCheckResults(writer io.Writer, results []checkResult) {
+	table := metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Check", Type: "string"},
+			{Name: "Member", Type: "string"},
+			{Name: "Result", Type: "string"},
+			{Name: "Details", Type: "string"},
+		},
+	}
+
+	for _, r := range results {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{r.Check, r.Member, string(r.Status), r.Details},
+		})
+	}
+
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	defer tabWriter.Flush()
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	if err := printer.PrintObj(&table, tabWriter); err != nil {
+		fmt.Fprintf(writer, "Error printing table: %v\n", err)
+	}
+}
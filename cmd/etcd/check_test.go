@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"maps"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	etcdv1beta1 "github.com/k0sproject/k0s/pkg/apis/etcd/v1beta1"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEtcdCheckCmd(t *testing.T) {
+	newClient := func(t *testing.T) *fakeEtcdCheckClient {
+		return &fakeEtcdCheckClient{
+			members: []etcd.Member{
+				{ID: 0x1f, Name: "node-1", PeerURL: "https://10.0.0.1:2380", ClientURL: "https://127.0.0.1:2379"},
+				{ID: 0x2a, Name: "node-2", PeerURL: "https://10.0.0.2:2380", ClientURL: "https://127.0.0.1:2379"},
+			},
+			local: &etcd.MemberReport{
+				Status:      etcd.MemberStatus{ID: 0x1f, Leader: 0x1f, Version: "3.6.5"},
+				WALFsyncP99: 2 * time.Millisecond, BackendCommitP99: 5 * time.Millisecond,
+			},
+			reports: map[uint64]*etcd.MemberReport{
+				// The local member's report is outdated.
+				0x1f: {Status: etcd.MemberStatus{ID: 0x1f, Leader: 0x2a, Version: "3.6.4"}, WALFsyncP99: -1, BackendCommitP99: -1},
+				0x2a: {Status: etcd.MemberStatus{ID: 0x2a, Leader: 0x1f, Version: "3.6.5"}, WALFsyncP99: 3 * time.Millisecond, BackendCommitP99: -1},
+			},
+			clocks: map[string]*etcd.ClockOffset{
+				"https://10.0.0.1:2380": {Offset: 100 * time.Millisecond, Uncertainty: 501 * time.Millisecond},
+				"https://10.0.0.2:2380": {Offset: -200 * time.Millisecond, Uncertainty: 502 * time.Millisecond},
+			},
+			certs: map[string]*x509.Certificate{
+				"https://10.0.0.1:2380": newTestCert(t, "10.0.0.1"),
+				"https://10.0.0.2:2380": newTestCert(t, "10.0.0.2"),
+			},
+		}
+	}
+
+	newEtcdMember := func(name, memberID string) *etcdv1beta1.EtcdMember {
+		member := &etcdv1beta1.EtcdMember{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     etcdv1beta1.Status{MemberID: memberID},
+		}
+		member.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionTrue, "", time.Now())
+		return member
+	}
+
+	run := func(t *testing.T, client *fakeEtcdCheckClient, etcdMembers ...*etcdv1beta1.EtcdMember) (string, error) {
+		var objects []runtime.Object
+		for _, m := range etcdMembers {
+			objects = append(objects, m)
+		}
+		ctx := k0scontext.WithValue[etcdCheckClient](t.Context(), client)
+		ctx = k0scontext.WithValue[kubernetes.ClientFactoryInterface](ctx, testutil.NewFakeClientFactory(objects...))
+		var stdout, stderr strings.Builder
+		underTest := etcdCheckCmd()
+		underTest.SetOut(&stdout)
+		underTest.SetErr(&stderr)
+		err := underTest.ExecuteContext(ctx)
+		assert.True(t, client.closed, "expected the etcd client to be closed")
+		return stdout.String(), err
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		out, err := run(t, newClient(t), newEtcdMember("node-1", "1f"), newEtcdMember("node-2", "2A"))
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"CHECK              MEMBER   RESULT   DETAILS\n"+
+			"health             node-1   OK       leader, version 3.6.5\n"+
+			"health             node-2   OK       follower, version 3.6.5\n"+
+			"clock skew         node-1   OK       clock is 100ms (±501ms) ahead of the local clock\n"+
+			"clock skew         node-2   OK       clock is 200ms (±502ms) behind the local clock\n"+
+			"disk latency       node-1   OK       WAL fsync p99 is 2ms, backend commit p99 is 5ms\n"+
+			"disk latency       node-2   OK       WAL fsync p99 is 3ms, backend commit p99 unknown\n"+
+			"peer certificate   node-1   OK       certificate is valid for 10.0.0.1\n"+
+			"peer certificate   node-2   OK       certificate is valid for 10.0.0.2\n"+
+			"member object      node-1   OK       member ID 1f\n"+
+			"member object      node-2   OK       member ID 2A\n",
+			out)
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		client := newClient(t)
+		client.reports[0x2a].Status.Errors = []string{"alarm:NOSPACE"}
+		client.reports[0x2a].WALFsyncP99 = 42 * time.Millisecond
+		client.clocks["https://10.0.0.1:2380"].Offset = 2 * time.Second
+		client.certs["https://10.0.0.2:2380"] = newTestCert(t, "10.0.0.3")
+
+		out, err := run(t, client, newEtcdMember("node-1", "ff"), newEtcdMember("node-3", "3c"))
+		assert.ErrorContains(t, err, "4 of 11 etcd checks failed")
+		assert.Contains(t, out, "health             node-2   FAIL     alarm:NOSPACE\n")
+		assert.Contains(t, out, "clock skew         node-1   WARN     clock is 2s (±501ms) ahead of the local clock\n")
+		assert.Contains(t, out, "disk latency       node-2   WARN     WAL fsync p99 is 42ms (should be below 10ms), backend commit p99 unknown\n")
+		assert.Contains(t, out, "peer certificate   node-2   FAIL     certificate isn't valid for 10.0.0.2, subject alternative names are 10.0.0.3\n")
+		assert.Contains(t, out, `member object      node-1   FAIL     EtcdMember object has member ID "ff", but the etcd cluster reports 1f`+"\n")
+		assert.Contains(t, out, "member object      node-2   WARN     no EtcdMember object found\n")
+		assert.Contains(t, out, `member object      node-3   FAIL     EtcdMember object with member ID "3c" is marked as joined, but it's not an etcd cluster member`+"\n")
+	})
+
+	t.Run("unreported_member", func(t *testing.T) {
+		client := newClient(t)
+		delete(client.reports, 0x2a)
+
+		out, err := run(t, client, newEtcdMember("node-1", "1f"), newEtcdMember("node-2", "2a"))
+		assert.ErrorContains(t, err, "2 of 10 etcd checks failed")
+		assert.Contains(t, out, "health             node-2   UNCHECKED   no recent status report from this member\n")
+		assert.Contains(t, out, "clock skew         node-2   OK          clock is 200ms (±502ms) behind the local clock\n")
+		assert.Contains(t, out, "disk latency       node-2   UNCHECKED   no recent status report from this member\n")
+	})
+
+	t.Run("unreachable_member", func(t *testing.T) {
+		client := newClient(t)
+		delete(client.reports, 0x2a)
+		delete(client.clocks, "https://10.0.0.2:2380")
+		delete(client.certs, "https://10.0.0.2:2380")
+
+		out, err := run(t, client, newEtcdMember("node-1", "1f"), newEtcdMember("node-2", "2a"))
+		assert.ErrorContains(t, err, "3 of 10 etcd checks failed")
+		assert.Contains(t, out, "health             node-2   UNCHECKED   no recent status report from this member\n")
+		assert.Contains(t, out, "clock skew         node-2   WARN        can't determine the member's clock: connection refused\n")
+		assert.Contains(t, out, "peer certificate   node-2   FAIL        can't connect to peer URL: connection refused\n")
+	})
+
+	t.Run("local_member_down", func(t *testing.T) {
+		client := newClient(t)
+		client.local = nil
+
+		// Fall back to the local member's report.
+		out, err := run(t, client, newEtcdMember("node-1", "1f"), newEtcdMember("node-2", "2a"))
+		require.NoError(t, err)
+		assert.Contains(t, out, "health             node-1   OK       follower, version 3.6.4\n")
+		assert.Contains(t, out, "disk latency       node-1   OK       WAL fsync p99 unknown, backend commit p99 unknown\n")
+	})
+}
+
+func newTestCert(t *testing.T, ip string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP(ip)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+type fakeEtcdCheckClient struct {
+	members []etcd.Member
+	local   *etcd.MemberReport
+	reports map[uint64]*etcd.MemberReport
+	clocks  map[string]*etcd.ClockOffset
+	certs   map[string]*x509.Certificate
+	closed  bool
+}
+
+func (c *fakeEtcdCheckClient) ListMembers(context.Context) ([]etcd.Member, error) {
+	return c.members, nil
+}
+
+func (c *fakeEtcdCheckClient) LocalMemberStatus(context.Context) (*etcd.MemberStatus, error) {
+	if c.local == nil {
+		return nil, errors.New("connection refused")
+	}
+	return &c.local.Status, nil
+}
+
+func (c *fakeEtcdCheckClient) LocalMemberMetrics(context.Context) (*etcd.MemberMetrics, error) {
+	if c.local == nil {
+		return nil, errors.New("connection refused")
+	}
+	return &etcd.MemberMetrics{WALFsyncP99: c.local.WALFsyncP99, BackendCommitP99: c.local.BackendCommitP99}, nil
+}
+
+func (c *fakeEtcdCheckClient) MemberReports(context.Context) (map[uint64]*etcd.MemberReport, error) {
+	return maps.Clone(c.reports), nil
+}
+
+func (c *fakeEtcdCheckClient) PeerClockOffset(_ context.Context, peerURL string) (*etcd.ClockOffset, error) {
+	if clock, ok := c.clocks[peerURL]; ok {
+		return clock, nil
+	}
+	return nil, errors.New("connection refused")
+}
+
+func (c *fakeEtcdCheckClient) PeerCertificate(_ context.Context, peerURL string) (*x509.Certificate, error) {
+	if cert, ok := c.certs[peerURL]; ok {
+		return cert, nil
+	}
+	return nil, errors.New("connection refused")
+}
+
+func (c *fakeEtcdCheckClient) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type etcdDefragClient interface {
+	etcd.MaintenanceClient
+	Close() error
+}
+
+func etcdDefragCmd() *cobra.Command {
+	var (
+		threshold uint8
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "defrag",
+		Short: "Defragment the database of the local etcd member",
+		Long: `Defragment the database of the local etcd member.
+
+K0s-managed etcd members only serve clients on the loopback interface, so this
+command defragments the etcd member that runs on the same controller. Run it on
+each controller to defragment the whole cluster.
+
+Only one member is defragmented at a time, cluster-wide. The leader goes last:
+it's only defragmented once no other member exceeds the threshold. A member
+won't serve any requests while it's being defragmented. Hence, voting members
+are only defragmented if the remaining members are healthy enough to retain the
+cluster's quorum, unless --force is given. The health of the other members is
+determined from the reports that their controllers publish periodically.`,
+		Example: `  k0s etcd defrag
+  k0s etcd defrag --threshold 30`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			etcdClient := k0scontext.Value[etcdDefragClient](ctx)
+			if etcdClient == nil {
+				etcdClient, err = etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+				if err != nil {
+					return fmt.Errorf("can't connect to etcd: %w", err)
+				}
+			}
+			defer etcdClient.Close()
+
+			log := k0scontext.ValueOrElse(ctx, func() logrus.FieldLogger {
+				return logrus.StandardLogger()
+			})
+
+			return defragment(ctx, cmd, etcdClient, log, threshold, force)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.Uint8Var(&threshold, "threshold", 0, "Only defragment the member if at least this percentage of its database is unused")
+	flags.BoolVar(&force, "force", false, "Defragment a voting member even if the cluster temporarily loses its quorum")
+
+	return cmd
+}
+
+func defragment(ctx context.Context, cmd *cobra.Command, client etcd.MaintenanceClient, log logrus.FieldLogger, threshold uint8, force bool) error {
+	result, err := etcd.DefragmentLocalMember(ctx, client, log, etcd.DefragOptions{
+		Select: func(status *etcd.MemberStatus) bool {
+			return status.FragmentedPercent() >= int64(threshold)
+		},
+		AllowQuorumLoss: force,
+	})
+
+	switch {
+	case errors.Is(err, etcd.ErrNoQuorum):
+		return fmt.Errorf("%w; use --force to defragment anyway", err)
+	case errors.Is(err, etcd.ErrLocked), errors.Is(err, etcd.ErrDefragInProgress):
+		return errors.New("another etcd member is being defragmented, try again later")
+	case err != nil:
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if result == nil {
+		fmt.Fprintln(out, "The local etcd member doesn't need defragmentation")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Defragmented %s: %s -> %s\n", result.Member.Name,
+		humanize.IBytes(uint64(result.SizeBefore)), humanize.IBytes(uint64(result.SizeAfter)),
+	)
+	return nil
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEtcdDefragCmd(t *testing.T) {
+	newClient := func() *fakeEtcdDefragClient {
+		return &fakeEtcdDefragClient{
+			members: []etcd.Member{
+				{ID: 1, Name: "node-1"},
+				{ID: 2, Name: "node-2"},
+				{ID: 3, Name: "node-3"},
+			},
+			local: 2,
+			sizes: map[uint64]int64{1: 4 << 20, 2: 2 << 20, 3: 2 << 20},
+		}
+	}
+
+	run := func(t *testing.T, client *fakeEtcdDefragClient, args ...string) (string, error) {
+		ctx := k0scontext.WithValue[etcdDefragClient](t.Context(), client)
+		var stdout, stderr strings.Builder
+		underTest := etcdDefragCmd()
+		underTest.SetArgs(args)
+		underTest.SetOut(&stdout)
+		underTest.SetErr(&stderr)
+		err := underTest.ExecuteContext(ctx)
+		assert.True(t, client.closed, "expected the etcd client to be closed")
+		assert.False(t, client.locked, "expected the lock to be released")
+		return stdout.String(), err
+	}
+
+	t.Run("defragments_local_member", func(t *testing.T) {
+		client := newClient()
+		out, err := run(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{2}, client.defragmented)
+		assert.Equal(t, "Defragmented node-2: 2.0 MiB -> 1.0 MiB\n", out)
+	})
+
+	t.Run("honors_threshold", func(t *testing.T) {
+		client := newClient()
+		out, err := run(t, client, "--threshold", "60")
+		require.NoError(t, err)
+		assert.Empty(t, client.defragmented)
+		assert.Equal(t, "The local etcd member doesn't need defragmentation\n", out)
+	})
+
+	t.Run("defragments_leader_last", func(t *testing.T) {
+		client := newClient()
+		client.local = 1
+		_, err := run(t, client)
+		assert.ErrorIs(t, err, etcd.ErrLeaderDeferred)
+		assert.ErrorContains(t, err, "the leader is defragmented last, waiting for node-2")
+		assert.Empty(t, client.defragmented)
+
+		client = newClient()
+		client.local = 1
+		out, err := run(t, client, "--threshold", "60")
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{1}, client.defragmented)
+		assert.Equal(t, "Defragmented node-1: 4.0 MiB -> 1.0 MiB\n", out)
+	})
+
+	t.Run("waits_for_lock", func(t *testing.T) {
+		client := newClient()
+		client.lockedElsewhere = true
+		_, err := run(t, client)
+		assert.ErrorContains(t, err, "another etcd member is being defragmented, try again later")
+		assert.Empty(t, client.defragmented)
+
+		client = newClient()
+		client.defragmenting = []uint64{3}
+		_, err = run(t, client)
+		assert.ErrorContains(t, err, "another etcd member is being defragmented, try again later")
+		assert.Empty(t, client.defragmented)
+	})
+
+	t.Run("requires_force_without_quorum", func(t *testing.T) {
+		client := newClient()
+		client.unreported = []uint64{3}
+		_, err := run(t, client)
+		assert.ErrorIs(t, err, etcd.ErrNoQuorum)
+		assert.ErrorContains(t, err, "use --force to defragment anyway")
+		assert.Empty(t, client.defragmented)
+
+		client = newClient()
+		client.unreported = []uint64{3}
+		_, err = run(t, client, "--force")
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{2}, client.defragmented)
+	})
+
+	t.Run("single_member", func(t *testing.T) {
+		client := newClient()
+		client.members = client.members[1:2]
+		_, err := run(t, client)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{2}, client.defragmented)
+	})
+}
+
+// A fake etcd cluster with node-1 as the leader, in which every member's
+// database shrinks to 1 MiB after defragmentation.
+type fakeEtcdDefragClient struct {
+	members         []etcd.Member
+	local           uint64
+	unreported      []uint64
+	sizes           map[uint64]int64
+	defragmented    []uint64
+	locked          bool
+	lockedElsewhere bool
+	defragmenting   []uint64
+	closed          bool
+}
+
+func (c *fakeEtcdDefragClient) ListMembers(context.Context) ([]etcd.Member, error) {
+	return c.members, nil
+}
+
+func (c *fakeEtcdDefragClient) status(id uint64) *etcd.MemberStatus {
+	return &etcd.MemberStatus{ID: id, Leader: 1, DBSize: c.sizes[id], DBSizeInUse: 1 << 20}
+}
+
+func (c *fakeEtcdDefragClient) LocalMemberStatus(context.Context) (*etcd.MemberStatus, error) {
+	return c.status(c.local), nil
+}
+
+func (c *fakeEtcdDefragClient) MemberReports(context.Context) (map[uint64]*etcd.MemberReport, error) {
+	reports := make(map[uint64]*etcd.MemberReport)
+	for _, m := range c.members {
+		if !slices.Contains(c.unreported, m.ID) {
+			reports[m.ID] = &etcd.MemberReport{Status: *c.status(m.ID)}
+		}
+	}
+	return reports, nil
+}
+
+func (c *fakeEtcdDefragClient) DefragmentLocalMember(context.Context) error {
+	if !c.locked {
+		return errors.New("not locked")
+	}
+	c.defragmented = append(c.defragmented, c.local)
+	c.sizes[c.local] = 1 << 20
+	return nil
+}
+
+func (c *fakeEtcdDefragClient) TryLock(context.Context, string) (func(context.Context) error, error) {
+	if c.locked || c.lockedElsewhere {
+		return nil, etcd.ErrLocked
+	}
+	c.locked = true
+	return func(context.Context) error { c.locked = false; return nil }, nil
+}
+
+func (c *fakeEtcdDefragClient) DefragmentingMembers(context.Context) ([]uint64, error) {
+	return slices.Clone(c.defragmenting), nil
+}
+
+func (c *fakeEtcdDefragClient) MarkDefragmenting(_ context.Context, id uint64) error {
+	c.defragmenting = append(c.defragmenting, id)
+	return nil
+}
+
+func (c *fakeEtcdDefragClient) UnmarkDefragmenting(_ context.Context, id uint64) error {
+	c.defragmenting = slices.DeleteFunc(c.defragmenting, func(marked uint64) bool { return marked == id })
+	return nil
+}
+
+func (c *fakeEtcdDefragClient) Close() error {
+	c.closed = true
+	return nil
+}
@@ -48,8 +48,11 @@ func NewEtcdCmd() *cobra.Command {
 	debugFlags.AddToFlagSet(pflags)
 	pflags.AddFlagSet(config.GetPersistentFlagSet())
 
+	cmd.AddCommand(etcdCheckCmd())
+	cmd.AddCommand(etcdDefragCmd())
 	cmd.AddCommand(etcdLeaveCmd())
 	cmd.AddCommand(etcdListCmd())
+	cmd.AddCommand(etcdStatusCmd())
 
 	return cmd
 }
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type etcdStatusClient interface {
+	ListMembers(context.Context) ([]etcd.Member, error)
+	LocalMemberStatus(context.Context) (*etcd.MemberStatus, error)
+	MemberReports(context.Context) (map[uint64]*etcd.MemberReport, error)
+	ListAlarms(context.Context) ([]etcd.Alarm, error)
+	Close() error
+}
+
+// Where the status of an etcd member has been obtained from.
+type statusSource string
+
+const (
+	statusSourceLocal  statusSource = "local"  // Queried from the local member.
+	statusSourceReport statusSource = "report" // Taken from the member's report.
+)
+
+// The status of a single etcd member, as printed by "k0s etcd status".
+type memberStatus struct {
+	Name        string       `json:"name"`
+	ID          string       `json:"id"`
+	ClientURL   string       `json:"clientURL,omitempty"`
+	Source      statusSource `json:"source,omitempty"` // Empty if the status is unknown.
+	ReportedAt  *metav1.Time `json:"reportedAt,omitempty"`
+	Leader      bool         `json:"leader"`
+	Learner     bool         `json:"learner"`
+	Version     string       `json:"version,omitempty"`
+	DBSize      int64        `json:"dbSize,omitempty"`
+	DBSizeInUse int64        `json:"dbSizeInUse,omitempty"`
+	RaftTerm    uint64       `json:"raftTerm,omitempty"`
+	RaftIndex   uint64       `json:"raftIndex,omitempty"`
+	Alarms      []string     `json:"alarms,omitempty"`
+	Errors      []string     `json:"errors,omitempty"`
+}
+
+func etcdStatusCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the etcd cluster members",
+		Long: `Show the status of the etcd cluster members.
+
+K0s-managed etcd members only serve clients on the loopback interface. Hence,
+the status of the local member is queried directly, whereas the statuses of the
+other members are taken from the reports that their controllers publish
+periodically. Members without a recent report are still listed, but without any
+status details.`,
+		Example: `  k0s etcd status
+  k0s etcd status -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format: %q", output)
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			etcdClient := k0scontext.Value[etcdStatusClient](ctx)
+			if etcdClient == nil {
+				etcdClient, err = etcd.NewClient(opts.K0sVars.CertRootDir, opts.K0sVars.EtcdCertDir, nodeConfig.Spec.Storage.Etcd)
+				if err != nil {
+					return fmt.Errorf("can't connect to etcd: %w", err)
+				}
+			}
+			defer etcdClient.Close()
+
+			log := k0scontext.ValueOrElse(ctx, func() logrus.FieldLogger {
+				return logrus.StandardLogger()
+			})
+
+			statuses, err := collectMemberStatuses(ctx, etcdClient, log)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if output == "json" {
+				return json.NewEncoder(out).Encode(statuses)
+			}
+			printMemberStatuses(out, statuses)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.StringVarP(&output, "output", "o", "table", "Output format. Must be one of table|json")
+
+	return cmd
+}
+
+func collectMemberStatuses(ctx context.Context, client etcdStatusClient, log logrus.FieldLogger) ([]memberStatus, error) {
+	members, err := client.ListMembers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't list etcd cluster members: %w", err)
+	}
+
+	alarms, err := client.ListAlarms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't list etcd alarms: %w", err)
+	}
+
+	reports, err := client.MemberReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get etcd member reports: %w", err)
+	}
+
+	local, err := client.LocalMemberStatus(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Failed to query status of local etcd member")
+	}
+
+	statuses := make([]memberStatus, 0, len(members))
+	for _, member := range members {
+		status := memberStatus{
+			Name:      member.Name,
+			ID:        strconv.FormatUint(member.ID, 16),
+			ClientURL: member.ClientURL,
+			Learner:   member.IsLearner,
+		}
+		for _, alarm := range alarms {
+			if alarm.MemberID == member.ID {
+				status.Alarms = append(status.Alarms, alarm.Type)
+			}
+		}
+
+		var s *etcd.MemberStatus
+		if local != nil && local.ID == member.ID {
+			s, status.Source = local, statusSourceLocal
+		} else if report, ok := reports[member.ID]; ok {
+			s, status.Source = &report.Status, statusSourceReport
+			status.ReportedAt = &metav1.Time{Time: report.ReportedAt}
+		}
+
+		switch {
+		case s != nil:
+			status.Leader = s.IsLeader()
+			status.Learner = s.IsLearner
+			status.Version = s.Version
+			status.DBSize = s.DBSize
+			status.DBSizeInUse = s.DBSizeInUse
+			status.RaftTerm = s.RaftTerm
+			status.RaftIndex = s.RaftIndex
+			status.Errors = s.Errors
+		case member.ClientURL == "":
+			status.Errors = []string{"member has not been started yet"}
+		default:
+			status.Errors = []string{"no recent status report from this member"}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	slices.SortFunc(statuses, func(l, r memberStatus) int { return cmp.Compare(l.Name, r.Name) })
+	return statuses, nil
+}
+
+func printMemberStatuses(writer io.Writer, statuses []memberStatus) {
+	table := metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "ID", Type: "string"},
+			{Name: "Leader", Type: "boolean"},
+			{Name: "Learner", Type: "boolean"},
+			{Name: "Version", Type: "string"},
+			{Name: "DB Size", Type: "string"},
+			{Name: "In Use", Type: "string"},
+			{Name: "Raft Term", Type: "string"},
+			{Name: "Raft Index", Type: "string"},
+			{Name: "Alarms", Type: "string"},
+			{Name: "Age", Type: "string"},
+		},
+	}
+
+	none := func(values []string) string {
+		if len(values) == 0 {
+			return "<none>"
+		}
+		return strings.Join(values, ",")
+	}
+
+	for _, status := range statuses {
+		if status.Source == "" {
+			table.Rows = append(table.Rows, metav1.TableRow{Cells: []any{
+				status.Name, status.ID, "<unknown>", status.Learner, "<unknown>",
+				"-", "-", "-", "-", none(status.Alarms), "-",
+			}})
+			continue
+		}
+
+		// The age of the status. Reports may be a few seconds old.
+		age := "<live>"
+		if status.ReportedAt != nil {
+			age = duration.HumanDuration(time.Since(status.ReportedAt.Time))
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: []any{
+			status.Name, status.ID, status.Leader, status.Learner, status.Version,
+			humanize.IBytes(uint64(status.DBSize)), humanize.IBytes(uint64(status.DBSizeInUse)),
+			strconv.FormatUint(status.RaftTerm, 10), strconv.FormatUint(status.RaftIndex, 10),
+			none(status.Alarms), age,
+		}})
+	}
+
+	tabWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	defer tabWriter.Flush()
+
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+	if err := printer.PrintObj(&table, tabWriter); err != nil {
+		fmt.Fprintf(writer, "Error printing table: %v\n", err)
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/k0scontext"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEtcdStatusCmd(t *testing.T) {
+	reportedAt := time.Now().Add(-10 * time.Second).Truncate(time.Second)
+	newClient := func() *fakeEtcdStatusClient {
+		return &fakeEtcdStatusClient{
+			members: []etcd.Member{
+				{ID: 0x2a, Name: "node-2", ClientURL: "https://127.0.0.1:2379"},
+				{ID: 0x1f, Name: "node-1", ClientURL: "https://127.0.0.1:2379"},
+				{ID: 0x3c, Name: "node-3", ClientURL: "https://127.0.0.1:2379", IsLearner: true},
+			},
+			local: &etcd.MemberStatus{ID: 0x1f, Leader: 0x1f, Version: "3.6.5", DBSize: 4 << 20, DBSizeInUse: 3 << 20, RaftTerm: 3, RaftIndex: 1234},
+			reports: map[uint64]*etcd.MemberReport{
+				0x2a: {
+					Status:     etcd.MemberStatus{ID: 0x2a, Leader: 0x1f, Version: "3.6.5", DBSize: 4 << 20, DBSizeInUse: 2 << 20, RaftTerm: 3, RaftIndex: 1230},
+					ReportedAt: reportedAt,
+				},
+			},
+			alarms: []etcd.Alarm{{MemberID: 0x2a, Type: "NOSPACE"}},
+		}
+	}
+
+	run := func(t *testing.T, client *fakeEtcdStatusClient, args ...string) (string, error) {
+		log, _ := test.NewNullLogger()
+		ctx := k0scontext.WithValue[etcdStatusClient](t.Context(), client)
+		ctx = k0scontext.WithValue[logrus.FieldLogger](ctx, log)
+		var stdout, stderr strings.Builder
+		underTest := etcdStatusCmd()
+		underTest.SetArgs(args)
+		underTest.SetOut(&stdout)
+		underTest.SetErr(&stderr)
+		err := underTest.ExecuteContext(ctx)
+		assert.True(t, client.closed, "expected the etcd client to be closed")
+		return stdout.String(), err
+	}
+
+	t.Run("table", func(t *testing.T) {
+		out, err := run(t, newClient())
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"NAME     ID    LEADER      LEARNER   VERSION     DB SIZE   IN USE    RAFT TERM   RAFT INDEX   ALARMS    AGE\n"+
+			"node-1   1f    true        false     3.6.5       4.0 MiB   3.0 MiB   3           1234         <none>    <live>\n"+
+			"node-2   2a    false       false     3.6.5       4.0 MiB   2.0 MiB   3           1230         NOSPACE   10s\n"+
+			"node-3   3c    <unknown>   true      <unknown>   -         -         -           -            <none>    -\n",
+			out)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := run(t, newClient(), "-o", "json")
+		require.NoError(t, err)
+
+		var statuses []memberStatus
+		require.NoError(t, json.Unmarshal([]byte(out), &statuses))
+		require.Len(t, statuses, 3)
+		assert.Equal(t, statusSourceLocal, statuses[0].Source)
+		assert.Nil(t, statuses[0].ReportedAt)
+		assert.Equal(t, memberStatus{
+			Name: "node-2", ID: "2a", ClientURL: "https://127.0.0.1:2379",
+			Source: statusSourceReport, ReportedAt: &metav1.Time{Time: reportedAt},
+			Version: "3.6.5", DBSize: 4 << 20, DBSizeInUse: 2 << 20, RaftTerm: 3, RaftIndex: 1230,
+			Alarms: []string{"NOSPACE"},
+		}, statuses[1])
+		assert.Empty(t, statuses[2].Source)
+		assert.Equal(t, []string{"no recent status report from this member"}, statuses[2].Errors)
+	})
+
+	t.Run("rejects_unknown_output", func(t *testing.T) {
+		client := newClient()
+		client.closed = true // not even opened
+		_, err := run(t, client, "-o", "yaml")
+		assert.ErrorContains(t, err, `unsupported output format: "yaml"`)
+	})
+}
+
+type fakeEtcdStatusClient struct {
+	members []etcd.Member
+	local   *etcd.MemberStatus
+	reports map[uint64]*etcd.MemberReport
+	alarms  []etcd.Alarm
+	closed  bool
+}
+
+func (c *fakeEtcdStatusClient) ListMembers(context.Context) ([]etcd.Member, error) {
+	return c.members, nil
+}
+
+func (c *fakeEtcdStatusClient) LocalMemberStatus(context.Context) (*etcd.MemberStatus, error) {
+	if c.local == nil {
+		return nil, errors.New("connection refused")
+	}
+	return c.local, nil
+}
+
+func (c *fakeEtcdStatusClient) MemberReports(context.Context) (map[uint64]*etcd.MemberReport, error) {
+	return c.reports, nil
+}
+
+func (c *fakeEtcdStatusClient) ListAlarms(context.Context) ([]etcd.Alarm, error) {
+	return c.alarms, nil
+}
+
+func (c *fakeEtcdStatusClient) Close() error {
+	c.closed = true
+	return nil
+}
@@ -44,6 +44,9 @@ With the controller subcommand you can setup a single node cluster by running:
 
 Flags:
       --api-server-stop-timeout duration               time to wait for the API server to stop
+      --component-log-files                            additionally write the logs of each component into a separate file in <data-dir>/logs
+      --component-log-max-backups int                  number of rotated component log files to keep (default 3)
+      --component-log-max-size int                     size in megabytes after which component log files are rotated (default 100)
   -c, --config string                                  config file, use '-' to read the config from stdin (default `+defaultConfigPath+`)
       --cri-socket string                              container runtime socket to use, default to internal containerd. Format: [remote|docker]:[path-to-socket]
       --data-dir string                                Data Directory for k0s. DO NOT CHANGE for an existing setup, things will break! (default `+defaultDataDir+`)
@@ -51,7 +54,7 @@ Flags:
       --enable-cloud-provider                          Whether or not to enable cloud provider support in kubelet
       --enable-dynamic-config                          enable cluster-wide dynamic config based on custom resource
       --enable-k0s-cloud-provider                      enables the k0s-cloud-provider (default false)
-      --enable-metrics-scraper                         enable scraping metrics from the controller components (kube-scheduler, kube-controller-manager)
+      --enable-metrics-scraper                         enable the metrics endpoint for the controller components (etcd/kine, kube-scheduler, kube-controller-manager, k0s)
       --enable-worker                                  enable worker (default false)
       --feature-gates mapStringBool                    feature gates to enable (comma separated list of key=value pairs)
   -h, --help                                           help for controller
@@ -64,13 +67,19 @@ Flags:
       --kubelet-extra-args string                      extra args for kubelet
       --kubelet-root-dir string                        Kubelet root directory for k0s
       --labels mapStringString                         Node labels, list of key=value pairs
+      --log-format string                              log format (valid values: text, json) (default "text")
   -l, --logging stringToString                         Logging Levels for the different components (default [containerd=info,etcd=info,konnectivity-server=1,kube-apiserver=1,kube-controller-manager=1,kube-scheduler=1,kubelet=1])
+      --metrics-bind-address string                    address on which k0s serves its own metrics without authentication, e.g. 127.0.0.1:9445 (disabled if empty)
+      --metrics-endpoint-insecure                      serve the controller metrics endpoint via plain HTTP instead of HTTPS
+      --metrics-endpoint-port int                      the port on which the controller metrics endpoint is served (default 9444)
       --no-taints                                      disable default taints for controller node
       --profile string                                 worker profile to use on the node (default "default")
       --single                                         enable single node (implies --enable-worker, default false)
       --status-socket string                           Full file path to the socket file. (default: <rundir>/status.sock)
       --taints strings                                 Node taints, list of key=value:effect strings
       --token-file string                              Path to the file containing join-token.
+      --tracing-endpoint string                        URL of an OTLP/gRPC endpoint to export traces to, e.g. http://localhost:4317 (tracing is disabled if neither this nor --tracing-file is set)
+      --tracing-file string                            path of a file to which traces are written as JSON
 
 Global Flags:
   -d, --debug                  Debug logging (implies verbose logging)
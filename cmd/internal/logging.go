@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"path/filepath"
+
+	internallog "github.com/k0sproject/k0s/internal/pkg/log"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ConfigureLogging applies the logging options of long-running commands. The
+// returned function closes the component log files, if any.
+func ConfigureLogging(opts *config.WorkerOptions, dataDir string) (func(), error) {
+	if err := internallog.SetFormat(opts.LogFormat); err != nil {
+		return nil, err
+	}
+
+	if !opts.ComponentLogFiles {
+		return func() {}, nil
+	}
+
+	formatter, err := internallog.NewFormatter(opts.LogFormat)
+	if err != nil {
+		return nil, err
+	}
+	if formatter, ok := formatter.(*logrus.TextFormatter); ok {
+		formatter.DisableColors = true
+	}
+
+	files := &internallog.ComponentFiles{
+		Dir:        filepath.Join(dataDir, "logs"),
+		MaxSize:    int64(opts.ComponentLogMaxSize) * 1024 * 1024,
+		MaxBackups: opts.ComponentLogBackups,
+		Formatter:  formatter,
+	}
+	logrus.AddHook(files)
+
+	return func() {
+		if err := files.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close component log files")
+		}
+	}, nil
+}
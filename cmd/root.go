@@ -8,6 +8,7 @@ import (
 
 	"github.com/k0sproject/k0s/cmd/airgap"
 	"github.com/k0sproject/k0s/cmd/api"
+	"github.com/k0sproject/k0s/cmd/autopilot"
 	"github.com/k0sproject/k0s/cmd/config"
 	"github.com/k0sproject/k0s/cmd/ctr"
 	"github.com/k0sproject/k0s/cmd/etcd"
@@ -41,6 +42,7 @@ func NewRootCmd() *cobra.Command {
 
 	cmd.AddCommand(airgap.NewAirgapCmd())
 	cmd.AddCommand(api.NewAPICmd())
+	cmd.AddCommand(autopilot.NewAutopilotCmd())
 	cmd.AddCommand(ctr.NewCtrCommand())
 	cmd.AddCommand(config.NewConfigCmd())
 	cmd.AddCommand(etcd.NewEtcdCmd())
@@ -8,6 +8,8 @@ import (
 	"github.com/k0sproject/k0s/cmd/controller"
 	"github.com/k0sproject/k0s/cmd/keepalived"
 	"github.com/k0sproject/k0s/cmd/restore"
+	"github.com/k0sproject/k0s/cmd/storage"
+	"github.com/k0sproject/k0s/cmd/supportbundle"
 
 	"github.com/spf13/cobra"
 )
@@ -17,4 +19,6 @@ func addPlatformSpecificCommands(root *cobra.Command) {
 	root.AddCommand(controller.NewControllerCmd())
 	root.AddCommand(keepalived.NewKeepalivedSetStateCmd()) // hidden
 	root.AddCommand(restore.NewRestoreCmd())
+	root.AddCommand(storage.NewStorageCmd())
+	root.AddCommand(supportbundle.NewSupportBundleCmd())
 }
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"strings"
 
 	"github.com/k0sproject/k0s/cmd/internal"
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	"github.com/k0sproject/k0s/pkg/component/status"
 	"github.com/k0sproject/k0s/pkg/config"
 
@@ -35,7 +37,7 @@ func NewStatusCmd() *cobra.Command {
 				return err
 			}
 
-			statusInfo, err := status.GetStatusInfo(opts.K0sVars.StatusSocketPath)
+			statusInfo, err := status.GetStatusInfo(cmd.Context(), opts.K0sVars.StatusSocketPath)
 			if err != nil {
 				return err
 			}
@@ -80,7 +82,7 @@ func NewStatusSubCmdComponents() *cobra.Command {
 				return err
 			}
 			fmt.Fprintln(cmd.ErrOrStderr(), "!!! per component status is not yet finally ready, information here might be not full yet")
-			state, err := status.GetComponentStatus(opts.K0sVars.StatusSocketPath, maxCount)
+			state, err := status.GetComponentStatus(cmd.Context(), opts.K0sVars.StatusSocketPath, maxCount)
 			if err != nil {
 				return err
 			}
@@ -127,6 +129,42 @@ func printStatus(w io.Writer, status *status.K0sStatus, output string) {
 		if status.StubFile != "" {
 			fmt.Fprintln(w, "Service file:", status.StubFile)
 		}
+		if status.CPLB != nil {
+			printCPLBStatus(w, status.CPLB)
+		}
+		if status.KonnectivityAgents != nil {
+			printKonnectivityAgentsStatus(w, status.KonnectivityAgents)
+		}
+
+	}
+}
+
+func printCPLBStatus(w io.Writer, status *apv1beta2.CPLBStatus) {
+	fmt.Fprintln(w, "Control plane load balancing:", status.Type)
+	for _, vip := range status.VirtualIPs {
+		fmt.Fprintf(w, "  Virtual IP %s held: %t\n", vip.Address, vip.Held)
+	}
+	for _, vrrp := range status.VRRPInstances {
+		fmt.Fprintf(w, "  VRRP instance %d on %s: %s\n", vrrp.VirtualRouterID, vrrp.Interface, vrrp.State)
+	}
+	for _, backend := range status.Backends {
+		fmt.Fprintf(w, "  Backend %s healthy: %t", backend.Address, backend.Healthy)
+		if backend.Ejected {
+			fmt.Fprint(w, " (ejected)")
+		}
+		if backend.ActiveConnections > 0 {
+			fmt.Fprintf(w, ", active connections: %d", backend.ActiveConnections)
+		}
+		fmt.Fprintln(w)
+	}
+}
 
+func printKonnectivityAgentsStatus(w io.Writer, status *status.KonnectivityAgentsStatus) {
+	fmt.Fprintln(w, "Konnectivity server:", status.Server)
+	if status.Error != "" {
+		fmt.Fprintln(w, "  Failed to get konnectivity agents:", status.Error)
+		return
 	}
+	fmt.Fprintf(w, "  Connected agents (%d): %s\n", len(status.Connected), strings.Join(status.Connected, ", "))
+	fmt.Fprintf(w, "  Disconnected agents (%d): %s\n", len(status.Disconnected), strings.Join(status.Disconnected, ", "))
 }
@@ -0,0 +1,518 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/component/controller"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/component/status"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/config/kine"
+	"github.com/k0sproject/k0s/pkg/etcd"
+	"github.com/k0sproject/k0s/pkg/storage"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/snapshot"
+	utilsnapshot "go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
+)
+
+func storageMigrateCmd() *cobra.Command {
+	var (
+		to     string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the controller's data to another storage backend while k0s is stopped. Must be run as root (or with sudo)",
+		Long: `Migrate the controller's data to another storage backend while k0s is stopped.
+
+Copies all Kubernetes API data from the currently configured storage backend
+into a new one, verifies the copy, and switches the storage configuration in
+the k0s configuration file accordingly. The new backend needs to be empty.
+Migrating to etcd creates a new single-member etcd cluster on this controller.
+Migrating to kine uses the kine data source from the configuration file, if
+any, or an SQLite database in the k0s data directory. Only single-member etcd
+clusters can be migrated to kine.
+
+This is an offline migration: k0s must be stopped, so the Kubernetes API isn't
+available during the migration. The revision of the new backend is raised
+above the one of the previous backend, so that Kubernetes resource versions
+don't go backwards. Kine is only supported with SQLite as a target. The data
+of the previous storage backend is left untouched, and a copy of the previous
+configuration file is kept, so that the migration can be rolled back.
+
+Use the same --config and --single flags that the controller uses. The --single
+flag is required to detect the kine storage of single node controllers that
+have been started without a configuration file.`,
+		Example: `  k0s storage migrate --to etcd --dry-run
+  k0s storage migrate --to etcd
+  k0s storage migrate --to kine --config /etc/k0s/k0s.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+
+			target := v1beta1.StorageType(to)
+			if target != v1beta1.EtcdStorageType && target != v1beta1.KineStorageType {
+				return fmt.Errorf("unsupported storage type: %q", to)
+			}
+
+			opts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			if os.Geteuid() != 0 {
+				return errors.New("this command must be run as root")
+			}
+			if opts.K0sVars.StartupConfigPath == "-" {
+				return errors.New("reading the configuration from stdin isn't supported, as the storage configuration needs to be updated")
+			}
+			if k0sStatus, _ := status.GetStatusInfo(cmd.Context(), opts.K0sVars.StatusSocketPath); k0sStatus != nil && k0sStatus.Pid != 0 {
+				return errors.New("k0s seems to be running, it must be stopped during the migration")
+			}
+
+			nodeConfig, err := opts.K0sVars.NodeConfig()
+			if err != nil {
+				return err
+			}
+
+			m := migration{
+				k0sVars: opts.K0sVars,
+				source:  nodeConfig.Spec.Storage,
+				dryRun:  dryRun,
+				out:     cmd.OutOrStdout(),
+				log:     logrus.StandardLogger(),
+			}
+			if m.target, err = targetStorage(m.source, target, opts.K0sVars.DataDir); err != nil {
+				return err
+			}
+
+			return m.run(cmd.Context())
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.AddFlagSet(config.FileInputFlag())
+	flags.Bool("single", false, "the controller runs in single node mode, i.e. uses kine by default")
+	flags.StringVar(&to, "to", "", "the storage type to migrate to (etcd or kine)")
+	flags.BoolVar(&dryRun, "dry-run", false, "only read and check the data, don't copy it and don't change the configuration")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+type migration struct {
+	k0sVars        *config.CfgVars
+	source, target *v1beta1.StorageSpec
+	dryRun         bool
+	out            io.Writer
+	log            logrus.FieldLogger
+}
+
+func (m *migration) run(ctx context.Context) error {
+	targetPath, err := checkTargetUnused(m.k0sVars, m.target)
+	if err != nil {
+		return err
+	}
+	if targetPath == "" {
+		return errors.New("only SQLite is supported as a kine data source to migrate to, as the revision of other databases can't be raised")
+	}
+
+	m.log.Infof("Reading data from %s storage", m.source.Type)
+	snapshot, err := m.readSource(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(m.out, "Read %d keys (%s) at revision %d from %s storage\n",
+		len(snapshot.KeyValues), humanize.IBytes(uint64(snapshot.Size())), snapshot.Revision, m.source.Type)
+
+	if m.dryRun {
+		fmt.Fprintf(m.out, "Dry run: not copying the keys into %s storage and not updating %s\n", m.target.Type, m.k0sVars.StartupConfigPath)
+		return nil
+	}
+
+	m.log.Infof("Copying data into %s storage", m.target.Type)
+	result, err := m.writeTarget(ctx, snapshot, targetPath)
+	if err != nil {
+		if targetPath != "" {
+			m.log.Infof("Removing incomplete %s data at %s", m.target.Type, targetPath)
+			err = errors.Join(err, os.RemoveAll(targetPath))
+		} else {
+			m.log.Warnf("The %s storage may contain incomplete data, it needs to be cleaned up manually before retrying", m.target.Type)
+		}
+		return fmt.Errorf("failed to migrate to %s storage: %w", m.target.Type, err)
+	}
+	fmt.Fprintf(m.out, "Copied and verified %d keys in %s storage (revision %d to %d)\n",
+		result.Keys, m.target.Type, result.RevisionBefore, result.RevisionAfter)
+
+	backupPath, err := switchStorageConfig(m.k0sVars.StartupConfigPath, m.target)
+	if err != nil {
+		return fmt.Errorf("failed to update storage configuration: %w", err)
+	}
+	fmt.Fprintf(m.out, "Switched the storage type to %s in %s\n", m.target.Type, m.k0sVars.StartupConfigPath)
+	if backupPath != "" {
+		fmt.Fprintf(m.out, "The previous configuration has been saved to %s\n", backupPath)
+	}
+	fmt.Fprintf(m.out, "The %s data has been left untouched. Start k0s to use the %s storage.\n", m.source.Type, m.target.Type)
+
+	return nil
+}
+
+func (m *migration) readSource(ctx context.Context) (_ *storage.Snapshot, err error) {
+	source, err := runBackend(ctx, m.k0sVars, m.source)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, source.stop()) }()
+
+	// Leaving other etcd members behind would split the cluster in two.
+	if m.source.Type == v1beta1.EtcdStorageType {
+		if err := source.CheckSingleMember(ctx); err != nil {
+			if errors.Is(err, storage.ErrMultipleMembers) {
+				err = fmt.Errorf("%w; remove all other controllers from the cluster before migrating", err)
+			}
+			return nil, err
+		}
+	}
+
+	snapshot, err := storage.TakeSnapshot(ctx, source, storage.KeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data from %s storage: %w", m.source.Type, err)
+	}
+
+	return snapshot, nil
+}
+
+func (m *migration) writeTarget(ctx context.Context, snapshot *storage.Snapshot, targetPath string) (_ *storage.CopyResult, err error) {
+	// Kubernetes resource versions are the storage revisions. Raise the target
+	// revision, so that they won't go backwards. Marking all revisions up to
+	// there as compacted lets clients that try to resume watches from older
+	// resource versions know that they need to re-list.
+	m.log.Infof("Raising the revision of %s storage to %d", m.target.Type, snapshot.Revision)
+	switch m.target.Type {
+	case v1beta1.EtcdStorageType:
+		err = m.bumpEtcdRevision(ctx, snapshot.Revision)
+	case v1beta1.KineStorageType:
+		err = m.bumpKineRevision(ctx, targetPath, snapshot.Revision)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to raise revision: %w", err)
+	}
+
+	target, err := runBackend(ctx, m.k0sVars, m.target)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, target.stop()) }()
+
+	result, err := storage.Copy(ctx, snapshot, target, storage.KeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.Verify(ctx, snapshot, target, storage.KeyPrefix, result); err != nil {
+		return nil, fmt.Errorf("verification failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// Raises the revision of a new etcd member by restoring a snapshot of it, just
+// like etcdutl snapshot restore --bump-revision --mark-compacted does.
+func (m *migration) bumpEtcdRevision(ctx context.Context, revision int64) error {
+	tmpDir, err := os.MkdirTemp("", "k0s-storage-migrate-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	snapshotPath := filepath.Join(tmpDir, "etcd-snapshot.db")
+
+	// Let etcd initialize its data directory, then take a snapshot of it.
+	target, err := runBackend(ctx, m.k0sVars, m.target)
+	if err != nil {
+		return err
+	}
+	_, err = snapshot.SaveWithVersion(ctx, zap.NewNop(), target.config, snapshotPath)
+	if err = errors.Join(err, target.stop()); err != nil {
+		return fmt.Errorf("failed to take snapshot: %w", err)
+	}
+
+	name, err := (&controller.Etcd{Config: m.target.Etcd}).MemberName()
+	if err != nil {
+		return err
+	}
+	peerURL := m.target.Etcd.GetPeerURL()
+
+	if err := os.RemoveAll(m.k0sVars.EtcdDataDir); err != nil {
+		return err
+	}
+	return utilsnapshot.NewV3(zap.NewNop()).Restore(utilsnapshot.RestoreConfig{
+		SnapshotPath:   snapshotPath,
+		Name:           name,
+		OutputDataDir:  m.k0sVars.EtcdDataDir,
+		PeerURLs:       []string{peerURL},
+		InitialCluster: name + "=" + peerURL,
+		RevisionBump:   uint64(revision),
+		MarkCompacted:  true,
+	})
+}
+
+// Raises the revision of a new kine SQLite database.
+func (m *migration) bumpKineRevision(ctx context.Context, path string, revision int64) (err error) {
+	// Let kine initialize its database.
+	target, err := runBackend(ctx, m.k0sVars, m.target)
+	if err != nil {
+		return err
+	}
+	if err := target.stop(); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", (&url.URL{Scheme: "file", Path: path}).String())
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, db.Close()) }()
+
+	return storage.BumpKineRevision(ctx, db, revision)
+}
+
+// Determines the storage configuration to migrate to. Retains the respective
+// settings of the current configuration, if any.
+func targetStorage(source *v1beta1.StorageSpec, to v1beta1.StorageType, dataDir string) (*v1beta1.StorageSpec, error) {
+	if source.Type == to {
+		return nil, fmt.Errorf("already using %s storage", to)
+	}
+	if source.Type == v1beta1.EtcdStorageType && source.Etcd.IsExternalClusterUsed() {
+		return nil, errors.New("migrating from an external etcd cluster isn't supported")
+	}
+
+	target := &v1beta1.StorageSpec{Type: to}
+	switch to {
+	case v1beta1.EtcdStorageType:
+		target.Etcd = source.Etcd
+		if target.Etcd == nil {
+			target.Etcd = v1beta1.DefaultEtcdConfig()
+		} else if target.Etcd.IsExternalClusterUsed() {
+			return nil, errors.New("migrating to an external etcd cluster isn't supported")
+		}
+
+	case v1beta1.KineStorageType:
+		target.Kine = source.Kine
+		if target.Kine == nil || target.Kine.DataSource == "" {
+			target.Kine = v1beta1.DefaultKineConfig(dataDir)
+		}
+	}
+
+	return target, nil
+}
+
+// Checks that the target storage's data location hasn't been used before, if
+// it's managed by k0s. Returns that location, so that it can be removed again
+// if the migration fails. Returns an empty path for data locations not managed
+// by k0s, which are only checked for emptiness once they are running.
+func checkTargetUnused(k0sVars *config.CfgVars, target *v1beta1.StorageSpec) (string, error) {
+	var path string
+	switch target.Type {
+	case v1beta1.EtcdStorageType:
+		path = k0sVars.EtcdDataDir
+		if file.Exists(filepath.Join(path, "member")) {
+			return "", fmt.Errorf("etcd data directory %s is already in use", path)
+		}
+
+	case v1beta1.KineStorageType:
+		backend, dsn, err := kine.SplitDataSource(target.Kine.DataSource)
+		if err != nil {
+			return "", fmt.Errorf("unsupported kine data source: %w", err)
+		}
+		if backend != "sqlite" {
+			return "", nil
+		}
+		if path, err = kine.GetSQLiteFilePath(k0sVars.DataDir, dsn); err != nil {
+			return "", nil
+		}
+		if file.Exists(path) {
+			return "", fmt.Errorf("SQLite database %s already exists", path)
+		}
+	}
+
+	return path, nil
+}
+
+// Updates the storage configuration in the given k0s configuration file, or
+// creates the file if it doesn't exist. All other settings are retained. The
+// previous file is backed up, and its path is returned.
+func switchStorageConfig(path string, target *v1beta1.StorageSpec) (backupPath string, _ error) {
+	doc := map[string]any{
+		"apiVersion": v1beta1.ClusterConfigAPIVersion,
+		"kind":       v1beta1.ClusterConfigKind,
+		"metadata":   map[string]any{"name": "k0s"},
+	}
+	mode := os.FileMode(0600)
+
+	content, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if stat, err := os.Stat(path); err == nil {
+			mode = stat.Mode().Perm()
+		}
+		backupPath = fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102150405"))
+		if err := file.WriteContentAtomically(backupPath, content, mode); err != nil {
+			return "", err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return "", err
+	}
+
+	spec := ensureMap(doc, "spec")
+	storageSpec := ensureMap(spec, "storage")
+	storageSpec["type"] = string(target.Type)
+	switch target.Type {
+	case v1beta1.EtcdStorageType:
+		etcdSpec := ensureMap(storageSpec, "etcd")
+		if _, ok := etcdSpec["peerAddress"]; !ok {
+			// Pin the address, as it's baked into the etcd peer certificate.
+			etcdSpec["peerAddress"] = target.Etcd.PeerAddress
+		}
+	case v1beta1.KineStorageType:
+		kineSpec := ensureMap(storageSpec, "kine")
+		if dataSource, _ := kineSpec["dataSource"].(string); dataSource == "" {
+			kineSpec["dataSource"] = target.Kine.DataSource
+		}
+	}
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return backupPath, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return backupPath, err
+	}
+	return backupPath, file.WriteContentAtomically(path, data, mode)
+}
+
+func ensureMap(m map[string]any, key string) map[string]any {
+	if value, ok := m[key].(map[string]any); ok {
+		return value
+	}
+	value := make(map[string]any)
+	m[key] = value
+	return value
+}
+
+// A storage backend that's run by this command.
+type runningBackend struct {
+	*storage.ClientBackend
+	component manager.Component
+	config    clientv3.Config
+}
+
+// Runs the storage backend for the given storage configuration, using the
+// same components as the controller.
+func runBackend(ctx context.Context, k0sVars *config.CfgVars, spec *v1beta1.StorageSpec) (_ *runningBackend, err error) {
+	var component manager.Component
+	var clientConfig func() (clientv3.Config, error)
+
+	switch spec.Type {
+	case v1beta1.KineStorageType:
+		component = &controller.Kine{Config: spec.Kine, K0sVars: k0sVars}
+		clientConfig = func() (clientv3.Config, error) {
+			return clientv3.Config{Endpoints: []string{(&url.URL{
+				Scheme: "unix", OmitHost: true,
+				Path: filepath.ToSlash(k0sVars.KineSocketPath),
+			}).String()}}, nil
+		}
+
+	case v1beta1.EtcdStorageType:
+		component = &controller.Etcd{
+			CertManager: certificate.Manager{K0sVars: k0sVars},
+			Config:      spec.Etcd,
+			K0sVars:     k0sVars,
+			LogLevel:    config.DefaultLogLevels().Etcd,
+			LeaveOnStop: func() bool { return false },
+		}
+		clientConfig = func() (clientv3.Config, error) {
+			// The client certificates are only available after etcd has been started.
+			client, err := etcd.NewClient(k0sVars.CertRootDir, k0sVars.EtcdCertDir, spec.Etcd)
+			if err != nil {
+				return clientv3.Config{}, err
+			}
+			defer client.Close()
+			return *client.Config, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid storage type: %s", spec.Type)
+	}
+
+	if err := component.Init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize %s: %w", spec.Type, err)
+	}
+	if err := component.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", spec.Type, err)
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, component.Stop())
+		}
+	}()
+
+	config, err := clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	backend, err := storage.NewClientBackend(config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, backend.Close())
+		}
+	}()
+
+	// Don't use the component's readiness checks, as kine's check writes a
+	// key that expires later on, which would interfere with the verification.
+	var lastErr error
+	if err := wait.PollUntilContextTimeout(ctx, 1*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_, lastErr = backend.Revision(ctx)
+		return lastErr == nil, nil
+	}); err != nil {
+		return nil, fmt.Errorf("%s didn't become ready: %w", spec.Type, errors.Join(err, lastErr))
+	}
+
+	return &runningBackend{backend, component, config}, nil
+}
+
+func (b *runningBackend) stop() error {
+	return errors.Join(b.Close(), b.component.Stop())
+}
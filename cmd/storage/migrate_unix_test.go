@@ -0,0 +1,148 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageMigrateCmd(t *testing.T) {
+	t.Run("requires_target", func(t *testing.T) {
+		cmd := storageMigrateCmd()
+		cmd.SetArgs(nil)
+		assert.ErrorContains(t, cmd.Execute(), `required flag(s) "to" not set`)
+	})
+
+	t.Run("rejects_unsupported_target", func(t *testing.T) {
+		cmd := storageMigrateCmd()
+		cmd.SetArgs([]string{"--to=postgres"})
+		assert.ErrorContains(t, cmd.Execute(), `unsupported storage type: "postgres"`)
+	})
+}
+
+func TestTargetStorage(t *testing.T) {
+	t.Run("KineToEtcd", func(t *testing.T) {
+		source := &v1beta1.StorageSpec{Type: v1beta1.KineStorageType, Kine: v1beta1.DefaultKineConfig("/data")}
+		target, err := targetStorage(source, v1beta1.EtcdStorageType, "/data")
+		require.NoError(t, err)
+		assert.Equal(t, v1beta1.EtcdStorageType, target.Type)
+		assert.Equal(t, v1beta1.DefaultEtcdConfig(), target.Etcd)
+	})
+
+	t.Run("EtcdToKine", func(t *testing.T) {
+		source := &v1beta1.StorageSpec{Type: v1beta1.EtcdStorageType, Etcd: v1beta1.DefaultEtcdConfig()}
+		target, err := targetStorage(source, v1beta1.KineStorageType, "/data")
+		require.NoError(t, err)
+		assert.Equal(t, v1beta1.KineStorageType, target.Type)
+		assert.Equal(t, v1beta1.DefaultKineConfig("/data"), target.Kine)
+	})
+
+	t.Run("RetainsKineDataSource", func(t *testing.T) {
+		source := &v1beta1.StorageSpec{
+			Type: v1beta1.EtcdStorageType,
+			Etcd: v1beta1.DefaultEtcdConfig(),
+			Kine: &v1beta1.KineConfig{DataSource: "mysql://k0s@tcp(db:3306)/k0s"},
+		}
+		target, err := targetStorage(source, v1beta1.KineStorageType, "/data")
+		require.NoError(t, err)
+		assert.Equal(t, "mysql://k0s@tcp(db:3306)/k0s", target.Kine.DataSource)
+	})
+
+	t.Run("SameType", func(t *testing.T) {
+		source := &v1beta1.StorageSpec{Type: v1beta1.EtcdStorageType, Etcd: v1beta1.DefaultEtcdConfig()}
+		_, err := targetStorage(source, v1beta1.EtcdStorageType, "/data")
+		assert.EqualError(t, err, "already using etcd storage")
+	})
+
+	t.Run("ExternalEtcd", func(t *testing.T) {
+		etcd := v1beta1.DefaultEtcdConfig()
+		etcd.ExternalCluster = &v1beta1.ExternalCluster{Endpoints: []string{"https://etcd:2379"}}
+		source := &v1beta1.StorageSpec{Type: v1beta1.EtcdStorageType, Etcd: etcd}
+		_, err := targetStorage(source, v1beta1.KineStorageType, "/data")
+		assert.EqualError(t, err, "migrating from an external etcd cluster isn't supported")
+	})
+}
+
+func TestSwitchStorageConfig(t *testing.T) {
+	t.Run("UpdatesExistingConfig", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "k0s.yaml")
+		original := `apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: k0s
+spec:
+  api:
+    address: 10.0.0.1
+  storage:
+    type: kine
+    kine:
+      dataSource: sqlite:///data/db/state.db
+`
+		require.NoError(t, os.WriteFile(path, []byte(original), 0640))
+
+		etcd := v1beta1.DefaultEtcdConfig()
+		etcd.PeerAddress = "10.0.0.1"
+		backupPath, err := switchStorageConfig(path, &v1beta1.StorageSpec{Type: v1beta1.EtcdStorageType, Etcd: etcd})
+		require.NoError(t, err)
+
+		if backup, err := os.ReadFile(backupPath); assert.NoError(t, err) {
+			assert.Equal(t, original, string(backup))
+		}
+		if stat, err := os.Stat(path); assert.NoError(t, err) {
+			assert.Equal(t, os.FileMode(0640), stat.Mode().Perm())
+		}
+		if updated, err := os.ReadFile(path); assert.NoError(t, err) {
+			assert.YAMLEq(t, `apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: k0s
+spec:
+  api:
+    address: 10.0.0.1
+  storage:
+    type: etcd
+    etcd:
+      peerAddress: 10.0.0.1
+    kine:
+      dataSource: sqlite:///data/db/state.db
+`, string(updated))
+		}
+	})
+
+	t.Run("CreatesMissingConfig", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "etc", "k0s.yaml")
+
+		backupPath, err := switchStorageConfig(path, &v1beta1.StorageSpec{
+			Type: v1beta1.KineStorageType,
+			Kine: v1beta1.DefaultKineConfig("/data"),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, backupPath)
+
+		if stat, err := os.Stat(path); assert.NoError(t, err) {
+			assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+		}
+		if updated, err := os.ReadFile(path); assert.NoError(t, err) {
+			assert.YAMLEq(t, `apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: k0s
+spec:
+  storage:
+    type: kine
+    kine:
+      dataSource: `+v1beta1.DefaultKineConfig("/data").DataSource+`
+`, string(updated))
+		}
+	})
+}
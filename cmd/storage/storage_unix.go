@@ -0,0 +1,31 @@
+//go:build unix
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"github.com/k0sproject/k0s/cmd/internal"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewStorageCmd() *cobra.Command {
+	var debugFlags internal.DebugFlags
+
+	cmd := &cobra.Command{
+		Use:              "storage",
+		Short:            "Manage the storage backend of the controller",
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE:             func(*cobra.Command, []string) error { return pflag.ErrHelp }, // Enforce arg validation
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+
+	cmd.AddCommand(storageMigrateCmd())
+
+	return cmd
+}
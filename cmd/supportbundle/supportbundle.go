@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supportbundle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/cmd/internal"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/supportbundle"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func NewSupportBundleCmd() *cobra.Command {
+	var (
+		debugFlags internal.DebugFlags
+		output     string
+		opts       = supportbundle.Options{
+			Since:   24 * time.Hour,
+			Timeout: 30 * time.Second,
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect diagnostic information into a tarball. Must be run as root (or with sudo)",
+		Long: `Collect diagnostic information into a tarball. Must be run as root (or with sudo).
+
+Collects the k0s version and configuration, the output of "k0s status" and
+"k0s sysinfo", the k0s logs, the state of the supervised processes, certificate
+expiry dates, the etcd cluster status, the containerd and kubelet
+configurations, as well as relevant Kubernetes objects. Secrets are redacted.
+
+Collection is best effort: information that can't be collected, e.g. because
+the Kubernetes API server is down, is skipped. The outcome of each collection
+step is recorded in the bundle's collection.log file.`,
+		Example: `  k0s support-bundle
+  k0s support-bundle --since 1h -o /tmp/bundle.tar.gz`,
+		Args:             cobra.NoArgs,
+		PersistentPreRun: debugFlags.Run,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			if os.Geteuid() != 0 {
+				return errors.New("this command must be run as root")
+			}
+
+			cmdOpts, err := config.GetCmdOpts(cmd)
+			if err != nil {
+				return err
+			}
+			opts.K0sVars = cmdOpts.K0sVars
+
+			if opts.Executable, err = os.Executable(); err != nil {
+				return err
+			}
+
+			name := bundleName()
+			if output == "" {
+				output = name + ".tar.gz"
+			} else {
+				name = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(output), ".gz"), ".tar")
+			}
+
+			f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, f.Close())
+				if err != nil {
+					_ = os.Remove(output)
+				}
+			}()
+
+			if err := supportbundle.Write(cmd.Context(), f, name, opts); err != nil {
+				return fmt.Errorf("failed to write support bundle: %w", err)
+			}
+
+			logrus.Info("Support bundle written to ", output)
+			return nil
+		},
+	}
+
+	debugFlags.AddToFlagSet(cmd.PersistentFlags())
+
+	flags := cmd.Flags()
+	flags.AddFlagSet(config.GetPersistentFlagSet())
+	flags.AddFlagSet(config.FileInputFlag())
+	flags.StringVarP(&output, "output", "o", "", "path of the support bundle to be written (default: k0s-support-bundle-<hostname>-<timestamp>.tar.gz)")
+	flags.DurationVar(&opts.Since, "since", opts.Since, "collect logs starting from this long ago")
+	flags.DurationVar(&opts.Timeout, "timeout", opts.Timeout, "maximum time for collecting a single piece of information")
+
+	return cmd
+}
+
+func bundleName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("k0s-support-bundle-%s-%s", hostname, time.Now().Format("20060102-150405"))
+}
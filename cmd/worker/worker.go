@@ -24,12 +24,14 @@ import (
 	"github.com/k0sproject/k0s/pkg/component/prober"
 	"github.com/k0sproject/k0s/pkg/component/status"
 	"github.com/k0sproject/k0s/pkg/component/worker"
+	"github.com/k0sproject/k0s/pkg/component/worker/cni"
 	workerconfig "github.com/k0sproject/k0s/pkg/component/worker/config"
 	"github.com/k0sproject/k0s/pkg/component/worker/containerd"
 	"github.com/k0sproject/k0s/pkg/component/worker/nllb"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/metrics"
 	"github.com/k0sproject/k0s/pkg/node"
 	"github.com/k0sproject/k0s/pkg/token"
 
@@ -79,6 +81,11 @@ func NewWorkerCmd() *cobra.Command {
 			if err := initLogging(ctx, opts.K0sVars.DataDir); err != nil {
 				return fmt.Errorf("failed to initialize logging: %w", err)
 			}
+			closeLogs, err := internal.ConfigureLogging(&opts.WorkerOptions, opts.K0sVars.DataDir)
+			if err != nil {
+				return fmt.Errorf("failed to configure logging: %w", err)
+			}
+			defer closeLogs()
 
 			c := (*Command)(opts)
 			if len(args) > 0 {
@@ -299,6 +306,11 @@ func (c *Command) Start(ctx context.Context, nodeName apitypes.NodeName, kubelet
 			PrimaryAddressFamily: workerConfig.PrimaryAddressFamily,
 		})
 
+	componentManager.Add(ctx, &cni.Cleanup{
+		NodeName:    nodeName,
+		CertManager: certManager,
+	})
+
 	addPlatformSpecificComponents(ctx, componentManager, c.K0sVars, workerConfig, controller, certManager)
 
 	if controller == nil {
@@ -320,6 +332,10 @@ func (c *Command) Start(ctx context.Context, nodeName apitypes.NodeName, kubelet
 			CertManager: certManager,
 			Socket:      c.K0sVars.StatusSocketPath,
 		})
+
+		if c.MetricsBindAddress != "" {
+			componentManager.Add(ctx, &metrics.Server{BindAddress: c.MetricsBindAddress})
+		}
 	}
 
 	// extract needed components
@@ -36,6 +36,12 @@ func FirstPublicAddress() (string, error) {
 		// Skip calico pod CNI interfaces
 		case strings.HasPrefix(i.Name, "cali"):
 			continue
+		// Skip cilium CNI interfaces (cilium_host, cilium_net, cilium_vxlan, ...)
+		case strings.HasPrefix(i.Name, "cilium_"):
+			continue
+		// Skip cilium pod CNI interfaces
+		case strings.HasPrefix(i.Name, "lxc"):
+			continue
 		}
 
 		addresses, err := interfaceAddrs(i)
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ComponentFiles is a logrus hook that additionally writes all log entries
+// that have a component field into a separate file per component. The files
+// are rotated as soon as they exceed a maximum size.
+type ComponentFiles struct {
+	Dir        string           // the directory in which to place the log files
+	MaxSize    int64            // the size in bytes after which files are rotated
+	MaxBackups int              // the number of rotated files to keep
+	Formatter  logrus.Formatter // formats the log entries
+
+	mu     sync.Mutex
+	files  map[string]*rotatingFile
+	closed bool
+}
+
+var _ logrus.Hook = (*ComponentFiles)(nil)
+
+// Levels implements [logrus.Hook].
+func (*ComponentFiles) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements [logrus.Hook].
+func (c *ComponentFiles) Fire(entry *logrus.Entry) error {
+	component, ok := entry.Data["component"].(string)
+	if !ok || component == "" {
+		return nil
+	}
+
+	line, err := c.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+
+	name := componentFileName(component)
+	file, ok := c.files[name]
+	if !ok {
+		if c.files == nil {
+			c.files = make(map[string]*rotatingFile)
+		}
+		file = &rotatingFile{
+			path:       filepath.Join(c.Dir, name+".log"),
+			maxSize:    c.MaxSize,
+			maxBackups: c.MaxBackups,
+		}
+		c.files[name] = file
+	}
+
+	return file.write(line)
+}
+
+// Close closes all open log files. Subsequent log entries won't be written to
+// files anymore.
+func (c *ComponentFiles) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	var errs []error
+	for _, file := range c.files {
+		errs = append(errs, file.close())
+	}
+	return errors.Join(errs...)
+}
+
+// Replaces all characters that shouldn't be part of file names.
+func componentFileName(component string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, component)
+}
+
+// A file that gets rotated when it exceeds its maximum size. The rotated files
+// get the suffixes .1, .2, ... with .1 being the most recent one.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+func (f *rotatingFile) write(p []byte) error {
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return err
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	f.file, f.size = file, stat.Size()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	backup := func(n int) string { return fmt.Sprintf("%s.%d", f.path, n) }
+	if f.maxBackups < 1 {
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else {
+		for n := f.maxBackups - 1; n > 0; n-- {
+			if err := os.Rename(backup(n), backup(n+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		if err := os.Rename(f.path, backup(1)); err != nil {
+			return err
+		}
+	}
+
+	return f.open()
+}
+
+func (f *rotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file, f.size = nil, 0
+	return err
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentFiles(t *testing.T) {
+	dir := t.TempDir()
+	formatter, err := NewFormatter(JSONFormat)
+	require.NoError(t, err)
+
+	underTest := &ComponentFiles{Dir: dir, MaxSize: 200, MaxBackups: 2, Formatter: formatter}
+	log := logrus.New()
+	log.SetOutput(new(nopWriter))
+	log.AddHook(underTest)
+
+	log.WithField("component", "kube/apiserver").WithField("pid", 42).Info("first")
+	log.WithField("component", "etcd").Info("etcd")
+	log.Info("no component")
+
+	data, err := os.ReadFile(filepath.Join(dir, "kube_apiserver.log"))
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "first", entry["msg"])
+	assert.Equal(t, "info", entry["level"])
+	assert.InDelta(t, 42, entry["pid"], 0)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"etcd.log", "kube_apiserver.log"}, names)
+
+	t.Run("Rotates", func(t *testing.T) {
+		for range 10 {
+			log.WithField("component", "rotated").Info("some message that takes up space")
+		}
+
+		for _, name := range []string{"rotated.log", "rotated.log.1", "rotated.log.2"} {
+			stat, err := os.Stat(filepath.Join(dir, name))
+			if assert.NoError(t, err) {
+				assert.LessOrEqual(t, stat.Size(), int64(200), "%s is too large", name)
+				assert.Positive(t, stat.Size(), "%s is empty", name)
+			}
+		}
+		assert.NoFileExists(t, filepath.Join(dir, "rotated.log.3"))
+	})
+
+	require.NoError(t, underTest.Close())
+	log.WithField("component", "closed").Info("after close")
+	assert.NoFileExists(t, filepath.Join(dir, "closed.log"))
+}
+
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
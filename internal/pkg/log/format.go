@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The supported log formats.
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// NewFormatter returns a logrus formatter for the given log format.
+func NewFormatter(format string) (logrus.Formatter, error) {
+	switch format {
+	case "", TextFormat:
+		return newTextFormatter(), nil
+
+	case JSONFormat:
+		formatter := new(logrus.JSONFormatter)
+		formatter.TimestampFormat = time.RFC3339Nano
+		return formatter, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported log format %q (valid values: %s, %s)", format, TextFormat, JSONFormat)
+	}
+}
+
+func newTextFormatter() *logrus.TextFormatter {
+	formatter := new(logrus.TextFormatter)
+	formatter.TimestampFormat = "2006-01-02 15:04:05"
+	formatter.FullTimestamp = true
+	return formatter
+}
+
+// SetFormat sets the format of the k0s log output. Fails for unsupported
+// formats, leaving the current format in place.
+func SetFormat(format string) error {
+	formatter, err := NewFormatter(format)
+	if err != nil {
+		return err
+	}
+
+	logrus.SetFormatter(formatter)
+	return nil
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFormat(t *testing.T) {
+	previous := logrus.StandardLogger().Formatter
+	t.Cleanup(func() { logrus.SetFormatter(previous) })
+
+	if assert.NoError(t, SetFormat(JSONFormat)) {
+		assert.IsType(t, &logrus.JSONFormatter{}, logrus.StandardLogger().Formatter)
+	}
+
+	err := SetFormat("yaml")
+	assert.ErrorContains(t, err, `unsupported log format "yaml" (valid values: text, json)`)
+	assert.IsType(t, &logrus.JSONFormatter{}, logrus.StandardLogger().Formatter, "format shouldn't have changed")
+}
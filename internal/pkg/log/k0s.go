@@ -18,10 +18,8 @@ type ShutdownLoggingFunc func()
 func InitLogging() (Backend, ShutdownLoggingFunc) {
 	backend, shutdown := installBackend()
 
-	customFormatter := new(logrus.TextFormatter)
-	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
-	customFormatter.FullTimestamp = true
-	logrus.SetFormatter(customFormatter)
+	// Use the text format until the command line has been parsed.
+	logrus.SetFormatter(newTextFormatter())
 
 	cfssllog.SetLogger((*cfsslAdapter)(logrus.WithField("component", "cfssl")))
 	crlog.SetLogger(logrusr.New(logrus.WithField("component", "controller-runtime")))
@@ -5,6 +5,7 @@ package log
 
 import (
 	"bytes"
+	"regexp"
 	"unicode/utf8"
 
 	"github.com/sirupsen/logrus"
@@ -16,11 +17,13 @@ import (
 // This is in contrast to logrus's implementation of io.Writer, which simply
 // errors out if the log line gets longer than 64k.
 type Writer struct {
-	log     *logrus.Entry // receives (possibly chunked) log lines
-	level   logrus.Level  // log level used to log lines
-	buf     []byte        // buffer in which to accumulate chunks; len(buf) determines the chunk length
-	len     int           // current buffer length
-	chunkNo uint          // current chunk number; 0 means "no chunk"
+	log         *logrus.Entry // receives (possibly chunked) log lines
+	level       logrus.Level  // log level used to log lines
+	parseLevels bool          // whether to detect the log level of lines
+	buf         []byte        // buffer in which to accumulate chunks; len(buf) determines the chunk length
+	len         int           // current buffer length
+	chunkNo     uint          // current chunk number; 0 means "no chunk"
+	chunkLevel  logrus.Level  // log level of the current chunked line
 }
 
 func NewWriter(log *logrus.Entry, level logrus.Level, chunkLen int) *Writer {
@@ -31,6 +34,14 @@ func NewWriter(log *logrus.Entry, level logrus.Level, chunkLen int) *Writer {
 	}
 }
 
+// ParseLevels makes the writer detect the log level of the lines it forwards,
+// using [ParseLevel]. Lines are logged at the detected level if it's more
+// severe than the writer's level.
+func (w *Writer) ParseLevels() *Writer {
+	w.parseLevels = true
+	return w
+}
+
 // Write implements [io.Writer].
 func (w *Writer) Write(in []byte) (int, error) {
 	w.writeBytes(in)
@@ -61,10 +72,10 @@ func (w *Writer) writeBytes(in []byte) {
 			line := bytes.TrimRight(w.buf[off:off+idx], "\r")
 
 			if w.chunkNo == 0 {
-				w.log.Logf(w.level, "%s", line)
+				w.log.Logf(w.lineLevel(line), "%s", line)
 			} else {
 				if len(line) > 0 {
-					w.log.WithField("chunk", w.chunkNo+1).Logf(w.level, "%s", line)
+					w.log.WithField("chunk", w.chunkNo+1).Logf(w.chunkLevel, "%s", line)
 				}
 				w.chunkNo = 0
 			}
@@ -86,9 +97,75 @@ func (w *Writer) writeBytes(in []byte) {
 			// Strip trailing carriage returns
 			line := bytes.TrimRight(w.buf[:len], "\r")
 
-			w.log.WithField("chunk", w.chunkNo+1).Logf(w.level, "%s", line)
+			if w.chunkNo == 0 {
+				w.chunkLevel = w.lineLevel(line)
+			}
+			w.log.WithField("chunk", w.chunkNo+1).Logf(w.chunkLevel, "%s", line)
 			w.chunkNo++                      // increase chunk number
 			w.len = copy(w.buf, w.buf[len:]) // discard logged bytes
 		}
 	}
 }
+
+func (w *Writer) lineLevel(line []byte) logrus.Level {
+	if w.parseLevels {
+		if level, ok := ParseLevel(line); ok && level < w.level {
+			return level
+		}
+	}
+	return w.level
+}
+
+// Matches the level in logfmt and JSON formatted lines, e.g. level=info or
+// "level":"info".
+var levelPattern = regexp.MustCompile(`(?:^|[\s{,])"?(?:level|lvl)"?\s*[=:]\s*"?([A-Za-z]+)`)
+
+// ParseLevel tries to detect the log level of a line emitted by a supervised
+// component. It understands the klog header format (e.g. I0708 08:46:25...),
+// as well as logfmt and JSON formatted lines with a level field. Fatal and
+// panic levels are reported as errors, as they're only informational here.
+func ParseLevel(line []byte) (logrus.Level, bool) {
+	if len(line) > 5 && isDigits(line[1:5]) {
+		switch line[0] {
+		case 'I':
+			return logrus.InfoLevel, true
+		case 'W':
+			return logrus.WarnLevel, true
+		case 'E', 'F':
+			return logrus.ErrorLevel, true
+		}
+	}
+
+	if match := levelPattern.FindSubmatch(line); match != nil {
+		level, err := logrus.ParseLevel(string(bytes.ToLower(match[1])))
+		switch {
+		case err != nil:
+			// Try some common abbreviations before giving up.
+			switch string(bytes.ToLower(match[1])) {
+			case "dbg":
+				return logrus.DebugLevel, true
+			case "inf":
+				return logrus.InfoLevel, true
+			case "wrn":
+				return logrus.WarnLevel, true
+			case "err", "eror", "crit", "critical":
+				return logrus.ErrorLevel, true
+			}
+		case level < logrus.ErrorLevel:
+			return logrus.ErrorLevel, true
+		default:
+			return level, true
+		}
+	}
+
+	return 0, false
+}
+
+func isDigits(b []byte) bool {
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -94,3 +94,53 @@ func TestWriter(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_ParseLevels(t *testing.T) {
+	log, logs := logtest.NewNullLogger()
+	log.SetLevel(logrus.TraceLevel)
+	underTest := NewWriter(logrus.NewEntry(log), logrus.InfoLevel, 32).ParseLevels()
+
+	underTest.writeBytes([]byte("E0708 08:46:25.876821 failure\n"))
+	underTest.writeBytes([]byte("level=debug msg=details\n"))
+	underTest.writeBytes([]byte("this is a long warning line, level=warn\n"))
+	underTest.writeBytes([]byte("level=error and some more text to chunk\n"))
+
+	var levels []logrus.Level
+	for _, entry := range logs.AllEntries() {
+		levels = append(levels, entry.Level)
+	}
+	assert.Equal(t, []logrus.Level{
+		logrus.ErrorLevel,
+		logrus.InfoLevel, // levels below the writer's level are raised
+		logrus.InfoLevel, // the level is only detected in the first chunk ...
+		logrus.InfoLevel,
+		logrus.ErrorLevel, // ... and used for all chunks
+		logrus.ErrorLevel,
+	}, levels)
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, test := range []struct {
+		line  string
+		level logrus.Level
+		ok    bool
+	}{
+		{"I0708 08:46:25.876821    1814 operation_generator.go:721] mounted", logrus.InfoLevel, true},
+		{"W0708 08:46:25.876821    1814 reflector.go:561] watch failed", logrus.WarnLevel, true},
+		{"E0708 08:46:25.876821    1814 server.go:302] error", logrus.ErrorLevel, true},
+		{"F0708 08:46:25.876821    1814 server.go:302] fatal", logrus.ErrorLevel, true},
+		{`time="2024-07-08T08:46:25Z" level=warning msg="something"`, logrus.WarnLevel, true},
+		{`{"level":"info","ts":"2024-07-08T08:46:25Z","msg":"etcd"}`, logrus.InfoLevel, true},
+		{`{"level":"panic","msg":"etcd"}`, logrus.ErrorLevel, true},
+		{`[2024-07-08 08:46:25.876][1][debug][main] lvl=DBG envoy`, logrus.DebugLevel, true},
+		{"Ignoring this line", 0, false},
+		{"Invalid level=foo", 0, false},
+		{"sublevel=error", 0, false},
+	} {
+		t.Run(test.line, func(t *testing.T) {
+			level, ok := ParseLevel([]byte(test.line))
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.level, level)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tcpproxy
+
+import (
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Policy determines how a Proxy distributes connections among the routes of a
+// listener.
+type Policy int
+
+const (
+	// RoundRobin sends connections to the routes in turn.
+	RoundRobin Policy = iota
+
+	// LeastConnections sends each connection to the route with the fewest
+	// active connections. Ties are broken in a round robin fashion.
+	LeastConnections
+
+	// PowerOfTwoChoices picks two routes at random and sends the connection to
+	// the one with fewer active connections.
+	PowerOfTwoChoices
+)
+
+// The state of a single route of a listener. Backends are identified by their
+// address, so that their state survives route updates.
+type backend struct {
+	route Route
+
+	// The connections currently being established or proxied to this
+	// backend. The established ones are tracked in conns (src => dst).
+	active int
+	conns  map[net.Conn]net.Conn
+
+	failures      int // consecutive dial failures
+	ejections     int // consecutive ejections, for exponential backoff
+	ejectedUntil  time.Time
+	ejectionTimer *time.Timer
+
+	removed    bool // no longer part of the routes, draining connections
+	drained    bool // the drain timeout expired, connections got closed
+	drainTimer *time.Timer
+}
+
+func (p *Proxy) maxDialFailures() int {
+	if p.MaxDialFailures != 0 {
+		return p.MaxDialFailures
+	}
+	return 3
+}
+
+func (p *Proxy) ejectionTime() time.Duration {
+	if p.EjectionTime > 0 {
+		return p.EjectionTime
+	}
+	return 5 * time.Second
+}
+
+func (p *Proxy) maxEjectionTime() time.Duration {
+	if p.MaxEjectionTime > 0 {
+		return p.MaxEjectionTime
+	}
+	return 2 * time.Minute
+}
+
+func (p *Proxy) drainTimeout() time.Duration {
+	if p.DrainTimeout != 0 {
+		return p.DrainTimeout
+	}
+	return time.Minute
+}
+
+func (p *Proxy) log() logrus.FieldLogger {
+	return logrus.WithFields(logrus.Fields{"component": "tcpproxy", "proxy": p.Name})
+}
+
+// Synchronizes the backends of cfg with its routes. Backends that are no
+// longer routed to get drained. Needs to be called with the lock held.
+func (p *Proxy) updateBackends(cfg *config) {
+	if cfg.backends == nil {
+		cfg.backends = make(map[string]*backend)
+	}
+
+	for _, route := range cfg.routes {
+		if b, ok := cfg.backends[route.Addr]; ok {
+			b.route = route
+			if b.removed {
+				b.removed, b.drained = false, false
+				if b.drainTimer != nil {
+					b.drainTimer.Stop()
+					b.drainTimer = nil
+				}
+			}
+		} else {
+			cfg.backends[route.Addr] = &backend{route: route}
+		}
+	}
+
+	for addr, b := range cfg.backends {
+		if b.removed || slices.ContainsFunc(cfg.routes, func(r Route) bool { return r.Addr == addr }) {
+			continue
+		}
+
+		b.removed = true
+		if b.active == 0 {
+			p.forget(cfg, b)
+			continue
+		}
+
+		p.log().Infof("Draining %d connections to %s", b.active, addr)
+		if timeout := p.drainTimeout(); timeout > 0 {
+			b.drainTimer = time.AfterFunc(timeout, func() { p.closeDrained(b) })
+		}
+	}
+}
+
+// Closes all connections to a removed backend whose drain timeout expired.
+func (p *Proxy) closeDrained(b *backend) {
+	p.mux.Lock()
+	if !b.removed {
+		p.mux.Unlock()
+		return // re-added in the meantime
+	}
+	b.drained = true
+	addr := b.route.Addr
+	conns := make([]net.Conn, 0, 2*len(b.conns))
+	for src, dst := range b.conns {
+		conns = append(conns, src, dst)
+	}
+	p.mux.Unlock()
+
+	if len(conns) > 0 {
+		p.log().Infof("Closing %d connections to %s after drain timeout", len(conns)/2, addr)
+	}
+	for _, c := range conns {
+		_ = c.Close()
+	}
+}
+
+// Removes a backend from cfg. Needs to be called with the lock held.
+func (p *Proxy) forget(cfg *config, b *backend) {
+	if b.drainTimer != nil {
+		b.drainTimer.Stop()
+	}
+	if b.ejectionTimer != nil {
+		b.ejectionTimer.Stop()
+	}
+	delete(cfg.backends, b.route.Addr)
+	deleteBackendMetrics(p.Name, b.route.Addr)
+}
+
+// Picks the backend for a new connection, skipping the ones that have already
+// been tried. Ejected backends are only considered if there's nothing else
+// left. Returns nil if there are no backends left. The picked backend's active
+// connection count is incremented. Needs to be called with the lock held.
+func (p *Proxy) pick(cfg *config, tried []*backend) *backend {
+	now := time.Now()
+	var candidates, ejected []*backend
+	for _, route := range cfg.routes {
+		b := cfg.backends[route.Addr]
+		if b == nil || slices.Contains(tried, b) || slices.Contains(candidates, b) || slices.Contains(ejected, b) {
+			continue
+		}
+		if now.Before(b.ejectedUntil) {
+			ejected = append(ejected, b)
+		} else {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) < 1 {
+		if len(ejected) < 1 {
+			return nil
+		}
+		candidates = ejected
+	}
+
+	var picked *backend
+	switch n := len(candidates); p.Policy {
+	case LeastConnections:
+		// Start at a rotating offset, so that ties are broken in turn.
+		p.connNumber++
+		for i := range n {
+			b := candidates[(p.connNumber+i)%n]
+			if picked == nil || b.active < picked.active {
+				picked = b
+			}
+		}
+
+	case PowerOfTwoChoices:
+		picked = candidates[0]
+		if n > 1 {
+			i, j := rand.IntN(n), rand.IntN(n-1)
+			if j >= i {
+				j++
+			}
+			picked = candidates[i]
+			if candidates[j].active < picked.active {
+				picked = candidates[j]
+			}
+		}
+
+	default:
+		p.connNumber++
+		picked = candidates[p.connNumber%n]
+	}
+
+	picked.active++
+	backendActiveConnections.WithLabelValues(p.Name, picked.route.Addr).Inc()
+	return picked
+}
+
+// Records a successfully established connection to a backend. Returns false if
+// the backend has been drained or the proxy has been closed in the meantime,
+// and the connection must not be used.
+func (p *Proxy) connected(b *backend, src, dst net.Conn) bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if b.drained || p.closed {
+		return false
+	}
+
+	b.failures, b.ejections = 0, 0
+	if !b.ejectedUntil.IsZero() {
+		b.ejectedUntil = time.Time{}
+		if b.ejectionTimer != nil {
+			b.ejectionTimer.Stop()
+			b.ejectionTimer = nil
+		}
+		backendEjected.WithLabelValues(p.Name, b.route.Addr).Set(0)
+	}
+
+	if b.conns == nil {
+		b.conns = make(map[net.Conn]net.Conn)
+	}
+	b.conns[src] = dst
+	backendConnections.WithLabelValues(p.Name, b.route.Addr).Inc()
+	return true
+}
+
+// Records a failed attempt to connect to a backend, and ejects it for an
+// exponentially increasing amount of time if it failed too often in a row.
+func (p *Proxy) dialFailed(cfg *config, b *backend, err error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	addr := b.route.Addr
+	b.failures++
+	backendDialFailures.WithLabelValues(p.Name, addr).Inc()
+
+	if maxFailures := p.maxDialFailures(); maxFailures > 0 && b.failures >= maxFailures {
+		duration := p.ejectionTime()
+		for i := 0; i < b.ejections && duration < p.maxEjectionTime(); i++ {
+			duration *= 2
+		}
+		duration = min(duration, p.maxEjectionTime())
+
+		b.ejections++
+		b.ejectedUntil = time.Now().Add(duration)
+		backendEjected.WithLabelValues(p.Name, addr).Set(1)
+		p.log().WithError(err).Warnf("Ejecting %s for %s after %d consecutive dial failures", addr, duration, b.failures)
+
+		if b.ejectionTimer != nil {
+			b.ejectionTimer.Stop()
+		}
+		b.ejectionTimer = time.AfterFunc(duration, func() {
+			p.mux.Lock()
+			defer p.mux.Unlock()
+			if cfg.backends[addr] == b && !time.Now().Before(b.ejectedUntil) {
+				backendEjected.WithLabelValues(p.Name, addr).Set(0)
+			}
+		})
+	}
+
+	p.release(cfg, b, nil)
+}
+
+// Releases a connection that was accounted for when picking the backend.
+// Needs to be called with the lock held.
+func (p *Proxy) release(cfg *config, b *backend, src net.Conn) {
+	b.active--
+	if src != nil {
+		delete(b.conns, src)
+	}
+	backendActiveConnections.WithLabelValues(p.Name, b.route.Addr).Dec()
+
+	if b.removed && b.active == 0 && cfg.backends[b.route.Addr] == b {
+		p.forget(cfg, b)
+	}
+}
+
+// BackendStatus is the state of a backend of a Proxy.
+type BackendStatus struct {
+	Addr              string
+	ActiveConnections int
+	Ejected           bool
+}
+
+// Backends returns the state of the backends of all listeners, sorted by
+// address. Backends that are only being drained are omitted.
+func (p *Proxy) Backends() []BackendStatus {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	now := time.Now()
+	var backends []BackendStatus
+	for _, cfg := range p.configs {
+		for _, b := range cfg.backends {
+			if !b.removed {
+				backends = append(backends, BackendStatus{
+					Addr:              b.route.Addr,
+					ActiveConnections: b.active,
+					Ejected:           now.Before(b.ejectedUntil),
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(backends, func(a, b BackendStatus) int { return strings.Compare(a.Addr, b.Addr) })
+	return backends
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tcpproxy
+
+import (
+	"github.com/k0sproject/k0s/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	backendLabels = []string{"proxy", "backend"}
+
+	backendActiveConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "tcpproxy",
+		Name:      "backend_active_connections",
+		Help:      "Number of connections that are currently proxied to a backend.",
+	}, backendLabels)
+
+	backendConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "tcpproxy",
+		Name:      "backend_connections_total",
+		Help:      "Number of connections that have been established to a backend.",
+	}, backendLabels)
+
+	backendDialFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "tcpproxy",
+		Name:      "backend_dial_failures_total",
+		Help:      "Number of failed attempts to connect to a backend.",
+	}, backendLabels)
+
+	backendEjected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "tcpproxy",
+		Name:      "backend_ejected",
+		Help:      "Whether a backend is currently ejected because of failed connection attempts.",
+	}, backendLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(backendActiveConnections, backendConnections, backendDialFailures, backendEjected)
+}
+
+func deleteBackendMetrics(proxy, backend string) {
+	backendActiveConnections.DeleteLabelValues(proxy, backend)
+	backendConnections.DeleteLabelValues(proxy, backend)
+	backendDialFailures.DeleteLabelValues(proxy, backend)
+	backendEjected.DeleteLabelValues(proxy, backend)
+}
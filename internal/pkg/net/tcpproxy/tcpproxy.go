@@ -28,6 +28,7 @@ type Proxy struct {
 	configs map[string]*config // ip:port => config
 
 	lns        []net.Listener
+	closed     bool          // Close has been called
 	donec      chan struct{} // closed before err
 	err        error         // any error from listening
 	connNumber int           // connection number counter, used for round robin
@@ -37,6 +38,32 @@ type Proxy struct {
 	// The provided net is always "tcp". This is to match
 	// the signature of net.Listen.
 	ListenFunc func(net, laddr string) (net.Listener, error)
+
+	// Name optionally identifies the proxy in its metrics.
+	Name string
+
+	// Policy determines how connections are distributed among
+	// the routes of a listener. The default is RoundRobin.
+	Policy Policy
+
+	// MaxDialFailures is the number of consecutive failed
+	// attempts to connect to a route after which the route gets
+	// ejected, i.e. it won't receive new connections for a
+	// while. If zero, a default is used. If negative, routes are
+	// never ejected.
+	MaxDialFailures int
+
+	// EjectionTime is the duration of a route's first ejection.
+	// It doubles for each subsequent ejection without a
+	// successful connection in between, up to MaxEjectionTime.
+	// If zero, a default is used.
+	EjectionTime, MaxEjectionTime time.Duration
+
+	// DrainTimeout is how long the connections to a route are
+	// kept open after it has been removed. If zero, a default is
+	// used. If negative, they're kept open until either side
+	// closes them.
+	DrainTimeout time.Duration
 }
 
 // Matcher reports whether hostname matches the Matcher's criteria.
@@ -44,7 +71,8 @@ type Matcher func(ctx context.Context, hostname string) bool
 
 // config contains the proxying state for one listener.
 type config struct {
-	routes []Route
+	routes   []Route
+	backends map[string]*backend // addr => backend
 }
 
 func (p *Proxy) netListen() func(net, laddr string) (net.Listener, error) {
@@ -67,13 +95,14 @@ func (p *Proxy) configFor(ipPort string) *config {
 func (p *Proxy) setRoutes(ipPort string, routes []Route) {
 	cfg := p.configFor(ipPort)
 	cfg.routes = routes
+	p.updateBackends(cfg)
 }
 
 // SetRoutes replaces routes for the ipPort.
 //
-// It's possible that the old routes are still used once after this
-// function is called. If an empty slice is passed, the routes are
-// preserved in order to avoid an infinite loop.
+// Removed routes won't receive new connections. Their existing
+// connections are drained, i.e. closed after DrainTimeout. Passing an
+// empty slice is a programming error and panics.
 func (p *Proxy) SetRoutes(ipPort string, targets []Route) {
 	p.mux.Lock()
 	defer p.mux.Unlock()
@@ -95,7 +124,8 @@ func (p *Proxy) Run() error {
 }
 
 // Wait waits for the Proxy to finish running. Currently this can only
-// happen if a Listener is closed, or Close is called on the proxy.
+// happen if a Listener is closed, or Close is called on the proxy. It
+// returns immediately if the proxy has no listeners.
 //
 // It is only valid to call Wait after a successful call to Start.
 func (p *Proxy) Wait() error {
@@ -103,11 +133,28 @@ func (p *Proxy) Wait() error {
 	return p.err
 }
 
-// Close closes all the proxy's self-opened listeners.
+// Close closes all the proxy's self-opened listeners, as well as all
+// connections that are currently being proxied.
 func (p *Proxy) Close() error {
 	for _, c := range p.lns {
 		c.Close()
 	}
+
+	p.mux.Lock()
+	p.closed = true
+	var conns []net.Conn
+	for _, cfg := range p.configs {
+		for _, b := range cfg.backends {
+			for src, dst := range b.conns {
+				conns = append(conns, src, dst)
+			}
+		}
+	}
+	p.mux.Unlock()
+
+	for _, c := range conns {
+		c.Close()
+	}
 	return nil
 }
 
@@ -133,6 +180,10 @@ func (p *Proxy) Start() error {
 		p.lns = append(p.lns, ln)
 		go p.serveListener(errc, ln, config)
 	}
+	if len(p.lns) < 1 {
+		close(p.donec) // nothing to run
+		return nil
+	}
 	go p.awaitFirstError(errc)
 	return nil
 }
@@ -154,15 +205,10 @@ func (p *Proxy) serveListener(ret chan<- error, ln net.Listener, cfg *config) {
 }
 
 // serveConn runs in its own goroutine and matches c against routes.
-// It returns whether it matched purely for testing.
+// If connecting to the picked route fails, the remaining routes are
+// tried in turn. It returns whether it matched purely for testing.
 func (p *Proxy) serveConn(c net.Conn, cfg *config) bool {
 	br := bufio.NewReader(c)
-
-	p.mux.RLock()
-	p.connNumber++
-	route := cfg.routes[p.connNumber%(len(cfg.routes))]
-	p.mux.RUnlock()
-
 	if n := br.Buffered(); n > 0 {
 		peeked, _ := br.Peek(br.Buffered())
 		c = &Conn{
@@ -170,7 +216,50 @@ func (p *Proxy) serveConn(c net.Conn, cfg *config) bool {
 			Conn:   c,
 		}
 	}
-	route.HandleConn(c)
+
+	var (
+		tried     []*backend
+		lastRoute *Route
+		lastErr   error
+	)
+	for {
+		p.mux.Lock()
+		b := p.pick(cfg, tried)
+		var route Route
+		if b != nil {
+			route = b.route
+		}
+		p.mux.Unlock()
+		if b == nil {
+			break
+		}
+		tried = append(tried, b)
+
+		dst, err := route.dial(c)
+		if err != nil {
+			p.dialFailed(cfg, b, err)
+			lastRoute, lastErr = &route, err
+			continue
+		}
+
+		if p.connected(b, c, dst) {
+			route.pipe(c, dst)
+		} else {
+			c.Close()
+			dst.Close()
+		}
+
+		p.mux.Lock()
+		p.release(cfg, b, c)
+		p.mux.Unlock()
+		return true
+	}
+
+	if lastRoute == nil {
+		c.Close()
+		return false
+	}
+	lastRoute.onDialError()(c, lastErr)
 	return true
 }
 
@@ -292,6 +381,16 @@ func closeWrite(c net.Conn) {
 
 // HandleConn implements the Target interface.
 func (r *Route) HandleConn(src net.Conn) {
+	dst, err := r.dial(src)
+	if err != nil {
+		r.onDialError()(src, err)
+		return
+	}
+	r.pipe(src, dst)
+}
+
+// dial connects to Addr and sends the PROXY header, if any.
+func (r *Route) dial(src net.Conn) (net.Conn, error) {
 	ctx := context.Background()
 	var cancel context.CancelFunc
 	if r.DialTimeout >= 0 {
@@ -302,15 +401,21 @@ func (r *Route) HandleConn(src net.Conn) {
 		cancel()
 	}
 	if err != nil {
-		r.onDialError()(src, err)
-		return
+		return nil, err
 	}
-	defer dst.Close()
 
 	if err = r.sendProxyHeader(dst, src); err != nil {
-		r.onDialError()(src, err)
-		return
+		dst.Close()
+		return nil, err
 	}
+
+	return dst, nil
+}
+
+// pipe copies data between src and dst in both directions until
+// both are done, and closes them afterwards.
+func (r *Route) pipe(src, dst net.Conn) {
+	defer dst.Close()
 	defer src.Close()
 
 	if ka := r.keepAlivePeriod(); ka > 0 {
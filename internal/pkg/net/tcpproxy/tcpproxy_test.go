@@ -0,0 +1,487 @@
+// SPDX-FileCopyrightText: Copyright 2017 Google Inc.
+// SPDX-FileCopyrightText: 2024 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tcpproxy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestProxyStartNone(t *testing.T) {
+	var p Proxy
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newLocalListener(t *testing.T) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		ln, err = net.Listen("tcp", "[::1]:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ln
+}
+
+const testFrontAddr = "1.2.3.4:567"
+
+func testListenFunc(t *testing.T, ln net.Listener) func(network, laddr string) (net.Listener, error) {
+	return func(network, laddr string) (net.Listener, error) {
+		if network != "tcp" {
+			t.Errorf("got Listen call with network %q, not tcp", network)
+			return nil, errors.New("invalid network")
+		}
+		if laddr != testFrontAddr {
+			t.Fatalf("got Listen call with laddr %q, want %q", laddr, testFrontAddr)
+			panic("bogus address")
+		}
+		return ln, nil
+	}
+}
+
+func testProxy(t *testing.T, front net.Listener) *Proxy {
+	return &Proxy{
+		ListenFunc: testListenFunc(t, front),
+	}
+}
+
+func TestBufferedClose(t *testing.T) {
+	front := newLocalListener(t)
+	defer front.Close()
+	back := newLocalListener(t)
+	defer back.Close()
+
+	p := testProxy(t, front)
+	p.SetRoutes(testFrontAddr, []Route{To(back.Addr().String())})
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	toFront, err := net.Dial("tcp", front.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer toFront.Close()
+
+	fromProxy, err := back.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fromProxy.Close()
+	const msg = "message"
+	if _, err := io.WriteString(toFront, msg); err != nil {
+		t.Fatal(err)
+	}
+	// actively close toFront, the write should still make to the back.
+	toFront.Close()
+
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(fromProxy, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != msg {
+		t.Fatalf("got %q; want %q", buf, msg)
+	}
+}
+
+func TestProxyCloseConns(t *testing.T) {
+	front := newLocalListener(t)
+	defer front.Close()
+	back := newLocalListener(t)
+	defer back.Close()
+
+	p := testProxy(t, front)
+	p.SetRoutes(testFrontAddr, []Route{To(back.Addr().String())})
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	toFront, err := net.Dial("tcp", front.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer toFront.Close()
+
+	fromProxy, err := back.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fromProxy.Close()
+
+	// Wait until the connection is established.
+	const msg = "message"
+	if _, err := io.WriteString(toFront, msg); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(fromProxy, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Wait(); err == nil {
+		t.Fatal("expected an error from the closed listener")
+	}
+
+	// Both sides of the proxied connection get closed.
+	for _, c := range []net.Conn{toFront, fromProxy} {
+		if err := c.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Read(buf); err != io.EOF {
+			t.Fatalf("got %v; want EOF", err)
+		}
+	}
+}
+
+func TestProxyAlwaysMatch(t *testing.T) {
+	front := newLocalListener(t)
+	defer front.Close()
+	back := newLocalListener(t)
+	defer back.Close()
+
+	p := testProxy(t, front)
+	p.setRoutes(testFrontAddr, []Route{To(back.Addr().String())})
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	toFront, err := net.Dial("tcp", front.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer toFront.Close()
+
+	fromProxy, err := back.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fromProxy.Close()
+	const msg = "message"
+	_, _ = io.WriteString(toFront, msg)
+
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(fromProxy, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != msg {
+		t.Fatalf("got %q; want %q", buf, msg)
+	}
+}
+
+func TestProxyPROXYOut(t *testing.T) {
+	front := newLocalListener(t)
+	defer front.Close()
+	back := newLocalListener(t)
+	defer back.Close()
+
+	p := testProxy(t, front)
+	p.SetRoutes(testFrontAddr, []Route{{
+		Addr:                 back.Addr().String(),
+		ProxyProtocolVersion: 1,
+	}})
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	toFront, err := net.Dial("tcp", front.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = io.WriteString(toFront, "foo")
+	toFront.Close()
+
+	fromProxy, err := back.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err := io.ReadAll(fromProxy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf("PROXY TCP4 %s %s %d %d\r\nfoo", toFront.LocalAddr().(*net.TCPAddr).IP, toFront.RemoteAddr().(*net.TCPAddr).IP, toFront.LocalAddr().(*net.TCPAddr).Port, toFront.RemoteAddr().(*net.TCPAddr).Port)
+	if string(bs) != want {
+		t.Fatalf("got %q; want %q", bs, want)
+	}
+}
+
+func TestSetRoutes(t *testing.T) {
+
+	var p Proxy
+	ipPort := ":8080"
+	p.setRoutes(ipPort, []Route{To("127.0.0.2:8080")})
+	cfg := p.configFor(ipPort)
+
+	expectedAddrsList := [][]string{
+		{"127.0.0.1:80"},
+		{"127.0.0.1:80", "127.0.0.1:443"},
+		{},
+		{"127.0.0.1:80"},
+	}
+
+	for _, expectedAddrs := range expectedAddrsList {
+		p.setRoutes(ipPort, stringsToTargets(expectedAddrs))
+		if !equalRoutes(cfg.routes, expectedAddrs) {
+			t.Fatalf("got %v; want %v", cfg.routes, expectedAddrs)
+		}
+	}
+}
+
+func stringsToTargets(s []string) []Route {
+	targets := make([]Route, len(s))
+	for i, v := range s {
+		targets[i] = To(v)
+	}
+
+	return targets
+}
+func equalRoutes(routes []Route, expectedAddrs []string) bool {
+	if len(routes) != len(expectedAddrs) {
+		return false
+	}
+
+	for i := range routes {
+		addr := routes[i].Addr
+		if addr != expectedAddrs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPickLeastConnections(t *testing.T) {
+	p := Proxy{Policy: LeastConnections}
+	p.setRoutes(testFrontAddr, stringsToTargets([]string{"a:1", "b:1", "c:1"}))
+	cfg := p.configFor(testFrontAddr)
+	t.Cleanup(func() {
+		for addr := range cfg.backends {
+			deleteBackendMetrics(p.Name, addr)
+		}
+	})
+
+	cfg.backends["a:1"].active = 2
+	cfg.backends["c:1"].active = 1
+
+	if b := p.pick(cfg, nil); b.route.Addr != "b:1" {
+		t.Fatalf("got %s; want b:1", b.route.Addr)
+	}
+	// Now b has one connection as well, so c is the only one left with one
+	// connection when b is excluded.
+	if b := p.pick(cfg, []*backend{cfg.backends["b:1"]}); b.route.Addr != "c:1" {
+		t.Fatalf("got %s; want c:1", b.route.Addr)
+	}
+
+	// Ejected backends are only picked if nothing else is left.
+	cfg.backends["b:1"].ejectedUntil = time.Now().Add(time.Hour)
+	cfg.backends["c:1"].ejectedUntil = time.Now().Add(time.Hour)
+	if b := p.pick(cfg, nil); b.route.Addr != "a:1" {
+		t.Fatalf("got %s; want a:1", b.route.Addr)
+	}
+	if b := p.pick(cfg, []*backend{cfg.backends["a:1"]}); b.route.Addr != "b:1" {
+		t.Fatalf("got %s; want b:1", b.route.Addr)
+	}
+	tried := []*backend{cfg.backends["a:1"], cfg.backends["b:1"], cfg.backends["c:1"]}
+	if b := p.pick(cfg, tried); b != nil {
+		t.Fatalf("got %s; want nil", b.route.Addr)
+	}
+}
+
+func TestBackends(t *testing.T) {
+	var p Proxy
+	p.setRoutes(testFrontAddr, stringsToTargets([]string{"b:1", "a:1", "c:1"}))
+	cfg := p.configFor(testFrontAddr)
+	t.Cleanup(func() {
+		for addr, b := range cfg.backends {
+			if b.drainTimer != nil {
+				b.drainTimer.Stop()
+			}
+			deleteBackendMetrics(p.Name, addr)
+		}
+	})
+
+	cfg.backends["a:1"].active = 2
+	cfg.backends["b:1"].ejectedUntil = time.Now().Add(time.Hour)
+	cfg.backends["c:1"].active = 1
+	p.setRoutes(testFrontAddr, stringsToTargets([]string{"a:1", "b:1"})) // c is draining
+
+	want := []BackendStatus{
+		{Addr: "a:1", ActiveConnections: 2},
+		{Addr: "b:1", Ejected: true},
+	}
+	if got := p.Backends(); !slices.Equal(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
+
+func TestEjectionBackoff(t *testing.T) {
+	p := Proxy{MaxDialFailures: 2, EjectionTime: time.Hour, MaxEjectionTime: 3 * time.Hour}
+	p.setRoutes(testFrontAddr, stringsToTargets([]string{"a:1"}))
+	cfg := p.configFor(testFrontAddr)
+	b := cfg.backends["a:1"]
+	t.Cleanup(func() {
+		p.mux.Lock()
+		p.forget(cfg, b)
+		p.mux.Unlock()
+	})
+
+	fail := func() time.Duration {
+		p.mux.Lock()
+		b.active++
+		p.mux.Unlock()
+		p.dialFailed(cfg, b, errors.New("failed"))
+		return time.Until(b.ejectedUntil).Round(time.Hour)
+	}
+
+	if ejected := fail(); ejected > 0 {
+		t.Fatalf("ejected for %s after a single failure", ejected)
+	}
+	for _, want := range []time.Duration{1 * time.Hour, 2 * time.Hour, 3 * time.Hour, 3 * time.Hour} {
+		if ejected := fail(); ejected != want {
+			t.Fatalf("ejected for %s; want %s", ejected, want)
+		}
+	}
+
+	if got := testutil.ToFloat64(backendEjected.WithLabelValues(p.Name, "a:1")); got != 1 {
+		t.Fatalf("got ejected metric %v; want 1", got)
+	}
+	if got := testutil.ToFloat64(backendDialFailures.WithLabelValues(p.Name, "a:1")); got != 5 {
+		t.Fatalf("got dial failures metric %v; want 5", got)
+	}
+
+	// A successful connection resets everything.
+	src, dst := net.Pipe()
+	defer src.Close()
+	defer dst.Close()
+	if !p.connected(b, src, dst) {
+		t.Fatal("connection rejected")
+	}
+	if b.failures != 0 || b.ejections != 0 || !b.ejectedUntil.IsZero() {
+		t.Fatalf("backend state not reset: %d failures, %d ejections, ejected until %s", b.failures, b.ejections, b.ejectedUntil)
+	}
+	if got := testutil.ToFloat64(backendEjected.WithLabelValues(p.Name, "a:1")); got != 0 {
+		t.Fatalf("got ejected metric %v; want 0", got)
+	}
+}
+
+func TestDialFailureRetriesOtherRoutes(t *testing.T) {
+	front := newLocalListener(t)
+	defer front.Close()
+	back := newLocalListener(t)
+	defer back.Close()
+	dead := newLocalListener(t)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	p := testProxy(t, front)
+	p.Name = t.Name()
+	p.MaxDialFailures = 2
+	p.EjectionTime = time.Hour
+	p.SetRoutes(testFrontAddr, []Route{To(deadAddr), To(back.Addr().String())})
+	t.Cleanup(func() { deleteBackendMetrics(p.Name, deadAddr); deleteBackendMetrics(p.Name, back.Addr().String()) })
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	for i := range 6 {
+		toFront, err := net.Dial("tcp", front.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromProxy, err := back.Accept()
+		if err != nil {
+			t.Fatalf("connection %d: %v", i, err)
+		}
+		fromProxy.Close()
+		toFront.Close()
+	}
+
+	if got := testutil.ToFloat64(backendDialFailures.WithLabelValues(p.Name, deadAddr)); got != 2 {
+		t.Fatalf("got %v dial failures; want 2", got)
+	}
+	if got := testutil.ToFloat64(backendEjected.WithLabelValues(p.Name, deadAddr)); got != 1 {
+		t.Fatalf("got ejected metric %v; want 1", got)
+	}
+}
+
+func TestDrainRemovedRoute(t *testing.T) {
+	front := newLocalListener(t)
+	defer front.Close()
+	back := newLocalListener(t)
+	defer back.Close()
+	other := newLocalListener(t)
+	defer other.Close()
+
+	p := testProxy(t, front)
+	p.Name = t.Name()
+	p.DrainTimeout = 100 * time.Millisecond
+	p.SetRoutes(testFrontAddr, []Route{To(back.Addr().String())})
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	toFront, err := net.Dial("tcp", front.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer toFront.Close()
+	fromProxy, err := back.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fromProxy.Close()
+
+	p.SetRoutes(testFrontAddr, []Route{To(other.Addr().String())})
+	t.Cleanup(func() { deleteBackendMetrics(p.Name, other.Addr().String()) })
+
+	// The drained connection is still usable until the timeout expires.
+	const msg = "message"
+	if _, err := io.WriteString(toFront, msg); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(fromProxy, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then it gets closed, and the backend is forgotten.
+	_ = toFront.SetReadDeadline(time.Now().Add(10 * time.Second))
+	if _, err := toFront.Read(buf); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v; want EOF", err)
+	}
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		p.mux.Lock()
+		_, found := p.configFor(testFrontAddr).backends[back.Addr().String()]
+		p.mux.Unlock()
+		if !found {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("drained backend not forgotten")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -18,10 +18,12 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/k0sproject/k0s/pkg/token"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/k0sproject/k0s/inttest/common"
@@ -32,6 +34,7 @@ type CPLBUserSpaceSuite struct {
 	common.BootlooseSuite
 	isIPv6Only         bool
 	useExternalAddress bool
+	useLeaseVIP        bool
 }
 
 const nllbControllerConfig = `
@@ -49,6 +52,20 @@ spec:
       type: EnvoyProxy
 `
 
+const leaseVIPControllerConfig = `
+spec:
+  network:
+    controlPlaneLoadBalancing:
+      enabled: true
+      type: LeaseVIP
+      leaseVIP:
+        virtualIPs: ["%s"]
+        interface: "%s"
+    nodeLocalLoadBalancing:
+      enabled: true
+      type: EnvoyProxy
+`
+
 const extAddrControllerConfig = `
 spec:
   images:
@@ -75,6 +92,9 @@ spec:
 `
 
 func (s *CPLBUserSpaceSuite) getK0sCfg(lb string, nic string) string {
+	if s.useLeaseVIP {
+		return fmt.Sprintf(leaseVIPControllerConfig, common.GetCPLBVIPCIDR(lb, s.isIPv6Only), nic)
+	}
 	if !s.useExternalAddress {
 		return fmt.Sprintf(nllbControllerConfig, common.GetCPLBVIPCIDR(lb, s.isIPv6Only))
 	}
@@ -170,6 +190,7 @@ func (s *CPLBUserSpaceSuite) TestK0sGetsUp() {
 		}
 	}
 	s.Require().Equal(1, count, "Expected exactly one controller to have the VIP")
+	s.checkCPLBStatus(ctx, lb)
 
 	// Verify that controller+worker nodes are working normally.
 	s.T().Log("waiting to see CNI pods ready")
@@ -205,6 +226,11 @@ func (s *CPLBUserSpaceSuite) TestK0sGetsUp() {
 		s.Require().LessOrEqual(attempt, 15, "Failed to get a signature from all controllers")
 	}
 
+	if s.useLeaseVIP {
+		s.testLeaseVIPFailover(ctx, lb)
+		return
+	}
+
 	s.T().Log("Verify that controllers resolved the interface name from the MAC address correctly")
 	for idx := range s.ControllerCount {
 		keepalivedCfg := s.getFile(ctx, s.ControllerNode(idx), "/run/k0s/keepalived.conf")
@@ -215,6 +241,76 @@ func (s *CPLBUserSpaceSuite) TestK0sGetsUp() {
 	}
 }
 
+// checkCPLBStatus checks that the controllers report the VIP ownership and
+// healthy backends in their ControlNode status, and that the controller that
+// reports holding the VIP actually has it.
+func (s *CPLBUserSpaceSuite) checkCPLBStatus(ctx context.Context, vip string) {
+	apClient, err := s.AutopilotClient(s.ControllerNode(0))
+	s.Require().NoError(err)
+
+	s.T().Log("Waiting for the controllers to report the CPLB status")
+	var holder string
+	s.Require().NoError(wait.PollUntilContextTimeout(ctx, 2*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		holder = ""
+		for idx := range s.ControllerCount {
+			node, err := apClient.AutopilotV1beta2().ControlNodes().Get(ctx, s.ControllerNode(idx), metav1.GetOptions{})
+			if err != nil || node.Status.CPLB == nil {
+				return false, nil
+			}
+			status := node.Status.CPLB
+			healthy := 0
+			for _, backend := range status.Backends {
+				if backend.Healthy {
+					healthy++
+				}
+			}
+			if healthy != s.ControllerCount || len(status.VirtualIPs) != 1 {
+				return false, nil
+			}
+			if status.VirtualIPs[0].Held {
+				if holder != "" {
+					return false, nil
+				}
+				holder = node.Name
+			}
+		}
+		return holder != "", nil
+	}), "Controllers didn't report a consistent CPLB status")
+
+	s.T().Log("CPLB status reports that the VIP is held by ", holder)
+	s.Require().True(s.hasVIP(ctx, holder, vip), "%s reports holding the VIP, but doesn't have it", holder)
+}
+
+// testLeaseVIPFailover stops the controller holding the VIP and verifies that
+// another controller takes it over.
+func (s *CPLBUserSpaceSuite) testLeaseVIPFailover(ctx context.Context, vip string) {
+	leader := -1
+	for idx := range s.ControllerCount {
+		if s.hasVIP(ctx, s.ControllerNode(idx), vip) {
+			leader = idx
+		}
+	}
+	s.Require().GreaterOrEqual(leader, 0, "No controller has the VIP")
+
+	s.T().Log("Stopping the VIP holder ", s.ControllerNode(leader))
+	s.Require().NoError(s.StopController(s.ControllerNode(leader)))
+	s.Require().False(s.hasVIP(ctx, s.ControllerNode(leader), vip), "Stopped controller still has the VIP")
+
+	s.Require().NoError(wait.PollUntilContextTimeout(ctx, time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		for idx := range s.ControllerCount {
+			if idx != leader && s.hasVIP(ctx, s.ControllerNode(idx), vip) {
+				s.T().Log("VIP taken over by ", s.ControllerNode(idx))
+				return true, nil
+			}
+		}
+		return false, nil
+	}), "No other controller took over the VIP")
+
+	url := url.URL{Scheme: "https", Host: net.JoinHostPort(vip, strconv.Itoa(6443))}
+	_, err := getServerCertSignature(ctx, url.String())
+	s.Require().NoError(err, "API server not reachable via the VIP after failover")
+}
+
 // checkDummy checks that the dummy interface isn't present in the node.
 func (s *CPLBUserSpaceSuite) checkDummy(ctx context.Context, node string) {
 	ssh, err := s.SSH(ctx, node)
@@ -319,6 +415,10 @@ func TestCPLBUserSpaceSuite(t *testing.T) {
 	case strings.Contains(target, "extaddr"):
 		t.Log("Testing external address")
 		cplbSuite.useExternalAddress = true
+
+	case strings.Contains(target, "leasevip"):
+		t.Log("Testing lease-based VIPs")
+		cplbSuite.useLeaseVIP = true
 	}
 
 	suite.Run(t, cplbSuite)
@@ -62,16 +62,17 @@ func (s *KineSuite) TestK0sGetsUp() {
 	})
 
 	s.Run("metrics", func() {
-		s.Require().NoError(common.WaitForDeployment(s.Context(), kc, "k0s-pushgateway", "k0s-system"))
-		s.Require().NoError(wait.PollImmediateInfiniteWithContext(s.Context(), 5*time.Second, func(ctx context.Context) (bool, error) {
-			b, err := kc.RESTClient().Get().AbsPath("/api/v1/namespaces/k0s-system/services/http:k0s-pushgateway:http/proxy/metrics").DoRaw(s.Context())
+		ssh, err := s.SSH(s.Context(), s.ControllerNode(0))
+		s.Require().NoError(err, "failed to SSH into controller")
+		defer ssh.Disconnect()
+
+		s.Require().NoError(wait.PollUntilContextCancel(s.Context(), 5*time.Second, true, func(ctx context.Context) (bool, error) {
+			output, err := ssh.ExecWithOutput(ctx, "curl -sSf --cacert /var/lib/k0s/pki/ca.crt --cert /var/lib/k0s/pki/admin.crt --key /var/lib/k0s/pki/admin.key https://localhost:9444/metrics/kine")
 			if err != nil {
 				return false, nil
 			}
 
-			// wait for kube-scheduler and kube-controller-manager metrics
-			output := string(b)
-			return strings.Contains(output, `job="kube-scheduler"`) && strings.Contains(output, `job="kube-controller-manager"`) && strings.Contains(output, `job="kine"`), nil
+			return strings.Contains(output, "# TYPE "), nil
 		}))
 	})
 }
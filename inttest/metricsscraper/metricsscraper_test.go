@@ -5,15 +5,14 @@ package metricsscraper
 
 import (
 	"context"
-	"encoding/json"
 	"os"
-	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/k0sproject/k0s/inttest/common"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/stretchr/testify/suite"
@@ -25,7 +24,7 @@ type MetricsScraperSuite struct {
 
 func (s *MetricsScraperSuite) TestK0sGetsUp() {
 	flags := []string{"--enable-metrics-scraper"}
-	expectedJobs := []string{"kube-controller-manager", "kube-scheduler"}
+	expectedJobs := []string{"kube-controller-manager", "kube-scheduler", "k0s"}
 	if strings.Contains(os.Getenv("K0S_INTTEST_TARGET"), "singlenode") {
 		flags = append(flags, "--single")
 		expectedJobs = append(expectedJobs, "kine")
@@ -44,77 +43,51 @@ func (s *MetricsScraperSuite) TestK0sGetsUp() {
 	err = s.WaitForNodeReady(s.ControllerNode(0), kc)
 	s.Require().NoError(err)
 
-	s.T().Logf("Waiting to see pushgateway is ready")
-	s.Require().NoError(s.waitForPushgateway())
-
-	s.T().Logf("Waiting for metrics")
-	s.Require().NoError(s.waitForMetrics(expectedJobs...))
-}
-
-func (s *MetricsScraperSuite) waitForPushgateway() error {
-	kc, err := s.KubeClient(s.ControllerNode(0))
-	if err != nil {
-		return err
-	}
-
-	return common.WaitForDeployment(s.Context(), kc, "k0s-pushgateway", "k0s-system")
-}
-
-func (s *MetricsScraperSuite) waitForMetrics(expectedJobs ...string) error {
-	kc, err := s.KubeClient(s.ControllerNode(0))
-	if err != nil {
-		return err
-	}
-
-	slices.Sort(expectedJobs)
-
-	return wait.PollUntilContextCancel(s.Context(), 5*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		b, err := kc.RESTClient().Get().AbsPath("/api/v1/namespaces/k0s-system/services/http:k0s-pushgateway:http/proxy/api/v1/metrics").DoRaw(s.Context())
+	s.T().Logf("Waiting for the metrics endpoint to be registered")
+	s.Require().NoError(wait.PollUntilContextCancel(s.Context(), 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		ep, err := kc.CoreV1().Endpoints(metav1.NamespaceSystem).Get(ctx, "k0s-metrics", metav1.GetOptions{})
 		if err != nil {
-			return false, nil
-		}
-
-		var metrics struct {
-			Data []struct {
-				// Last Unix time when changing this group in the Pushgateway succeeded.
-				PushTimeSeconds struct {
-					Metrics []struct {
-						Labels map[string]string `json:"labels"`
-						Value  string            `json:"value"`
-					} `json:"metrics"`
-				} `json:"push_time_seconds"`
-			} `json:"data"`
-			Status string `json:"status"`
-		}
-		if err := json.Unmarshal(b, &metrics); err != nil {
 			s.T().Log(err)
 			return false, nil
 		}
-
-		if metrics.Status != "success" {
-			return false, err
-		}
-
-		// Collect all the jobs that had successful pushes.
-		var jobs []string
-		for i := range metrics.Data {
-			pts := &metrics.Data[i].PushTimeSeconds
-			for i := range pts.Metrics {
-				if job, ok := pts.Metrics[i].Labels["job"]; ok {
-					if pts.Metrics[i].Value > "0" {
-						if idx, found := slices.BinarySearch(jobs, job); !found {
-							jobs = slices.Insert(jobs, idx, job)
-						}
-						break
-					}
+		for _, subset := range ep.Subsets {
+			for _, address := range subset.Addresses {
+				if address.IP == s.GetControllerIPAddress(0) {
+					return true, nil
 				}
 			}
 		}
+		return false, nil
+	}))
+
+	ssh, err := s.SSH(s.Context(), s.ControllerNode(0))
+	s.Require().NoError(err)
+	defer ssh.Disconnect()
+
+	s.T().Logf("Waiting for metrics")
+	s.Require().NoError(s.waitForMetrics(ssh, expectedJobs...))
+
+	s.T().Logf("Checking that unauthenticated requests are rejected")
+	out, err := ssh.ExecWithOutput(s.Context(), "curl -s -o /dev/null -w '%{http_code}' --cacert /var/lib/k0s/pki/ca.crt https://localhost:9444/metrics/k0s")
+	s.Require().NoError(err)
+	s.Equal("401", out)
+}
 
-		s.T().Log("Jobs:", jobs)
+func (s *MetricsScraperSuite) waitForMetrics(ssh *common.SSHConnection, expectedJobs ...string) error {
+	return wait.PollUntilContextCancel(s.Context(), 5*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		for _, job := range expectedJobs {
+			out, err := ssh.ExecWithOutput(ctx, "curl -sSf --cacert /var/lib/k0s/pki/ca.crt --cert /var/lib/k0s/pki/admin.crt --key /var/lib/k0s/pki/admin.key https://localhost:9444/metrics/"+job)
+			if err != nil {
+				s.T().Logf("Metrics for %s not yet available: %v", job, err)
+				return false, nil
+			}
+			if !strings.Contains(out, "# TYPE ") {
+				s.T().Logf("Metrics for %s don't look like metrics", job)
+				return false, nil
+			}
+		}
 
-		// Return if the job lists match.
-		return slices.Equal(expectedJobs, jobs), nil
+		return true, nil
 	})
 }
 
@@ -277,11 +277,14 @@ func (s *suite) checkClusterReadiness(ctx context.Context, restConfig *rest.Conf
 
 			s.T().Logf("Node %s is ready", nodeName)
 
-			nllbPodName := "nllb-" + nodeName
-			if err := common.WaitForPod(ctx, clients, nllbPodName, metav1.NamespaceSystem); err != nil {
-				return fmt.Errorf("Pod %s/%s is not ready: %w", nllbPodName, metav1.NamespaceSystem, err)
+			// The built-in TCP proxy runs inside the k0s process, not as a Pod.
+			if s.nllbType != v1beta1.NllbTypeTCPProxy {
+				nllbPodName := "nllb-" + nodeName
+				if err := common.WaitForPod(ctx, clients, nllbPodName, metav1.NamespaceSystem); err != nil {
+					return fmt.Errorf("Pod %s/%s is not ready: %w", nllbPodName, metav1.NamespaceSystem, err)
+				}
+				s.T().Logf("Pod %s/%s is ready", metav1.NamespaceSystem, nllbPodName)
 			}
-			s.T().Logf("Pod %s/%s is ready", metav1.NamespaceSystem, nllbPodName)
 
 			if pendingWorkers.Add(-1) < 1 {
 				close(workersReady)
@@ -362,6 +365,9 @@ func TestNodeLocalLoadBalancingSuite(t *testing.T) {
 	if strings.Contains(os.Getenv("K0S_INTTEST_TARGET"), "traefik") {
 		t.Log("Using Traefik")
 		s.nllbType = v1beta1.NllbTypeTraefik
+	} else if strings.Contains(os.Getenv("K0S_INTTEST_TARGET"), "tcpproxy") {
+		t.Log("Using the built-in TCP proxy")
+		s.nllbType = v1beta1.NllbTypeTCPProxy
 	} else {
 		t.Log("Using the default NLLB backend")
 	}
@@ -38,11 +38,6 @@ func GetImageURIs(env TargetEnv, all bool) (uris []string) {
 			env.Spec.Images.MetricsServer.URI(),
 		)
 
-		// Include disabled-by default images, if the user wants all images.
-		if all {
-			uris = append(uris, env.Spec.Images.PushGateway.URI())
-		}
-
 	case "windows":
 		// Enabled by default.
 		uris = append(uris,
@@ -77,6 +72,19 @@ func GetImageURIs(env TargetEnv, all bool) (uris []string) {
 		}
 	}
 
+	if all || env.wantsNetworkProvider("cilium") {
+		switch env.Platform.OS {
+		case "linux":
+			uris = append(uris,
+				env.Spec.Images.Cilium.Agent.URI(),
+				env.Spec.Images.Cilium.Operator.URI(),
+			)
+			if all || env.wantsHubble() {
+				uris = append(uris, env.Spec.Images.Cilium.HubbleRelay.URI())
+			}
+		}
+	}
+
 	if all || env.wantsNLLBBackend(v1beta1.NllbTypeEnvoyProxy) {
 		switch env.Platform.OS {
 		case "linux":
@@ -108,6 +116,11 @@ func (e *TargetEnv) wantsNetworkProvider(provider string) bool {
 	return provider == usedProvider
 }
 
+func (e *TargetEnv) wantsHubble() bool {
+	return e.Spec != nil && e.Spec.Network != nil &&
+		e.Spec.Network.Cilium != nil && e.Spec.Network.Cilium.Hubble.Enabled
+}
+
 func (e *TargetEnv) wantsNLLBBackend(backend v1beta1.NllbType) bool {
 	var nllbType v1beta1.NllbType
 	if e.Spec != nil && e.Spec.Network != nil {
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceWindow restricts the times at which autopilot plans are allowed
+// to signal nodes. As soon as there's at least one MaintenanceWindow in the
+// cluster, nodes will only be signaled while at least one of the periods of
+// any of the windows is active. Nodes that have already been signaled will
+// finish their update, even if the window closes in the meantime.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +genclient
+// +genclient:onlyVerbs=create,delete,list,get,watch,update
+// +genclient:nonNamespaced
+type MaintenanceWindow struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec MaintenanceWindowSpec `json:"spec"`
+}
+
+// MaintenanceWindowSpec describes the recurring periods of a `MaintenanceWindow`.
+type MaintenanceWindowSpec struct {
+	// Periods are the recurring periods during which autopilot may signal nodes.
+	// The start times are evaluated in the local time zone of the controllers.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Periods []MaintenanceWindowPeriod `json:"periods"`
+}
+
+// MaintenanceWindowPeriod is a recurring period of a `MaintenanceWindow`.
+type MaintenanceWindowPeriod struct {
+	// Days are the days of the week on which the period starts. If omitted, the
+	// period starts on every day of the week.
+	//
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	// +listType=set
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// StartTime is the time of the day at which the period starts, in 24-hour
+	// notation, e.g. "22:30".
+	//
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Length is the duration of the period, e.g. "4h" or "90m". Periods may
+	// extend into the following days.
+	//
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	Length string `json:"length"`
+}
+
+type parsedMaintenanceWindowPeriod struct {
+	days         []time.Weekday
+	hour, minute int
+	length       time.Duration
+}
+
+func (p *MaintenanceWindowPeriod) parse() (*parsedMaintenanceWindowPeriod, error) {
+	var parsed parsedMaintenanceWindowPeriod
+
+	for _, day := range p.Days {
+		weekday := slices.IndexFunc([]time.Weekday{
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+		}, func(weekday time.Weekday) bool { return weekday.String() == day })
+		if weekday < 0 {
+			return nil, fmt.Errorf("invalid day: %q", day)
+		}
+		parsed.days = append(parsed.days, time.Weekday(weekday))
+	}
+
+	startTime, err := time.Parse("15:04", p.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time: %w", err)
+	}
+	parsed.hour, parsed.minute = startTime.Hour(), startTime.Minute()
+
+	parsed.length, err = time.ParseDuration(p.Length)
+	if err != nil {
+		return nil, fmt.Errorf("invalid length: %w", err)
+	}
+	if parsed.length <= 0 {
+		return nil, fmt.Errorf("invalid length: must be positive: %s", p.Length)
+	}
+
+	return &parsed, nil
+}
+
+// Validate checks that the period can be evaluated.
+func (p *MaintenanceWindowPeriod) Validate() error {
+	_, err := p.parse()
+	return err
+}
+
+// IsActive determines if the period is active at the given time. It returns an
+// error if the period is invalid.
+func (p *MaintenanceWindowPeriod) IsActive(t time.Time) (bool, error) {
+	parsed, err := p.parse()
+	if err != nil {
+		return false, err
+	}
+
+	// Go back day by day, as periods that started on a previous day may still
+	// be active. Every earlier start has an earlier end, so stop as soon as a
+	// period has ended.
+	for daysAgo := 0; ; daysAgo++ {
+		start := time.Date(t.Year(), t.Month(), t.Day()-daysAgo, parsed.hour, parsed.minute, 0, 0, t.Location())
+		if start.After(t) {
+			continue
+		}
+		if !t.Before(start.Add(parsed.length)) {
+			return false, nil
+		}
+		if len(parsed.days) == 0 || slices.Contains(parsed.days, start.Weekday()) {
+			return true, nil
+		}
+	}
+}
+
+// Validate checks that all the periods of this window can be evaluated.
+func (w *MaintenanceWindow) Validate() error {
+	var errs []error
+	for i := range w.Spec.Periods {
+		if err := w.Spec.Periods[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("period %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsOpen determines if any of the periods of this window is active at the given
+// time. It returns an error if the window is invalid.
+func (w *MaintenanceWindow) IsOpen(t time.Time) (bool, error) {
+	if err := w.Validate(); err != nil {
+		return false, err
+	}
+
+	for i := range w.Spec.Periods {
+		if active, err := w.Spec.Periods[i].IsActive(t); err != nil {
+			return false, err
+		} else if active {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// MaintenanceWindowList is a list of MaintenanceWindow instances.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MaintenanceWindow `json:"items"`
+}
+
+// IsOpen determines if autopilot may signal nodes at the given time, which is
+// the case if there are no windows at all, or if any of the valid windows is
+// open. Invalid windows are reported as an error. They never open, so that
+// there's no accidental signaling of nodes.
+func (l *MaintenanceWindowList) IsOpen(t time.Time) (bool, error) {
+	if len(l.Items) == 0 {
+		return true, nil
+	}
+
+	var open bool
+	var errs []error
+	for i := range l.Items {
+		windowOpen, err := l.Items[i].IsOpen(t)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid maintenance window %s: %w", l.Items[i].Name, err))
+		} else if windowOpen {
+			open = true
+		}
+	}
+
+	return open, errors.Join(errs...)
+}
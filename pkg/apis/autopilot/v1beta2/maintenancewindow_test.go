@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindowPeriod_IsActive(t *testing.T) {
+	// A Monday
+	monday := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		period MaintenanceWindowPeriod
+		time   time.Time
+		want   bool
+	}{
+		{"every_day", MaintenanceWindowPeriod{StartTime: "02:00", Length: "1h"}, monday.Add(2*time.Hour + 30*time.Minute), true},
+		{"at_start", MaintenanceWindowPeriod{StartTime: "02:00", Length: "1h"}, monday.Add(2 * time.Hour), true},
+		{"at_end", MaintenanceWindowPeriod{StartTime: "02:00", Length: "1h"}, monday.Add(3 * time.Hour), false},
+		{"before_start", MaintenanceWindowPeriod{StartTime: "02:00", Length: "1h"}, monday.Add(time.Hour), false},
+		{"matching_day", MaintenanceWindowPeriod{Days: []string{"Monday"}, StartTime: "02:00", Length: "1h"}, monday.Add(2 * time.Hour), true},
+		{"other_day", MaintenanceWindowPeriod{Days: []string{"Tuesday"}, StartTime: "02:00", Length: "1h"}, monday.Add(2 * time.Hour), false},
+
+		// A period starting on Sunday at 23:00 is still active on Monday at
+		// 01:00, but not one starting on Monday at 23:00.
+		{"across_midnight", MaintenanceWindowPeriod{Days: []string{"Sunday"}, StartTime: "23:00", Length: "3h"}, monday.Add(time.Hour), true},
+		{"across_midnight_ended", MaintenanceWindowPeriod{Days: []string{"Sunday"}, StartTime: "23:00", Length: "3h"}, monday.Add(2 * time.Hour), false},
+		{"across_midnight_other_day", MaintenanceWindowPeriod{Days: []string{"Monday"}, StartTime: "23:00", Length: "3h"}, monday.Add(time.Hour), false},
+		{"across_midnight_every_day", MaintenanceWindowPeriod{StartTime: "23:00", Length: "3h"}, monday.Add(time.Hour), true},
+
+		// Periods may also span multiple days.
+		{"multiple_days", MaintenanceWindowPeriod{Days: []string{"Saturday"}, StartTime: "20:00", Length: "36h"}, monday.Add(7 * time.Hour), true},
+		{"multiple_days_ended", MaintenanceWindowPeriod{Days: []string{"Saturday"}, StartTime: "20:00", Length: "36h"}, monday.Add(8 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, err := tt.period.IsActive(tt.time)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, active)
+		})
+	}
+}
+
+func TestMaintenanceWindowPeriod_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		period MaintenanceWindowPeriod
+		err    string
+	}{
+		{"no_start_time", MaintenanceWindowPeriod{Length: "1h"}, "invalid start time: "},
+		{"bad_start_time", MaintenanceWindowPeriod{StartTime: "25:00", Length: "1h"}, "invalid start time: "},
+		{"no_length", MaintenanceWindowPeriod{StartTime: "02:00"}, "invalid length: "},
+		{"bad_length", MaintenanceWindowPeriod{StartTime: "02:00", Length: "1 day"}, "invalid length: "},
+		{"zero_length", MaintenanceWindowPeriod{StartTime: "02:00", Length: "0s"}, "invalid length: must be positive: 0s"},
+		{"bad_day", MaintenanceWindowPeriod{Days: []string{"Caturday"}, StartTime: "02:00", Length: "1h"}, `invalid day: "Caturday"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.period.Validate(), tt.err)
+
+			active, err := tt.period.IsActive(time.Now())
+			assert.ErrorContains(t, err, tt.err)
+			assert.False(t, active)
+		})
+	}
+}
+
+func TestMaintenanceWindowList_IsOpen(t *testing.T) {
+	// A Monday
+	monday := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	window := func(name string, periods ...MaintenanceWindowPeriod) MaintenanceWindow {
+		w := MaintenanceWindow{Spec: MaintenanceWindowSpec{Periods: periods}}
+		w.Name = name
+		return w
+	}
+
+	t.Run("no_windows", func(t *testing.T) {
+		var windows MaintenanceWindowList
+		open, err := windows.IsOpen(monday)
+		assert.NoError(t, err)
+		assert.True(t, open)
+	})
+
+	t.Run("invalid_window_never_opens", func(t *testing.T) {
+		windows := MaintenanceWindowList{Items: []MaintenanceWindow{
+			window("invalid",
+				MaintenanceWindowPeriod{StartTime: "00:00", Length: "24h"},
+				MaintenanceWindowPeriod{StartTime: "bogus", Length: "1h"},
+			),
+		}}
+		open, err := windows.IsOpen(monday)
+		assert.ErrorContains(t, err, "invalid maintenance window invalid: period 1: invalid start time: ")
+		assert.False(t, open)
+	})
+
+	t.Run("valid_window_opens_despite_invalid_one", func(t *testing.T) {
+		windows := MaintenanceWindowList{Items: []MaintenanceWindow{
+			window("invalid", MaintenanceWindowPeriod{StartTime: "00:00", Length: "bogus"}),
+			window("valid", MaintenanceWindowPeriod{StartTime: "00:00", Length: "1h"}),
+		}}
+		open, err := windows.IsOpen(monday)
+		assert.ErrorContains(t, err, "invalid maintenance window invalid: period 0: invalid length: ")
+		assert.True(t, open)
+	})
+}
@@ -6,6 +6,7 @@ package v1beta2
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ControlNode is a node which behaves as a controller, able to receive autopilot
@@ -29,6 +30,78 @@ type ControlNode struct {
 type ControlNodeStatus struct {
 	Addresses  []corev1.NodeAddress `json:"addresses,omitempty"`
 	K0sVersion string               `json:"k0sVersion,omitempty"`
+
+	// CPLB is the status of the control plane load balancing on the
+	// controller. Only set if control plane load balancing is enabled.
+	// +optional
+	CPLB *CPLBStatus `json:"cplb,omitempty"`
+}
+
+// CPLBStatus is the status of the control plane load balancing on a
+// controller.
+type CPLBStatus struct {
+	// Type is the type of the control plane load balancer, e.g. Keepalived.
+	Type string `json:"type"`
+
+	// VirtualIPs lists the virtual IPs, and whether the controller currently
+	// holds them.
+	// +optional
+	VirtualIPs []CPLBVirtualIPStatus `json:"virtualIPs,omitempty"`
+
+	// VRRPInstances lists the state of the VRRP instances. Only set for the
+	// Keepalived type.
+	// +optional
+	VRRPInstances []CPLBVRRPInstanceStatus `json:"vrrpInstances,omitempty"`
+
+	// Backends lists the API servers known to the load balancer, and their
+	// health. Not set if the load balancer is disabled.
+	// +optional
+	Backends []CPLBBackendStatus `json:"backends,omitempty"`
+}
+
+// CPLBVirtualIPStatus is the status of a virtual IP on a controller.
+type CPLBVirtualIPStatus struct {
+	// Address is the virtual IP, as configured.
+	Address string `json:"address"`
+
+	// Held indicates whether the controller currently holds the virtual IP,
+	// i.e. has it on its network interface, or advertises it via BGP.
+	Held bool `json:"held"`
+}
+
+// CPLBVRRPInstanceStatus is the state of a VRRP instance on a controller.
+type CPLBVRRPInstanceStatus struct {
+	// VirtualRouterID is the VRRP router ID of the instance.
+	VirtualRouterID int32 `json:"virtualRouterID"`
+
+	// Interface is the network interface used by the instance.
+	Interface string `json:"interface"`
+
+	// State is the state of the instance as observed on the interface:
+	// MASTER if the controller holds all of the instance's virtual IPs,
+	// BACKUP if it doesn't, and FAULT if the interface isn't up.
+	State string `json:"state"`
+}
+
+// CPLBBackendStatus is the status of an API server as seen by a controller's
+// load balancer.
+type CPLBBackendStatus struct {
+	// Address is the API server's address.
+	Address string `json:"address"`
+
+	// Healthy indicates whether the API server passes the health checks.
+	Healthy bool `json:"healthy"`
+
+	// Ejected indicates whether the userspace proxy temporarily stopped
+	// sending connections to the API server because connection attempts
+	// failed.
+	// +optional
+	Ejected bool `json:"ejected,omitempty"`
+
+	// ActiveConnections is the number of connections the userspace proxy
+	// currently forwards to the API server.
+	// +optional
+	ActiveConnections int32 `json:"activeConnections,omitempty"`
 }
 
 // GetInternalIP returns the internal IP address for the object. Returns empty string if the object does not have InternalIP set.
@@ -107,6 +180,9 @@ type PlanCommand struct {
 
 	// AirgapUpdate is the `AirgapUpdate` command which is responsible for updating a k0s airgap bundle.
 	AirgapUpdate *PlanCommandAirgapUpdate `json:"airgapupdate,omitempty"`
+
+	// HelmUpdate is the `HelmUpdate` command which is responsible for upgrading a set of Helm charts.
+	HelmUpdate *PlanCommandHelmUpdate `json:"helmupdate,omitempty"`
 }
 
 // PlanPlatformResourceURLMap is a mapping of `PlanResourceURL` instances mapped to platform identifiers.
@@ -143,6 +219,49 @@ type PlanCommandAirgapUpdate struct {
 	Workers PlanCommandTarget `json:"workers"`
 }
 
+// PlanCommandHelmUpdate provides all of the information for a `HelmUpdate` command to
+// upgrade a set of Helm charts, one after the other.
+type PlanCommandHelmUpdate struct {
+	// Charts are the charts to be upgraded, in the order in which they are listed.
+	// A chart is only upgraded once all of the preceding charts have been upgraded
+	// and are healthy.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Charts []PlanCommandHelmUpdateChart `json:"charts"`
+
+	// Timeout is the time that a chart is given to be upgraded and to become healthy.
+	// If it exceeds this time, or if its upgrade fails, its release is rolled back
+	// to the previous revision and the plan is stopped.
+	//
+	// +kubebuilder:default="10m"
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PlanCommandHelmUpdateChart identifies a `Chart` resource and the version it should be upgraded to.
+type PlanCommandHelmUpdateChart struct {
+	// Name is the name of the `Chart` resource.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the `Chart` resource.
+	//
+	// +kubebuilder:default=kube-system
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Version is the chart version to upgrade to.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// Values, if set, replace the values of the `Chart` resource.
+	//
+	// +optional
+	Values *string `json:"values,omitempty"`
+}
+
 // PlanResourceURL is a remote URL resource.
 type PlanResourceURL struct {
 	// URL is the URL of a downloadable resource.
@@ -176,6 +295,31 @@ type PlanCommandTarget struct {
 	// +kubebuilder:default={concurrent:1}
 	// +optional
 	Limits PlanCommandTargetLimits `json:"limits"`
+
+	// Waves splits the discovered nodes of this target into ordered phases of a
+	// rollout (ie. one canary node, then 10%, then the rest). A wave is only
+	// started once all nodes of the previous waves have completed, are healthy,
+	// and the previous wave's soak time has elapsed. Any nodes not covered by
+	// the listed waves are updated in a final, implicit wave.
+	//
+	// +optional
+	Waves []PlanCommandTargetWave `json:"waves,omitempty"`
+}
+
+// PlanCommandTargetWave is a single phase of a phased rollout across the nodes of a target.
+type PlanCommandTargetWave struct {
+	// Size is either the absolute number of nodes (ie. '1'), or the percentage of all discovered
+	// nodes of the target (ie. '10%') that are part of this wave. Percentages are rounded up,
+	// and every wave contains at least one node, as long as there are nodes left.
+	//
+	// +kubebuilder:validation:XIntOrString
+	Size intstr.IntOrString `json:"size"`
+
+	// SoakTime is the time to wait after all nodes of this wave have completed before the
+	// next wave is started.
+	//
+	// +optional
+	SoakTime metav1.Duration `json:"soakTime,omitempty"`
 }
 
 // PlanCommandTargetLimits are limits that can be imposed on a target of a command.
@@ -245,6 +389,9 @@ type PlanCommandStatus struct {
 
 	// AirgapUpdate is the status of the `AirgapUpdate` command.
 	AirgapUpdate *PlanCommandAirgapUpdateStatus `json:"airgapupdate,omitempty"`
+
+	// HelmUpdate is the status of the `HelmUpdate` command.
+	HelmUpdate *PlanCommandHelmUpdateStatus `json:"helmupdate,omitempty"`
 }
 
 // PlanCommandK0sUpdateStatus is the status of a `K0sUpdate` command for a collection
@@ -264,6 +411,48 @@ type PlanCommandAirgapUpdateStatus struct {
 	Workers []PlanCommandTargetStatus `json:"workers,omitempty"`
 }
 
+// PlanCommandHelmUpdateStatus is the status of a `HelmUpdate` command.
+type PlanCommandHelmUpdateStatus struct {
+	// Charts are a collection of status for each of the charts, in upgrade order.
+	Charts []PlanCommandHelmChartStatus `json:"charts,omitempty"`
+}
+
+// PlanCommandHelmChartStatus is the status of the upgrade of a single `Chart` resource.
+type PlanCommandHelmChartStatus struct {
+	// Name is the name of the `Chart` resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the `Chart` resource.
+	Namespace string `json:"namespace"`
+
+	// State is the current state of the chart upgrade.
+	State PlanCommandTargetStateType `json:"state"`
+
+	// Description is additional information about the state of the chart upgrade.
+	//
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// PreviousVersion is the chart version before the upgrade.
+	//
+	// +optional
+	PreviousVersion string `json:"previousVersion,omitempty"`
+
+	// PreviousValues are the chart values before the upgrade.
+	//
+	// +optional
+	PreviousValues string `json:"previousValues,omitempty"`
+
+	// PreviousRevision is the release revision before the upgrade,
+	// which is the revision that's used when rolling back.
+	//
+	// +optional
+	PreviousRevision int64 `json:"previousRevision,omitempty"`
+
+	// LastUpdatedTimestamp is a timestamp of the last time the status has changed.
+	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp"`
+}
+
 // PlanCommandTargetStateType is the state of a PlanCommandTarget
 type PlanCommandTargetStateType PlanStateType
 
@@ -277,4 +466,9 @@ type PlanCommandTargetStatus struct {
 
 	// LastUpdatedTimestamp is a timestamp of the last time the status has changed.
 	LastUpdatedTimestamp metav1.Time `json:"lastUpdatedTimestamp"`
+
+	// Wave is the index of the rollout wave this target has been assigned to.
+	//
+	// +optional
+	Wave int `json:"wave,omitempty"`
 }
@@ -91,6 +91,81 @@ func (in *AutopilotPlanSpec) DeepCopy() *AutopilotPlanSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPLBBackendStatus) DeepCopyInto(out *CPLBBackendStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPLBBackendStatus.
+func (in *CPLBBackendStatus) DeepCopy() *CPLBBackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CPLBBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPLBStatus) DeepCopyInto(out *CPLBStatus) {
+	*out = *in
+	if in.VirtualIPs != nil {
+		in, out := &in.VirtualIPs, &out.VirtualIPs
+		*out = make([]CPLBVirtualIPStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.VRRPInstances != nil {
+		in, out := &in.VRRPInstances, &out.VRRPInstances
+		*out = make([]CPLBVRRPInstanceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]CPLBBackendStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPLBStatus.
+func (in *CPLBStatus) DeepCopy() *CPLBStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CPLBStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPLBVRRPInstanceStatus) DeepCopyInto(out *CPLBVRRPInstanceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPLBVRRPInstanceStatus.
+func (in *CPLBVRRPInstanceStatus) DeepCopy() *CPLBVRRPInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CPLBVRRPInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPLBVirtualIPStatus) DeepCopyInto(out *CPLBVirtualIPStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPLBVirtualIPStatus.
+func (in *CPLBVirtualIPStatus) DeepCopy() *CPLBVirtualIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CPLBVirtualIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlNode) DeepCopyInto(out *ControlNode) {
 	*out = *in
@@ -157,6 +232,11 @@ func (in *ControlNodeStatus) DeepCopyInto(out *ControlNodeStatus) {
 		*out = make([]v1.NodeAddress, len(*in))
 		copy(*out, *in)
 	}
+	if in.CPLB != nil {
+		in, out := &in.CPLB, &out.CPLB
+		*out = new(CPLBStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlNodeStatus.
@@ -169,6 +249,106 @@ func (in *ControlNodeStatus) DeepCopy() *ControlNodeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowList) DeepCopyInto(out *MaintenanceWindowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowList.
+func (in *MaintenanceWindowList) DeepCopy() *MaintenanceWindowList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowPeriod) DeepCopyInto(out *MaintenanceWindowPeriod) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowPeriod.
+func (in *MaintenanceWindowPeriod) DeepCopy() *MaintenanceWindowPeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowPeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	if in.Periods != nil {
+		in, out := &in.Periods, &out.Periods
+		*out = make([]MaintenanceWindowPeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PeriodicUpgradeStrategy) DeepCopyInto(out *PeriodicUpgradeStrategy) {
 	*out = *in
@@ -229,6 +409,11 @@ func (in *PlanCommand) DeepCopyInto(out *PlanCommand) {
 		*out = new(PlanCommandAirgapUpdate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HelmUpdate != nil {
+		in, out := &in.HelmUpdate, &out.HelmUpdate
+		*out = new(PlanCommandHelmUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommand.
@@ -286,6 +471,87 @@ func (in *PlanCommandAirgapUpdateStatus) DeepCopy() *PlanCommandAirgapUpdateStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmChartStatus) DeepCopyInto(out *PlanCommandHelmChartStatus) {
+	*out = *in
+	in.LastUpdatedTimestamp.DeepCopyInto(&out.LastUpdatedTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmChartStatus.
+func (in *PlanCommandHelmChartStatus) DeepCopy() *PlanCommandHelmChartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmChartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmUpdate) DeepCopyInto(out *PlanCommandHelmUpdate) {
+	*out = *in
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = make([]PlanCommandHelmUpdateChart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmUpdate.
+func (in *PlanCommandHelmUpdate) DeepCopy() *PlanCommandHelmUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmUpdateChart) DeepCopyInto(out *PlanCommandHelmUpdateChart) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmUpdateChart.
+func (in *PlanCommandHelmUpdateChart) DeepCopy() *PlanCommandHelmUpdateChart {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmUpdateChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandHelmUpdateStatus) DeepCopyInto(out *PlanCommandHelmUpdateStatus) {
+	*out = *in
+	if in.Charts != nil {
+		in, out := &in.Charts, &out.Charts
+		*out = make([]PlanCommandHelmChartStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandHelmUpdateStatus.
+func (in *PlanCommandHelmUpdateStatus) DeepCopy() *PlanCommandHelmUpdateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandHelmUpdateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandK0sUpdate) DeepCopyInto(out *PlanCommandK0sUpdate) {
 	*out = *in
@@ -351,6 +617,11 @@ func (in *PlanCommandStatus) DeepCopyInto(out *PlanCommandStatus) {
 		*out = new(PlanCommandAirgapUpdateStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HelmUpdate != nil {
+		in, out := &in.HelmUpdate, &out.HelmUpdate
+		*out = new(PlanCommandHelmUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandStatus.
@@ -368,6 +639,11 @@ func (in *PlanCommandTarget) DeepCopyInto(out *PlanCommandTarget) {
 	*out = *in
 	in.Discovery.DeepCopyInto(&out.Discovery)
 	out.Limits = in.Limits
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]PlanCommandTargetWave, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTarget.
@@ -471,6 +747,23 @@ func (in *PlanCommandTargetStatus) DeepCopy() *PlanCommandTargetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanCommandTargetWave) DeepCopyInto(out *PlanCommandTargetWave) {
+	*out = *in
+	out.Size = in.Size
+	out.SoakTime = in.SoakTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanCommandTargetWave.
+func (in *PlanCommandTargetWave) DeepCopy() *PlanCommandTargetWave {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanCommandTargetWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlanCommandTargets) DeepCopyInto(out *PlanCommandTargets) {
 	*out = *in
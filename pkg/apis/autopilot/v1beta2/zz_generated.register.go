@@ -51,6 +51,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ControlNode{},
 		&ControlNodeList{},
+		&MaintenanceWindow{},
+		&MaintenanceWindowList{},
 		&Plan{},
 		&PlanList{},
 		&UpdateConfig{},
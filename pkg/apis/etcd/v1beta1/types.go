@@ -50,6 +50,13 @@ const (
 type EtcdMemberSpec struct {
 	// Leave is a flag to indicate that the member should be removed from the cluster
 	Leave bool `json:"leave,omitempty"`
+	// Replace is a flag to indicate that the member has failed and should be
+	// replaced by a new controller with the same name. The member is removed
+	// from the cluster as long as this is safe with regard to the etcd quorum.
+	// A new controller with the same name is then allowed to join, even if its
+	// peer address differs. The flag is cleared once the new controller has
+	// joined.
+	Replace bool `json:"replace,omitempty"`
 }
 
 type Status struct {
@@ -65,12 +72,34 @@ type Status struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []JoinCondition `json:"conditions,omitempty"`
+	// ReplacedMembers lists the etcd members that have previously been
+	// registered under this name, and that have been removed from the cluster
+	// in order to be replaced.
+	// +listType=atomic
+	// +optional
+	ReplacedMembers []ReplacedMember `json:"replacedMembers,omitempty"`
+}
+
+// ReplacedMember describes an etcd member that has been removed from the
+// cluster in order to be replaced by a new controller with the same name.
+type ReplacedMember struct {
+	// PeerAddress is the address of the replaced etcd peer
+	PeerAddress string `json:"peerAddress"`
+	// MemberID is the hex form ID of the replaced etcd member
+	MemberID string `json:"memberID"`
+	// RemovalTime is the time at which the member has been removed from the
+	// etcd cluster.
+	RemovalTime metav1.Time `json:"removalTime"`
 }
 
 type ConditionType string
 
 const (
+	// The member is a voting member of the etcd cluster.
 	ConditionTypeJoined ConditionType = "Joined"
+	// The member has caught up with the leader's log after having been added
+	// to the etcd cluster as a non-voting learner.
+	ConditionTypeCaughtUp ConditionType = "CaughtUp"
 )
 
 // +kubebuilder:validation:Enum=True;False;Unknown
@@ -86,7 +115,7 @@ const (
 )
 
 type JoinCondition struct {
-	// +kubebuilder:validation:Enum=Joined
+	// +kubebuilder:validation:Enum=Joined;CaughtUp
 	Type   ConditionType   `json:"type"`
 	Status ConditionStatus `json:"status"`
 	// Last time the condition transitioned from one status to another.
@@ -101,6 +101,22 @@ func (in *JoinCondition) DeepCopy() *JoinCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacedMember) DeepCopyInto(out *ReplacedMember) {
+	*out = *in
+	in.RemovalTime.DeepCopyInto(&out.RemovalTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacedMember.
+func (in *ReplacedMember) DeepCopy() *ReplacedMember {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacedMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
@@ -111,6 +127,13 @@ func (in *Status) DeepCopyInto(out *Status) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReplacedMembers != nil {
+		in, out := &in.ReplacedMembers, &out.ReplacedMembers
+		*out = make([]ReplacedMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Status.
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Cilium defines the Cilium related config options
+type Cilium struct {
+	// How pod traffic is routed between nodes. Either `tunnel`, which
+	// encapsulates the traffic, or `native`, which relies on the underlying
+	// network to route the pod CIDRs. (default: tunnel)
+	// +kubebuilder:default=tunnel
+	RoutingMode CiliumRoutingMode `json:"routingMode,omitempty"`
+
+	// The encapsulation protocol in tunnel mode. Either `vxlan` or `geneve`.
+	// Will be ignored in native routing mode. (default: vxlan)
+	// +kubebuilder:default=vxlan
+	TunnelProtocol CiliumTunnelProtocol `json:"tunnelProtocol,omitempty"`
+
+	// Let Cilium replace kube-proxy. Requires kube-proxy to be disabled via
+	// `spec.network.kubeProxy.disabled`. (default: false)
+	KubeProxyReplacement bool `json:"kubeProxyReplacement,omitempty"`
+
+	// MTU for the pod network. Set to 0 for auto-detection. (default: 0)
+	// +kubebuilder:validation:Minimum=0
+	MTU int `json:"mtu,omitempty"`
+
+	// Hubble defines the configuration options for Hubble, Cilium's
+	// observability layer.
+	// +optional
+	Hubble CiliumHubble `json:"hubble"`
+
+	// Patches holds customizations applied to the Cilium resources generated by k0s before they applied.
+	// +optional
+	Patches Patches `json:"patches,omitempty"`
+}
+
+// CiliumHubble defines the Hubble related config options
+type CiliumHubble struct {
+	// Enable Hubble in the Cilium agents and deploy Hubble Relay, so that the
+	// network flows of the whole cluster can be observed. (default: false)
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// Indicates how Cilium routes pod traffic between nodes.
+// +kubebuilder:validation:Enum=tunnel;native
+type CiliumRoutingMode string
+
+const (
+	CiliumRoutingModeTunnel CiliumRoutingMode = "tunnel"
+	CiliumRoutingModeNative CiliumRoutingMode = "native"
+)
+
+// Indicates the encapsulation protocol Cilium uses in tunnel mode.
+// +kubebuilder:validation:Enum=vxlan;geneve
+type CiliumTunnelProtocol string
+
+const (
+	CiliumTunnelProtocolVXLAN  CiliumTunnelProtocol = "vxlan"
+	CiliumTunnelProtocolGeneve CiliumTunnelProtocol = "geneve"
+)
+
+// DefaultCilium returns sane defaults for cilium
+func DefaultCilium() *Cilium {
+	return &Cilium{
+		RoutingMode:    CiliumRoutingModeTunnel,
+		TunnelProtocol: CiliumTunnelProtocolVXLAN,
+	}
+}
+
+// UnmarshalJSON sets in some sane defaults when unmarshaling the data from JSON
+func (c *Cilium) UnmarshalJSON(data []byte) error {
+	c.RoutingMode = CiliumRoutingModeTunnel
+	c.TunnelProtocol = CiliumTunnelProtocolVXLAN
+
+	type cilium Cilium
+	jc := (*cilium)(c)
+	return json.Unmarshal(data, jc)
+}
+
+func (c *Cilium) Validate(path *field.Path) (errs []error) {
+	if c == nil {
+		return
+	}
+
+	if c.RoutingMode == "" {
+		errs = append(errs, field.Required(path.Child("routingMode"), ""))
+	} else if allowed := []CiliumRoutingMode{
+		CiliumRoutingModeTunnel, CiliumRoutingModeNative,
+	}; !slices.Contains(allowed, c.RoutingMode) {
+		errs = append(errs, field.NotSupported(path.Child("routingMode"), c.RoutingMode, allowed))
+	}
+
+	if c.TunnelProtocol == "" {
+		errs = append(errs, field.Required(path.Child("tunnelProtocol"), ""))
+	} else if allowed := []CiliumTunnelProtocol{
+		CiliumTunnelProtocolVXLAN, CiliumTunnelProtocolGeneve,
+	}; !slices.Contains(allowed, c.TunnelProtocol) {
+		errs = append(errs, field.NotSupported(path.Child("tunnelProtocol"), c.TunnelProtocol, allowed))
+	}
+
+	if c.MTU < 0 {
+		errs = append(errs, field.Invalid(path.Child("mtu"), c.MTU, "must be non-negative"))
+	}
+
+	errs = append(errs, c.Patches.validate(path.Child("patches"))...)
+
+	return
+}
@@ -47,8 +47,57 @@ type ClusterSpec struct {
 
 // ClusterConfigStatus defines the observed state of ClusterConfig
 type ClusterConfigStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// The progress of the most recent migration from one network provider to
+	// another. Only present if the network provider has been changed.
+	// +optional
+	NetworkProviderMigration *NetworkProviderMigrationStatus `json:"networkProviderMigration,omitempty"`
+}
+
+// NetworkProviderMigrationStatus reports the progress of a migration between
+// two network providers.
+type NetworkProviderMigrationStatus struct {
+	// The network provider that is being migrated away from.
+	From string `json:"from"`
+	// The network provider that is being migrated to.
+	To string `json:"to"`
+	// The phase the migration is in.
+	Phase NetworkProviderMigrationPhase `json:"phase"`
+	// The node that is currently being migrated, if any.
+	// +optional
+	CurrentNode string `json:"currentNode,omitempty"`
+	// The number of nodes that have been migrated to the new network provider.
+	MigratedNodes int `json:"migratedNodes"`
+	// The total number of nodes in the cluster.
+	TotalNodes int `json:"totalNodes"`
+	// A human-readable message indicating details about the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The last time the phase or the current node changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// Indicates the phase of a network provider migration.
+// +kubebuilder:validation:Enum=InProgress;Completed;Failed
+type NetworkProviderMigrationPhase string
+
+const (
+	// The nodes are being migrated, one after another.
+	NetworkProviderMigrationInProgress NetworkProviderMigrationPhase = "InProgress"
+	// All nodes have been migrated and the old network provider is removed.
+	NetworkProviderMigrationCompleted NetworkProviderMigrationPhase = "Completed"
+	// The migration can't be performed. No nodes have been touched.
+	NetworkProviderMigrationFailed NetworkProviderMigrationPhase = "Failed"
+)
+
+// ActiveNetworkProviderMigration returns the network provider migration that
+// is in progress, i.e. while some nodes are running the old and some are
+// running the new network provider. Returns nil if there's none.
+func (s *ClusterConfigStatus) ActiveNetworkProviderMigration() *NetworkProviderMigrationStatus {
+	if s != nil && s.NetworkProviderMigration != nil && s.NetworkProviderMigration.Phase == NetworkProviderMigrationInProgress {
+		return s.NetworkProviderMigration
+	}
+	return nil
 }
 
 // ClusterConfig is the Schema for the clusterconfigs API
@@ -56,7 +105,7 @@ type ClusterConfigStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +genclient
-// +genclient:onlyVerbs=create,delete,list,get,watch,update
+// +genclient:onlyVerbs=create,delete,list,get,watch,update,updateStatus
 type ClusterConfig struct {
 	metav1.TypeMeta `json:",inline"`
 	// +optional
@@ -91,6 +140,7 @@ func (c *ClusterConfig) StripDefaults() *ClusterConfig {
 		orig := nllb.DeepCopy()
 		nllb.EnvoyProxy = nil
 		nllb.Traefik = nil
+		nllb.TCPProxy = nil
 		// NLLB images are rewritten with spec.images.repository, so strip them
 		// against the defaults rewritten with the same repository.
 		var repository string
@@ -116,6 +166,8 @@ func (c *ClusterConfig) StripDefaults() *ClusterConfig {
 					return img
 				})
 			}
+		case NllbTypeTCPProxy:
+			nllb.TCPProxy = orig.TCPProxy
 		}
 	}
 	if reflect.DeepEqual(c.Spec.Telemetry, DefaultClusterTelemetry()) {
@@ -463,6 +515,10 @@ func (s *ClusterSpec) Validate() (errs []error) {
 		errs = append(errs, err)
 	}
 
+	for _, err := range s.ValidateKonnectivity() {
+		errs = append(errs, err)
+	}
+
 	if s.Network != nil && s.Network.ControlPlaneLoadBalancing != nil {
 		for _, err := range s.Network.ControlPlaneLoadBalancing.Validate() {
 			errs = append(errs, fmt.Errorf("controlPlaneLoadBalancing: %w", err))
@@ -493,6 +549,24 @@ func (s *ClusterSpec) ValidateNodeLocalLoadBalancing() (errs field.ErrorList) {
 	return
 }
 
+// ValidateKonnectivity checks the konnectivity settings that depend on other
+// parts of the spec.
+func (s *ClusterSpec) ValidateKonnectivity() (errs field.ErrorList) {
+	if !s.Konnectivity.IsPerServer() || s.API == nil {
+		return
+	}
+
+	// The agents discover the servers via the endpoints of the kubernetes
+	// service. Those contain the external address, not the controllers.
+	if s.API.ExternalAddress != "" {
+		path := field.NewPath("konnectivity", "agentConnectionMode")
+		detail := "cannot be used in conjunction with an external Kubernetes API server address"
+		errs = append(errs, field.Forbidden(path, detail))
+	}
+
+	return
+}
+
 func (s *ClusterSpec) overrideImageRepositories() {
 	if s != nil &&
 		s.Images != nil &&
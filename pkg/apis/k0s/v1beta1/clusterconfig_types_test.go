@@ -183,7 +183,7 @@ spec:
 	assert.NoError(t, err)
 	errors := c.Validate()
 	if assert.Len(t, errors, 1) {
-		assert.ErrorContains(t, errors[0], `spec: network: provider: Unsupported value: "invalidProvider": supported values: "kuberouter", "calico", "custom"`)
+		assert.ErrorContains(t, errors[0], `spec: network: provider: Unsupported value: "invalidProvider": supported values: "kuberouter", "calico", "cilium", "custom"`)
 	}
 }
 
@@ -205,6 +205,31 @@ spec:
 	assert.Equal(t, "https://foo.bar.com:9443", c.Spec.API.K0sControlPlaneAPIAddress())
 }
 
+func TestKonnectivityValidation_PerServerWithExternalAddress(t *testing.T) {
+	yamlData := []byte(`
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: foobar
+spec:
+  api:
+    externalAddress: foo.bar.com
+    address: 1.2.3.4
+  konnectivity:
+    agentConnectionMode: PerServer
+`)
+
+	c, err := ConfigFromBytes(yamlData)
+	require.NoError(t, err)
+	errors := c.Validate()
+	if assert.Len(t, errors, 1) {
+		assert.ErrorContains(t, errors[0], "spec: konnectivity.agentConnectionMode: Forbidden: cannot be used in conjunction with an external Kubernetes API server address")
+	}
+
+	// The cluster-wide config doesn't contain the API spec.
+	assert.Empty(t, c.GetClusterWideConfig().Spec.ValidateKonnectivity())
+}
+
 func TestApiNoExternalAddress(t *testing.T) {
 	yamlData := []byte(`
 apiVersion: k0s.k0sproject.io/v1beta1
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"slices"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,23 +30,39 @@ type ControlPlaneLoadBalancingSpec struct {
 	Enabled bool `json:"enabled"`
 
 	// type indicates the type of the control plane load balancer to deploy on
-	// controller nodes. Currently, the only supported type is "Keepalived".
+	// controller nodes. Either "Keepalived", "LeaseVIP" or "BGP".
 	// +kubebuilder:default=Keepalived
 	Type CPLBType `json:"type,omitempty"`
 
 	// Keepalived contains configuration options related to the "Keepalived" type
 	// of load balancing.
 	Keepalived *KeepalivedSpec `json:"keepalived,omitempty"`
+
+	// LeaseVIP contains configuration options related to the "LeaseVIP" type
+	// of load balancing.
+	LeaseVIP *LeaseVIPSpec `json:"leaseVIP,omitempty"`
+
+	// BGP contains configuration options related to the "BGP" type of load
+	// balancing.
+	BGP *BGPSpec `json:"bgp,omitempty"`
 }
 
 // CPLBType describes which type of load balancer should be deployed for the
 // control plane load balancing. The default is [CPLBTypeKeepalived].
-// +kubebuilder:validation:Enum=Keepalived
+// +kubebuilder:validation:Enum=Keepalived;LeaseVIP;BGP
 type CPLBType string
 
 const (
 	// CPLBTypeKeepalived selects Keepalived as the backing load balancer.
 	CPLBTypeKeepalived CPLBType = "Keepalived"
+
+	// CPLBTypeLeaseVIP makes k0s manage the virtual IPs itself, based on a
+	// leader election among the controllers.
+	CPLBTypeLeaseVIP CPLBType = "LeaseVIP"
+
+	// CPLBTypeBGP makes each healthy controller advertise the virtual IPs to
+	// BGP peers.
+	CPLBTypeBGP CPLBType = "BGP"
 )
 
 type KeepalivedSpec struct {
@@ -63,6 +80,13 @@ type KeepalivedSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
 	UserSpaceProxyPort int `json:"userSpaceProxyBindPort,omitempty"`
+	// UserSpaceProxyBalancing is the algorithm the userspace proxy uses to
+	// distribute connections among the API servers. Defaults to
+	// LeastConnections.
+	//
+	// +kubebuilder:default=LeastConnections
+	// +optional
+	UserSpaceProxyBalancing UserSpaceProxyBalancing `json:"userSpaceProxyBalancing,omitempty"`
 	// DisableLoadBalancer disables the load balancer.
 	DisableLoadBalancer bool `json:"disableLoadBalancer,omitempty"`
 	// ConfigTemplateVRRP specifies the path to a custom Keepalived configuration template for VRRP.
@@ -77,6 +101,132 @@ type KeepalivedSpec struct {
 	ConfigTemplateVS string `json:"configTemplateVS,omitempty"`
 }
 
+// UserSpaceProxyBalancing describes how the userspace proxy distributes
+// connections among the API servers.
+// +kubebuilder:validation:Enum=LeastConnections;PowerOfTwoChoices;RoundRobin
+type UserSpaceProxyBalancing string
+
+const (
+	// UserSpaceProxyBalancingLeastConnections sends each connection to the API
+	// server with the fewest active connections.
+	UserSpaceProxyBalancingLeastConnections UserSpaceProxyBalancing = "LeastConnections"
+	// UserSpaceProxyBalancingPowerOfTwoChoices sends each connection to the
+	// API server with fewer active connections out of two randomly chosen
+	// ones.
+	UserSpaceProxyBalancingPowerOfTwoChoices UserSpaceProxyBalancing = "PowerOfTwoChoices"
+	// UserSpaceProxyBalancingRoundRobin sends connections to the API servers
+	// in turn, regardless of their load.
+	UserSpaceProxyBalancingRoundRobin UserSpaceProxyBalancing = "RoundRobin"
+)
+
+// LeaseVIPSpec defines the configuration options for the "LeaseVIP" type of
+// control plane load balancing. The controllers elect a leader using a
+// Kubernetes Lease. The leader adds the virtual IPs to its network interface
+// and announces them using gratuitous ARP (IPv4) or unsolicited neighbor
+// advertisements (IPv6). No VRRP traffic and no keepalived binary is involved.
+type LeaseVIPSpec struct {
+	// VirtualIPs is the list of virtual IP addresses held by the leader.
+	// Each virtual IP must be a CIDR as defined in RFC 4632 and RFC 4291.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	VirtualIPs []string `json:"virtualIPs"`
+
+	// Interface specifies the NIC the virtual IPs are added to.
+	// If not specified, k0s will use the interface that owns the default route.
+	// If a MAC address is specified instead of an interface name, k0s will
+	// try to resolve the interface name based on the MAC address.
+	Interface string `json:"interface,omitempty"`
+
+	// LeaseDurationSeconds is the time after which the other controllers take
+	// over the virtual IPs if the leader stops renewing its lease. Defaults
+	// to 10 seconds.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:default=10
+	LeaseDurationSeconds int32 `json:"leaseDurationSeconds,omitempty"`
+
+	// UserspaceProxyPort is the port where the userspace proxy will bind
+	// to. This port is only used internally, but listens on every interface.
+	// Defaults to 6444
+	//
+	// +kubebuilder:default=6444
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	UserSpaceProxyPort int `json:"userSpaceProxyBindPort,omitempty"`
+	// UserSpaceProxyBalancing is the algorithm the userspace proxy uses to
+	// distribute connections among the API servers. Defaults to
+	// LeastConnections.
+	//
+	// +kubebuilder:default=LeastConnections
+	// +optional
+	UserSpaceProxyBalancing UserSpaceProxyBalancing `json:"userSpaceProxyBalancing,omitempty"`
+	// DisableLoadBalancer disables the load balancer. The leader's API server
+	// will then serve all the requests to the virtual IPs.
+	DisableLoadBalancer bool `json:"disableLoadBalancer,omitempty"`
+}
+
+// BGPSpec defines the configuration options for the "BGP" type of control
+// plane load balancing. Each controller advertises the virtual IPs as host
+// routes (/32 or /128) to its BGP peers, as long as its local API server is
+// healthy. The routers then distribute the traffic among the controllers,
+// e.g. via ECMP. This works across L3 boundaries, in contrast to the types that
+// rely on a shared L2 segment.
+type BGPSpec struct {
+	// VirtualIPs is the list of virtual IP addresses advertised by the
+	// controllers. Each virtual IP must be a plain IP address, not a CIDR.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	VirtualIPs []string `json:"virtualIPs"`
+
+	// LocalASN is the autonomous system number of the controllers.
+	// +kubebuilder:validation:Minimum=1
+	LocalASN uint32 `json:"localASN"`
+
+	// RouterID is the BGP identifier of the controller, in the form of an IPv4
+	// address. If not specified, the controller's API address is used, which
+	// then needs to be an IPv4 address.
+	RouterID string `json:"routerID,omitempty"`
+
+	// HoldTimeSeconds is the time after which a peer considers a controller
+	// gone if it stops sending keepalives, and withdraws its routes. The
+	// lower of the two hold times proposed by the controller and the peer is
+	// used. Defaults to 90 seconds.
+	// +kubebuilder:validation:Minimum=3
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=90
+	HoldTimeSeconds int32 `json:"holdTimeSeconds,omitempty"`
+
+	// Peers is the list of BGP peers the virtual IPs are advertised to. Each
+	// peer only receives the virtual IPs of its own address family.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=address
+	Peers []BGPPeer `json:"peers"`
+}
+
+// BGPPeer defines a BGP peer of the controllers.
+type BGPPeer struct {
+	// Address is the IP address of the peer.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// ASN is the autonomous system number of the peer. If it's the same as
+	// the local ASN, the session is an iBGP session.
+	// +kubebuilder:validation:Minimum=1
+	ASN uint32 `json:"asn"`
+
+	// Port is the TCP port of the peer. Defaults to 179.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=179
+	Port int `json:"port,omitempty"`
+
+	// Password enables TCP MD5 signatures (RFC 2385) for the session with the
+	// peer, using the given password. The peer needs to be configured with
+	// the same password.
+	// +kubebuilder:validation:MaxLength=80
+	Password string `json:"password,omitempty"`
+}
+
 // VRRPInstances is a list of VRRPInstance
 // +kubebuilder:validation:MaxItems=255
 type VRRPInstances []VRRPInstance
@@ -328,13 +478,23 @@ func (c *ControlPlaneLoadBalancingSpec) Validate() (errs []error) {
 
 	switch c.Type {
 	case CPLBTypeKeepalived:
+	case CPLBTypeLeaseVIP:
+		if c.Enabled && c.LeaseVIP == nil {
+			errs = append(errs, fmt.Errorf("%s load balancing requires leaseVIP to be configured", c.Type))
+		}
+	case CPLBTypeBGP:
+		if c.Enabled && c.BGP == nil {
+			errs = append(errs, fmt.Errorf("%s load balancing requires bgp to be configured", c.Type))
+		}
 	case "":
 		c.Type = CPLBTypeKeepalived
 	default:
-		errs = append(errs, fmt.Errorf("unsupported CPLB type: %s. Only allowed value: %s", c.Type, CPLBTypeKeepalived))
+		errs = append(errs, fmt.Errorf("unsupported CPLB type: %s. Allowed values: %s, %s, %s", c.Type, CPLBTypeKeepalived, CPLBTypeLeaseVIP, CPLBTypeBGP))
 	}
 
-	return append(errs, c.Keepalived.Validate()...)
+	errs = append(errs, c.Keepalived.Validate()...)
+	errs = append(errs, c.LeaseVIP.validate(nil)...)
+	return append(errs, c.BGP.validate()...)
 }
 
 // Validate validates the KeepalivedSpec
@@ -345,11 +505,126 @@ func (k *KeepalivedSpec) Validate() (errs []error) {
 
 	errs = append(errs, k.validateVRRPInstances(nil)...)
 	errs = append(errs, k.validateVirtualServers()...)
-	if k.UserSpaceProxyPort == 0 {
-		k.UserSpaceProxyPort = 6444
-	} else if k.UserSpaceProxyPort < 1 || k.UserSpaceProxyPort > 65535 {
+	return append(errs, validateUserSpaceProxy(&k.UserSpaceProxyPort, &k.UserSpaceProxyBalancing)...)
+}
+
+// validateUserSpaceProxy validates the userspace proxy settings and sets their
+// default values if undefined.
+func validateUserSpaceProxy(port *int, balancing *UserSpaceProxyBalancing) (errs []error) {
+	if *port == 0 {
+		*port = 6444
+	} else if *port < 1 || *port > 65535 {
 		errs = append(errs, errors.New("UserSpaceProxyPort must be in the range of 1-65535"))
 	}
 
+	switch *balancing {
+	case UserSpaceProxyBalancingLeastConnections, UserSpaceProxyBalancingPowerOfTwoChoices, UserSpaceProxyBalancingRoundRobin:
+	case "":
+		*balancing = UserSpaceProxyBalancingLeastConnections
+	default:
+		errs = append(errs, fmt.Errorf("unsupported UserSpaceProxyBalancing: %s", *balancing))
+	}
+
+	return errs
+}
+
+// validate validates the LeaseVIPSpec and sets the default values of undefined
+// fields.
+func (l *LeaseVIPSpec) validate(getDefaultNICFn func() (string, error)) (errs []error) {
+	if l == nil {
+		return nil
+	}
+	if getDefaultNICFn == nil {
+		getDefaultNICFn = getDefaultNIC
+	}
+
+	if l.Interface == "" {
+		nic, err := getDefaultNICFn()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get default NIC: %w", err))
+		}
+		l.Interface = nic
+	} else if _, err := net.ParseMAC(l.Interface); err == nil {
+		macToInterfaceName(&l.Interface, &errs)
+	}
+
+	if len(l.VirtualIPs) == 0 {
+		errs = append(errs, errors.New("VirtualIPs must be defined"))
+	}
+	for _, vip := range l.VirtualIPs {
+		if _, _, err := net.ParseCIDR(vip); err != nil {
+			errs = append(errs, fmt.Errorf("VirtualIPs must be a CIDR. Got: %s", vip))
+		}
+	}
+
+	if l.LeaseDurationSeconds == 0 {
+		l.LeaseDurationSeconds = 10
+	} else if l.LeaseDurationSeconds < 2 {
+		errs = append(errs, errors.New("LeaseDurationSeconds must be at least 2"))
+	}
+
+	return append(errs, validateUserSpaceProxy(&l.UserSpaceProxyPort, &l.UserSpaceProxyBalancing)...)
+}
+
+// validate validates the BGPSpec and sets the default values of undefined
+// fields.
+func (b *BGPSpec) validate() (errs []error) {
+	if b == nil {
+		return nil
+	}
+
+	if len(b.VirtualIPs) == 0 {
+		errs = append(errs, errors.New("VirtualIPs must be defined"))
+	}
+	for _, vip := range b.VirtualIPs {
+		ip := net.ParseIP(vip)
+		if ip == nil {
+			errs = append(errs, fmt.Errorf("VirtualIPs must be IP addresses. Got: %s", vip))
+			continue
+		}
+		if !slices.ContainsFunc(b.Peers, func(p BGPPeer) bool {
+			peerIP := net.ParseIP(p.Address)
+			return peerIP != nil && (peerIP.To4() == nil) == (ip.To4() == nil)
+		}) {
+			errs = append(errs, fmt.Errorf("no peer of the same IP family to advertise %s to", vip))
+		}
+	}
+
+	if b.LocalASN == 0 {
+		errs = append(errs, errors.New("LocalASN must be defined"))
+	}
+
+	if b.RouterID != "" {
+		if ip := net.ParseIP(b.RouterID); ip == nil || ip.To4() == nil {
+			errs = append(errs, fmt.Errorf("RouterID must be an IPv4 address. Got: %s", b.RouterID))
+		}
+	}
+
+	if b.HoldTimeSeconds == 0 {
+		b.HoldTimeSeconds = 90
+	} else if b.HoldTimeSeconds < 3 || b.HoldTimeSeconds > math.MaxUint16 {
+		errs = append(errs, errors.New("HoldTimeSeconds must be in the range of 3-65535"))
+	}
+
+	if len(b.Peers) == 0 {
+		errs = append(errs, errors.New("Peers must be defined"))
+	}
+	for i := range b.Peers {
+		if net.ParseIP(b.Peers[i].Address) == nil {
+			errs = append(errs, fmt.Errorf("peer address must be an IP address. Got: %s", b.Peers[i].Address))
+		}
+		if b.Peers[i].ASN == 0 {
+			errs = append(errs, fmt.Errorf("ASN of peer %s must be defined", b.Peers[i].Address))
+		}
+		if b.Peers[i].Port == 0 {
+			b.Peers[i].Port = 179
+		} else if b.Peers[i].Port < 1 || b.Peers[i].Port > 65535 {
+			errs = append(errs, fmt.Errorf("port of peer %s must be in the range of 1-65535", b.Peers[i].Address))
+		}
+		if len(b.Peers[i].Password) > 80 {
+			errs = append(errs, fmt.Errorf("password of peer %s must be 80 characters or less", b.Peers[i].Address))
+		}
+	}
+
 	return errs
 }
@@ -5,6 +5,7 @@ package v1beta1
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -325,8 +326,75 @@ func (s *CPLBSuite) TestValidateVirtualServers() {
 		})
 	}
 }
+
+func (s *CPLBSuite) TestValidateUserSpaceProxyBalancing() {
+	k := &KeepalivedSpec{}
+	s.Require().Empty(k.Validate())
+	s.Equal(UserSpaceProxyBalancingLeastConnections, k.UserSpaceProxyBalancing, "Should default to LeastConnections")
+
+	k = &KeepalivedSpec{UserSpaceProxyBalancing: UserSpaceProxyBalancingRoundRobin}
+	s.Require().Empty(k.Validate())
+	s.Equal(UserSpaceProxyBalancingRoundRobin, k.UserSpaceProxyBalancing)
+
+	k = &KeepalivedSpec{UserSpaceProxyBalancing: "invalid"}
+	s.Require().Error(errors.Join(k.Validate()...))
+}
+
 func TestCPLBSuite(t *testing.T) {
 	cplbSuite := &CPLBSuite{}
 
 	suite.Run(t, cplbSuite)
 }
+
+func (s *CPLBSuite) TestValidateLeaseVIP() {
+	l := &LeaseVIPSpec{VirtualIPs: []string{"192.168.1.100/24"}}
+	s.Require().Empty(l.validate(returnNIC))
+	s.Equal("fake-nic-0", l.Interface)
+	s.Equal(int32(10), l.LeaseDurationSeconds)
+	s.Equal(6444, l.UserSpaceProxyPort)
+	s.Equal(UserSpaceProxyBalancingLeastConnections, l.UserSpaceProxyBalancing)
+
+	for _, l := range []*LeaseVIPSpec{
+		{},
+		{VirtualIPs: []string{"192.168.1.100"}},
+		{VirtualIPs: []string{"192.168.1.100/24"}, LeaseDurationSeconds: 1},
+		{VirtualIPs: []string{"192.168.1.100/24"}, UserSpaceProxyPort: 65536},
+	} {
+		s.Error(errors.Join(l.validate(returnNIC)...), "For %+v", l)
+	}
+
+	c := &ControlPlaneLoadBalancingSpec{Enabled: true, Type: CPLBTypeLeaseVIP}
+	s.Error(errors.Join(c.Validate()...), "LeaseVIP type requires a leaseVIP config")
+}
+
+func (s *CPLBSuite) TestValidateBGP() {
+	b := &BGPSpec{
+		VirtualIPs: []string{"192.168.1.100", "fd00::100"},
+		LocalASN:   65001,
+		Peers:      []BGPPeer{{Address: "10.0.0.1", ASN: 65000}, {Address: "fd00::1", ASN: 65000, Port: 1179}},
+	}
+	s.Require().Empty(b.validate())
+	s.Equal(int32(90), b.HoldTimeSeconds)
+	s.Equal(179, b.Peers[0].Port)
+	s.Equal(1179, b.Peers[1].Port)
+
+	peers := []BGPPeer{{Address: "10.0.0.1", ASN: 65000}}
+	for _, b := range []*BGPSpec{
+		{},
+		{VirtualIPs: []string{"192.168.1.100/32"}, LocalASN: 65001, Peers: peers},
+		{VirtualIPs: []string{"fd00::100"}, LocalASN: 65001, Peers: peers},
+		{VirtualIPs: []string{"192.168.1.100"}, Peers: peers},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001, Peers: peers, RouterID: "fd00::1"},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001, Peers: peers, HoldTimeSeconds: 2},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001, Peers: []BGPPeer{{Address: "10.0.0.1"}}},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001, Peers: []BGPPeer{{Address: "router", ASN: 65000}}},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001, Peers: []BGPPeer{{Address: "10.0.0.1", ASN: 65000, Port: 65536}}},
+		{VirtualIPs: []string{"192.168.1.100"}, LocalASN: 65001, Peers: []BGPPeer{{Address: "10.0.0.1", ASN: 65000, Password: strings.Repeat("x", 81)}}},
+	} {
+		s.Error(errors.Join(b.validate()...), "For %+v", b)
+	}
+
+	c := &ControlPlaneLoadBalancingSpec{Enabled: true, Type: CPLBTypeBGP}
+	s.Error(errors.Join(c.Validate()...), "BGP type requires a bgp config")
+}
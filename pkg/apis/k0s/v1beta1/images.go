@@ -54,8 +54,11 @@ func (s *ImageSpec) URI() string {
 
 // ClusterImages sets docker images for addon components
 type ClusterImages struct {
-	Konnectivity  *ImageSpec        `json:"konnectivity,omitempty"`
-	PushGateway   *ImageSpec        `json:"pushgateway,omitempty"`
+	Konnectivity *ImageSpec `json:"konnectivity,omitempty"`
+
+	// Deprecated: The pushgateway is no longer used by k0s. This setting is ignored.
+	PushGateway *ImageSpec `json:"pushgateway,omitempty"`
+
 	MetricsServer *ImageSpec        `json:"metricsserver,omitempty"`
 	KubeProxy     *ImageSpec        `json:"kubeproxy,omitempty"`
 	CoreDNS       *ImageSpec        `json:"coredns,omitempty"`
@@ -63,6 +66,7 @@ type ClusterImages struct {
 	Windows       *WindowsImageSpec `json:"windows,omitempty"`
 
 	Calico     *CalicoImageSpec     `json:"calico,omitempty"`
+	Cilium     *CiliumImageSpec     `json:"cilium,omitempty"`
 	KubeRouter *KubeRouterImageSpec `json:"kuberouter,omitempty"`
 
 	Repository string `json:"repository,omitempty"`
@@ -111,6 +115,7 @@ func (ci *ClusterImages) Validate(path *field.Path) (errs field.ErrorList) {
 	errs = append(errs, ci.CoreDNS.Validate(path.Child("coredns"))...)
 	errs = append(errs, ci.Pause.Validate(path.Child("pause"))...)
 	errs = append(errs, ci.Calico.Validate(path.Child("calico"))...)
+	errs = append(errs, ci.Cilium.Validate(path.Child("cilium"))...)
 	errs = append(errs, ci.KubeRouter.Validate(path.Child("kuberouter"))...)
 	errs = append(errs, ci.Windows.Validate(path.Child("windows"))...)
 	return
@@ -132,6 +137,9 @@ func (ci *ClusterImages) overrideImageRepositories() {
 	override(ci.Calico.KubeControllers)
 	override(ci.Calico.Windows.CNI)
 	override(ci.Calico.Windows.Node)
+	override(ci.Cilium.Agent)
+	override(ci.Cilium.Operator)
+	override(ci.Cilium.HubbleRelay)
 	override(ci.KubeRouter.CNI)
 	override(ci.KubeRouter.CNIInstaller)
 	override(ci.Pause)
@@ -187,6 +195,23 @@ func (s *CalicoWindowsImageSpec) Validate(path *field.Path) (errs field.ErrorLis
 	return
 }
 
+// CiliumImageSpec config group for cilium related images
+type CiliumImageSpec struct {
+	Agent       *ImageSpec `json:"agent,omitempty"`
+	Operator    *ImageSpec `json:"operator,omitempty"`
+	HubbleRelay *ImageSpec `json:"hubbleRelay,omitempty"`
+}
+
+func (s *CiliumImageSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s == nil {
+		return
+	}
+	errs = append(errs, s.Agent.Validate(path.Child("agent"))...)
+	errs = append(errs, s.Operator.Validate(path.Child("operator"))...)
+	errs = append(errs, s.HubbleRelay.Validate(path.Child("hubbleRelay"))...)
+	return
+}
+
 // KubeRouterImageSpec config group for kube-router related images
 type KubeRouterImageSpec struct {
 	CNI          *ImageSpec `json:"cni,omitempty"`
@@ -250,6 +275,20 @@ func DefaultClusterImages() *ClusterImages {
 				},
 			},
 		},
+		Cilium: &CiliumImageSpec{
+			Agent: &ImageSpec{
+				Image:   constant.CiliumAgentImage,
+				Version: constant.CiliumAgentImageVersion,
+			},
+			Operator: &ImageSpec{
+				Image:   constant.CiliumOperatorImage,
+				Version: constant.CiliumOperatorImageVersion,
+			},
+			HubbleRelay: &ImageSpec{
+				Image:   constant.CiliumHubbleRelayImage,
+				Version: constant.CiliumHubbleRelayImageVersion,
+			},
+		},
 		KubeRouter: &KubeRouterImageSpec{
 			CNI: &ImageSpec{
 				Image:   constant.KubeRouterCNIImage,
@@ -12,6 +12,11 @@ var _ Validateable = (*KonnectivitySpec)(nil)
 
 // KonnectivitySpec defines the requested state for Konnectivity
 type KonnectivitySpec struct {
+	// Disables konnectivity. The API servers will then connect to the
+	// kubelets directly, so the controllers need to be able to reach them.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
 	// admin port to listen on (default 8133)
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
@@ -27,6 +32,41 @@ type KonnectivitySpec struct {
 	// external address to advertise for the konnectivity agent to connect to
 	// +optional
 	ExternalAddress string `json:"externalAddress,omitempty"`
+
+	// How the konnectivity agents connect to the konnectivity servers. With
+	// ServerCount, the agents connect via a single address and keep
+	// connecting until they reached all servers. With PerServer, the agents
+	// connect to each server directly. Defaults to ServerCount.
+	// +kubebuilder:validation:Enum=ServerCount;PerServer
+	// +optional
+	AgentConnectionMode KonnectivityAgentConnectionMode `json:"agentConnectionMode,omitempty"`
+}
+
+// KonnectivityAgentConnectionMode describes how the konnectivity agents
+// connect to the konnectivity servers.
+type KonnectivityAgentConnectionMode string
+
+const (
+	// KonnectivityAgentConnectionModeServerCount makes the agents connect via
+	// a single address, which is expected to be load balanced among all
+	// servers. The agents keep connecting until they reached as many distinct
+	// servers as there are active controllers.
+	KonnectivityAgentConnectionModeServerCount KonnectivityAgentConnectionMode = "ServerCount"
+
+	// KonnectivityAgentConnectionModePerServer makes the agents connect to
+	// each server directly, using the API server addresses of the
+	// controllers. There's a separate agent for each server.
+	KonnectivityAgentConnectionModePerServer KonnectivityAgentConnectionMode = "PerServer"
+)
+
+// IsEnabled returns whether konnectivity is enabled.
+func (k *KonnectivitySpec) IsEnabled() bool {
+	return k == nil || !k.Disabled
+}
+
+// IsPerServer returns whether the agents connect to each server directly.
+func (k *KonnectivitySpec) IsPerServer() bool {
+	return k != nil && k.AgentConnectionMode == KonnectivityAgentConnectionModePerServer
 }
 
 // DefaultKonnectivitySpec builds default KonnectivitySpec
@@ -51,5 +91,21 @@ func (k *KonnectivitySpec) Validate() (errs []error) {
 		errs = append(errs, field.Invalid(field.NewPath("agentPort"), k.AgentPort, msg))
 	}
 
+	switch k.AgentConnectionMode {
+	case "", KonnectivityAgentConnectionModeServerCount:
+	case KonnectivityAgentConnectionModePerServer:
+		if k.ExternalAddress != "" {
+			errs = append(errs, field.Forbidden(field.NewPath("externalAddress"), "cannot be used with agentConnectionMode "+string(k.AgentConnectionMode)))
+		}
+		if k.Disabled {
+			errs = append(errs, field.Forbidden(field.NewPath("agentConnectionMode"), "cannot be "+string(k.AgentConnectionMode)+" if konnectivity is disabled"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("agentConnectionMode"), k.AgentConnectionMode, []KonnectivityAgentConnectionMode{
+			KonnectivityAgentConnectionModeServerCount,
+			KonnectivityAgentConnectionModePerServer,
+		}))
+	}
+
 	return errs
 }
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKonnectivitySpec_Validate(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		spec *KonnectivitySpec
+		errs []string
+	}{
+		{"nil", nil, nil},
+		{"default", DefaultKonnectivitySpec(), nil},
+		{"server_count", &KonnectivitySpec{AgentPort: 8132, AdminPort: 8133, AgentConnectionMode: KonnectivityAgentConnectionModeServerCount, ExternalAddress: "konnectivity.example.com"}, nil},
+		{"per_server", &KonnectivitySpec{AgentPort: 8132, AdminPort: 8133, AgentConnectionMode: KonnectivityAgentConnectionModePerServer}, nil},
+		{"per_server_external_address", &KonnectivitySpec{AgentPort: 8132, AdminPort: 8133, AgentConnectionMode: KonnectivityAgentConnectionModePerServer, ExternalAddress: "konnectivity.example.com"}, []string{
+			"externalAddress: Forbidden: cannot be used with agentConnectionMode PerServer",
+		}},
+		{"disabled", &KonnectivitySpec{Disabled: true, AgentPort: 8132, AdminPort: 8133}, nil},
+		{"disabled_per_server", &KonnectivitySpec{Disabled: true, AgentPort: 8132, AdminPort: 8133, AgentConnectionMode: KonnectivityAgentConnectionModePerServer}, []string{
+			"agentConnectionMode: Forbidden: cannot be PerServer if konnectivity is disabled",
+		}},
+		{"unknown_mode", &KonnectivitySpec{AgentPort: 8132, AdminPort: 8133, AgentConnectionMode: "Random"}, []string{
+			`agentConnectionMode: Unsupported value: "Random": supported values: "ServerCount", "PerServer"`,
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var errs []string
+			for _, err := range test.spec.Validate() {
+				errs = append(errs, err.Error())
+			}
+			assert.Equal(t, test.errs, errs)
+		})
+	}
+}
+
+func TestKonnectivitySpec_IsPerServer(t *testing.T) {
+	assert.False(t, (*KonnectivitySpec)(nil).IsPerServer())
+	assert.False(t, DefaultKonnectivitySpec().IsPerServer())
+	assert.True(t, (&KonnectivitySpec{AgentConnectionMode: KonnectivityAgentConnectionModePerServer}).IsPerServer())
+}
+
+func TestKonnectivitySpec_IsEnabled(t *testing.T) {
+	assert.True(t, (*KonnectivitySpec)(nil).IsEnabled())
+	assert.True(t, DefaultKonnectivitySpec().IsEnabled())
+	assert.False(t, (&KonnectivitySpec{Disabled: true}).IsEnabled())
+}
@@ -4,6 +4,7 @@
 package v1beta1
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -21,6 +22,7 @@ var _ Validateable = (*Network)(nil)
 // Network defines the network related config options
 type Network struct {
 	Calico *Calico `json:"calico,omitempty"`
+	Cilium *Cilium `json:"cilium,omitempty"`
 	// +optional
 	DualStack DualStack `json:"dualStack"`
 
@@ -38,13 +40,22 @@ type Network struct {
 	// control plane load balancing feature.
 	ControlPlaneLoadBalancing *ControlPlaneLoadBalancingSpec `json:"controlPlaneLoadBalancing,omitempty"`
 
+	// Policies defines the baseline network policies managed by k0s.
+	Policies *NetworkPolicies `json:"policies,omitempty"`
+
 	// Pod network CIDR to use in the cluster
 	// +kubebuilder:default="10.244.0.0/16"
 	PodCIDR string `json:"podCIDR,omitempty"`
-	// Network provider (valid values: calico, kuberouter, or custom)
-	// +kubebuilder:validation:Enum=kuberouter;calico;custom
+	// Network provider (valid values: calico, cilium, kuberouter, or custom)
+	// +kubebuilder:validation:Enum=kuberouter;calico;cilium;custom
 	// +kubebuilder:default=kuberouter
 	Provider string `json:"provider,omitempty"`
+	// Allows to change the network provider by migrating the nodes one after
+	// another (only between kuberouter and calico). Pods on different nodes
+	// may get conflicting IP addresses until the migration is completed, so
+	// this is meant to be enabled during a maintenance window only.
+	// +optional
+	AllowProviderMigration bool `json:"allowProviderMigration,omitempty"`
 	// Network CIDR to use for cluster VIP services
 	// +kubebuilder:default="10.96.0.0/12"
 	ServiceCIDR string `json:"serviceCIDR,omitempty"`
@@ -92,8 +103,8 @@ func (n *Network) Validate() []error {
 
 	if n.Provider == "" {
 		errors = append(errors, field.Required(field.NewPath("provider"), ""))
-	} else if n.Provider != "calico" && n.Provider != "custom" && n.Provider != "kuberouter" {
-		errors = append(errors, field.NotSupported(field.NewPath("provider"), n.Provider, []string{"kuberouter", "calico", "custom"}))
+	} else if allowed := []string{"kuberouter", "calico", "cilium", "custom"}; !slices.Contains(allowed, n.Provider) {
+		errors = append(errors, field.NotSupported(field.NewPath("provider"), n.Provider, allowed))
 	}
 
 	validCIDRs := true
@@ -179,6 +190,15 @@ func (n *Network) Validate() []error {
 	errors = append(errors, n.KubeProxy.Validate()...)
 	errors = append(errors, n.KubeRouter.Validate(field.NewPath("kuberouter"))...)
 	errors = append(errors, n.Calico.Validate(field.NewPath("calico"))...)
+	errors = append(errors, n.Cilium.Validate(field.NewPath("cilium"))...)
+	if n.Provider == "cilium" && n.Cilium != nil && n.KubeProxy != nil {
+		// Without kube-proxy, there's nothing but Cilium to implement Services.
+		if n.Cilium.KubeProxyReplacement && !n.KubeProxy.Disabled {
+			errors = append(errors, field.Forbidden(field.NewPath("cilium", "kubeProxyReplacement"), "requires kubeProxy.disabled to be true"))
+		} else if !n.Cilium.KubeProxyReplacement && n.KubeProxy.Disabled {
+			errors = append(errors, field.Forbidden(field.NewPath("kubeProxy", "disabled"), "requires cilium.kubeProxyReplacement to be true"))
+		}
+	}
 	errors = append(errors, n.CoreDNS.Validate(field.NewPath("coreDNS"))...)
 	for _, err := range n.NodeLocalLoadBalancing.Validate(field.NewPath("nodeLocalLoadBalancing")) {
 		errors = append(errors, err)
@@ -250,18 +270,41 @@ func (n *Network) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	// The configuration of providers other than the selected one is dropped,
+	// unless it has been given explicitly. This retains the configuration of
+	// the previous provider while migrating to another one.
+	var explicit struct {
+		Calico     json.RawMessage `json:"calico"`
+		Cilium     json.RawMessage `json:"cilium"`
+		KubeRouter json.RawMessage `json:"kuberouter"`
+	}
+	if err := json.Unmarshal(data, &explicit); err != nil {
+		return err
+	}
+
 	switch n.Provider {
 	case "calico":
 		if n.Calico == nil {
 			n.Calico = DefaultCalico()
 		}
-		n.KubeRouter = nil
+	case "cilium":
+		if n.Cilium == nil {
+			n.Cilium = DefaultCilium()
+		}
 	case "kuberouter":
 		if n.KubeRouter == nil {
 			n.KubeRouter = DefaultKubeRouter()
 		}
+	}
+	if n.Provider != "calico" && isJSONNull(explicit.Calico) {
 		n.Calico = nil
 	}
+	if n.Provider != "cilium" && isJSONNull(explicit.Cilium) {
+		n.Cilium = nil
+	}
+	if n.Provider != "kuberouter" && isJSONNull(explicit.KubeRouter) {
+		n.KubeRouter = nil
+	}
 
 	if n.KubeProxy == nil {
 		n.KubeProxy = DefaultKubeProxy()
@@ -270,6 +313,10 @@ func (n *Network) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func isJSONNull(data json.RawMessage) bool {
+	return len(data) == 0 || bytes.Equal(data, []byte("null"))
+}
+
 // BuildServiceCIDR returns actual argument value for service cidr
 func (n *Network) BuildServiceCIDR(primaryAddressFamily PrimaryAddressFamilyType) string {
 	if !n.DualStack.Enabled {
@@ -173,6 +173,31 @@ spec:
 	s.Equal("Never", n.Calico.Overlay)
 }
 
+func (s *NetworkSuite) TestExplicitConfigOfOtherProviderIsRetained() {
+	yamlData := []byte(`
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: foobar
+spec:
+  network:
+    provider: kuberouter
+    calico:
+      mode: bird
+`)
+
+	c, err := ConfigFromBytes(yamlData)
+	s.Require().NoError(err)
+	n := c.Spec.Network
+
+	s.Equal("kuberouter", n.Provider)
+	s.NotNil(n.KubeRouter)
+	if s.NotNil(n.Calico) {
+		s.Equal(CalicoModeBIRD, n.Calico.Mode)
+	}
+	s.Nil(n.Cilium)
+}
+
 func (s *NetworkSuite) TestKubeRouterDefaultsAfterMarshaling() {
 	yamlData := []byte(`
 apiVersion: k0s.k0sproject.io/v1beta1
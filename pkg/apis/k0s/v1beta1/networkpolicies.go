@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// NetworkPolicies defines the baseline network policies managed by k0s.
+type NetworkPolicies struct {
+	// Enabled deploys the baseline network policies into all namespaces that
+	// are created after enabling it, and into namespaces that are labeled with
+	// `k0s.k0sproject.io/network-policies=enabled`. Namespaces labeled with
+	// `k0s.k0sproject.io/network-policies=disabled` and the system namespaces
+	// are exempt. The baseline denies all traffic by default, but allows DNS
+	// queries to CoreDNS and traffic from kube-system.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if the baseline network policies are enabled.
+func (p *NetworkPolicies) IsEnabled() bool {
+	return p != nil && p.Enabled
+}
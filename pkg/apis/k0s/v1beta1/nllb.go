@@ -27,6 +27,7 @@ type NodeLocalLoadBalancing struct {
 	// worker nodes. Can be one of:
 	//   - EnvoyProxy (default)
 	//   - Traefik
+	//   - TCPProxy
 	// +kubebuilder:default=EnvoyProxy
 	Type NllbType `json:"type,omitempty"`
 
@@ -39,11 +40,15 @@ type NodeLocalLoadBalancing struct {
 	// traefik contains configuration options related to the "Traefik"
 	// type of load balancing.
 	Traefik *Traefik `json:"traefik,omitempty"`
+
+	// tcpProxy contains configuration options related to the "TCPProxy"
+	// type of load balancing.
+	TCPProxy *TCPProxy `json:"tcpProxy,omitempty"`
 }
 
 // NllbType describes which type of load balancer should be deployed for the
 // node-local load balancing. The default is [NllbTypeEnvoyProxy].
-// +kubebuilder:validation:Enum=EnvoyProxy;Traefik
+// +kubebuilder:validation:Enum=EnvoyProxy;Traefik;TCPProxy
 type NllbType string
 
 const (
@@ -51,6 +56,10 @@ const (
 	NllbTypeEnvoyProxy NllbType = "EnvoyProxy"
 	// NllbTypeTraefik selects Traefik as the backing load balancer.
 	NllbTypeTraefik NllbType = "Traefik"
+	// NllbTypeTCPProxy selects the TCP proxy built into k0s as the backing
+	// load balancer. It runs inside the k0s worker process and doesn't require
+	// any additional images.
+	NllbTypeTCPProxy NllbType = "TCPProxy"
 )
 
 // DefaultNodeLocalLoadBalancing returns the default node-local load balancing configuration.
@@ -86,6 +95,10 @@ func (n *NodeLocalLoadBalancing) setDefaults() {
 		if n.Traefik == nil {
 			n.Traefik = DefaultTraefik()
 		}
+	case NllbTypeTCPProxy:
+		if n.TCPProxy == nil {
+			n.TCPProxy = DefaultTCPProxy()
+		}
 	}
 }
 
@@ -97,16 +110,18 @@ func (n *NodeLocalLoadBalancing) Validate(path *field.Path) (errs field.ErrorLis
 	switch n.Type {
 	case NllbTypeEnvoyProxy:
 	case NllbTypeTraefik:
+	case NllbTypeTCPProxy:
 	case "":
 		if n.IsEnabled() {
 			errs = append(errs, field.Forbidden(path.Child("type"), "need to specify type if enabled"))
 		}
 	default:
-		errs = append(errs, field.NotSupported(path.Child("type"), n.Type, []string{string(NllbTypeEnvoyProxy), string(NllbTypeTraefik)}))
+		errs = append(errs, field.NotSupported(path.Child("type"), n.Type, []string{string(NllbTypeEnvoyProxy), string(NllbTypeTraefik), string(NllbTypeTCPProxy)}))
 	}
 
 	errs = append(errs, n.EnvoyProxy.Validate(path.Child("envoyProxy"))...)
 	errs = append(errs, n.Traefik.Validate(path.Child("traefik"))...)
+	errs = append(errs, n.TCPProxy.Validate(path.Child("tcpProxy"))...)
 
 	return
 }
@@ -175,6 +190,28 @@ type Traefik struct {
 	KonnectivityServerBindPort *int32 `json:"konnectivityServerBindPort,omitempty"`
 }
 
+// Describes configuration options required for using the TCP proxy built into
+// k0s as the backing implementation for node-local load balancing.
+type TCPProxy struct {
+	// apiServerBindPort is the port number on which to bind the TCP proxy for
+	// the Kubernetes API server to on a worker's loopback interface. This must
+	// be a valid port number, 0 < x < 65536.
+	// Default: 7443
+	// +kubebuilder:default=7443
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	APIServerBindPort int32 `json:"apiServerBindPort,omitempty"`
+
+	// konnectivityServerBindPort is the port number on which to bind the TCP
+	// proxy for the konnectivity server to on a worker's loopback interface.
+	// This must be a valid port number, 0 < x < 65536.
+	// Default: 7132
+	// +kubebuilder:default=7132
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	KonnectivityServerBindPort *int32 `json:"konnectivityServerBindPort,omitempty"`
+}
+
 // DefaultEnvoyProxy returns the default envoy proxy configuration.
 func DefaultEnvoyProxy() *EnvoyProxy {
 	p := new(EnvoyProxy)
@@ -281,6 +318,49 @@ func (p *Traefik) Validate(path *field.Path) (errs field.ErrorList) {
 	return validateProxyConfig(path, p.Image, p.ImagePullPolicy, p.APIServerBindPort, p.KonnectivityServerBindPort)
 }
 
+// Returns the default TCP proxy configuration.
+func DefaultTCPProxy() *TCPProxy {
+	p := new(TCPProxy)
+	p.setDefaults()
+	return p
+}
+
+func (p *TCPProxy) OrDefault() *TCPProxy {
+	if p == nil {
+		return DefaultTCPProxy()
+	}
+	return p
+}
+
+var _ json.Unmarshaler = (*TCPProxy)(nil)
+
+func (p *TCPProxy) UnmarshalJSON(data []byte) error {
+	type tcpProxy TCPProxy
+	if err := json.Unmarshal(data, (*tcpProxy)(p)); err != nil {
+		return err
+	}
+
+	p.setDefaults()
+
+	return nil
+}
+
+func (p *TCPProxy) setDefaults() {
+	if p.APIServerBindPort == 0 {
+		p.APIServerBindPort = 7443
+	}
+	if p.KonnectivityServerBindPort == nil {
+		p.KonnectivityServerBindPort = new(int32(7132))
+	}
+}
+
+func (p *TCPProxy) Validate(path *field.Path) (errs field.ErrorList) {
+	if p == nil {
+		return
+	}
+	return validateBindPorts(path, p.APIServerBindPort, p.KonnectivityServerBindPort)
+}
+
 func validateProxyConfig(path *field.Path, imageSpec *ImageSpec, pullPolicy corev1.PullPolicy, apiServerBindPort int32, konnectivityServerBindPort *int32) (errs field.ErrorList) {
 	image := path.Child("image")
 	if imageSpec == nil {
@@ -302,6 +382,12 @@ func validateProxyConfig(path *field.Path, imageSpec *ImageSpec, pullPolicy core
 		))
 	}
 
+	errs = append(errs, validateBindPorts(path, apiServerBindPort, konnectivityServerBindPort)...)
+
+	return
+}
+
+func validateBindPorts(path *field.Path, apiServerBindPort int32, konnectivityServerBindPort *int32) (errs field.ErrorList) {
 	if details := validation.IsValidPortNum(int(apiServerBindPort)); len(details) > 0 {
 		path := path.Child("apiServerBindPort")
 		for _, detail := range details {
@@ -182,3 +182,35 @@ spec:
 		})
 	}
 }
+
+func TestTCPProxy_Unmarshal(t *testing.T) {
+	yamlData := `
+apiVersion: k0s.k0sproject.io/v1beta1
+kind: ClusterConfig
+metadata:
+  name: TestTCPProxy_Unmarshal
+spec:
+  network:
+    nodeLocalLoadBalancing:
+      type: TCPProxy
+%s
+`
+
+	t.Run("defaults", func(t *testing.T) {
+		c, err := ConfigFromBytes(fmt.Appendf(nil, yamlData, ""))
+		require.NoError(t, err)
+		require.Empty(t, c.Validate())
+		nllb := c.Spec.Network.NodeLocalLoadBalancing
+		assert.Equal(t, DefaultTCPProxy(), nllb.TCPProxy)
+		assert.Equal(t, int32(7443), nllb.TCPProxy.APIServerBindPort)
+		assert.Equal(t, new(int32(7132)), nllb.TCPProxy.KonnectivityServerBindPort)
+	})
+
+	t.Run("invalid_port", func(t *testing.T) {
+		c, err := ConfigFromBytes(fmt.Appendf(nil, yamlData, "      tcpProxy: {apiServerBindPort: 65536}"))
+		require.NoError(t, err)
+		errs := c.Validate()
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "network: nodeLocalLoadBalancing.tcpProxy.apiServerBindPort: Invalid value: 65536: must be between 1 and 65535, inclusive")
+	})
+}
@@ -11,12 +11,15 @@ import (
 	"net/url"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/k0sproject/k0s/internal/pkg/iface"
 	"github.com/k0sproject/k0s/pkg/config/kine"
 	"github.com/k0sproject/k0s/pkg/constant"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/sirupsen/logrus"
@@ -130,6 +133,14 @@ func (s *StorageSpec) Validate() []error {
 		errors = append(errors, validateOptionalTLSProperties(s.Etcd.ExternalCluster)...)
 	}
 
+	if s.Etcd != nil && s.Etcd.Defrag != nil {
+		errors = append(errors, s.Etcd.Defrag.Validate(field.NewPath("etcd", "defrag"))...)
+	}
+
+	if s.Etcd != nil && s.Etcd.Compaction != nil {
+		errors = append(errors, s.Etcd.Compaction.Validate(field.NewPath("etcd", "compaction"))...)
+	}
+
 	return errors
 }
 
@@ -150,6 +161,14 @@ type EtcdConfig struct {
 
 	// Custom config for CA certificates.
 	CA *CA `json:"ca,omitempty"`
+
+	// Defragmentation of the etcd members' databases. Only applies to etcd
+	// clusters that are managed by k0s.
+	Defrag *EtcdDefrag `json:"defrag,omitempty"`
+
+	// Compaction of the etcd keyspace. Only applies to etcd clusters that are
+	// managed by k0s.
+	Compaction *EtcdCompaction `json:"compaction,omitempty"`
 }
 
 // ExternalCluster defines external etcd cluster related config options
@@ -171,6 +190,114 @@ type ExternalCluster struct {
 	ClientKeyFile string `json:"clientKeyFile,omitempty"`
 }
 
+// EtcdDefrag configures the automatic defragmentation of the etcd members'
+// databases, and when to warn about databases approaching the backend quota.
+type EtcdDefrag struct {
+	// Disables the automatic defragmentation. Warnings about databases
+	// approaching the backend quota are still issued.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// How often the database sizes of the etcd members are checked.
+	//
+	// +kubebuilder:default="10m"
+	// +optional
+	Interval metav1.Duration `json:"interval"`
+
+	// The percentage of a member's database that needs to be unused before
+	// the member gets defragmented.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=50
+	// +optional
+	Threshold int32 `json:"threshold"`
+
+	// Members whose database is smaller than this number of bytes are never
+	// defragmented.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=104857600
+	// +optional
+	MinDBSize int64 `json:"minDBSize"`
+
+	// The percentage of the backend quota that a member's database needs to
+	// reach before k0s warns about it.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=80
+	// +optional
+	QuotaWarningThreshold int32 `json:"quotaWarningThreshold"`
+}
+
+// Validate validates the etcd defragmentation settings.
+func (d *EtcdDefrag) Validate(path *field.Path) (errs []error) {
+	if d.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), d.Interval.String(), "must be positive"))
+	}
+	if d.Threshold < 1 || d.Threshold > 100 {
+		errs = append(errs, field.Invalid(path.Child("threshold"), d.Threshold, "must be between 1 and 100"))
+	}
+	if d.MinDBSize < 0 {
+		errs = append(errs, field.Invalid(path.Child("minDBSize"), d.MinDBSize, "must not be negative"))
+	}
+	if d.QuotaWarningThreshold < 1 || d.QuotaWarningThreshold > 100 {
+		errs = append(errs, field.Invalid(path.Child("quotaWarningThreshold"), d.QuotaWarningThreshold, "must be between 1 and 100"))
+	}
+	return errs
+}
+
+// DefaultEtcdDefrag returns the default etcd defragmentation settings.
+func DefaultEtcdDefrag() *EtcdDefrag {
+	return &EtcdDefrag{
+		Interval:              metav1.Duration{Duration: 10 * time.Minute},
+		Threshold:             50,
+		MinDBSize:             100 * 1024 * 1024,
+		QuotaWarningThreshold: 80,
+	}
+}
+
+// EtcdCompaction configures the periodic compaction of the etcd keyspace by
+// k0s. Compacting discards the superseded revisions of the keys, so that
+// they can be reclaimed by defragmentation.
+type EtcdCompaction struct {
+	// How often the leading controller compacts the etcd keyspace. If unset
+	// or zero, k0s doesn't compact the keyspace itself and leaves it to the
+	// Kubernetes API server, which compacts it every five minutes by default.
+	// Otherwise, the API server's own compaction is turned off.
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// The number of most recent revisions that are retained when compacting.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10000
+	// +optional
+	RetainedRevisions int64 `json:"retainedRevisions"`
+}
+
+// IsEnabled returns whether k0s compacts the etcd keyspace itself.
+func (c *EtcdCompaction) IsEnabled() bool {
+	return c != nil && c.Interval.Duration > 0
+}
+
+// Validate validates the etcd compaction settings.
+func (c *EtcdCompaction) Validate(path *field.Path) (errs []error) {
+	if c.Interval.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), c.Interval.String(), "must not be negative"))
+	}
+	if c.RetainedRevisions < 1 {
+		errs = append(errs, field.Invalid(path.Child("retainedRevisions"), c.RetainedRevisions, "must be positive"))
+	}
+	return errs
+}
+
+// DefaultEtcdCompaction returns the default etcd compaction settings.
+func DefaultEtcdCompaction() *EtcdCompaction {
+	return &EtcdCompaction{RetainedRevisions: 10000}
+}
+
 // DefaultEtcdConfig creates EtcdConfig with sane defaults
 func DefaultEtcdConfig() *EtcdConfig {
 	addr, err := iface.FirstPublicAddress()
@@ -183,10 +310,18 @@ func DefaultEtcdConfig() *EtcdConfig {
 		PeerAddress:     addr,
 		ExtraArgs:       make(map[string]string),
 		CA:              DefaultCA(),
+		Defrag:          DefaultEtcdDefrag(),
+		Compaction:      DefaultEtcdCompaction(),
 	}
 }
 
-const etcdNameExtraArg = "name"
+const (
+	etcdNameExtraArg       = "name"
+	etcdQuotaBytesExtraArg = "quota-backend-bytes"
+
+	// The backend quota that etcd uses if none has been configured explicitly.
+	DefaultEtcdQuotaBackendBytes int64 = 2 * 1024 * 1024 * 1024
+)
 
 // Returns the human-readable member name for the etcd peer, if any.
 func (e *EtcdConfig) GetMemberName() string {
@@ -197,6 +332,17 @@ func (e *EtcdConfig) GetMemberName() string {
 	return ""
 }
 
+// Returns the backend quota of the etcd members in bytes.
+func (e *EtcdConfig) GetQuotaBackendBytes() int64 {
+	if e.ExtraArgs != nil {
+		if quota, err := strconv.ParseInt(e.ExtraArgs[etcdQuotaBytesExtraArg], 10, 64); err == nil && quota > 0 {
+			return quota
+		}
+	}
+
+	return DefaultEtcdQuotaBackendBytes
+}
+
 // GetPeerURL returns the URL of PeerAddress
 func (e *EtcdConfig) GetPeerURL() string {
 	u := &url.URL{
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -406,3 +408,70 @@ func TestEtcdConfig_GetMemberName(t *testing.T) {
 		})
 	}
 }
+
+func TestEtcdConfig_GetQuotaBackendBytes(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		extraArgs map[string]string
+		want      int64
+	}{
+		{"default", nil, DefaultEtcdQuotaBackendBytes},
+		{"configured", map[string]string{"quota-backend-bytes": "8589934592"}, 8589934592},
+		{"invalid", map[string]string{"quota-backend-bytes": "8Gi"}, DefaultEtcdQuotaBackendBytes},
+		{"zero", map[string]string{"quota-backend-bytes": "0"}, DefaultEtcdQuotaBackendBytes},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			e := EtcdConfig{ExtraArgs: test.extraArgs}
+			assert.Equal(t, test.want, e.GetQuotaBackendBytes())
+		})
+	}
+}
+
+func TestEtcdDefragPartialConfigLoading(t *testing.T) {
+	c, err := ConfigFromBytes([]byte(`
+spec:
+  storage:
+    etcd:
+      defrag:
+        threshold: 30
+`))
+	require.NoError(t, err)
+
+	expected := DefaultEtcdDefrag()
+	expected.Threshold = 30
+	assert.Equal(t, expected, c.Spec.Storage.Etcd.Defrag)
+	assert.Empty(t, c.Spec.Storage.Validate())
+
+	c.Spec.Storage.Etcd.Defrag.Threshold = 0
+	c.Spec.Storage.Etcd.Defrag.Interval.Duration = 0
+	errs := c.Spec.Storage.Validate()
+	if assert.Len(t, errs, 2) {
+		assert.ErrorContains(t, errs[0], "etcd.defrag.interval: Invalid value")
+		assert.ErrorContains(t, errs[1], "etcd.defrag.threshold: Invalid value: 0: must be between 1 and 100")
+	}
+}
+
+func TestEtcdCompactionPartialConfigLoading(t *testing.T) {
+	c, err := ConfigFromBytes([]byte(`
+spec:
+  storage:
+    etcd:
+      compaction:
+        interval: 5m
+`))
+	require.NoError(t, err)
+
+	expected := DefaultEtcdCompaction()
+	expected.Interval.Duration = 5 * time.Minute
+	assert.Equal(t, expected, c.Spec.Storage.Etcd.Compaction)
+	assert.True(t, c.Spec.Storage.Etcd.Compaction.IsEnabled())
+	assert.Empty(t, c.Spec.Storage.Validate())
+
+	c.Spec.Storage.Etcd.Compaction.Interval.Duration = -1
+	c.Spec.Storage.Etcd.Compaction.RetainedRevisions = 0
+	errs := c.Spec.Storage.Validate()
+	if assert.Len(t, errs, 2) {
+		assert.ErrorContains(t, errs[0], "etcd.compaction.interval: Invalid value")
+		assert.ErrorContains(t, errs[1], "etcd.compaction.retainedRevisions: Invalid value: 0")
+	}
+}
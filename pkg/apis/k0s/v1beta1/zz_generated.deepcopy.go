@@ -48,6 +48,46 @@ func (in *APISpec) DeepCopy() *APISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPPeer) DeepCopyInto(out *BGPPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPPeer.
+func (in *BGPPeer) DeepCopy() *BGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPSpec) DeepCopyInto(out *BGPSpec) {
+	*out = *in
+	if in.VirtualIPs != nil {
+		in, out := &in.VirtualIPs, &out.VirtualIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]BGPPeer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPSpec.
+func (in *BGPSpec) DeepCopy() *BGPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackwardCompatibleDuration) DeepCopyInto(out *BackwardCompatibleDuration) {
 	*out = *in
@@ -223,6 +263,72 @@ func (in *Chart) DeepCopy() *Chart {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cilium) DeepCopyInto(out *Cilium) {
+	*out = *in
+	out.Hubble = in.Hubble
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make(Patches, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cilium.
+func (in *Cilium) DeepCopy() *Cilium {
+	if in == nil {
+		return nil
+	}
+	out := new(Cilium)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumHubble) DeepCopyInto(out *CiliumHubble) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumHubble.
+func (in *CiliumHubble) DeepCopy() *CiliumHubble {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumHubble)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumImageSpec) DeepCopyInto(out *CiliumImageSpec) {
+	*out = *in
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(ImageSpec)
+		**out = **in
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(ImageSpec)
+		**out = **in
+	}
+	if in.HubbleRelay != nil {
+		in, out := &in.HubbleRelay, &out.HubbleRelay
+		*out = new(ImageSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumImageSpec.
+func (in *CiliumImageSpec) DeepCopy() *CiliumImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ChartsSettings) DeepCopyInto(out *ChartsSettings) {
 	{
@@ -257,7 +363,7 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(ClusterConfigStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -314,6 +420,11 @@ func (in *ClusterConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfigStatus) DeepCopyInto(out *ClusterConfigStatus) {
 	*out = *in
+	if in.NetworkProviderMigration != nil {
+		in, out := &in.NetworkProviderMigration, &out.NetworkProviderMigration
+		*out = new(NetworkProviderMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigStatus.
@@ -394,6 +505,11 @@ func (in *ClusterImages) DeepCopyInto(out *ClusterImages) {
 		*out = new(CalicoImageSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cilium != nil {
+		in, out := &in.Cilium, &out.Cilium
+		*out = new(CiliumImageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.KubeRouter != nil {
 		in, out := &in.KubeRouter, &out.KubeRouter
 		*out = new(KubeRouterImageSpec)
@@ -523,6 +639,16 @@ func (in *ControlPlaneLoadBalancingSpec) DeepCopyInto(out *ControlPlaneLoadBalan
 		*out = new(KeepalivedSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LeaseVIP != nil {
+		in, out := &in.LeaseVIP, &out.LeaseVIP
+		*out = new(LeaseVIPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(BGPSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneLoadBalancingSpec.
@@ -622,6 +748,22 @@ func (in *EnvoyProxy) DeepCopy() *EnvoyProxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdCompaction) DeepCopyInto(out *EtcdCompaction) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdCompaction.
+func (in *EtcdCompaction) DeepCopy() *EtcdCompaction {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdCompaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
 	*out = *in
@@ -647,6 +789,16 @@ func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
 		*out = new(CA)
 		**out = **in
 	}
+	if in.Defrag != nil {
+		in, out := &in.Defrag, &out.Defrag
+		*out = new(EtcdDefrag)
+		**out = **in
+	}
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(EtcdCompaction)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdConfig.
@@ -659,6 +811,22 @@ func (in *EtcdConfig) DeepCopy() *EtcdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDefrag) DeepCopyInto(out *EtcdDefrag) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDefrag.
+func (in *EtcdDefrag) DeepCopy() *EtcdDefrag {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDefrag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdRequest) DeepCopyInto(out *EtcdRequest) {
 	*out = *in
@@ -1065,6 +1233,26 @@ func (in *KubeRouterImageSpec) DeepCopy() *KubeRouterImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaseVIPSpec) DeepCopyInto(out *LeaseVIPSpec) {
+	*out = *in
+	if in.VirtualIPs != nil {
+		in, out := &in.VirtualIPs, &out.VirtualIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaseVIPSpec.
+func (in *LeaseVIPSpec) DeepCopy() *LeaseVIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaseVIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsServer) DeepCopyInto(out *MetricsServer) {
 	*out = *in
@@ -1093,6 +1281,11 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(Calico)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cilium != nil {
+		in, out := &in.Cilium, &out.Cilium
+		*out = new(Cilium)
+		(*in).DeepCopyInto(*out)
+	}
 	out.DualStack = in.DualStack
 	if in.KubeProxy != nil {
 		in, out := &in.KubeProxy, &out.KubeProxy
@@ -1119,6 +1312,11 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(ControlPlaneLoadBalancingSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = new(NetworkPolicies)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
@@ -1131,6 +1329,37 @@ func (in *Network) DeepCopy() *Network {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicies) DeepCopyInto(out *NetworkPolicies) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicies.
+func (in *NetworkPolicies) DeepCopy() *NetworkPolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkProviderMigrationStatus) DeepCopyInto(out *NetworkProviderMigrationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProviderMigrationStatus.
+func (in *NetworkProviderMigrationStatus) DeepCopy() *NetworkProviderMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkProviderMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLocalLoadBalancing) DeepCopyInto(out *NodeLocalLoadBalancing) {
 	*out = *in
@@ -1144,6 +1373,11 @@ func (in *NodeLocalLoadBalancing) DeepCopyInto(out *NodeLocalLoadBalancing) {
 		*out = new(Traefik)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TCPProxy != nil {
+		in, out := &in.TCPProxy, &out.TCPProxy
+		*out = new(TCPProxy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLocalLoadBalancing.
@@ -1345,6 +1579,26 @@ func (in *SystemUser) DeepCopy() *SystemUser {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPProxy) DeepCopyInto(out *TCPProxy) {
+	*out = *in
+	if in.KonnectivityServerBindPort != nil {
+		in, out := &in.KonnectivityServerBindPort, &out.KonnectivityServerBindPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPProxy.
+func (in *TCPProxy) DeepCopy() *TCPProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Traefik) DeepCopyInto(out *Traefik) {
 	*out = *in
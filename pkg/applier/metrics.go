@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package applier
+
+import (
+	"github.com/k0sproject/k0s/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	stackApplies = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "applier",
+		Name:      "stack_applies_total",
+		Help:      "Number of attempts to apply a stack.",
+	}, []string{"stack"})
+
+	stackApplyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "applier",
+		Name:      "stack_apply_failures_total",
+		Help:      "Number of failed attempts to apply a stack.",
+	}, []string{"stack"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(stackApplies, stackApplyFailures)
+}
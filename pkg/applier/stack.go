@@ -16,6 +16,7 @@ import (
 
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/kubernetes/watch"
+	"github.com/k0sproject/k0s/pkg/tracing"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,6 +32,8 @@ import (
 	"github.com/avast/retry-go"
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func ApplyStack(ctx context.Context, clients kubernetes.ClientFactoryInterface, resources []*unstructured.Unstructured, stackName string) error {
@@ -73,9 +76,15 @@ type Stack struct {
 
 // Apply applies stack resources by creating or updating the resources. If prune is requested,
 // the previously applied stack resources which are not part of the current stack are removed from k8s api
-func (s *Stack) Apply(ctx context.Context, prune bool) error {
+func (s *Stack) Apply(ctx context.Context, prune bool) (err error) {
 	s.log = logrus.WithField("stack", s.Name)
 
+	ctx, span := tracer.Start(ctx, "Apply stack resources", withStack(s.Name), trace.WithAttributes(
+		attribute.Int("k0s.stack.resources", len(s.Resources)),
+		attribute.Bool("k0s.stack.prune", prune),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	discoveryClient, err := s.Clients.GetDiscoveryClient()
 	if err != nil {
 		return err
@@ -13,14 +13,18 @@ import (
 
 	"github.com/avast/retry-go"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/tracing"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // StackApplier applies a stack whenever the files on disk change.
 type StackApplier struct {
 	log  logrus.FieldLogger
+	name string
 	path string
 
 	doApply, doDelete func(context.Context) error
@@ -33,6 +37,7 @@ func NewStackApplier(path string, kubeClientFactory kubernetes.ClientFactoryInte
 
 	return &StackApplier{
 		log:  logrus.WithField("component", "applier-"+applier.Name),
+		name: applier.Name,
 		path: path,
 
 		doApply: func(ctx context.Context) error {
@@ -115,14 +120,24 @@ func (s *StackApplier) runWatcher(watcher *fsnotify.Watcher, trigger chan<- stru
 func (s *StackApplier) apply(ctx context.Context) {
 	s.log.Info("Applying manifests")
 
+	ctx, span := tracer.Start(ctx, "Apply stack", trace.WithNewRoot(), withStack(s.name))
 	err := retry.Do(
-		func() error { return s.doApply(ctx) },
+		func() error {
+			stackApplies.WithLabelValues(s.name).Inc()
+			err := s.doApply(ctx)
+			if err != nil {
+				stackApplyFailures.WithLabelValues(s.name).Inc()
+			}
+			return err
+		},
 		retry.OnRetry(func(attempt uint, err error) {
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", int(attempt)+1)))
 			s.log.WithError(err).Warnf("Failed to apply manifests in attempt #%d, retrying after backoff", attempt+1)
 		}),
 		retry.Context(ctx),
 		retry.LastErrorOnly(true),
 	)
+	tracing.End(span, err)
 
 	if err != nil {
 		s.log.WithError(err).Error("Failed to apply manifests")
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package applier
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/k0sproject/k0s/pkg/applier")
+
+func withStack(name string) trace.SpanStartEventOption {
+	return trace.WithAttributes(attribute.String("k0s.stack", name))
+}
@@ -8,23 +8,23 @@ import (
 )
 
 type DownloadURL struct {
-	Arch         string `yaml:"arch"`
-	OS           string `yaml:"os"`
-	K0S          string `yaml:"k0s"`
-	K0SSha256    string `yaml:"k0sSha256"`
-	AirgapBundle string `yaml:"airgapBundle"`
-	AirgapSha256 string `yaml:"airgapSha256"`
+	Arch         string `yaml:"arch" json:"arch"`
+	OS           string `yaml:"os" json:"os"`
+	K0S          string `yaml:"k0s" json:"k0s"`
+	K0SSha256    string `yaml:"k0sSha256" json:"k0sSha256"`
+	AirgapBundle string `yaml:"airgapBundle" json:"airgapBundle"`
+	AirgapSha256 string `yaml:"airgapSha256" json:"airgapSha256"`
 }
 
 type Channel struct {
-	Channel     string `yaml:"channel"`
-	EOLDate     string `yaml:"eolDate"`
-	VersionInfo `yaml:",inline"`
+	Channel     string `yaml:"channel" json:"channel"`
+	EOLDate     string `yaml:"eolDate" json:"eolDate"`
+	VersionInfo `yaml:",inline" json:",inline"`
 }
 
 type VersionInfo struct {
-	Version      string        `yaml:"version"`
-	DownloadURLs []DownloadURL `yaml:"downloadURLs"`
+	Version      string        `yaml:"version" json:"version"`
+	DownloadURLs []DownloadURL `yaml:"downloadURLs" json:"downloadURLs"`
 }
 
 func (v *VersionInfo) IsNewerThan(other string) (bool, error) {
@@ -37,9 +37,20 @@ func removedInVersion(candidate schema.GroupVersionKind) (string, string) {
 
 // Sorted array of removed APIs.
 var removedGVKs = [...]removedAPI{
+	{"autoscaling", "v2beta1", "HorizontalPodAutoscaler", "v1.25.0", "v2"},
+	{"autoscaling", "v2beta2", "HorizontalPodAutoscaler", "v1.26.0", "v2"},
+	{"batch", "v1beta1", "CronJob", "v1.25.0", "v1"},
+	{"discovery.k8s.io", "v1beta1", "EndpointSlice", "v1.25.0", "v1"},
+	{"events.k8s.io", "v1beta1", "Event", "v1.25.0", "v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta1", "FlowSchema", "v1.26.0", "v1"},
+	{"flowcontrol.apiserver.k8s.io", "v1beta1", "PriorityLevelConfiguration", "v1.26.0", "v1"},
 	{"flowcontrol.apiserver.k8s.io", "v1beta2", "FlowSchema", "v1.29.0", "v1beta3"},
 	{"flowcontrol.apiserver.k8s.io", "v1beta2", "PriorityLevelConfiguration", "v1.29.0", "v1"},
 	{"flowcontrol.apiserver.k8s.io", "v1beta3", "FlowSchema", "v1.32.0", "v1"},
 	{"flowcontrol.apiserver.k8s.io", "v1beta3", "PriorityLevelConfiguration", "v1.32.0", "v1"},
 	{"k0s.k0sproject.example.com", "v1beta1", "RemovedCRD", "v99.99.99", ""}, // This is a test entry
+	{"node.k8s.io", "v1beta1", "RuntimeClass", "v1.25.0", "v1"},
+	{"policy", "v1beta1", "PodDisruptionBudget", "v1.25.0", "v1"},
+	{"policy", "v1beta1", "PodSecurityPolicy", "v1.25.0", ""},
+	{"storage.k8s.io", "v1beta1", "CSIStorageCapacity", "v1.27.0", "v1"},
 }
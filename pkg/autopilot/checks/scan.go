@@ -0,0 +1,425 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/pkg/applier"
+	"github.com/k0sproject/k0s/pkg/helm"
+	"github.com/k0sproject/k0s/pkg/kubernetes"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/metadata"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
+)
+
+// RemovedAPISource denotes where a usage of a removed API has been found.
+type RemovedAPISource string
+
+const (
+	// The object is stored in the cluster and can only be accessed via the
+	// removed API.
+	RemovedAPISourceCluster RemovedAPISource = "Cluster"
+	// A CustomResourceDefinition still lists the removed API version in its
+	// stored versions, although it isn't served anymore. Objects may still be
+	// persisted using that version.
+	RemovedAPISourceCRDStorage RemovedAPISource = "CRDStorage"
+	// The object is part of the manifest of a Helm release that is managed by
+	// a k0s Chart resource.
+	RemovedAPISourceHelmRelease RemovedAPISource = "HelmRelease"
+	// The object is part of a manifest file in k0s's manifests directory.
+	RemovedAPISourceManifest RemovedAPISource = "Manifest"
+)
+
+// RemovedAPIUsage describes an object that uses an API which has been removed
+// in the Kubernetes version that is about to be installed.
+type RemovedAPIUsage struct {
+	Source RemovedAPISource
+	// Origin further qualifies the source, e.g. the Helm release or the
+	// manifest file that contains the object. Empty for objects in the cluster.
+	Origin string
+
+	GroupVersionKind schema.GroupVersionKind
+	// Resource is the plural resource name of the object, if known.
+	Resource  string
+	Namespace string
+	Name      string
+
+	// RemovedIn is the Kubernetes version in which the API has been removed.
+	RemovedIn string
+	// Replacement is the API version that replaces the removed one. Empty if
+	// there's no replacement.
+	Replacement string
+}
+
+// RemovedAPIScanner finds usages of APIs that are removed in a given
+// Kubernetes version.
+type RemovedAPIScanner struct {
+	Log           logrus.FieldLogger
+	ClientFactory kubernetes.ClientFactoryInterface
+
+	// ManifestsDir is k0s's manifests directory. It's only scanned if set.
+	ManifestsDir string
+
+	// The client used to list objects in the cluster. Created lazily if nil.
+	metaClient metadata.Interface
+	// Retrieves the manifest of a Helm release. Uses Helm if nil.
+	releaseManifest func(ctx context.Context, releaseName, namespace string) (string, error)
+}
+
+// Scan returns all usages of APIs that are removed in newVersion or earlier.
+// The usages are ordered by their source: objects in the cluster come first,
+// followed by CRD storage versions, Helm releases and manifest files.
+func (s *RemovedAPIScanner) Scan(ctx context.Context, newVersion string) ([]RemovedAPIUsage, error) {
+	var usages []RemovedAPIUsage
+
+	clusterUsages, err := s.scanCluster(ctx, newVersion)
+	if err != nil {
+		return nil, err
+	}
+	usages = append(usages, clusterUsages...)
+
+	storageUsages, err := s.scanCRDStorage(ctx, newVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan CustomResourceDefinitions: %w", err)
+	}
+	usages = append(usages, storageUsages...)
+
+	releaseUsages, err := s.scanHelmReleases(ctx, newVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan Helm releases: %w", err)
+	}
+	usages = append(usages, releaseUsages...)
+
+	if s.ManifestsDir != "" {
+		manifestUsages, err := s.scanManifests(newVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan manifests: %w", err)
+		}
+		usages = append(usages, manifestUsages...)
+	}
+
+	return usages, nil
+}
+
+// Looks for objects in the cluster that can only be accessed via removed APIs.
+func (s *RemovedAPIScanner) scanCluster(ctx context.Context, newVersion string) ([]RemovedAPIUsage, error) {
+	discoveryClient, err := s.ClientFactory.GetDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	_, resources, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil {
+		s.Log.WithError(err).Warn("Error while discovering supported API groups and resources")
+		if len(resources) == 0 {
+			return nil, err
+		}
+	}
+
+	var usages []RemovedAPIUsage
+	metaClient := s.metaClient
+	for _, r := range resources {
+		gv, err := schema.ParseGroupVersion(r.GroupVersion)
+		if err != nil {
+			s.Log.WithError(err).Warn("Skipping API version ", r.GroupVersion)
+			continue
+		}
+
+		for _, ar := range r.APIResources {
+			// Skip resources which don't have the same name and kind. This is to skip
+			// subresources such as FlowSchema/Status
+			if strings.Contains(ar.Name, "/") {
+				continue
+			}
+
+			gv := gv // Copy over the default GroupVersion from the list
+			// Apply resource-specific overrides
+			if ar.Group != "" {
+				gv.Group = ar.Group
+			}
+			if ar.Version != "" {
+				gv.Version = ar.Version
+			}
+
+			gvk := gv.WithKind(ar.Kind)
+			removedIn, currentVersion := removedInVersion(gvk)
+			if removedIn == "" || semver.Compare(newVersion, removedIn) < 0 {
+				continue
+			}
+
+			if metaClient == nil {
+				restConfig, err := s.ClientFactory.GetRESTConfig()
+				if err != nil {
+					return nil, err
+				}
+
+				if metaClient, err = metadata.NewForConfig(restConfig); err != nil {
+					return nil, err
+				}
+			}
+
+			metas, err := metaClient.Resource(gv.WithResource(ar.Name)).
+				Namespace(metav1.NamespaceAll).
+				List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, item := range metas.Items {
+				// If there's a current version, the API server might be serving
+				// the same object with an older GVK for compatibility reasons,
+				// while the current good API still works.
+				if currentVersion != "" {
+					_, err := metaClient.Resource(schema.GroupVersionResource{Group: gv.Group, Version: currentVersion, Resource: ar.Name}).
+						Namespace(item.Namespace).
+						Get(ctx, item.Name, metav1.GetOptions{})
+					if err == nil {
+						continue
+					}
+					if !apierrors.IsNotFound(err) {
+						return nil, err
+					}
+				}
+
+				usages = append(usages, RemovedAPIUsage{
+					Source:           RemovedAPISourceCluster,
+					GroupVersionKind: gvk,
+					Resource:         ar.Name,
+					Namespace:        item.Namespace,
+					Name:             item.Name,
+					RemovedIn:        removedIn,
+					Replacement:      replacementOf(gvk, currentVersion),
+				})
+			}
+		}
+	}
+
+	return usages, nil
+}
+
+// Looks for CustomResourceDefinitions that still list a removed version in
+// their stored versions, although that version isn't served anymore. Objects
+// that are persisted in such a version are invisible to the discovery-based
+// cluster scan and need a storage migration before the version can go away.
+func (s *RemovedAPIScanner) scanCRDStorage(ctx context.Context, newVersion string) ([]RemovedAPIUsage, error) {
+	client, err := s.ClientFactory.GetAPIExtensionsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	crds, err := client.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []RemovedAPIUsage
+	for _, crd := range crds.Items {
+		for _, version := range crd.Status.StoredVersions {
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version, Kind: crd.Spec.Names.Kind}
+			removedIn, currentVersion := removedInVersion(gvk)
+			if removedIn == "" || semver.Compare(newVersion, removedIn) < 0 {
+				continue
+			}
+
+			if slices.ContainsFunc(crd.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool {
+				return v.Name == version && v.Served
+			}) {
+				continue // served versions are covered by the cluster scan
+			}
+
+			usages = append(usages, RemovedAPIUsage{
+				Source:           RemovedAPISourceCRDStorage,
+				Origin:           "CustomResourceDefinition " + crd.Name,
+				GroupVersionKind: gvk,
+				Resource:         crd.Spec.Names.Plural,
+				Name:             crd.Name,
+				RemovedIn:        removedIn,
+				Replacement:      replacementOf(gvk, currentVersion),
+			})
+		}
+	}
+
+	return usages, nil
+}
+
+// Looks for removed APIs in the manifests of the Helm releases that are
+// managed by k0s Chart resources.
+func (s *RemovedAPIScanner) scanHelmReleases(ctx context.Context, newVersion string) ([]RemovedAPIUsage, error) {
+	client, err := s.ClientFactory.GetK0sClient()
+	if err != nil {
+		return nil, err
+	}
+
+	charts, err := client.HelmV1beta1().Charts(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil // The Chart CRD isn't installed, hence there are no releases.
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	releaseManifest := s.releaseManifest
+	if releaseManifest == nil {
+		releaseManifest = s.helmReleaseManifest
+	}
+
+	var usages []RemovedAPIUsage
+	for _, chart := range charts.Items {
+		releaseName, namespace := chart.Status.ReleaseName, chart.Status.Namespace
+		if releaseName == "" {
+			continue // not installed yet
+		}
+
+		origin := fmt.Sprintf("Helm release %s/%s", namespace, releaseName)
+		manifest, err := releaseManifest(ctx, releaseName, namespace)
+		if err != nil {
+			s.Log.WithError(err).Warn("Skipping ", origin)
+			continue
+		}
+
+		objects, err := applier.ReadUnstructuredStream(strings.NewReader(manifest), origin)
+		if err != nil {
+			s.Log.WithError(err).Warn("Skipping ", origin)
+			continue
+		}
+
+		usages = append(usages, findRemovedAPIUsages(RemovedAPISourceHelmRelease, origin, objects, newVersion)...)
+	}
+
+	return usages, nil
+}
+
+func (s *RemovedAPIScanner) helmReleaseManifest(ctx context.Context, releaseName, namespace string) (string, error) {
+	helmCmd, cleanup, err := helm.NewCommands(s.ClientFactory, nil)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	release, err := helmCmd.GetRelease(ctx, releaseName, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return release.Manifest, nil
+}
+
+// Looks for removed APIs in the manifest files of all the stacks in the
+// manifests directory.
+func (s *RemovedAPIScanner) scanManifests(newVersion string) ([]RemovedAPIUsage, error) {
+	stacks, err := dir.GetAll(s.ManifestsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var usages []RemovedAPIUsage
+	for _, stack := range stacks {
+		files, err := applier.FindManifestFilesInDir(filepath.Join(s.ManifestsDir, stack))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			objects, err := readManifestFile(file)
+			if err != nil {
+				s.Log.WithError(err).Warn("Skipping manifest file ", file)
+				continue
+			}
+
+			usages = append(usages, findRemovedAPIUsages(RemovedAPISourceManifest, file, objects, newVersion)...)
+		}
+	}
+
+	return usages, nil
+}
+
+func readManifestFile(path string) (_ []*unstructured.Unstructured, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+
+	return applier.ReadUnstructuredStream(f, path)
+}
+
+func findRemovedAPIUsages(source RemovedAPISource, origin string, objects []*unstructured.Unstructured, newVersion string) []RemovedAPIUsage {
+	var usages []RemovedAPIUsage
+	for _, object := range objects {
+		gvk := object.GroupVersionKind()
+		removedIn, currentVersion := removedInVersion(gvk)
+		if removedIn == "" || semver.Compare(newVersion, removedIn) < 0 {
+			continue
+		}
+
+		usages = append(usages, RemovedAPIUsage{
+			Source:           source,
+			Origin:           origin,
+			GroupVersionKind: gvk,
+			Namespace:        object.GetNamespace(),
+			Name:             object.GetName(),
+			RemovedIn:        removedIn,
+			Replacement:      replacementOf(gvk, currentVersion),
+		})
+	}
+
+	return usages
+}
+
+func replacementOf(gvk schema.GroupVersionKind, currentVersion string) string {
+	if currentVersion == "" {
+		return ""
+	}
+	return schema.GroupVersion{Group: gvk.Group, Version: currentVersion}.String()
+}
+
+// RemovedAPIsError is returned if objects use APIs that are removed in the
+// Kubernetes version that is about to be installed.
+type RemovedAPIsError struct {
+	Usages []RemovedAPIUsage
+}
+
+func (e *RemovedAPIsError) Error() string {
+	if len(e.Usages) == 0 {
+		return "no usages of removed APIs"
+	}
+
+	// Describe all usages of the same API from the same origin as the first one.
+	first := &e.Usages[0]
+	var found int
+	for i := range e.Usages {
+		u := &e.Usages[i]
+		if u.Source == first.Source && u.Origin == first.Origin && u.GroupVersionKind == first.GroupVersionKind {
+			found++
+		}
+	}
+
+	gvk := first.GroupVersionKind
+	switch first.Source {
+	case RemovedAPISourceCluster:
+		return fmt.Sprintf("%s.%s %s has been removed in Kubernetes %s, but there are %d such resources in the cluster", first.Resource, gvk.Group, gvk.Version, first.RemovedIn, found)
+	case RemovedAPISourceCRDStorage:
+		return fmt.Sprintf("%s.%s %s has been removed in Kubernetes %s, but it's still a stored version of the CustomResourceDefinition", first.Resource, gvk.Group, gvk.Version, first.RemovedIn)
+	default:
+		return fmt.Sprintf("%s %s has been removed in Kubernetes %s, but there are %d such resources in %s", gvk.GroupVersion(), gvk.Kind, first.RemovedIn, found, first.Origin)
+	}
+}
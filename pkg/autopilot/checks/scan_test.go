@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package checks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metadatafake "k8s.io/client-go/metadata/fake"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cronJobManifest = `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+  namespace: ops
+spec:
+  schedule: "@daily"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: backup
+              image: backup
+          restartPolicy: OnFailure
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unaffected
+  namespace: ops
+`
+
+func TestRemovedAPIScanner(t *testing.T) {
+	removedCRD := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "removedcrds.k0s.k0sproject.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "k0s.k0sproject.example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "RemovedCRD", Plural: "removedcrds"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Served: false},
+				{Name: "v1", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: []string{"v1beta1", "v1"},
+		},
+	}
+	chart := &helmv1beta1.Chart{
+		ObjectMeta: metav1.ObjectMeta{Name: "k0s-addon-chart-backup", Namespace: "kube-system"},
+		Status:     helmv1beta1.ChartStatus{ReleaseName: "backup", Namespace: "ops"},
+	}
+	pendingChart := &helmv1beta1.Chart{
+		ObjectMeta: metav1.ObjectMeta{Name: "k0s-addon-chart-pending", Namespace: "kube-system"},
+	}
+
+	manifestsDir := t.TempDir()
+	stackDir := filepath.Join(manifestsDir, "backup")
+	require.NoError(t, os.Mkdir(stackDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stackDir, "backup.yaml"), []byte(cronJobManifest), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(stackDir, "ignored.txt"), []byte("not a manifest"), 0644))
+
+	// A FlowSchema that's only accessible via the removed API.
+	flowSchema := schema.GroupVersionKind{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema"}
+	metaScheme := metadatafake.NewTestScheme()
+	metaScheme.AddKnownTypeWithName(flowSchema, &metav1.PartialObjectMetadata{})
+	metaScheme.AddKnownTypeWithName(flowSchema.GroupVersion().WithKind("FlowSchemaList"), &metav1.PartialObjectMetadataList{})
+	legacyFlowSchema := &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: flowSchema.GroupVersion().String(), Kind: flowSchema.Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+	}
+
+	log, _ := test.NewNullLogger()
+	var requestedReleases []string
+	underTest := RemovedAPIScanner{
+		Log:           log,
+		ClientFactory: testutil.NewFakeClientFactory(removedCRD, chart, pendingChart),
+		ManifestsDir:  manifestsDir,
+		metaClient:    metadatafake.NewSimpleMetadataClient(metaScheme, legacyFlowSchema),
+		releaseManifest: func(_ context.Context, releaseName, namespace string) (string, error) {
+			requestedReleases = append(requestedReleases, namespace+"/"+releaseName)
+			return cronJobManifest, nil
+		},
+	}
+
+	cronJob := schema.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"}
+
+	t.Run("BeforeRemoval", func(t *testing.T) {
+		usages, err := underTest.Scan(t.Context(), "v1.24.17+k0s.0")
+		require.NoError(t, err)
+		assert.Empty(t, usages)
+	})
+
+	t.Run("AfterRemoval", func(t *testing.T) {
+		requestedReleases = nil
+		usages, err := underTest.Scan(t.Context(), "v99.99.99")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ops/backup"}, requestedReleases)
+		assert.Equal(t, []RemovedAPIUsage{{
+			Source:           RemovedAPISourceCluster,
+			GroupVersionKind: flowSchema,
+			Resource:         "flowschemas",
+			Name:             "legacy",
+			RemovedIn:        "v1.32.0",
+			Replacement:      "flowcontrol.apiserver.k8s.io/v1",
+		}, {
+			Source:           RemovedAPISourceCRDStorage,
+			Origin:           "CustomResourceDefinition removedcrds.k0s.k0sproject.example.com",
+			GroupVersionKind: schema.GroupVersionKind{Group: "k0s.k0sproject.example.com", Version: "v1beta1", Kind: "RemovedCRD"},
+			Resource:         "removedcrds",
+			Name:             "removedcrds.k0s.k0sproject.example.com",
+			RemovedIn:        "v99.99.99",
+		}, {
+			Source:           RemovedAPISourceHelmRelease,
+			Origin:           "Helm release ops/backup",
+			GroupVersionKind: cronJob,
+			Namespace:        "ops",
+			Name:             "backup",
+			RemovedIn:        "v1.25.0",
+			Replacement:      "batch/v1",
+		}, {
+			Source:           RemovedAPISourceManifest,
+			Origin:           filepath.Join(stackDir, "backup.yaml"),
+			GroupVersionKind: cronJob,
+			Namespace:        "ops",
+			Name:             "backup",
+			RemovedIn:        "v1.25.0",
+			Replacement:      "batch/v1",
+		}}, usages)
+	})
+}
+
+func TestRemovedAPIsError(t *testing.T) {
+	flowSchema := schema.GroupVersionKind{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Kind: "FlowSchema"}
+	cronJob := schema.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"}
+
+	for _, test := range []struct {
+		name     string
+		usages   []RemovedAPIUsage
+		expected string
+	}{
+		{
+			"Cluster",
+			[]RemovedAPIUsage{
+				{Source: RemovedAPISourceCluster, GroupVersionKind: flowSchema, Resource: "flowschemas", Name: "a", RemovedIn: "v1.32.0"},
+				{Source: RemovedAPISourceCluster, GroupVersionKind: flowSchema, Resource: "flowschemas", Name: "b", RemovedIn: "v1.32.0"},
+				{Source: RemovedAPISourceManifest, Origin: "foo.yaml", GroupVersionKind: cronJob, Name: "c", RemovedIn: "v1.25.0"},
+			},
+			"flowschemas.flowcontrol.apiserver.k8s.io v1beta3 has been removed in Kubernetes v1.32.0, but there are 2 such resources in the cluster",
+		},
+		{
+			"CRDStorage",
+			[]RemovedAPIUsage{
+				{Source: RemovedAPISourceCRDStorage, GroupVersionKind: flowSchema, Resource: "flowschemas", RemovedIn: "v1.32.0"},
+			},
+			"flowschemas.flowcontrol.apiserver.k8s.io v1beta3 has been removed in Kubernetes v1.32.0, but it's still a stored version of the CustomResourceDefinition",
+		},
+		{
+			"HelmRelease",
+			[]RemovedAPIUsage{
+				{Source: RemovedAPISourceHelmRelease, Origin: "Helm release ops/backup", GroupVersionKind: cronJob, Name: "c", RemovedIn: "v1.25.0"},
+			},
+			"batch/v1beta1 CronJob has been removed in Kubernetes v1.25.0, but there are 1 such resources in Helm release ops/backup",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := &RemovedAPIsError{test.usages}
+			assert.Equal(t, test.expected, err.Error())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/applier"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// The name of the stack in which the extensions controller applies the
+	// charts declared in spec.extensions.helm. Mirrors
+	// controller.HelmExtensionStackName, which can't be imported from here.
+	extensionsStackName = "helm"
+
+	// The prefix of the names of the Chart resources that the extensions
+	// controller creates for the charts declared in spec.extensions.helm.
+	extensionsChartNamePrefix = "k0s-addon-chart-"
+)
+
+// errNotDeclared indicates that a managed chart can't be found in the cluster
+// configuration. This is the case if dynamic configuration is disabled.
+var errNotDeclared = errors.New("not declared in spec.extensions.helm of the dynamic cluster configuration")
+
+// isChartManaged determines if the chart is declared in the cluster
+// configuration, i.e. it belongs to the extensions controller's stack.
+func isChartManaged(chart *helmv1beta1.Chart) bool {
+	return chart.Labels[applier.NameLabel] == extensionsStackName
+}
+
+// readChartSpec returns the version and values with which a chart is declared.
+// Managed charts are declared in the cluster configuration, as the extensions
+// controller would revert any changes to their Chart resources.
+func (hup *helmupdate) readChartSpec(ctx context.Context, chart *helmv1beta1.Chart) (version string, values string, _ error) {
+	if !isChartManaged(chart) {
+		return chart.Spec.Version, chart.Spec.Values, nil
+	}
+
+	var config k0sv1beta1.ClusterConfig
+	declared, err := hup.getDeclaredChart(ctx, chart, &config)
+	if err != nil {
+		return "", "", err
+	}
+
+	return declared.Version, declared.Values, nil
+}
+
+// writeChartSpec updates the version and values with which a chart is declared.
+// It operates on the given chart for unmanaged charts, and on the freshly
+// fetched cluster configuration for managed ones.
+func (hup *helmupdate) writeChartSpec(ctx context.Context, chart *helmv1beta1.Chart, version, values string) error {
+	if !isChartManaged(chart) {
+		chart.Spec.Version, chart.Spec.Values = version, values
+		return hup.client.Update(ctx, chart)
+	}
+
+	var config k0sv1beta1.ClusterConfig
+	declared, err := hup.getDeclaredChart(ctx, chart, &config)
+	if err != nil {
+		return err
+	}
+
+	declared.Version, declared.Values = version, values
+	return hup.client.Update(ctx, &config)
+}
+
+// getDeclaredChart fetches the cluster configuration and returns the entry of
+// spec.extensions.helm.charts that corresponds to the given Chart resource.
+func (hup *helmupdate) getDeclaredChart(ctx context.Context, chart *helmv1beta1.Chart, config *k0sv1beta1.ClusterConfig) (*k0sv1beta1.Chart, error) {
+	key := crcli.ObjectKey{Namespace: constant.ClusterConfigNamespace, Name: constant.ClusterConfigObjectName}
+	if err := hup.client.Get(ctx, key, config); err != nil {
+		return nil, fmt.Errorf("failed to get cluster configuration: %w", err)
+	}
+
+	if spec := config.Spec; spec != nil && spec.Extensions != nil && spec.Extensions.Helm != nil {
+		for i := range spec.Extensions.Helm.Charts {
+			if declared := &spec.Extensions.Helm.Charts[i]; extensionsChartNamePrefix+declared.Name == chart.Name {
+				return declared, nil
+			}
+		}
+	}
+
+	return nil, errNotDeclared
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"errors"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// NewPlan handles the provider state 'newplan'
+func (hup *helmupdate) NewPlan(ctx context.Context, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := hup.logger.WithField("state", "newplan")
+	logger.Info("Processing")
+
+	status.State = appc.PlanSchedulableWait
+	status.HelmUpdate = &apv1beta2.PlanCommandHelmUpdateStatus{}
+
+	allChartsAccountedFor := true
+	for _, target := range cmd.HelmUpdate.Charts {
+		key := chartKey(target)
+		chartStatus := apv1beta2.PlanCommandHelmChartStatus{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		}
+
+		var chart helmv1beta1.Chart
+		if err := hup.client.Get(ctx, key, &chart); err != nil {
+			logger.WithError(err).Warn("Unable to find chart ", key)
+			hup.setChartState(&chartStatus, appc.ChartMissing, "")
+			allChartsAccountedFor = false
+		} else if version, values, err := hup.readChartSpec(ctx, &chart); err != nil {
+			// Managed charts can only be upgraded via the dynamic cluster
+			// configuration. The extensions controller would revert any
+			// changes to their Chart resources.
+			if !errors.Is(err, errNotDeclared) && !apierrors.IsNotFound(err) {
+				logger.WithError(err).Warn("Unable to determine the declaration of chart ", key, ", retrying")
+				return status.State, true, nil
+			}
+			logger.WithError(err).Warn("Chart ", key, " is managed by k0s, but not declared in the dynamic cluster configuration")
+			hup.setChartState(&chartStatus, appc.ChartMissing, "Chart is declared in spec.extensions.helm, which requires dynamic configuration to be upgraded")
+			allChartsAccountedFor = false
+		} else if isChartUpToDate(&chart, version, values, target) {
+			hup.setChartState(&chartStatus, appc.ChartCompleted, "Chart is already up to date")
+		} else {
+			hup.setChartState(&chartStatus, appc.ChartPending, "")
+		}
+
+		status.HelmUpdate.Charts = append(status.HelmUpdate.Charts, chartStatus)
+	}
+
+	if !allChartsAccountedFor {
+		return appc.PlanIncompleteTargets, false, nil
+	}
+
+	return appc.PlanSchedulableWait, false, nil
+}
+
+// isChartUpToDate determines if the chart is declared and has already been
+// deployed with the desired version and values.
+func isChartUpToDate(chart *helmv1beta1.Chart, version, values string, target apv1beta2.PlanCommandHelmUpdateChart) bool {
+	return version == target.Version &&
+		chart.Status.Version == target.Version &&
+		chart.Status.Error == "" &&
+		(target.Values == nil || *target.Values == values)
+}
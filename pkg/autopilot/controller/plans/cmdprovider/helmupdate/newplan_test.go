@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/applier"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apscheme "github.com/k0sproject/k0s/pkg/client/clientset/scheme"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var testNow = time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+type fakeReleaseManager struct {
+	ready      bool
+	rolledBack map[string]int
+	rollbacks  int
+}
+
+func (f *fakeReleaseManager) IsReleaseReady(context.Context, string, string) (bool, error) {
+	return f.ready, nil
+}
+
+func (f *fakeReleaseManager) RollbackRelease(_ context.Context, releaseName, namespace string, revision int) error {
+	if f.rolledBack == nil {
+		f.rolledBack = make(map[string]int)
+	}
+	f.rolledBack[namespace+"/"+releaseName] = revision
+	f.rollbacks++
+	return nil
+}
+
+func newTestProvider(t *testing.T, releases releaseManager, objects ...crcli.Object) (*helmupdate, crcli.Client) {
+	return newInterceptedTestProvider(t, releases, interceptor.Funcs{}, objects...)
+}
+
+func newInterceptedTestProvider(t *testing.T, releases releaseManager, funcs interceptor.Funcs, objects ...crcli.Object) (*helmupdate, crcli.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apscheme.AddToScheme(scheme))
+	client := crfake.NewClientBuilder().WithObjects(objects...).WithScheme(scheme).WithInterceptorFuncs(funcs).Build()
+
+	return &helmupdate{
+		logger:   logrus.NewEntry(logrus.StandardLogger()),
+		client:   client,
+		releases: releases,
+		now:      func() time.Time { return testNow },
+	}, client
+}
+
+func newChart(name, specVersion, statusVersion string, revision int64) *helmv1beta1.Chart {
+	return &helmv1beta1.Chart{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+		Spec: helmv1beta1.ChartSpec{
+			ChartName:   "repo/" + name,
+			ReleaseName: name,
+			Namespace:   "default",
+			Version:     specVersion,
+			Values:      "foo: bar",
+		},
+		Status: helmv1beta1.ChartStatus{
+			ReleaseName: name,
+			Namespace:   "default",
+			Version:     statusVersion,
+			Revision:    revision,
+		},
+	}
+}
+
+func newManagedChart(name, specVersion, statusVersion string, revision int64) *helmv1beta1.Chart {
+	chart := newChart(extensionsChartNamePrefix+name, specVersion, statusVersion, revision)
+	chart.Labels = map[string]string{applier.NameLabel: extensionsStackName}
+	chart.Spec.ReleaseName = name
+	chart.Status.ReleaseName = name
+	return chart
+}
+
+func newClusterConfig(charts ...k0sv1beta1.Chart) *k0sv1beta1.ClusterConfig {
+	return &k0sv1beta1.ClusterConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: constant.ClusterConfigObjectName, Namespace: constant.ClusterConfigNamespace},
+		Spec: &k0sv1beta1.ClusterSpec{
+			Extensions: &k0sv1beta1.ClusterExtensions{
+				Helm: &k0sv1beta1.HelmExtensions{Charts: charts},
+			},
+		},
+	}
+}
+
+// TestNewPlan ensures that all charts are discovered with their proper states.
+func TestNewPlan(t *testing.T) {
+	var tests = []struct {
+		name           string
+		objects        []crcli.Object
+		expectedState  apv1beta2.PlanStateType
+		expectedCharts []apv1beta2.PlanCommandTargetStateType
+	}{
+		{
+			"AllFound",
+			[]crcli.Object{newChart("a", "1.0.0", "1.0.0", 1), newChart("b", "1.0.0", "1.0.0", 1)},
+			appc.PlanSchedulableWait,
+			[]apv1beta2.PlanCommandTargetStateType{appc.ChartPending, appc.ChartPending},
+		},
+		{
+			"AlreadyUpToDate",
+			[]crcli.Object{newChart("a", "2.0.0", "2.0.0", 2), newChart("b", "1.0.0", "1.0.0", 1)},
+			appc.PlanSchedulableWait,
+			[]apv1beta2.PlanCommandTargetStateType{appc.ChartCompleted, appc.ChartPending},
+		},
+		{
+			"Missing",
+			[]crcli.Object{newChart("a", "1.0.0", "1.0.0", 1)},
+			appc.PlanIncompleteTargets,
+			[]apv1beta2.PlanCommandTargetStateType{appc.ChartPending, appc.ChartMissing},
+		},
+	}
+
+	cmd := apv1beta2.PlanCommand{
+		HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+			Charts: []apv1beta2.PlanCommandHelmUpdateChart{
+				{Name: "a", Version: "2.0.0"},
+				{Name: "b", Namespace: metav1.NamespaceSystem, Version: "2.0.0"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider, _ := newTestProvider(t, &fakeReleaseManager{}, test.objects...)
+
+			status := apv1beta2.PlanCommandStatus{}
+			nextState, retry, err := provider.NewPlan(t.Context(), cmd, &status)
+			assert.NoError(t, err)
+			assert.False(t, retry)
+			assert.Equal(t, test.expectedState, nextState)
+
+			require.NotNil(t, status.HelmUpdate)
+			var states []apv1beta2.PlanCommandTargetStateType
+			for _, chart := range status.HelmUpdate.Charts {
+				assert.Equal(t, metav1.NamespaceSystem, chart.Namespace)
+				states = append(states, chart.State)
+			}
+			assert.Equal(t, test.expectedCharts, states)
+		})
+	}
+}
+
+// TestNewPlanManagedCharts ensures that charts declared in the cluster
+// configuration can be upgraded if dynamic configuration is enabled.
+func TestNewPlanManagedCharts(t *testing.T) {
+	var tests = []struct {
+		name          string
+		objects       []crcli.Object
+		expectedState apv1beta2.PlanStateType
+		expectedChart apv1beta2.PlanCommandTargetStateType
+	}{
+		{
+			"Declared",
+			[]crcli.Object{newManagedChart("a", "1.0.0", "1.0.0", 1), newClusterConfig(k0sv1beta1.Chart{Name: "a", Version: "1.0.0"})},
+			appc.PlanSchedulableWait, appc.ChartPending,
+		},
+		{
+			"AlreadyUpToDate",
+			[]crcli.Object{newManagedChart("a", "2.0.0", "2.0.0", 2), newClusterConfig(k0sv1beta1.Chart{Name: "a", Version: "2.0.0"})},
+			appc.PlanSchedulableWait, appc.ChartCompleted,
+		},
+		{
+			"WithoutDynamicConfig",
+			[]crcli.Object{newManagedChart("a", "1.0.0", "1.0.0", 1)},
+			appc.PlanIncompleteTargets, appc.ChartMissing,
+		},
+		{
+			"NotDeclared",
+			[]crcli.Object{newManagedChart("a", "1.0.0", "1.0.0", 1), newClusterConfig(k0sv1beta1.Chart{Name: "b", Version: "1.0.0"})},
+			appc.PlanIncompleteTargets, appc.ChartMissing,
+		},
+	}
+
+	cmd := apv1beta2.PlanCommand{
+		HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+			Charts: []apv1beta2.PlanCommandHelmUpdateChart{
+				{Name: extensionsChartNamePrefix + "a", Version: "2.0.0"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider, _ := newTestProvider(t, &fakeReleaseManager{}, test.objects...)
+
+			status := apv1beta2.PlanCommandStatus{}
+			nextState, retry, err := provider.NewPlan(t.Context(), cmd, &status)
+			assert.NoError(t, err)
+			assert.False(t, retry)
+			assert.Equal(t, test.expectedState, nextState)
+
+			require.NotNil(t, status.HelmUpdate)
+			require.Len(t, status.HelmUpdate.Charts, 1)
+			assert.Equal(t, test.expectedChart, status.HelmUpdate.Charts[0].State)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	"github.com/k0sproject/k0s/pkg/helm"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	commandID = "HelmUpdate"
+
+	defaultTimeout = 10 * time.Minute
+)
+
+// releaseManager performs the Helm operations on the releases of the charts
+// that are upgraded by a plan.
+type releaseManager interface {
+	IsReleaseReady(ctx context.Context, releaseName, namespace string) (bool, error)
+	RollbackRelease(ctx context.Context, releaseName, namespace string, revision int) error
+}
+
+type helmupdate struct {
+	logger   *logrus.Entry
+	client   crcli.Client
+	releases releaseManager
+	now      func() time.Time
+}
+
+var _ appc.PlanCommandProvider = (*helmupdate)(nil)
+
+// NewHelmUpdatePlanCommandProvider creates a new `PlanCommandProvider` that
+// upgrades Helm `Chart` resources, one after the other.
+func NewHelmUpdatePlanCommandProvider(logger *logrus.Entry, client crcli.Client, clients helm.ClientGetter) appc.PlanCommandProvider {
+	return &helmupdate{
+		logger:   logger.WithField("command", "helmupdate"),
+		client:   client,
+		releases: &helmReleaseManager{clients},
+		now:      time.Now,
+	}
+}
+
+func (hup *helmupdate) CommandID() string {
+	return commandID
+}
+
+func (hup *helmupdate) setChartState(chartStatus *apv1beta2.PlanCommandHelmChartStatus, state apv1beta2.PlanCommandTargetStateType, description string) {
+	chartStatus.State = state
+	chartStatus.Description = description
+	chartStatus.LastUpdatedTimestamp = metav1.NewTime(hup.now())
+}
+
+func chartKey(chart apv1beta2.PlanCommandHelmUpdateChart) crcli.ObjectKey {
+	return crcli.ObjectKey{
+		Name:      chart.Name,
+		Namespace: cmp.Or(chart.Namespace, metav1.NamespaceSystem),
+	}
+}
+
+// releaseOf returns the name and namespace of the Helm release of a chart.
+func releaseOf(chart *helmv1beta1.Chart) (string, string) {
+	return cmp.Or(chart.Status.ReleaseName, chart.Spec.ReleaseName, chart.Name),
+		cmp.Or(chart.Status.Namespace, chart.Spec.Namespace)
+}
+
+// helmReleaseManager is the releaseManager backed by the Helm client.
+type helmReleaseManager struct {
+	clients helm.ClientGetter
+}
+
+func (m *helmReleaseManager) IsReleaseReady(ctx context.Context, releaseName, namespace string) (bool, error) {
+	helmCmd, cleanup, err := helm.NewCommands(m.clients, nil)
+	if err != nil {
+		return false, err
+	}
+	defer cleanup()
+
+	return helmCmd.IsReleaseReady(ctx, releaseName, namespace)
+}
+
+func (m *helmReleaseManager) RollbackRelease(ctx context.Context, releaseName, namespace string, revision int) error {
+	helmCmd, cleanup, err := helm.NewCommands(m.clients, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	return helmCmd.RollbackRelease(ctx, releaseName, namespace, revision)
+}
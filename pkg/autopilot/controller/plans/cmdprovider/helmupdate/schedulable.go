@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"fmt"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Schedulable handles the provider state 'schedulable'
+func (hup *helmupdate) Schedulable(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := hup.logger.WithField("state", "schedulable")
+	logger.Info("Processing")
+
+	// Charts are upgraded strictly in order, hence the first pending chart is
+	// the next one to be upgraded. If there's none left, we're done.
+
+	idx := findFirstChart(status.HelmUpdate.Charts, appc.ChartPending)
+	if idx < 0 {
+		logger.Info("All charts are completed")
+		return appc.PlanCompleted, false, nil
+	}
+
+	chartStatus, target := &status.HelmUpdate.Charts[idx], cmd.HelmUpdate.Charts[idx]
+	key := chartKey(target)
+
+	var chart helmv1beta1.Chart
+	if err := hup.client.Get(ctx, key, &chart); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Chart ", key, " has disappeared")
+			hup.setChartState(chartStatus, appc.ChartMissing, "")
+			return appc.PlanIncompleteTargets, false, nil
+		}
+		logger.WithError(err).Warn("Unable to get chart ", key, ", retrying")
+		return status.State, true, nil
+	}
+
+	// Remember the state of the chart right before upgrading it, so that it
+	// can be rolled back to it later on.
+
+	version, values, err := hup.readChartSpec(ctx, &chart)
+	if err != nil {
+		logger.WithError(err).Warn("Unable to determine the declaration of chart ", key, ", retrying")
+		return status.State, true, nil
+	}
+
+	chartStatus.PreviousVersion = version
+	chartStatus.PreviousValues = values
+	chartStatus.PreviousRevision = chart.Status.Revision
+
+	if target.Values != nil {
+		values = *target.Values
+	}
+
+	if err := hup.writeChartSpec(ctx, &chart, target.Version, values); err != nil {
+		if apierrors.IsConflict(err) {
+			logger.WithError(err).Warn("Conflict updating chart ", key, ", retrying")
+			return status.State, true, nil
+		}
+		return status.State, false, fmt.Errorf("unable to update chart %s: %w", key, err)
+	}
+
+	logger.Infof("Upgrading chart %s from version %s to %s", key, chartStatus.PreviousVersion, target.Version)
+	hup.setChartState(chartStatus, appc.ChartUpgrading, "")
+
+	return appc.PlanSchedulableWait, false, nil
+}
+
+// findFirstChart returns the index of the first chart with the given state,
+// or -1 if there's none.
+func findFirstChart(charts []apv1beta2.PlanCommandHelmChartStatus, state apv1beta2.PlanCommandTargetStateType) int {
+	for i := range charts {
+		if charts[i].State == state {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"testing"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestSchedulable ensures that the first pending chart gets upgraded.
+func TestSchedulable(t *testing.T) {
+	values := "foo: baz"
+	cmd := apv1beta2.PlanCommand{
+		HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+			Charts: []apv1beta2.PlanCommandHelmUpdateChart{
+				{Name: "a", Version: "2.0.0"},
+				{Name: "b", Version: "2.0.0", Values: &values},
+			},
+		},
+	}
+
+	t.Run("NextPending", func(t *testing.T) {
+		provider, client := newTestProvider(t, &fakeReleaseManager{},
+			newChart("a", "2.0.0", "2.0.0", 2), newChart("b", "1.0.0", "1.0.0", 3),
+		)
+
+		status := apv1beta2.PlanCommandStatus{
+			State: appc.PlanSchedulable,
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+				Charts: []apv1beta2.PlanCommandHelmChartStatus{
+					{Name: "a", Namespace: metav1.NamespaceSystem, State: appc.ChartCompleted},
+					{Name: "b", Namespace: metav1.NamespaceSystem, State: appc.ChartPending},
+				},
+			},
+		}
+
+		nextState, retry, err := provider.Schedulable(t.Context(), "id123", cmd, &status)
+		assert.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, appc.PlanSchedulableWait, nextState)
+
+		chartStatus := status.HelmUpdate.Charts[1]
+		assert.Equal(t, appc.ChartUpgrading, chartStatus.State)
+		assert.Equal(t, "1.0.0", chartStatus.PreviousVersion)
+		assert.Equal(t, "foo: bar", chartStatus.PreviousValues)
+		assert.Equal(t, int64(3), chartStatus.PreviousRevision)
+		assert.Equal(t, testNow, chartStatus.LastUpdatedTimestamp.Time)
+
+		var chart helmv1beta1.Chart
+		require.NoError(t, client.Get(t.Context(), crcli.ObjectKey{Name: "b", Namespace: metav1.NamespaceSystem}, &chart))
+		assert.Equal(t, "2.0.0", chart.Spec.Version)
+		assert.Equal(t, "foo: baz", chart.Spec.Values)
+	})
+
+	t.Run("Managed", func(t *testing.T) {
+		managed := newManagedChart("a", "1.0.0", "1.0.0", 3)
+		provider, client := newTestProvider(t, &fakeReleaseManager{},
+			managed, newClusterConfig(k0sv1beta1.Chart{Name: "a", Version: "1.0.0", Values: "foo: qux"}),
+		)
+
+		cmd := apv1beta2.PlanCommand{
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+				Charts: []apv1beta2.PlanCommandHelmUpdateChart{
+					{Name: managed.Name, Version: "2.0.0", Values: &values},
+				},
+			},
+		}
+		status := apv1beta2.PlanCommandStatus{
+			State: appc.PlanSchedulable,
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+				Charts: []apv1beta2.PlanCommandHelmChartStatus{
+					{Name: managed.Name, Namespace: metav1.NamespaceSystem, State: appc.ChartPending},
+				},
+			},
+		}
+
+		nextState, retry, err := provider.Schedulable(t.Context(), "id123", cmd, &status)
+		assert.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, appc.PlanSchedulableWait, nextState)
+
+		chartStatus := status.HelmUpdate.Charts[0]
+		assert.Equal(t, appc.ChartUpgrading, chartStatus.State)
+		assert.Equal(t, "1.0.0", chartStatus.PreviousVersion)
+		assert.Equal(t, "foo: qux", chartStatus.PreviousValues)
+		assert.Equal(t, int64(3), chartStatus.PreviousRevision)
+
+		// The cluster configuration has been updated, so that the extensions
+		// controller will upgrade the chart, instead of reverting it.
+		var config k0sv1beta1.ClusterConfig
+		require.NoError(t, client.Get(t.Context(), crcli.ObjectKey{Name: constant.ClusterConfigObjectName, Namespace: constant.ClusterConfigNamespace}, &config))
+		if assert.Len(t, config.Spec.Extensions.Helm.Charts, 1) {
+			assert.Equal(t, "2.0.0", config.Spec.Extensions.Helm.Charts[0].Version)
+			assert.Equal(t, "foo: baz", config.Spec.Extensions.Helm.Charts[0].Values)
+		}
+
+		var chart helmv1beta1.Chart
+		require.NoError(t, client.Get(t.Context(), crcli.ObjectKeyFromObject(managed), &chart))
+		assert.Equal(t, "1.0.0", chart.Spec.Version, "the Chart resource is left to the extensions controller")
+	})
+
+	t.Run("NonePending", func(t *testing.T) {
+		provider, _ := newTestProvider(t, &fakeReleaseManager{})
+
+		status := apv1beta2.PlanCommandStatus{
+			State: appc.PlanSchedulable,
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+				Charts: []apv1beta2.PlanCommandHelmChartStatus{
+					{Name: "a", Namespace: metav1.NamespaceSystem, State: appc.ChartCompleted},
+					{Name: "b", Namespace: metav1.NamespaceSystem, State: appc.ChartCompleted},
+				},
+			},
+		}
+
+		nextState, retry, err := provider.Schedulable(t.Context(), "id123", cmd, &status)
+		assert.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, appc.PlanCompleted, nextState)
+	})
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SchedulableWait handles the provider state 'schedulablewait'
+func (hup *helmupdate) SchedulableWait(ctx context.Context, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+	logger := hup.logger.WithField("state", "schedulablewait")
+	logger.Info("Processing")
+
+	// The first chart that isn't completed determines the next transition.
+
+	for i := range status.HelmUpdate.Charts {
+		chartStatus := &status.HelmUpdate.Charts[i]
+
+		switch chartStatus.State {
+		case appc.ChartCompleted:
+			continue
+
+		case appc.ChartPending:
+			logger.Info("Chart ", chartStatus.Namespace, "/", chartStatus.Name, " can be scheduled")
+			return appc.PlanSchedulable, false, nil
+
+		case appc.ChartUpgrading:
+			return hup.verifyChart(ctx, logger, cmd.HelmUpdate, cmd.HelmUpdate.Charts[i], chartStatus)
+
+		case appc.ChartMissing:
+			return appc.PlanIncompleteTargets, false, nil
+
+		default:
+			logger.Info("Plan is non-recoverable due to chart ", chartStatus.Namespace, "/", chartStatus.Name, " in state ", chartStatus.State)
+			return appc.PlanApplyFailed, false, nil
+		}
+	}
+
+	logger.Info("All charts completed")
+	return appc.PlanCompleted, false, nil
+}
+
+// verifyChart checks if a chart that's being upgraded has been deployed with
+// its new version and is healthy. Charts that don't get there within the
+// command's timeout are rolled back.
+func (hup *helmupdate) verifyChart(ctx context.Context, logger *logrus.Entry, cmd *apv1beta2.PlanCommandHelmUpdate, target apv1beta2.PlanCommandHelmUpdateChart, chartStatus *apv1beta2.PlanCommandHelmChartStatus) (apv1beta2.PlanStateType, bool, error) {
+	key := chartKey(target)
+
+	var chart helmv1beta1.Chart
+	if err := hup.client.Get(ctx, key, &chart); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Chart ", key, " has disappeared")
+			hup.setChartState(chartStatus, appc.ChartMissing, "")
+			return appc.PlanIncompleteTargets, false, nil
+		}
+		logger.WithError(err).Warn("Unable to get chart ", key, ", retrying")
+		return appc.PlanSchedulableWait, true, nil
+	}
+
+	releaseName, releaseNamespace := releaseOf(&chart)
+
+	if isChartUpgraded(&chart, target, chartStatus) {
+		ready, err := hup.releases.IsReleaseReady(ctx, releaseName, releaseNamespace)
+		if err != nil {
+			logger.WithError(err).Warn("Unable to check readiness of release ", releaseNamespace, "/", releaseName)
+		} else if ready {
+			logger.Info("Chart ", key, " has been upgraded to version ", target.Version, " and is healthy")
+			hup.setChartState(chartStatus, appc.ChartCompleted, "")
+			return appc.PlanSchedulableWait, false, nil
+		}
+	}
+
+	timeout := cmp.Or(cmd.Timeout.Duration, defaultTimeout)
+	if hup.now().Sub(chartStatus.LastUpdatedTimestamp.Time) < timeout {
+		logger.Info("Waiting for chart ", key, " to be upgraded and healthy")
+		return appc.PlanSchedulableWait, true, nil
+	}
+
+	logger.Warn("Chart ", key, " didn't become healthy within ", timeout, ", rolling back")
+	return hup.rollbackChart(ctx, logger, &chart, chartStatus, timeout)
+}
+
+// isChartUpgraded determines if the extensions controller reports that the
+// chart has been upgraded to the desired version.
+func isChartUpgraded(chart *helmv1beta1.Chart, target apv1beta2.PlanCommandHelmUpdateChart, chartStatus *apv1beta2.PlanCommandHelmChartStatus) bool {
+	return chart.Status.Error == "" &&
+		chart.Status.Version == target.Version &&
+		chart.Status.Revision > chartStatus.PreviousRevision
+}
+
+// rollbackChart rolls back the release of a chart to its previous revision, if
+// a new revision has been deployed, and restores the previous version and values
+// of the chart, so that the extensions controller won't retry the upgrade.
+//
+// The rollback happens exactly once: Retrying the whole operation would roll
+// back the release once more, as the status of the plan isn't recorded on
+// retries. Hence only restoring the chart is retried on conflicts.
+func (hup *helmupdate) rollbackChart(ctx context.Context, logger *logrus.Entry, chart *helmv1beta1.Chart, chartStatus *apv1beta2.PlanCommandHelmChartStatus, timeout time.Duration) (apv1beta2.PlanStateType, bool, error) {
+	description := fmt.Sprintf("Chart didn't become healthy within %s", timeout)
+	if chart.Status.Error != "" {
+		description = fmt.Sprintf("%s: %s", description, chart.Status.Error)
+	}
+
+	if chartStatus.PreviousRevision > 0 && chart.Status.Revision > chartStatus.PreviousRevision {
+		releaseName, releaseNamespace := releaseOf(chart)
+		if err := hup.releases.RollbackRelease(ctx, releaseName, releaseNamespace, int(chartStatus.PreviousRevision)); err != nil {
+			// Restoring the previous chart spec will still make the extensions
+			// controller converge the release back to its previous state.
+			logger.WithError(err).Warn("Failed to roll back release ", releaseNamespace, "/", releaseName)
+			description = fmt.Sprintf("%s (helm rollback failed: %v)", description, err)
+		}
+	}
+
+	key := crcli.ObjectKeyFromObject(chart)
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var chart helmv1beta1.Chart
+		if err := hup.client.Get(ctx, key, &chart); err != nil {
+			return err
+		}
+		return hup.writeChartSpec(ctx, &chart, chartStatus.PreviousVersion, chartStatus.PreviousValues)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to restore chart ", key, " to version ", chartStatus.PreviousVersion)
+		description = fmt.Sprintf("%s (restoring the chart failed: %v)", description, err)
+	} else {
+		logger.Info("Rolled back chart ", key, " to version ", chartStatus.PreviousVersion)
+	}
+
+	hup.setChartState(chartStatus, appc.ChartRolledBack, description)
+	return appc.PlanApplyFailed, false, nil
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helmupdate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// TestSchedulableWait covers the transitions of a chart that's being upgraded.
+func TestSchedulableWait(t *testing.T) {
+	cmd := apv1beta2.PlanCommand{
+		HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+			Charts: []apv1beta2.PlanCommandHelmUpdateChart{
+				{Name: "a", Version: "2.0.0"},
+				{Name: "b", Version: "2.0.0"},
+			},
+			Timeout: metav1.Duration{Duration: 5 * time.Minute},
+		},
+	}
+
+	upgrading := func(since time.Duration) apv1beta2.PlanCommandHelmChartStatus {
+		return apv1beta2.PlanCommandHelmChartStatus{
+			Name:                 "a",
+			Namespace:            metav1.NamespaceSystem,
+			State:                appc.ChartUpgrading,
+			PreviousVersion:      "1.0.0",
+			PreviousValues:       "foo: bar",
+			PreviousRevision:     3,
+			LastUpdatedTimestamp: metav1.NewTime(testNow.Add(-since)),
+		}
+	}
+	pending := apv1beta2.PlanCommandHelmChartStatus{Name: "b", Namespace: metav1.NamespaceSystem, State: appc.ChartPending}
+
+	failed := newChart("a", "2.0.0", "1.0.0", 3)
+	failed.Status.Error = "timed out waiting for the condition"
+
+	var tests = []struct {
+		name               string
+		chart              *helmv1beta1.Chart
+		ready              bool
+		status             apv1beta2.PlanCommandHelmChartStatus
+		expectedNextState  apv1beta2.PlanStateType
+		expectedRetry      bool
+		expectedState      apv1beta2.PlanCommandTargetStateType
+		expectedSpec       string
+		expectedRolledBack map[string]int
+	}{
+		{"UpgradedAndHealthy", newChart("a", "2.0.0", "2.0.0", 4), true, upgrading(time.Minute),
+			appc.PlanSchedulableWait, false, appc.ChartCompleted, "2.0.0", nil},
+		{"UpgradedButUnhealthy", newChart("a", "2.0.0", "2.0.0", 4), false, upgrading(time.Minute),
+			appc.PlanSchedulableWait, true, appc.ChartUpgrading, "2.0.0", nil},
+		{"NotYetUpgraded", newChart("a", "2.0.0", "1.0.0", 3), true, upgrading(time.Minute),
+			appc.PlanSchedulableWait, true, appc.ChartUpgrading, "2.0.0", nil},
+		{"UnhealthyTimeout", newChart("a", "2.0.0", "2.0.0", 4), false, upgrading(10 * time.Minute),
+			appc.PlanApplyFailed, false, appc.ChartRolledBack, "1.0.0", map[string]int{"default/a": 3}},
+		{"FailedTimeout", failed, false, upgrading(10 * time.Minute),
+			appc.PlanApplyFailed, false, appc.ChartRolledBack, "1.0.0", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			releases := &fakeReleaseManager{ready: test.ready}
+			provider, client := newTestProvider(t, releases, test.chart)
+
+			status := apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulableWait,
+				HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+					Charts: []apv1beta2.PlanCommandHelmChartStatus{test.status, pending},
+				},
+			}
+
+			nextState, retry, err := provider.SchedulableWait(t.Context(), "id123", cmd, &status)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedNextState, nextState)
+			assert.Equal(t, test.expectedRetry, retry)
+			assert.Equal(t, test.expectedState, status.HelmUpdate.Charts[0].State)
+			assert.Equal(t, test.expectedRolledBack, releases.rolledBack)
+
+			var chart helmv1beta1.Chart
+			require.NoError(t, client.Get(t.Context(), crcli.ObjectKey{Name: "a", Namespace: metav1.NamespaceSystem}, &chart))
+			assert.Equal(t, test.expectedSpec, chart.Spec.Version)
+			assert.Equal(t, "foo: bar", chart.Spec.Values)
+
+			if test.expectedState == appc.ChartRolledBack {
+				assert.Contains(t, status.HelmUpdate.Charts[0].Description, "didn't become healthy within 5m0s")
+				assert.Contains(t, status.HelmUpdate.Charts[0].Description, test.chart.Status.Error)
+			}
+		})
+	}
+
+	// A conflict when restoring the chart must not roll back the release again.
+	t.Run("RollbackConflict", func(t *testing.T) {
+		releases := &fakeReleaseManager{}
+		var conflicts int
+		provider, client := newInterceptedTestProvider(t, releases, interceptor.Funcs{
+			Update: func(ctx context.Context, client crcli.WithWatch, obj crcli.Object, opts ...crcli.UpdateOption) error {
+				if conflicts < 2 {
+					conflicts++
+					return apierrors.NewConflict(helmv1beta1.Resource("charts"), obj.GetName(), errors.New("test"))
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+		}, newChart("a", "2.0.0", "2.0.0", 4))
+
+		status := apv1beta2.PlanCommandStatus{
+			State: appc.PlanSchedulableWait,
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+				Charts: []apv1beta2.PlanCommandHelmChartStatus{upgrading(10 * time.Minute), pending},
+			},
+		}
+
+		nextState, retry, err := provider.SchedulableWait(t.Context(), "id123", cmd, &status)
+		assert.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, appc.PlanApplyFailed, nextState)
+		assert.Equal(t, appc.ChartRolledBack, status.HelmUpdate.Charts[0].State)
+		assert.Equal(t, 2, conflicts)
+		assert.Equal(t, 1, releases.rollbacks, "the release must be rolled back exactly once")
+
+		var chart helmv1beta1.Chart
+		require.NoError(t, client.Get(t.Context(), crcli.ObjectKey{Name: "a", Namespace: metav1.NamespaceSystem}, &chart))
+		assert.Equal(t, "1.0.0", chart.Spec.Version)
+	})
+
+	// Managed charts are restored in the cluster configuration.
+	t.Run("RollbackManaged", func(t *testing.T) {
+		releases := &fakeReleaseManager{}
+		managed := newManagedChart("a", "2.0.0", "2.0.0", 4)
+		provider, client := newTestProvider(t, releases,
+			managed, newClusterConfig(k0sv1beta1.Chart{Name: "a", Version: "2.0.0", Values: "foo: baz"}),
+		)
+
+		chartStatus := upgrading(10 * time.Minute)
+		chartStatus.Name = managed.Name
+		status := apv1beta2.PlanCommandStatus{
+			State: appc.PlanSchedulableWait,
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+				Charts: []apv1beta2.PlanCommandHelmChartStatus{chartStatus},
+			},
+		}
+		cmd := apv1beta2.PlanCommand{
+			HelmUpdate: &apv1beta2.PlanCommandHelmUpdate{
+				Charts:  []apv1beta2.PlanCommandHelmUpdateChart{{Name: managed.Name, Version: "2.0.0"}},
+				Timeout: metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}
+
+		nextState, retry, err := provider.SchedulableWait(t.Context(), "id123", cmd, &status)
+		assert.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, appc.PlanApplyFailed, nextState)
+		assert.Equal(t, appc.ChartRolledBack, status.HelmUpdate.Charts[0].State)
+		assert.Equal(t, map[string]int{"default/a": 3}, releases.rolledBack)
+
+		var config k0sv1beta1.ClusterConfig
+		require.NoError(t, client.Get(t.Context(), crcli.ObjectKey{Name: constant.ClusterConfigObjectName, Namespace: constant.ClusterConfigNamespace}, &config))
+		if assert.Len(t, config.Spec.Extensions.Helm.Charts, 1) {
+			assert.Equal(t, "1.0.0", config.Spec.Extensions.Helm.Charts[0].Version)
+			assert.Equal(t, "foo: bar", config.Spec.Extensions.Helm.Charts[0].Values)
+		}
+	})
+
+	t.Run("Transitions", func(t *testing.T) {
+		provider, _ := newTestProvider(t, &fakeReleaseManager{})
+
+		for _, test := range []struct {
+			states   []apv1beta2.PlanCommandTargetStateType
+			expected apv1beta2.PlanStateType
+		}{
+			{[]apv1beta2.PlanCommandTargetStateType{appc.ChartCompleted, appc.ChartPending}, appc.PlanSchedulable},
+			{[]apv1beta2.PlanCommandTargetStateType{appc.ChartCompleted, appc.ChartCompleted}, appc.PlanCompleted},
+			{[]apv1beta2.PlanCommandTargetStateType{appc.ChartRolledBack, appc.ChartPending}, appc.PlanApplyFailed},
+			{[]apv1beta2.PlanCommandTargetStateType{appc.ChartMissing, appc.ChartPending}, appc.PlanIncompleteTargets},
+		} {
+			status := apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulableWait,
+				HelmUpdate: &apv1beta2.PlanCommandHelmUpdateStatus{
+					Charts: []apv1beta2.PlanCommandHelmChartStatus{
+						{Name: "a", State: test.states[0]},
+						{Name: "b", State: test.states[1]},
+					},
+				},
+			}
+
+			nextState, retry, err := provider.SchedulableWait(t.Context(), "id123", cmd, &status)
+			assert.NoError(t, err)
+			assert.False(t, retry)
+			assert.Equal(t, test.expected, nextState, "For states %v", test.states)
+		}
+	})
+}
@@ -5,6 +5,7 @@ package k0supdate
 
 import (
 	"context"
+	"fmt"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	"github.com/k0sproject/k0s/pkg/autopilot/checks"
@@ -49,6 +50,20 @@ func (kp *k0supdate) NewPlan(ctx context.Context, cmd apv1beta2.PlanCommand, sta
 		return appc.PlanIncompleteTargets, false, nil
 	}
 
+	// Split the discovered targets into their rollout waves, if any.
+
+	if err := appku.AssignWaves(cmd.K0sUpdate.Targets.Controllers.Waves, status.K0sUpdate.Controllers); err != nil {
+		status.State = appc.PlanWarning
+		status.Description = fmt.Sprintf("controllers: %v", err)
+		return appc.PlanWarning, false, err
+	}
+
+	if err := appku.AssignWaves(cmd.K0sUpdate.Targets.Workers.Waves, status.K0sUpdate.Workers); err != nil {
+		status.State = appc.PlanWarning
+		status.Description = fmt.Sprintf("workers: %v", err)
+		return appc.PlanWarning, false, err
+	}
+
 	// With the work done for this command, determine if the content should be restricted. Performing this
 	// assertion after processing prevents keeps this function consistent in that the content is guaranteed
 	// to be processed (vs. exiting early with incomplete results)
@@ -120,7 +120,9 @@ func findNextSchedulableTarget(logger *logrus.Entry, cmd *apv1beta2.PlanCommandK
 	}
 
 	for _, target := range targets {
-		pendingNodes := appku.FindPending(target.nodes)
+		// Only targets of the current rollout wave are eligible.
+		_, waveNodes, _ := appku.FindCurrentWave(target.nodes)
+		pendingNodes := appku.FindPending(waveNodes)
 		pendingNodeCount := len(pendingNodes)
 
 		if pendingNodeCount > 0 {
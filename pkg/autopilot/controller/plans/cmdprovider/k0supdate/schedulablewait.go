@@ -6,6 +6,7 @@ package k0supdate
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
@@ -13,6 +14,9 @@ import (
 	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
 	apsigcomm "github.com/k0sproject/k0s/pkg/autopilot/controller/signal/common"
 	apsigv2 "github.com/k0sproject/k0s/pkg/autopilot/signaling/v2"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // SchedulableWait handles the provider state 'schedulablewait'
@@ -24,7 +28,8 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 	// of their respective signal node objects.
 
 	logger.Info("Reconciling controller/worker signal node statuses")
-	if err := kp.reconcileSignalNodeStatus(ctx, planID, status); err != nil {
+	statusChanged, err := kp.reconcileSignalNodeStatus(ctx, planID, status)
+	if err != nil {
 		return status.State, false, fmt.Errorf("failed to reconcile signal node status: %w", err)
 	}
 
@@ -44,8 +49,16 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 		return appc.PlanCompleted, false, nil
 	}
 
-	canScheduleController, _ := isSchedulableControllers(status.K0sUpdate.Controllers)
-	canScheduleWorkers, _ := isSchedulableWorkers(cmd.K0sUpdate.Targets.Workers, status.K0sUpdate.Workers)
+	// Only the targets of the current rollout wave are considered for scheduling. A new wave
+	// may only be started once the previous waves have soaked and are healthy.
+
+	controllersWave, controllers, _ := appku.FindCurrentWave(status.K0sUpdate.Controllers)
+	workersWave, workers, _ := appku.FindCurrentWave(status.K0sUpdate.Workers)
+
+	canScheduleController, _ := isSchedulableControllers(controllers)
+	canScheduleWorkers, _ := isSchedulableWorkers(cmd.K0sUpdate.Targets.Workers, workers)
+
+	var waveBlocked bool
 
 	// Controllers have priority for scheduling evaluation, as it is important that controllers
 	// are updated before workers due to the Kubernetes version-skew policy.
@@ -53,15 +66,29 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 	// https://kubernetes.io/releases/version-skew-policy/
 
 	if !controllersDone && canScheduleController {
-		logger.Info("Controllers can be scheduled")
-		return appc.PlanSchedulable, false, nil
+		if kp.isWaveReady(ctx, logger, cmd.K0sUpdate.Targets.Controllers, apdel.ControllerDelegateController, *status.K0sUpdate, status.K0sUpdate.Controllers, controllersWave) {
+			logger.Info("Controllers can be scheduled")
+			return appc.PlanSchedulable, false, nil
+		}
+		waveBlocked = true
 	}
 
 	// Only once controllers are done can we consider workers.
 
 	if !workersDone && canScheduleWorkers && controllersDone {
-		logger.Info("Workers can be scheduled (controllers done)")
-		return appc.PlanSchedulable, false, nil
+		if kp.isWaveReady(ctx, logger, cmd.K0sUpdate.Targets.Workers, apdel.ControllerDelegateWorker, *status.K0sUpdate, status.K0sUpdate.Workers, workersWave) {
+			logger.Info("Workers can be scheduled (controllers done)")
+			return appc.PlanSchedulable, false, nil
+		}
+		waveBlocked = true
+	}
+
+	// Persist any target state changes while waiting for the next wave, so that
+	// the completion time of the wave that is currently soaking won't get lost.
+
+	if waveBlocked && statusChanged {
+		logger.Info("Waiting for the next wave, recording target statuses")
+		return appc.PlanSchedulableWait, false, nil
 	}
 
 	logger.Info("No applicable transitions available, requesting retry")
@@ -70,7 +97,8 @@ func (kp *k0supdate) SchedulableWait(ctx context.Context, planID string, cmd apv
 
 // reconcileSignalNodeStatus performs a reconciliation of the status of every signal node (controller/worker)
 // defined in the update status, ensuring that signal nodes marked as 'Completed' are updated in the plan status.
-func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID string, cmdStatus *apv1beta2.PlanCommandStatus) error {
+// Returns true if the status of any signal node has changed.
+func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID string, cmdStatus *apv1beta2.PlanCommandStatus) (bool, error) {
 	var targets = []struct {
 		nodes []apv1beta2.PlanCommandTargetStatus
 		label string
@@ -79,22 +107,26 @@ func (kp *k0supdate) reconcileSignalNodeStatus(ctx context.Context, planID strin
 		{cmdStatus.K0sUpdate.Workers, "worker"},
 	}
 
+	var changed bool
 	for _, target := range targets {
 		delegate, found := kp.controllerDelegateMap[target.label]
 		if !found {
-			return fmt.Errorf("unable to find controller delegate '%s'", target.label)
+			return false, fmt.Errorf("unable to find controller delegate '%s'", target.label)
 		}
 
-		kp.reconcileSignalNodeStatusTarget(ctx, planID, *cmdStatus, delegate, target.nodes)
+		if kp.reconcileSignalNodeStatusTarget(ctx, planID, *cmdStatus, delegate, target.nodes) {
+			changed = true
+		}
 	}
 
-	return nil
+	return changed, nil
 }
 
 // reconcileSignalNodeStatusTarget performs a reconciliation of the status of every signal node provided
 // against the current state maintained in the plan status. This ensures that any signal nodes that
 // have been transitioned to 'Completed' will also appear in the plan status as 'Completed'.
-func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus) {
+// Returns true if the status of any of the signal nodes has changed.
+func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID string, cmdStatus apv1beta2.PlanCommandStatus, delegate apdel.ControllerDelegate, signalNodes []apv1beta2.PlanCommandTargetStatus) (changed bool) {
 	for i := range signalNodes {
 		key := delegate.CreateNamespacedName(signalNodes[i].Name)
 		signalNode := delegate.CreateObject()
@@ -124,6 +156,11 @@ func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID
 							signalNodes[i].State = appc.SignalCompleted
 						}
 
+						if signalNodes[i].State != origState {
+							signalNodes[i].LastUpdatedTimestamp = metav1.Now()
+							changed = true
+						}
+
 						kp.logger.Infof("Signal node '%s' status changed from '%s' to '%s' (reason: %s)", signalNodes[i].Name, origState, signalNodes[i].State, signalData.Status.Status)
 					}
 				} else {
@@ -134,6 +171,63 @@ func (kp *k0supdate) reconcileSignalNodeStatusTarget(ctx context.Context, planID
 			}
 		}
 	}
+
+	return changed
+}
+
+// isWaveReady determines if the provided rollout wave of a target may be worked on. This is the case
+// if the wave has already been started, or if all targets of the preceding waves are healthy and the
+// soak time of the preceding wave has elapsed.
+func (kp *k0supdate) isWaveReady(ctx context.Context, logger *logrus.Entry, target apv1beta2.PlanCommandTarget, label string, status apv1beta2.PlanCommandK0sUpdateStatus, nodes []apv1beta2.PlanCommandTargetStatus, wave int) bool {
+	if wave == 0 {
+		return true
+	}
+
+	for _, node := range appku.FindInWave(nodes, wave) {
+		if node.State != appc.SignalPending {
+			return true
+		}
+	}
+
+	if remaining := appku.WaveSoakRemaining(target.Waves, nodes, wave, time.Now()); remaining > 0 {
+		logger.Infof("Wave %d of the %s targets is soaking for another %s", wave-1, label, remaining.Round(time.Second))
+		return false
+	}
+
+	delegate, found := kp.controllerDelegateMap[label]
+	if !found {
+		logger.Warnf("Unable to find controller delegate '%s'", label)
+		return false
+	}
+
+	// The readiness of controllers is determined by probing all of them at
+	// once, regardless of the signal node in question. Probe them only once per
+	// wave, instead of once per node. The readiness of workers is read from
+	// their respective node objects.
+	probeOnce := label == apdel.ControllerDelegateController
+
+	for _, node := range nodes {
+		if node.Wave >= wave {
+			continue
+		}
+
+		signalNode := delegate.CreateObject()
+		if err := kp.client.Get(ctx, delegate.CreateNamespacedName(node.Name), signalNode); err != nil {
+			logger.Warnf("Unable to find signal node '%s' for wave health check: %v", node.Name, err)
+			return false
+		}
+
+		if delegate.K0sUpdateReady(ctx, status, signalNode) != apdel.CanUpdate {
+			logger.Infof("Signal node '%s' of wave %d is not healthy, holding back wave %d", node.Name, node.Wave, wave)
+			return false
+		}
+
+		if probeOnce {
+			break
+		}
+	}
+
+	return true
 }
 
 // isSchedulableControllers determines if any of the controllers in the plan status have
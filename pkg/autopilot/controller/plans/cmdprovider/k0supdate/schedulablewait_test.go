@@ -4,7 +4,9 @@
 package k0supdate
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/k0sproject/k0s/internal/testutil"
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
@@ -21,6 +23,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	crcli "sigs.k8s.io/controller-runtime/pkg/client"
 	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -32,6 +35,22 @@ func signalNodeStatusDataAnnotations(sd apsigv2.SignalData) map[string]string {
 	return data
 }
 
+func newWaveTargetStatus(name string, state apv1beta2.PlanCommandTargetStateType, wave int, lastUpdated time.Time) apv1beta2.PlanCommandTargetStatus {
+	status := apv1beta2.NewPlanCommandTargetStatus(name, state)
+	status.Wave = wave
+	status.LastUpdatedTimestamp = metav1.NewTime(lastUpdated)
+	return status
+}
+
+func readyNode(name string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
 // TestSchedulableWait runs through a table of plans, ensuring that the plan will move
 // to `Schedulable` only under certain conditions.
 func TestSchedulableWait(t *testing.T) {
@@ -442,6 +461,111 @@ func TestSchedulableWait(t *testing.T) {
 			},
 		},
 
+		// Ensures that the next wave of workers isn't started while the previous wave is
+		// still soaking.
+		{
+			"WorkersWaveSoaking",
+			[]crcli.Object{readyNode("worker0")},
+			apv1beta2.PlanCommand{
+				K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{
+					Targets: apv1beta2.PlanCommandTargets{
+						Workers: apv1beta2.PlanCommandTarget{
+							Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 1},
+							Waves: []apv1beta2.PlanCommandTargetWave{
+								{Size: intstr.FromInt32(1), SoakTime: metav1.Duration{Duration: time.Hour}},
+							},
+						},
+					},
+				},
+			},
+			apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulableWait,
+				K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+					Workers: []apv1beta2.PlanCommandTargetStatus{
+						newWaveTargetStatus("worker0", appc.SignalCompleted, 0, time.Now().Add(-time.Minute)),
+						newWaveTargetStatus("worker1", appc.SignalPending, 1, time.Now()),
+					},
+				},
+			},
+			appc.PlanSchedulableWait,
+			true,
+			nil,
+			[]apv1beta2.PlanCommandTargetStatus{
+				newWaveTargetStatus("worker0", appc.SignalCompleted, 0, time.Time{}),
+				newWaveTargetStatus("worker1", appc.SignalPending, 1, time.Time{}),
+			},
+		},
+
+		// Ensures that the next wave of workers is started once the previous wave has soaked
+		// and its nodes are healthy.
+		{
+			"WorkersWaveSoaked",
+			[]crcli.Object{readyNode("worker0")},
+			apv1beta2.PlanCommand{
+				K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{
+					Targets: apv1beta2.PlanCommandTargets{
+						Workers: apv1beta2.PlanCommandTarget{
+							Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 1},
+							Waves: []apv1beta2.PlanCommandTargetWave{
+								{Size: intstr.FromInt32(1), SoakTime: metav1.Duration{Duration: time.Hour}},
+							},
+						},
+					},
+				},
+			},
+			apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulableWait,
+				K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+					Workers: []apv1beta2.PlanCommandTargetStatus{
+						newWaveTargetStatus("worker0", appc.SignalCompleted, 0, time.Now().Add(-2*time.Hour)),
+						newWaveTargetStatus("worker1", appc.SignalPending, 1, time.Now()),
+					},
+				},
+			},
+			appc.PlanSchedulable,
+			false,
+			nil,
+			[]apv1beta2.PlanCommandTargetStatus{
+				newWaveTargetStatus("worker0", appc.SignalCompleted, 0, time.Time{}),
+				newWaveTargetStatus("worker1", appc.SignalPending, 1, time.Time{}),
+			},
+		},
+
+		// Ensures that the next wave of workers isn't started as long as nodes of the previous
+		// wave are unhealthy.
+		{
+			"WorkersWaveUnhealthy",
+			[]crcli.Object{&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker0"}}},
+			apv1beta2.PlanCommand{
+				K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{
+					Targets: apv1beta2.PlanCommandTargets{
+						Workers: apv1beta2.PlanCommandTarget{
+							Limits: apv1beta2.PlanCommandTargetLimits{Concurrent: 1},
+							Waves: []apv1beta2.PlanCommandTargetWave{
+								{Size: intstr.FromInt32(1)},
+							},
+						},
+					},
+				},
+			},
+			apv1beta2.PlanCommandStatus{
+				State: appc.PlanSchedulableWait,
+				K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+					Workers: []apv1beta2.PlanCommandTargetStatus{
+						newWaveTargetStatus("worker0", appc.SignalCompleted, 0, time.Now()),
+						newWaveTargetStatus("worker1", appc.SignalPending, 1, time.Now()),
+					},
+				},
+			},
+			appc.PlanSchedulableWait,
+			true,
+			nil,
+			[]apv1beta2.PlanCommandTargetStatus{
+				newWaveTargetStatus("worker0", appc.SignalCompleted, 0, time.Time{}),
+				newWaveTargetStatus("worker1", appc.SignalPending, 1, time.Time{}),
+			},
+		},
+
 		// Covers the scenario of a v1.Node that contains autopilot state that indicates that
 		// an update has completed, with a different plan ID from the test data. This should
 		// result in the v1.Node autopilot state NOT getting reconciled as current, and ignored.
@@ -568,3 +692,62 @@ func TestSchedulableWait(t *testing.T) {
 		})
 	}
 }
+
+// TestSchedulableWaitProbesControllersOnce ensures that the readiness of the
+// controllers is probed only once per wave, as each probe covers all of them.
+func TestSchedulableWaitProbesControllersOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, apscheme.AddToScheme(scheme))
+	assert.NoError(t, v1.AddToScheme(scheme))
+
+	client := crfake.NewClientBuilder().WithObjects(
+		&apv1beta2.ControlNode{ObjectMeta: metav1.ObjectMeta{Name: "controller0"}},
+		&apv1beta2.ControlNode{ObjectMeta: metav1.ObjectMeta{Name: "controller1"}},
+		&apv1beta2.ControlNode{ObjectMeta: metav1.ObjectMeta{Name: "controller2"}},
+	).WithScheme(scheme).Build()
+
+	var probes int
+	provider := NewK0sUpdatePlanCommandProvider(
+		logrus.NewEntry(logrus.StandardLogger()),
+		client,
+		map[string]apdel.ControllerDelegate{
+			"controller": apdel.ControlNodeControllerDelegate(apdel.WithReadyForUpdateFunc(
+				func(context.Context, apv1beta2.PlanCommandK0sUpdateStatus, crcli.Object) apdel.K0sUpdateReadyStatus {
+					probes++
+					return apdel.CanUpdate
+				},
+			)),
+			"worker": apdel.NodeControllerDelegate(),
+		},
+		testutil.NewFakeClientFactory(),
+		[]string{},
+	)
+
+	command := apv1beta2.PlanCommand{
+		K0sUpdate: &apv1beta2.PlanCommandK0sUpdate{
+			Targets: apv1beta2.PlanCommandTargets{
+				Controllers: apv1beta2.PlanCommandTarget{
+					Waves: []apv1beta2.PlanCommandTargetWave{
+						{Size: intstr.FromInt32(2)},
+					},
+				},
+			},
+		},
+	}
+	status := apv1beta2.PlanCommandStatus{
+		State: appc.PlanSchedulableWait,
+		K0sUpdate: &apv1beta2.PlanCommandK0sUpdateStatus{
+			Controllers: []apv1beta2.PlanCommandTargetStatus{
+				newWaveTargetStatus("controller0", appc.SignalCompleted, 0, time.Now().Add(-time.Hour)),
+				newWaveTargetStatus("controller1", appc.SignalCompleted, 0, time.Now().Add(-time.Hour)),
+				newWaveTargetStatus("controller2", appc.SignalPending, 1, time.Now()),
+			},
+		},
+	}
+
+	nextState, retry, err := provider.SchedulableWait(t.Context(), "id123", command, &status)
+	assert.NoError(t, err)
+	assert.Equal(t, appc.PlanSchedulable, nextState)
+	assert.False(t, retry)
+	assert.Equal(t, 1, probes, "controllers should have been probed exactly once")
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// AssignWaves assigns every target to a rollout wave, in the order in which
+// the targets have been discovered. Targets that aren't covered by any of the
+// provided waves are assigned to a final, implicit wave.
+func AssignWaves(waves []apv1beta2.PlanCommandTargetWave, nodes []apv1beta2.PlanCommandTargetStatus) error {
+	total, idx := len(nodes), 0
+
+	for wave := range waves {
+		size, err := intstr.GetScaledValueFromIntOrPercent(&waves[wave].Size, total, true)
+		if err != nil {
+			return fmt.Errorf("invalid size for wave %d: %w", wave, err)
+		}
+		if size < 0 {
+			return fmt.Errorf("invalid size for wave %d: must not be negative", wave)
+		}
+
+		for end := min(idx+max(size, 1), total); idx < end; idx++ {
+			nodes[idx].Wave = wave
+		}
+	}
+
+	for ; idx < total; idx++ {
+		nodes[idx].Wave = len(waves)
+	}
+
+	return nil
+}
+
+// FindCurrentWave returns the index of the lowest wave that still contains
+// targets that aren't completed, along with those targets. If all targets are
+// completed, false is returned.
+func FindCurrentWave(nodes []apv1beta2.PlanCommandTargetStatus) (int, []apv1beta2.PlanCommandTargetStatus, bool) {
+	current, found := 0, false
+	for _, node := range nodes {
+		if node.State != appc.SignalCompleted && (!found || node.Wave < current) {
+			current, found = node.Wave, true
+		}
+	}
+
+	if !found {
+		return 0, nil, false
+	}
+
+	return current, FindInWave(nodes, current), true
+}
+
+// FindInWave returns all of the targets that are assigned to the provided wave.
+func FindInWave(nodes []apv1beta2.PlanCommandTargetStatus, wave int) []apv1beta2.PlanCommandTargetStatus {
+	var waveNodes []apv1beta2.PlanCommandTargetStatus
+
+	for _, node := range nodes {
+		if node.Wave == wave {
+			waveNodes = append(waveNodes, node)
+		}
+	}
+
+	return waveNodes
+}
+
+// WaveSoakRemaining determines how much of the soak time of the waves that
+// precede the provided wave remains. The soak time starts as soon as the last
+// target of the preceding non-empty wave has been completed.
+func WaveSoakRemaining(waves []apv1beta2.PlanCommandTargetWave, nodes []apv1beta2.PlanCommandTargetStatus, wave int, now time.Time) time.Duration {
+	for prev := wave - 1; prev >= 0; prev-- {
+		prevNodes := FindInWave(nodes, prev)
+		if len(prevNodes) == 0 {
+			continue
+		}
+
+		if prev >= len(waves) {
+			return 0
+		}
+
+		var completedAt time.Time
+		for _, node := range prevNodes {
+			if node.LastUpdatedTimestamp.After(completedAt) {
+				completedAt = node.LastUpdatedTimestamp.Time
+			}
+		}
+
+		return max(completedAt.Add(waves[prev].SoakTime.Duration).Sub(now), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TestAssignWaves ensures that targets get assigned to their waves in discovery order.
+func TestAssignWaves(t *testing.T) {
+	newNodes := func(count int) []apv1beta2.PlanCommandTargetStatus {
+		nodes := make([]apv1beta2.PlanCommandTargetStatus, count)
+		for i := range nodes {
+			nodes[i] = apv1beta2.NewPlanCommandTargetStatus("node", appc.SignalPending)
+		}
+		return nodes
+	}
+
+	waveIndexes := func(nodes []apv1beta2.PlanCommandTargetStatus) []int {
+		var waves []int
+		for _, node := range nodes {
+			waves = append(waves, node.Wave)
+		}
+		return waves
+	}
+
+	var tests = []struct {
+		name     string
+		waves    []apv1beta2.PlanCommandTargetWave
+		count    int
+		expected []int
+	}{
+		{"NoWaves", nil, 3, []int{0, 0, 0}},
+		{"CanaryThenRest", []apv1beta2.PlanCommandTargetWave{
+			{Size: intstr.FromInt32(1)},
+		}, 4, []int{0, 1, 1, 1}},
+		{"CanaryPercentThenRest", []apv1beta2.PlanCommandTargetWave{
+			{Size: intstr.FromInt32(1)},
+			{Size: intstr.FromString("10%")},
+		}, 21, []int{0, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}},
+		{"AtLeastOneNodePerWave", []apv1beta2.PlanCommandTargetWave{
+			{Size: intstr.FromString("0%")},
+			{Size: intstr.FromString("10%")},
+		}, 3, []int{0, 1, 2}},
+		{"MoreWavesThanNodes", []apv1beta2.PlanCommandTargetWave{
+			{Size: intstr.FromInt32(1)},
+			{Size: intstr.FromInt32(1)},
+			{Size: intstr.FromInt32(1)},
+		}, 2, []int{0, 1}},
+		{"CoveringAllNodes", []apv1beta2.PlanCommandTargetWave{
+			{Size: intstr.FromInt32(1)},
+			{Size: intstr.FromString("100%")},
+		}, 3, []int{0, 1, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodes := newNodes(test.count)
+			require.NoError(t, AssignWaves(test.waves, nodes))
+			assert.Equal(t, test.expected, waveIndexes(nodes))
+		})
+	}
+
+	t.Run("InvalidSize", func(t *testing.T) {
+		waves := []apv1beta2.PlanCommandTargetWave{{Size: intstr.FromString("lots")}}
+		assert.ErrorContains(t, AssignWaves(waves, newNodes(1)), "invalid size for wave 0")
+	})
+}
+
+// TestFindCurrentWave ensures that the lowest incomplete wave is found.
+func TestFindCurrentWave(t *testing.T) {
+	newNode := func(name string, state apv1beta2.PlanCommandTargetStateType, wave int) apv1beta2.PlanCommandTargetStatus {
+		node := apv1beta2.NewPlanCommandTargetStatus(name, state)
+		node.Wave = wave
+		return node
+	}
+
+	nodes := []apv1beta2.PlanCommandTargetStatus{
+		newNode("aaa", appc.SignalCompleted, 0),
+		newNode("bbb", appc.SignalCompleted, 1),
+		newNode("ccc", appc.SignalSent, 1),
+		newNode("ddd", appc.SignalPending, 2),
+	}
+
+	wave, waveNodes, found := FindCurrentWave(nodes)
+	assert.True(t, found)
+	assert.Equal(t, 1, wave)
+	if assert.Len(t, waveNodes, 2) {
+		assert.Equal(t, "bbb", waveNodes[0].Name)
+		assert.Equal(t, "ccc", waveNodes[1].Name)
+	}
+
+	_, _, found = FindCurrentWave([]apv1beta2.PlanCommandTargetStatus{
+		newNode("aaa", appc.SignalCompleted, 0),
+	})
+	assert.False(t, found)
+}
+
+// TestWaveSoakRemaining ensures that the soak time of the preceding non-empty wave is honored.
+func TestWaveSoakRemaining(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	newNode := func(completedAgo time.Duration, wave int) apv1beta2.PlanCommandTargetStatus {
+		return apv1beta2.PlanCommandTargetStatus{
+			State:                appc.SignalCompleted,
+			LastUpdatedTimestamp: metav1.NewTime(now.Add(-completedAgo)),
+			Wave:                 wave,
+		}
+	}
+
+	waves := []apv1beta2.PlanCommandTargetWave{
+		{Size: intstr.FromInt32(1), SoakTime: metav1.Duration{Duration: time.Hour}},
+		{Size: intstr.FromInt32(1), SoakTime: metav1.Duration{Duration: 10 * time.Minute}},
+	}
+
+	nodes := []apv1beta2.PlanCommandTargetStatus{
+		newNode(2*time.Hour, 0),
+		newNode(time.Minute, 1),
+		newNode(5*time.Minute, 1),
+	}
+
+	assert.Zero(t, WaveSoakRemaining(waves, nodes, 0, now))
+	assert.Zero(t, WaveSoakRemaining(waves, nodes, 1, now))
+	assert.Equal(t, 9*time.Minute, WaveSoakRemaining(waves, nodes, 2, now))
+	assert.Equal(t, 9*time.Minute, WaveSoakRemaining(waves, nodes, 3, now), "empty waves should be skipped")
+}
@@ -9,8 +9,12 @@ import (
 	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	"github.com/k0sproject/k0s/pkg/tracing"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	cr "sigs.k8s.io/controller-runtime"
 	crcli "sigs.k8s.io/controller-runtime/pkg/client"
 	crrec "sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -20,6 +24,8 @@ const (
 	defaultRequeueDuration = 5 * time.Second
 )
 
+var tracer = otel.Tracer("github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core")
+
 type planStateController struct {
 	name    string
 	logger  *logrus.Entry
@@ -40,7 +46,7 @@ func NewPlanStateController(name string, logger *logrus.Entry, client crcli.Clie
 
 // Reconcile performs the basic operations for every controller: obtain the plan, copy it,
 // delegate to the handler, and update status if available.
-func (c *planStateController) Reconcile(ctx context.Context, req cr.Request) (cr.Result, error) {
+func (c *planStateController) Reconcile(ctx context.Context, req cr.Request) (_ cr.Result, err error) {
 	logger := c.logger.WithField("controller", c.name)
 
 	plan := apv1beta2.Plan{}
@@ -49,6 +55,13 @@ func (c *planStateController) Reconcile(ctx context.Context, req cr.Request) (cr
 		return cr.Result{}, nil
 	}
 
+	ctx, span := tracer.Start(ctx, "Handle plan state", trace.WithAttributes(
+		attribute.String("k0s.autopilot.controller", c.name),
+		attribute.String("k0s.autopilot.plan", plan.Name),
+		attribute.String("k0s.autopilot.plan.state", plan.Status.State.String()),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	planCopy := plan.DeepCopy()
 
 	// Pass the processing along to the `PlanStateHandler`
@@ -58,9 +71,17 @@ func (c *planStateController) Reconcile(ctx context.Context, req cr.Request) (cr
 		// which we don't want. Requeuing is explicit on our side.
 
 		logger.Errorf("Unable to process state controller handler: %v", err)
+		tracing.RecordError(span, err)
 		return cr.Result{}, nil
 	}
 
+	if planCopy.Status.State != plan.Status.State {
+		span.AddEvent("transition", trace.WithAttributes(
+			attribute.String("k0s.autopilot.plan.state.from", plan.Status.State.String()),
+			attribute.String("k0s.autopilot.plan.state.to", planCopy.Status.State.String()),
+		))
+	}
+
 	if res == ProviderResultRetry {
 		c.logger.Info("Requeuing request due to explicit retry")
 		return cr.Result{RequeueAfter: defaultRequeueDuration}, nil
@@ -31,6 +31,15 @@ var (
 	SignalApplyFailed     apv1beta2.PlanCommandTargetStateType = "SignalApplyFailed"
 )
 
+// PlanCommandChartStatusType
+var (
+	ChartPending    apv1beta2.PlanCommandTargetStateType = "ChartPending"
+	ChartUpgrading  apv1beta2.PlanCommandTargetStateType = "ChartUpgrading"
+	ChartCompleted  apv1beta2.PlanCommandTargetStateType = "ChartCompleted"
+	ChartMissing    apv1beta2.PlanCommandTargetStateType = "ChartMissing"
+	ChartRolledBack apv1beta2.PlanCommandTargetStateType = "ChartRolledBack"
+)
+
 type ProviderResult int
 
 const (
@@ -6,12 +6,14 @@ package plans
 import (
 	"context"
 	"fmt"
+	"time"
 
 	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	apcomm "github.com/k0sproject/k0s/pkg/autopilot/common"
 	apconst "github.com/k0sproject/k0s/pkg/autopilot/constant"
 	apdel "github.com/k0sproject/k0s/pkg/autopilot/controller/delegate"
 	appagupdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/airgapupdate"
+	apphupdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/helmupdate"
 	appk0supdate "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/cmdprovider/k0supdate"
 	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
@@ -31,6 +33,7 @@ func RegisterControllers(ctx context.Context, logger *logrus.Entry, mgr crman.Ma
 	cmdProviders := []appc.PlanCommandProvider{
 		appk0supdate.NewK0sUpdatePlanCommandProvider(logger, mgr.GetClient(), controllerDelegateMap, cf, excludeFromPlans),
 		appagupdate.NewAirgapUpdatePlanCommandProvider(logger, mgr.GetClient(), controllerDelegateMap, cf, excludeFromPlans),
+		apphupdate.NewHelmUpdatePlanCommandProvider(logger, mgr.GetClient(), cf),
 	}
 
 	if leaderMode {
@@ -80,13 +83,13 @@ func registerSchedulableWaitStateController(logger *logrus.Entry, mgr crman.Mana
 }
 
 // registerSchedulableStateController registers the 'schedulable' plan state controller to
-// controller-runtime.
+// controller-runtime. Signaling is only performed within the cluster's maintenance windows.
 func registerSchedulableStateController(logger *logrus.Entry, mgr crman.Manager, providers []appc.PlanCommandProvider) error {
 	handler := appc.NewPlanStateHandler(
 		logger,
-		func(ctx context.Context, provider appc.PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+		withinMaintenanceWindows(logger, mgr.GetClient(), time.Now, func(ctx context.Context, provider appc.PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
 			return provider.Schedulable(ctx, planID, cmd, status)
-		},
+		}),
 		providers...,
 	)
 
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plans
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+
+	"github.com/sirupsen/logrus"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// withinMaintenanceWindows wraps a `PlanStateHandlerAdapter`, only delegating to
+// it while the cluster's maintenance windows allow it. Outside of the windows,
+// a retry is requested, so that processing resumes once the next window opens.
+func withinMaintenanceWindows(logger *logrus.Entry, client crcli.Client, now func() time.Time, adapter appc.PlanStateHandlerAdapter) appc.PlanStateHandlerAdapter {
+	logger = logger.WithField("component", "maintenancewindows")
+
+	// Only log at info level when entering or leaving the maintenance windows,
+	// as the retries happen frequently.
+	var outside atomic.Bool
+	var invalid atomic.Pointer[string]
+
+	return func(ctx context.Context, provider appc.PlanCommandProvider, planID string, cmd apv1beta2.PlanCommand, status *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+		var windows apv1beta2.MaintenanceWindowList
+		if err := client.List(ctx, &windows); err != nil {
+			logger.WithError(err).Warn("Unable to list maintenance windows, requesting retry")
+			return status.State, true, nil
+		}
+
+		open, err := windows.IsOpen(now())
+		if err != nil {
+			// Invalid windows won't fix themselves. Report them only once, and
+			// again whenever they change.
+			msg := err.Error()
+			if previous := invalid.Swap(&msg); previous == nil || *previous != msg {
+				logger.WithError(err).Error("Found invalid maintenance windows, they will never open")
+			}
+		} else {
+			invalid.Store(nil)
+		}
+
+		if !open {
+			if outside.CompareAndSwap(false, true) {
+				logger.Info("Outside of all maintenance windows, deferring plan processing")
+			}
+			logger.Debug("Outside of all maintenance windows, requesting retry")
+			return status.State, true, nil
+		}
+
+		if outside.CompareAndSwap(true, false) {
+			logger.Info("Maintenance window opened, resuming plan processing")
+		}
+
+		return adapter(ctx, provider, planID, cmd, status)
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	appc "github.com/k0sproject/k0s/pkg/autopilot/controller/plans/core"
+	apscheme "github.com/k0sproject/k0s/pkg/client/clientset/scheme"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	crcli "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestWithinMaintenanceWindows ensures that the wrapped adapter is only called
+// while the maintenance windows are open.
+func TestWithinMaintenanceWindows(t *testing.T) {
+	// A Monday
+	monday := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	window := &apv1beta2.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekly"},
+		Spec: apv1beta2.MaintenanceWindowSpec{
+			Periods: []apv1beta2.MaintenanceWindowPeriod{
+				{Days: []string{"Monday"}, StartTime: "22:00", Length: "4h"},
+			},
+		},
+	}
+
+	invalidWindow := &apv1beta2.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec: apv1beta2.MaintenanceWindowSpec{
+			Periods: []apv1beta2.MaintenanceWindowPeriod{
+				{StartTime: "22:00", Length: "forever"},
+			},
+		},
+	}
+
+	var tests = []struct {
+		name           string
+		objects        []crcli.Object
+		now            time.Time
+		expectedCalled bool
+	}{
+		{"NoWindows", nil, monday.Add(12 * time.Hour), true},
+		{"InsideWindow", []crcli.Object{window}, monday.Add(23 * time.Hour), true},
+		{"BeforeWindow", []crcli.Object{window}, monday.Add(21 * time.Hour), false},
+		{"OtherDay", []crcli.Object{window}, monday.Add(24*time.Hour + 23*time.Hour), false},
+		{"AfterMidnight", []crcli.Object{window}, monday.Add(24*time.Hour + time.Hour), true},
+		{"InvalidWindow", []crcli.Object{invalidWindow}, monday.Add(23 * time.Hour), false},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, apscheme.AddToScheme(scheme))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := crfake.NewClientBuilder().WithObjects(test.objects...).WithScheme(scheme).Build()
+
+			var called bool
+			adapter := withinMaintenanceWindows(
+				logrus.NewEntry(logrus.StandardLogger()),
+				client,
+				func() time.Time { return test.now },
+				func(context.Context, appc.PlanCommandProvider, string, apv1beta2.PlanCommand, *apv1beta2.PlanCommandStatus) (apv1beta2.PlanStateType, bool, error) {
+					called = true
+					return appc.PlanSchedulableWait, false, nil
+				},
+			)
+
+			status := apv1beta2.PlanCommandStatus{State: appc.PlanSchedulable}
+			nextState, retry, err := adapter(t.Context(), nil, "id123", apv1beta2.PlanCommand{}, &status)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCalled, called)
+
+			if test.expectedCalled {
+				assert.Equal(t, appc.PlanSchedulableWait, nextState)
+				assert.False(t, retry)
+			} else {
+				assert.Equal(t, appc.PlanSchedulable, nextState)
+				assert.True(t, retry)
+			}
+		})
+	}
+}
@@ -109,13 +109,12 @@ func (sc *rootController) createControlNode(ctx context.Context, name, nodeName
 		return err
 	}
 
-	node.Status = apv1beta2.ControlNodeStatus{
-		Addresses: []corev1.NodeAddress{
-			{Type: corev1.NodeInternalIP, Address: apiAddress},
-			{Type: corev1.NodeHostName, Address: nodeName},
-		},
-		K0sVersion: build.Version,
+	// The CPLB status is maintained by the control plane load balancer.
+	node.Status.Addresses = []corev1.NodeAddress{
+		{Type: corev1.NodeInternalIP, Address: apiAddress},
+		{Type: corev1.NodeHostName, Address: nodeName},
 	}
+	node.Status.K0sVersion = build.Version
 
 	logger.Infof("Updating controlnode status '%s'", name)
 	if node, err = client.AutopilotV1beta2().ControlNodes().UpdateStatus(ctx, node, metav1.UpdateOptions{}); err != nil {
@@ -11,8 +11,8 @@ import (
 )
 
 type Update struct {
-	Version      Version      `yaml:"version"`
-	DownloadURLs DownloadURLs `yaml:"downloadURLs"`
+	Version      Version      `yaml:"version" json:"version"`
+	DownloadURLs DownloadURLs `yaml:"downloadURLs" json:"downloadURLs"`
 }
 
 // DownloadURLs is a mapping from os-arch to download URLs
@@ -21,8 +21,8 @@ type DownloadURLs map[string]map[string]string
 type Version string
 
 type Channel struct {
-	Name     string    `yaml:"name"`
-	Versions []Version `yaml:"versions"`
+	Name     string    `yaml:"name" json:"name"`
+	Versions []Version `yaml:"versions" json:"versions"`
 }
 
 // The version comparison stuff is mostly copy-pasted from semver lib with the addition of dealing with
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package updateserver implements an autopilot update server that serves
+// update channels and their artifacts from a local directory.
+//
+// The directory is expected to contain one subdirectory per channel, each of
+// which holds an index.yaml file describing the latest version of that
+// channel, in the very same format as it is consumed by autopilot:
+//
+//	stable/
+//	  index.yaml
+//	  k0s-v1.35.1+k0s.0-amd64
+//	  k0s-airgap-bundle-v1.35.1+k0s.0-amd64
+//
+// The download URLs in the index may be relative, in which case they're
+// resolved against the channel's URL on this server.
+package updateserver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/k0sproject/k0s/pkg/autopilot/channels"
+	"github.com/k0sproject/k0s/pkg/autopilot/updater"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// The name of the file that describes a channel.
+const IndexFileName = "index.yaml"
+
+// Config configures the update server.
+type Config struct {
+	// DataDir is the directory from which channels and artifacts are served.
+	DataDir string
+
+	// BaseURL is the externally reachable URL of the update server. If empty,
+	// relative download URLs are resolved against the URL of the request.
+	BaseURL *url.URL
+
+	// Token is an optional bearer token that clients need to present when
+	// requesting channel metadata. Artifacts are served without
+	// authentication, since autopilot downloads them anonymously.
+	Token string
+}
+
+type server struct {
+	log    logrus.FieldLogger
+	config Config
+	files  fs.FS
+}
+
+// NewHandler returns an HTTP handler serving the update server API.
+//
+//   - GET /{channel}/index.yaml serves the channel metadata as consumed by
+//     UpdateConfigs using the periodic update strategy.
+//   - GET /{channel} serves the update metadata as consumed by UpdateConfigs
+//     using the cron update strategy.
+//   - GET /{channel}/{artifact...} serves the artifacts of a channel.
+func NewHandler(log logrus.FieldLogger, config Config) http.Handler {
+	s := &server{
+		log:    log,
+		config: config,
+		files:  os.DirFS(config.DataDir),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /{channel}/"+IndexFileName, s.authenticated(http.HandlerFunc(s.serveChannel)))
+	mux.Handle("GET /{channel}", s.authenticated(http.HandlerFunc(s.serveUpdate)))
+	mux.HandleFunc("GET /{channel}/{artifact...}", s.serveArtifact)
+
+	return mux
+}
+
+func (s *server) authenticated(next http.Handler) http.Handler {
+	if s.config.Token == "" {
+		return next
+	}
+
+	expected := []byte("Bearer " + s.config.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) serveChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := s.loadChannel(w, r)
+	if !ok {
+		return
+	}
+
+	s.writeYAML(w, channel)
+}
+
+func (s *server) serveUpdate(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.log.WithFields(logrus.Fields{
+		"channel":          r.PathValue("channel"),
+		"clusterID":        query.Get("clusterID"),
+		"lastUpdateStatus": query.Get("lastUpdateStatus"),
+		"currentVersion":   query.Get("currentVersion"),
+	}).Debug("Update requested")
+
+	channel, ok := s.loadChannel(w, r)
+	if !ok {
+		return
+	}
+
+	s.writeYAML(w, ToUpdate(&channel.VersionInfo))
+}
+
+func (s *server) serveArtifact(w http.ResponseWriter, r *http.Request) {
+	name := path.Join(r.PathValue("channel"), r.PathValue("artifact"))
+	if !fs.ValidPath(name) || strings.HasPrefix(path.Base(name), ".") {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Don't serve directory listings.
+	if stat, err := fs.Stat(s.files, name); err != nil || stat.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeFileFS(w, r, s.files, name)
+}
+
+func (s *server) loadChannel(w http.ResponseWriter, r *http.Request) (*channels.Channel, bool) {
+	name := r.PathValue("channel")
+	if !fs.ValidPath(name) || strings.HasPrefix(name, ".") {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	channel, err := LoadChannel(s.files, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, r)
+		return nil, false
+	}
+	if err != nil {
+		s.log.WithError(err).Error("Failed to load channel ", name)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if err := ResolveDownloadURLs(&channel.VersionInfo, s.channelURL(r, name)); err != nil {
+		s.log.WithError(err).Error("Failed to resolve download URLs of channel ", name)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return channel, true
+}
+
+// Returns the URL of the given channel, including a trailing slash, so that
+// relative download URLs are resolved within the channel.
+func (s *server) channelURL(r *http.Request, channel string) *url.URL {
+	var base url.URL
+	if s.config.BaseURL != nil {
+		base = *s.config.BaseURL
+	} else {
+		base.Scheme, base.Host = "http", r.Host
+		if r.TLS != nil {
+			base.Scheme = "https"
+		}
+	}
+
+	return base.JoinPath(channel, "/")
+}
+
+func (s *server) writeYAML(w http.ResponseWriter, obj any) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to marshal response")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	if _, err := w.Write(data); err != nil {
+		s.log.WithError(err).Debug("Failed to write response")
+	}
+}
+
+// LoadChannel reads the index of the given channel from files.
+func LoadChannel(files fs.FS, name string) (*channels.Channel, error) {
+	data, err := fs.ReadFile(files, path.Join(name, IndexFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var channel channels.Channel
+	if err := yaml.Unmarshal(data, &channel); err != nil {
+		return nil, fmt.Errorf("failed to parse index of channel %s: %w", name, err)
+	}
+	if channel.Channel == "" {
+		channel.Channel = name
+	}
+	if channel.Version == "" {
+		return nil, fmt.Errorf("index of channel %s doesn't specify a version", name)
+	}
+
+	return &channel, nil
+}
+
+// ResolveDownloadURLs resolves all relative download URLs of the given version
+// against the given base URL.
+func ResolveDownloadURLs(info *channels.VersionInfo, base *url.URL) error {
+	resolve := func(ref *string) error {
+		if *ref == "" {
+			return nil
+		}
+		u, err := url.Parse(*ref)
+		if err != nil {
+			return err
+		}
+		*ref = base.ResolveReference(u).String()
+		return nil
+	}
+
+	for i := range info.DownloadURLs {
+		downloadURL := &info.DownloadURLs[i]
+		if err := resolve(&downloadURL.K0S); err != nil {
+			return fmt.Errorf("invalid k0s URL for %s-%s: %w", downloadURL.OS, downloadURL.Arch, err)
+		}
+		if err := resolve(&downloadURL.AirgapBundle); err != nil {
+			return fmt.Errorf("invalid airgap bundle URL for %s-%s: %w", downloadURL.OS, downloadURL.Arch, err)
+		}
+	}
+
+	return nil
+}
+
+// ToUpdate converts the given version into the format that is served to
+// update clients.
+func ToUpdate(info *channels.VersionInfo) *updater.Update {
+	update := updater.Update{
+		Version:      updater.Version(info.Version),
+		DownloadURLs: updater.DownloadURLs{},
+	}
+
+	add := func(kind, osArch, url string) {
+		if url == "" {
+			return
+		}
+		if update.DownloadURLs[kind] == nil {
+			update.DownloadURLs[kind] = make(map[string]string)
+		}
+		update.DownloadURLs[kind][osArch] = url
+	}
+
+	for _, downloadURL := range info.DownloadURLs {
+		osArch := downloadURL.OS + "-" + downloadURL.Arch
+		add("k0s", osArch, downloadURL.K0S)
+		add("airgap", osArch, downloadURL.AirgapBundle)
+	}
+
+	return &update
+}
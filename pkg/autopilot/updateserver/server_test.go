@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package updateserver_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/autopilot/channels"
+	"github.com/k0sproject/k0s/pkg/autopilot/updater"
+	"github.com/k0sproject/k0s/pkg/autopilot/updateserver"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stableIndex = `
+version: v1.35.1+k0s.0
+downloadURLs:
+  - os: linux
+    arch: amd64
+    k0s: k0s-v1.35.1+k0s.0-amd64
+    k0sSha256: cafe
+    airgapBundle: https://mirror.example.com/airgap-amd64
+  - os: linux
+    arch: arm64
+    k0s: k0s-v1.35.1+k0s.0-arm64
+`
+
+func writeDataDir(t *testing.T) string {
+	dataDir := t.TempDir()
+	stable := filepath.Join(dataDir, "stable")
+	require.NoError(t, os.MkdirAll(filepath.Join(stable, "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stable, updateserver.IndexFileName), []byte(stableIndex), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(stable, "k0s-v1.35.1+k0s.0-amd64"), []byte("k0s binary"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(stable, ".hidden"), []byte("secret"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "top-level"), []byte("top"), 0644))
+	return dataDir
+}
+
+func TestHandler_Clients(t *testing.T) {
+	dataDir := writeDataDir(t)
+	server := httptest.NewServer(updateserver.NewHandler(logrus.StandardLogger(), updateserver.Config{
+		DataDir: dataDir,
+		Token:   "s3cr3t",
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("ChannelClient", func(t *testing.T) {
+		client, err := channels.NewChannelClient(server.URL, "stable", "s3cr3t")
+		require.NoError(t, err)
+
+		info, err := client.GetLatest(t.Context(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.35.1+k0s.0", info.Version)
+		assert.Equal(t, []channels.DownloadURL{{
+			OS:           "linux",
+			Arch:         "amd64",
+			K0S:          server.URL + "/stable/k0s-v1.35.1+k0s.0-amd64",
+			K0SSha256:    "cafe",
+			AirgapBundle: "https://mirror.example.com/airgap-amd64",
+		}, {
+			OS:   "linux",
+			Arch: "arm64",
+			K0S:  server.URL + "/stable/k0s-v1.35.1+k0s.0-arm64",
+		}}, info.DownloadURLs)
+	})
+
+	t.Run("UpdaterClient", func(t *testing.T) {
+		client, err := updater.NewClient(server.URL, "s3cr3t")
+		require.NoError(t, err)
+
+		update, err := client.GetUpdate("stable", "cluster", "Completed", "v1.35.0+k0s.0")
+		require.NoError(t, err)
+		assert.Equal(t, updater.Version("v1.35.1+k0s.0"), update.Version)
+		assert.Equal(t, updater.DownloadURLs{
+			"k0s": {
+				"linux-amd64": server.URL + "/stable/k0s-v1.35.1+k0s.0-amd64",
+				"linux-arm64": server.URL + "/stable/k0s-v1.35.1+k0s.0-arm64",
+			},
+			"airgap": {
+				"linux-amd64": "https://mirror.example.com/airgap-amd64",
+			},
+		}, update.DownloadURLs)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		client, err := channels.NewChannelClient(server.URL, "stable", "wrong")
+		require.NoError(t, err)
+		_, err = client.GetLatest(t.Context(), nil)
+		assert.ErrorContains(t, err, "401 Unauthorized")
+
+		updateClient, err := updater.NewClient(server.URL, "")
+		require.NoError(t, err)
+		_, err = updateClient.GetUpdate("stable", "", "", "")
+		assert.ErrorContains(t, err, "(401)")
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		client, err := channels.NewChannelClient(server.URL, "edge", "s3cr3t")
+		require.NoError(t, err)
+		_, err = client.GetLatest(t.Context(), nil)
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+}
+
+func TestHandler_Artifacts(t *testing.T) {
+	dataDir := writeDataDir(t)
+	handler := updateserver.NewHandler(logrus.StandardLogger(), updateserver.Config{
+		DataDir: dataDir,
+		BaseURL: &url.URL{Scheme: "https", Host: "updates.example.com", Path: "/k0s"},
+		Token:   "s3cr3t",
+	})
+
+	get := func(t *testing.T, path string) *http.Response {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Result()
+	}
+
+	t.Run("Artifact", func(t *testing.T) {
+		resp := get(t, "/stable/k0s-v1.35.1+k0s.0-amd64")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "k0s binary", string(body))
+	})
+
+	for _, test := range []struct {
+		path   string
+		status int
+	}{
+		{"/stable/nested", http.StatusNotFound},
+		{"/stable/nested/", http.StatusNotFound},
+		{"/stable/.hidden", http.StatusNotFound},
+		{"/stable/missing", http.StatusNotFound},
+		{"/stable/../top-level", http.StatusTemporaryRedirect},
+		{"/top-level", http.StatusUnauthorized},
+	} {
+		t.Run("Get"+test.path, func(t *testing.T) {
+			assert.Equal(t, test.status, get(t, test.path).StatusCode)
+		})
+	}
+
+	t.Run("BaseURL", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/stable/index.yaml", nil)
+		req.Header.Set("Authorization", "Bearer s3cr3t")
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "k0s: https://updates.example.com/k0s/stable/k0s-v1.35.1+k0s.0-amd64\n")
+	})
+}
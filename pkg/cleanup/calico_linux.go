@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// The route protocols used by Felix and BIRD.
+const (
+	rtprotFelix = 80
+	rtprotBIRD  = 12
+)
+
+type calicoNetwork struct{}
+
+// Name returns the name of the step
+func (calicoNetwork) Name() string {
+	return "Calico network leftovers cleanup step"
+}
+
+// Run removes the VXLAN interfaces and the routes that Calico programmed. The
+// routes would otherwise shadow the pod routes of another network provider.
+func (calicoNetwork) Run() error {
+	var errs []error
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to get link list from netlink: %w", err)
+	}
+	for _, l := range links {
+		if slices.Contains([]string{"vxlan.calico", "vxlan-v6.calico"}, l.Attrs().Name) {
+			if err := netlink.LinkDel(l); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete %s: %w", l.Attrs().Name, err))
+			}
+		}
+	}
+
+	for _, proto := range []netlink.RouteProtocol{rtprotFelix, rtprotBIRD} {
+		routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{
+			Protocol: proto,
+			Table:    unix.RT_TABLE_MAIN,
+		}, netlink.RT_FILTER_PROTOCOL|netlink.RT_FILTER_TABLE)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list routes: %w", err))
+			continue
+		}
+		for _, route := range routes {
+			if err := netlink.RouteDel(&route); err != nil && !errors.Is(err, unix.ESRCH) {
+				errs = append(errs, fmt.Errorf("failed to delete route %s: %w", route, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -25,6 +25,13 @@ func NewConfig(debug bool, k0sVars *config.CfgVars, systemUsers *k0sv1beta1.Syst
 	return &Config{cleanupSteps: steps}, nil
 }
 
+// NewNetworkProviderConfig returns a [Config] that removes the leftovers of the
+// given network provider from this host, e.g. after it has been replaced by
+// another network provider.
+func NewNetworkProviderConfig(provider string) *Config {
+	return &Config{cleanupSteps: networkProviderSteps(provider)}
+}
+
 func (c *Config) Cleanup() error {
 	var errs []error
 
@@ -5,9 +5,21 @@
 
 package cleanup
 
-type cni struct{}
+type cni struct {
+	// The network provider whose leftovers are to be removed. Removes the
+	// leftovers of all network providers if empty.
+	provider string
+}
 
 // Name returns the name of the step
 func (c *cni) Name() string {
+	if c.provider != "" {
+		return c.provider + " CNI leftovers cleanup step"
+	}
 	return "CNI leftovers cleanup step"
 }
+
+// Returns true if the leftovers of the given network provider are to be removed.
+func (c *cni) includes(provider string) bool {
+	return c.provider == "" || c.provider == provider
+}
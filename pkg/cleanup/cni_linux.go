@@ -11,6 +11,8 @@ import (
 	"io/fs"
 	"os"
 
+	"github.com/k0sproject/k0s/pkg/constant"
+
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,10 +20,18 @@ import (
 func (c *cni) Run() error {
 	var errs []error
 
-	files := []string{
-		"/etc/cni/net.d/10-calico.conflist",
-		"/etc/cni/net.d/calico-kubeconfig",
-		"/etc/cni/net.d/10-kuberouter.conflist",
+	var files []string
+	if c.includes(constant.CNIProviderCalico) {
+		files = append(files,
+			"/etc/cni/net.d/10-calico.conflist",
+			"/etc/cni/net.d/calico-kubeconfig",
+		)
+	}
+	if c.includes(constant.CNIProviderKubeRouter) {
+		files = append(files, "/etc/cni/net.d/10-kuberouter.conflist")
+	}
+	if c.includes(constant.CNIProviderCilium) {
+		files = append(files, "/etc/cni/net.d/05-cilium.conflist")
 	}
 	for _, f := range files {
 		if err := os.Remove(f); err != nil && !errors.Is(err, fs.ErrNotExist) {
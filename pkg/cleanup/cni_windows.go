@@ -15,29 +15,38 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/k0sproject/k0s/pkg/constant"
+
 	"github.com/sirupsen/logrus"
 )
 
 // Run removes Windows CNI artifacts.
 func (c *cni) Run() error {
-	removeCNIConfigFiles()
-	removeCalicoServices()
-	cleanupHNSArtifacts()
-	cleanupVethernetAdapters()
-	cleanupCalicoEnvVars()
-	cleanupCalicoFirewallRules()
+	c.removeCNIConfigFiles()
+	if c.includes(constant.CNIProviderCalico) {
+		removeCalicoServices()
+		cleanupHNSArtifacts()
+		cleanupVethernetAdapters()
+		cleanupCalicoEnvVars()
+		cleanupCalicoFirewallRules()
+	}
 	return nil
 }
 
 // removeCNIConfigFiles deletes all known Windows CNI config artifacts from disk.
-func removeCNIConfigFiles() {
+func (c *cni) removeCNIConfigFiles() {
 	logrus.Debug("removing Windows CNI configuration files")
 	var errs []error
 
-	files := []string{
-		`C:\\etc\\cni\\net.d\\10-calico.conflist`,
-		`C:\\etc\\cni\\net.d\\calico-kubeconfig`,
-		`C:\\etc\\cni\\net.d\\10-kuberouter.conflist`,
+	var files []string
+	if c.includes(constant.CNIProviderCalico) {
+		files = append(files,
+			`C:\\etc\\cni\\net.d\\10-calico.conflist`,
+			`C:\\etc\\cni\\net.d\\calico-kubeconfig`,
+		)
+	}
+	if c.includes(constant.CNIProviderKubeRouter) {
+		files = append(files, `C:\\etc\\cni\\net.d\\10-kuberouter.conflist`)
 	}
 	for _, f := range files {
 		if err := os.Remove(f); err != nil && !errors.Is(err, fs.ErrNotExist) {
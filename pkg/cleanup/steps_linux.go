@@ -8,6 +8,7 @@ package cleanup
 import (
 	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/constant"
 )
 
 func buildSteps(debug bool, k0sVars *config.CfgVars, systemUsers *k0sv1beta1.SystemUser, criSocketFlag string) ([]Step, error) {
@@ -34,3 +35,16 @@ func buildSteps(debug bool, k0sVars *config.CfgVars, systemUsers *k0sv1beta1.Sys
 
 	return steps, nil
 }
+
+func networkProviderSteps(provider string) []Step {
+	steps := []Step{&cni{provider: provider}}
+
+	switch provider {
+	case constant.CNIProviderKubeRouter:
+		steps = append(steps, newBridgeStep())
+	case constant.CNIProviderCalico:
+		steps = append(steps, calicoNetwork{})
+	}
+
+	return steps
+}
@@ -17,3 +17,7 @@ import (
 func buildSteps(bool, *config.CfgVars, *k0sv1beta1.SystemUser, string) ([]Step, error) {
 	return nil, fmt.Errorf("%w on %s", errors.ErrUnsupported, runtime.GOOS)
 }
+
+func networkProviderSteps(string) []Step {
+	return nil
+}
@@ -29,3 +29,7 @@ func buildSteps(debug bool, k0sVars *config.CfgVars, _ *k0sv1beta1.SystemUser, c
 
 	return steps, nil
 }
+
+func networkProviderSteps(provider string) []Step {
+	return []Step{&cni{provider: provider}}
+}
@@ -16,6 +16,7 @@ import (
 type AutopilotV1beta2Interface interface {
 	RESTClient() rest.Interface
 	ControlNodesGetter
+	MaintenanceWindowsGetter
 	PlansGetter
 	UpdateConfigsGetter
 }
@@ -29,6 +30,10 @@ func (c *AutopilotV1beta2Client) ControlNodes() ControlNodeInterface {
 	return newControlNodes(c)
 }
 
+func (c *AutopilotV1beta2Client) MaintenanceWindows() MaintenanceWindowInterface {
+	return newMaintenanceWindows(c)
+}
+
 func (c *AutopilotV1beta2Client) Plans() PlanInterface {
 	return newPlans(c)
 }
@@ -19,6 +19,10 @@ func (c *FakeAutopilotV1beta2) ControlNodes() v1beta2.ControlNodeInterface {
 	return newFakeControlNodes(c)
 }
 
+func (c *FakeAutopilotV1beta2) MaintenanceWindows() v1beta2.MaintenanceWindowInterface {
+	return newFakeMaintenanceWindows(c)
+}
+
 func (c *FakeAutopilotV1beta2) Plans() v1beta2.PlanInterface {
 	return newFakePlans(c)
 }
@@ -7,6 +7,8 @@ package v1beta2
 
 type ControlNodeExpansion interface{}
 
+type MaintenanceWindowExpansion interface{}
+
 type PlanExpansion interface{}
 
 type UpdateConfigExpansion interface{}
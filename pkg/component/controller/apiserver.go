@@ -172,6 +172,13 @@ func (a *APIServer) buildSupervisor() (*supervisor.Supervisor, error) {
 		args["tls-cipher-suites"] = constant.AllowedTLS12CipherSuiteNames()
 	}
 
+	// The keyspace is compacted by k0s itself.
+	if storage := a.NodeConfig.Spec.Storage; storage.Type == v1beta1.EtcdStorageType &&
+		!storage.Etcd.IsExternalClusterUsed() && storage.Etcd.Compaction.IsEnabled() &&
+		args["etcd-compaction-interval"] == "" {
+		args["etcd-compaction-interval"] = "0"
+	}
+
 	if a.DisableEndpointReconciler {
 		args["endpoint-reconciler-type"] = "none"
 	}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/config"
@@ -244,3 +245,43 @@ func (a *apiServerSuite) TestCapNetBindServiceForLowPorts() {
 			"Port 80 should require CAP_NET_BIND_SERVICE capability")
 	})
 }
+
+func (a *apiServerSuite) TestEtcdCompactionInterval() {
+	k0sVars := &config.CfgVars{
+		BinDir:      "/var/lib/k0s/bin",
+		CertRootDir: "/var/lib/k0s/pki",
+		DataDir:     "/var/lib/k0s",
+		RunDir:      "/run/k0s",
+	}
+
+	buildArgs := func(clusterConfig *v1beta1.ClusterConfig) []string {
+		apiServer := &APIServer{
+			NodeConfig:     clusterConfig,
+			K0sVars:        k0sVars,
+			LogLevel:       "1",
+			executablePath: "/fake/path/kube-apiserver",
+		}
+		supervisor, err := apiServer.buildSupervisor()
+		a.Require().NoError(err)
+		return supervisor.Args
+	}
+
+	a.Run("compacted by the API server by default", func() {
+		a.NotContains(buildArgs(v1beta1.DefaultClusterConfig()), "--etcd-compaction-interval=0")
+	})
+
+	a.Run("compacted by k0s", func() {
+		clusterConfig := v1beta1.DefaultClusterConfig()
+		clusterConfig.Spec.Storage.Etcd.Compaction.Interval.Duration = 10 * time.Minute
+		a.Contains(buildArgs(clusterConfig), "--etcd-compaction-interval=0")
+	})
+
+	a.Run("user-provided interval wins", func() {
+		clusterConfig := v1beta1.DefaultClusterConfig()
+		clusterConfig.Spec.Storage.Etcd.Compaction.Interval.Duration = 10 * time.Minute
+		clusterConfig.Spec.API.ExtraArgs = map[string]string{"etcd-compaction-interval": "1h"}
+		args := buildArgs(clusterConfig)
+		a.Contains(args, "--etcd-compaction-interval=1h")
+		a.NotContains(args, "--etcd-compaction-interval=0")
+	})
+}
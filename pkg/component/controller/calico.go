@@ -9,10 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"os"
 	"path"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
@@ -34,8 +34,6 @@ import (
 var _ manager.Component = (*Calico)(nil)
 var _ manager.Reconciler = (*Calico)(nil)
 
-var calicoCRDOnce sync.Once
-
 // Calico is the Component interface implementation to manage Calico
 type Calico struct {
 	log                  logrus.FieldLogger
@@ -55,6 +53,7 @@ type Calico struct {
 type calicoReconcileConfig struct {
 	cluster *calicoClusterConfig
 	patches v1beta1.Patches
+	remove  bool
 }
 
 type calicoMode string
@@ -98,6 +97,7 @@ type calicoClusterConfig struct {
 	IPAutodetectionMethod      string
 	IPV6AutodetectionMethod    string
 	PullPolicy                 string
+	NodeAffinity               *cniNodeAffinity
 }
 
 // NewCalico creates new Calico reconciler component
@@ -145,14 +145,36 @@ func (c *Calico) Start(context.Context) error {
 		hasWin, hasWinChanged := c.hasWindowsNodes()
 
 		var retry <-chan time.Time
+		var crdsWritten bool
 
 		for {
 			switch {
-			case config == nil, hasWin == nil:
+			case config == nil:
+				c.log.Debug("Waiting for configuration")
+
+			case config.remove:
+				retry = nil
+				if err := c.removeManifests(); err != nil {
+					retry = time.After(10 * time.Second)
+					c.log.WithError(err).Error("Failed to remove manifests, retrying in 10 seconds")
+				} else {
+					crdsWritten = false
+					c.log.Info("Removed manifests")
+				}
+
+			case hasWin == nil:
 				c.log.Debug("Waiting for configuration")
 
 			default:
 				retry = nil
+				// Write the CRD definitions only once, they do not change during runtime
+				if !crdsWritten {
+					if err := c.dumpCRDs(config.patches); err != nil {
+						c.log.WithError(err).Error("Failed to write Calico CRDs")
+					} else {
+						crdsWritten = true
+					}
+				}
 				if err := c.processConfigChanges(&calicoConfig{
 					calicoNodeConfig:    &c.nodeConfig,
 					calicoClusterConfig: config.cluster,
@@ -207,7 +229,10 @@ func (c *Calico) Start(context.Context) error {
 func (c *Calico) dumpCRDs(patches v1beta1.Patches) error {
 	var emptyStruct struct{}
 
-	// Write the CRD definitions only at "boot", they do not change during runtime
+	if err := dir.Init(filepath.Join(c.manifestsDir, "calico_init"), constant.ManifestsDirMode); err != nil {
+		return err
+	}
+
 	crds, err := fs.ReadDir(static.CalicoManifests, "CustomResourceDefinition")
 	if err != nil {
 		return err
@@ -245,6 +270,12 @@ func (c *Calico) dumpCRDs(patches v1beta1.Patches) error {
 }
 
 func (c *Calico) processConfigChanges(newConfig *calicoConfig, patches v1beta1.Patches) error {
+	// The directory is gone if Calico has been replaced by another network
+	// provider in the meantime.
+	if err := dir.Init(filepath.Join(c.manifestsDir, "calico"), constant.ManifestsDirMode); err != nil {
+		return err
+	}
+
 	manifestDirectories, err := fs.ReadDir(static.CalicoManifests, ".")
 	if err != nil {
 		return fmt.Errorf("error retrieving calico manifests: %w, will retry", err)
@@ -292,21 +323,37 @@ func (c *Calico) processConfigChanges(newConfig *calicoConfig, patches v1beta1.P
 	return nil
 }
 
+// removeManifests removes all Calico manifests, so that the applier deletes
+// the corresponding resources from the cluster.
+func (c *Calico) removeManifests() error {
+	return errors.Join(
+		os.RemoveAll(filepath.Join(c.manifestsDir, "calico")),
+		os.RemoveAll(filepath.Join(c.manifestsDir, "calico_init")),
+	)
+}
+
 func (c *Calico) getConfig(clusterConfig *v1beta1.ClusterConfig) (*calicoClusterConfig, error) {
-	ipv6AutoDetectionMethod := clusterConfig.Spec.Network.Calico.IPAutodetectionMethod
-	if clusterConfig.Spec.Network.Calico.IPv6AutodetectionMethod != "" {
-		ipv6AutoDetectionMethod = clusterConfig.Spec.Network.Calico.IPv6AutodetectionMethod
+	// The config section may be absent when Calico is the network provider
+	// that is being migrated away from.
+	calico := clusterConfig.Spec.Network.Calico
+	if calico == nil {
+		calico = v1beta1.DefaultCalico()
+	}
+
+	ipv6AutoDetectionMethod := calico.IPAutodetectionMethod
+	if calico.IPv6AutodetectionMethod != "" {
+		ipv6AutoDetectionMethod = calico.IPv6AutodetectionMethod
 	}
 
 	primaryAFIPv4 := c.primaryAddressFamily == v1beta1.PrimaryFamilyIPv4
 	isDualStack := clusterConfig.Spec.Network.DualStack.Enabled
 	config := calicoClusterConfig{
-		MTU:                        clusterConfig.Spec.Network.Calico.MTU,
-		VxlanPort:                  clusterConfig.Spec.Network.Calico.VxlanPort,
-		VxlanVNI:                   clusterConfig.Spec.Network.Calico.VxlanVNI,
-		EnableWireguard:            clusterConfig.Spec.Network.Calico.EnableWireguard,
-		EnvVars:                    clusterConfig.Spec.Network.Calico.EnvVars,
-		FlexVolumeDriverPath:       clusterConfig.Spec.Network.Calico.FlexVolumeDriverPath,
+		MTU:                        calico.MTU,
+		VxlanPort:                  calico.VxlanPort,
+		VxlanVNI:                   calico.VxlanVNI,
+		EnableWireguard:            calico.EnableWireguard,
+		EnvVars:                    calico.EnvVars,
+		FlexVolumeDriverPath:       calico.FlexVolumeDriverPath,
 		EnableIPv4:                 isDualStack || primaryAFIPv4,
 		EnableIPv6:                 isDualStack || !primaryAFIPv4,
 		CalicoCNIImage:             clusterConfig.Spec.Images.Calico.CNI.URI(),
@@ -314,19 +361,19 @@ func (c *Calico) getConfig(clusterConfig *v1beta1.ClusterConfig) (*calicoCluster
 		CalicoNodeImage:            clusterConfig.Spec.Images.Calico.Node.URI(),
 		CalicoNodeWindowsImage:     clusterConfig.Spec.Images.Calico.Windows.Node.URI(),
 		CalicoKubeControllersImage: clusterConfig.Spec.Images.Calico.KubeControllers.URI(),
-		Overlay:                    clusterConfig.Spec.Network.Calico.Overlay,
-		IPAutodetectionMethod:      clusterConfig.Spec.Network.Calico.IPAutodetectionMethod,
+		Overlay:                    calico.Overlay,
+		IPAutodetectionMethod:      calico.IPAutodetectionMethod,
 		IPV6AutodetectionMethod:    ipv6AutoDetectionMethod,
 		PullPolicy:                 clusterConfig.Spec.Images.DefaultPullPolicy,
 	}
 
-	switch clusterConfig.Spec.Network.Calico.Mode {
+	switch calico.Mode {
 	case v1beta1.CalicoModeBIRD, v1beta1.CalicoModeIPIP:
 		config.Mode = calicoModeBIRD
 	case v1beta1.CalicoModeVXLAN:
 		config.Mode = calicoModeVXLAN
 	default:
-		return nil, fmt.Errorf("unsupported mode: %q", clusterConfig.Spec.Network.Calico.Mode)
+		return nil, fmt.Errorf("unsupported mode: %q", calico.Mode)
 	}
 
 	if isDualStack {
@@ -351,27 +398,32 @@ func (c *Calico) Stop() error {
 // Reconcile detects changes in configuration and applies them to the component
 func (c *Calico) Reconcile(_ context.Context, cfg *v1beta1.ClusterConfig) error {
 	c.log.Debug("reconcile method called for: Calico")
-	if cfg.Spec.Network.Provider != "calico" {
-		return nil
+	deployment, err := planCNIDeployment(c.manifestsDir, constant.CNIProviderCalico, cfg)
+	if err != nil {
+		return err
 	}
 
-	existingCNI := existingCNIProvider(c.manifestsDir)
-	if existingCNI != "" && existingCNI != constant.CNIProviderCalico {
-		return fmt.Errorf("cannot change CNI provider from %s to %s", existingCNI, constant.CNIProviderCalico)
+	switch {
+	case deployment.Remove:
+		c.config.Set(&calicoReconcileConfig{remove: true})
+		return nil
+	case !deployment.Deploy:
+		return nil
 	}
 
-	calicoCRDOnce.Do(func() {
-		if err := c.dumpCRDs(cfg.Spec.Network.Calico.Patches); err != nil {
-			c.log.Errorf("error dumping Calico CRDs: %v", err)
-		}
-	})
 	newConfig, err := c.getConfig(cfg)
 	if err != nil {
 		return fmt.Errorf("while generating Calico configuration: %w", err)
 	}
+	newConfig.NodeAffinity = deployment.NodeAffinity
+
+	var patches v1beta1.Patches
+	if cfg.Spec.Network.Calico != nil {
+		patches = cfg.Spec.Network.Calico.Patches
+	}
 	c.config.Set(&calicoReconcileConfig{
 		cluster: newConfig,
-		patches: cfg.Spec.Network.Calico.Patches,
+		patches: patches,
 	})
 	return nil
 }
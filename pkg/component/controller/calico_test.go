@@ -10,6 +10,8 @@ import (
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/stretchr/testify/assert"
@@ -78,6 +80,54 @@ func TestCalicoManifests(t *testing.T) {
 		spec.RequireContainerHasNoEnvVariable(t, "calico-node", "FELIX_WIREGUARDENABLED")
 	})
 
+	t.Run("must_restrict_nodes_during_network_provider_migration", func(t *testing.T) {
+		for _, test := range []struct{ name, provider, from, to string }{
+			{"to_calico", "calico", "kuberouter", "calico"},
+			// After a revert, Calico is the network provider that is being
+			// migrated away from. It must still only select its own nodes.
+			{"reverted", "kuberouter", "calico", "kuberouter"},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				clusterConfig := clusterConfig.DeepCopy()
+				clusterConfig.Spec.Network.Provider = test.provider
+				clusterConfig.Status = &v1beta1.ClusterConfigStatus{
+					NetworkProviderMigration: &v1beta1.NetworkProviderMigrationStatus{
+						From:  test.from,
+						To:    test.to,
+						Phase: v1beta1.NetworkProviderMigrationInProgress,
+					},
+				}
+
+				calico := newTestInstance(t)
+				deployment, err := planCNIDeployment(calico.manifestsDir, "calico", clusterConfig)
+				require.NoError(t, err)
+				require.True(t, deployment.Deploy)
+				cfg, err := calico.getConfig(clusterConfig)
+				require.NoError(t, err)
+				cfg.Mode = calicoModeVXLAN
+				cfg.NodeAffinity = deployment.NodeAffinity
+				require.NoError(t, calico.processConfigChanges(&calicoConfig{&calico.nodeConfig, cfg, true}, nil))
+
+				for _, name := range []string{"calico-node", "calico-node-windows"} {
+					daemonSetManifestRaw, err := os.ReadFile(filepath.Join(calico.manifestsDir, "calico", "calico-DaemonSet-"+name+".yaml"))
+					require.NoError(t, err, "must have daemon set %s", name)
+
+					var ds appsv1.DaemonSet
+					require.NoError(t, yaml.Unmarshal(daemonSetManifestRaw, &ds))
+					if affinity := ds.Spec.Template.Spec.Affinity; assert.NotNil(t, affinity, name) && assert.NotNil(t, affinity.NodeAffinity, name) {
+						assert.Equal(t, []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{{
+								Key:      "k0s.k0sproject.io/network-provider",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{"calico"},
+							}},
+						}}, affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, name)
+					}
+				}
+			})
+		}
+	})
+
 	t.Run("ip_autodetection", func(t *testing.T) {
 		t.Run("use_IPAutodetectionMethod_for_both_families_by_default", func(t *testing.T) {
 			calicoNetSpec := clusterConfig.Spec.Network.Calico
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	k0snet "github.com/k0sproject/k0s/internal/pkg/net"
+	"github.com/k0sproject/k0s/internal/pkg/templatewriter"
+	"github.com/k0sproject/k0s/internal/pkg/users"
+	"github.com/k0sproject/k0s/internal/sync/value"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/certificate"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/constant"
+	"github.com/k0sproject/k0s/static"
+
+	"github.com/sirupsen/logrus"
+)
+
+var _ manager.Component = (*Cilium)(nil)
+var _ manager.Reconciler = (*Cilium)(nil)
+
+// Cilium is the Component interface implementation to manage Cilium
+type Cilium struct {
+	log                  logrus.FieldLogger
+	nodeConfig           ciliumNodeConfig
+	primaryAddressFamily v1beta1.PrimaryAddressFamilyType
+	manifestsDir         string
+	certManager          certificate.Manager
+	caExpiry             time.Duration
+	certExpiry           time.Duration
+
+	config value.Latest[*ciliumReconcileConfig]
+	stop   func()
+}
+
+// Bundles everything a reconcile depends on, so that a change to any part of
+// it, including the patches, triggers a re-render.
+type ciliumReconcileConfig struct {
+	cluster *ciliumClusterConfig
+	patches v1beta1.Patches
+}
+
+type ciliumConfig struct {
+	*ciliumNodeConfig
+	*ciliumClusterConfig
+	HubbleCerts *hubbleCerts
+}
+
+// The key material that secures the Hubble API. The agents serve it via TLS,
+// and Hubble Relay authenticates against them with a client certificate while
+// serving TLS itself.
+type hubbleCerts struct {
+	CACert      string
+	Server      certificate.Certificate
+	RelayClient certificate.Certificate
+	RelayServer certificate.Certificate
+}
+
+type ciliumNodeConfig struct {
+	APIServer     *k0snet.HostPort
+	ClusterDomain string
+}
+
+type ciliumClusterConfig struct {
+	RoutingMode          v1beta1.CiliumRoutingMode
+	TunnelProtocol       v1beta1.CiliumTunnelProtocol
+	KubeProxyReplacement bool
+	MTU                  int
+	Hubble               bool
+	EnableIPv4           bool
+	EnableIPv6           bool
+	ClusterCIDRIPv4      string
+	ClusterCIDRIPv6      string
+
+	AgentImage       string
+	OperatorImage    string
+	HubbleRelayImage string
+	PullPolicy       string
+}
+
+// NewCilium creates new Cilium reconciler component
+func NewCilium(k0sVars *config.CfgVars, nodeConfig *v1beta1.ClusterConfig) (*Cilium, error) {
+	apiServer, err := nodeConfig.Spec.API.APIServerHostPort()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cilium{
+		log: logrus.WithFields(logrus.Fields{"component": "cilium"}),
+		nodeConfig: ciliumNodeConfig{
+			APIServer:     apiServer,
+			ClusterDomain: nodeConfig.Spec.Network.ClusterDomain,
+		},
+		primaryAddressFamily: nodeConfig.Spec.PrimaryAddressFamily(),
+		manifestsDir:         k0sVars.ManifestsDir,
+		certManager:          certificate.Manager{K0sVars: k0sVars},
+		caExpiry:             nodeConfig.Spec.API.CA.ExpiresAfter.Duration,
+		certExpiry:           nodeConfig.Spec.API.CA.CertificatesExpireAfter.Duration,
+	}, nil
+}
+
+// Init implements [manager.Component].
+func (c *Cilium) Init(context.Context) error {
+	return dir.Init(filepath.Join(c.manifestsDir, "cilium"), constant.ManifestsDirMode)
+}
+
+// Start implements [manager.Component].
+func (c *Cilium) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		config, configChanged := c.config.Peek()
+
+		var retry <-chan time.Time
+
+		for {
+			if config == nil {
+				c.log.Debug("Waiting for configuration")
+			} else {
+				retry = nil
+				if err := c.processConfigChanges(&ciliumConfig{
+					ciliumNodeConfig:    &c.nodeConfig,
+					ciliumClusterConfig: config.cluster,
+				}, config.patches); err != nil {
+					retry = time.After(10 * time.Second)
+					c.log.WithError(err).Error("Failed to process configuration changes, retrying in 10 seconds")
+				} else {
+					c.log.Info("Processed configuration changes")
+				}
+			}
+
+		waitForUpdates:
+			for {
+				select {
+				case <-configChanged:
+					var newConfig *ciliumReconcileConfig
+					newConfig, configChanged = c.config.Peek()
+					if updateIfChanged(&config, newConfig) {
+						c.log.Info("Cluster configuration changed")
+						break waitForUpdates
+					} else {
+						c.log.Debug("Cluster config unchanged")
+					}
+
+				case <-retry:
+					c.log.Debug("Attempting to process configuration again")
+					break waitForUpdates
+
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	c.stop = func() { cancel(); <-done }
+	return nil
+}
+
+func (c *Cilium) processConfigChanges(newConfig *ciliumConfig, patches v1beta1.Patches) error {
+	if newConfig.Hubble {
+		certs, err := c.ensureHubbleCerts()
+		if err != nil {
+			return fmt.Errorf("failed to ensure Hubble certificates: %w", err)
+		}
+		newConfig.HubbleCerts = certs
+	}
+
+	manifestDirectories, err := fs.ReadDir(static.CiliumManifests, ".")
+	if err != nil {
+		return fmt.Errorf("error retrieving cilium manifests: %w, will retry", err)
+	}
+
+	for _, entry := range manifestDirectories {
+		dir := entry.Name()
+		manifestPaths, err := fs.ReadDir(static.CiliumManifests, dir)
+		if err != nil {
+			return fmt.Errorf("error retrieving cilium manifests: %w, will retry", err)
+		}
+
+		for _, entry := range manifestPaths {
+			filename := entry.Name()
+			manifestName := fmt.Sprintf("cilium-%s-%s", dir, filename)
+			contents, err := fs.ReadFile(static.CiliumManifests, path.Join(dir, filename))
+			if err != nil {
+				return fmt.Errorf("can't find manifest %s: %w", manifestName, err)
+			}
+
+			var output bytes.Buffer
+			tw := templatewriter.TemplateWriter{
+				Name:     fmt.Sprintf("cilium-%s-%s", dir, strings.TrimSuffix(filename, filepath.Ext(filename))),
+				Template: string(contents),
+				Data:     newConfig,
+				Patches:  patches,
+			}
+			if err := tw.WriteToBuffer(&output); err != nil {
+				return fmt.Errorf("failed to render manifest %s: %w", manifestName, err)
+			}
+			perms := os.FileMode(constant.CertMode)
+			if dir == "Secret" {
+				perms = constant.CertSecureMode
+			}
+			if err := file.AtomicWithTarget(filepath.Join(c.manifestsDir, "cilium", manifestName)).
+				WithPermissions(perms).
+				Write(output.Bytes()); err != nil {
+				return fmt.Errorf("failed to write manifest %s: %w", manifestName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Issues the Hubble certificates. They're signed by a CA that is dedicated to
+// Hubble, so that they can't be used to authenticate against anything else in
+// the cluster. The CA is local to each controller: whenever another controller
+// takes over applying the manifests, all of the Secrets are replaced at once,
+// so agents and relay always agree on the CA.
+func (c *Cilium) ensureHubbleCerts() (*hubbleCerts, error) {
+	certDir := filepath.Join(c.certManager.K0sVars.CertRootDir, "hubble")
+	if err := dir.Init(certDir, constant.CertRootDirMode); err != nil {
+		return nil, err
+	}
+	if err := c.certManager.EnsureCA(filepath.Join("hubble", "ca"), "hubble-ca", c.caExpiry); err != nil {
+		return nil, err
+	}
+	caCert, err := os.ReadFile(filepath.Join(certDir, "ca.crt"))
+	if err != nil {
+		return nil, err
+	}
+
+	issue := func(name, cn string, hostnames ...string) (certificate.Certificate, error) {
+		return c.certManager.EnsureCertificate(certificate.Request{
+			Name:      filepath.Join("hubble", name),
+			CN:        cn,
+			O:         "hubble",
+			CACert:    filepath.Join(certDir, "ca.crt"),
+			CAKey:     filepath.Join(certDir, "ca.key"),
+			Hostnames: append([]string{cn}, hostnames...),
+		}, users.RootUID, c.certExpiry)
+	}
+
+	certs := hubbleCerts{CACert: string(caCert)}
+	// The relay verifies the agents' server names against the cluster name,
+	// which is always "default".
+	if certs.Server, err = issue("server", "*.default.hubble-grpc.cilium.io"); err != nil {
+		return nil, err
+	}
+	if certs.RelayClient, err = issue("relay-client", "*.hubble-relay.cilium.io"); err != nil {
+		return nil, err
+	}
+	if certs.RelayServer, err = issue("relay-server", "*.hubble-relay.cilium.io",
+		"hubble-relay",
+		"hubble-relay.kube-system",
+		"hubble-relay.kube-system.svc",
+		"hubble-relay.kube-system.svc."+c.nodeConfig.ClusterDomain,
+	); err != nil {
+		return nil, err
+	}
+
+	return &certs, nil
+}
+
+func (c *Cilium) getConfig(clusterConfig *v1beta1.ClusterConfig) *ciliumClusterConfig {
+	cilium := clusterConfig.Spec.Network.Cilium
+	primaryAFIPv4 := c.primaryAddressFamily == v1beta1.PrimaryFamilyIPv4
+	isDualStack := clusterConfig.Spec.Network.DualStack.Enabled
+
+	config := ciliumClusterConfig{
+		RoutingMode:          cilium.RoutingMode,
+		TunnelProtocol:       cilium.TunnelProtocol,
+		KubeProxyReplacement: cilium.KubeProxyReplacement,
+		MTU:                  cilium.MTU,
+		Hubble:               cilium.Hubble.Enabled,
+		EnableIPv4:           isDualStack || primaryAFIPv4,
+		EnableIPv6:           isDualStack || !primaryAFIPv4,
+		AgentImage:           clusterConfig.Spec.Images.Cilium.Agent.URI(),
+		OperatorImage:        clusterConfig.Spec.Images.Cilium.Operator.URI(),
+		HubbleRelayImage:     clusterConfig.Spec.Images.Cilium.HubbleRelay.URI(),
+		PullPolicy:           clusterConfig.Spec.Images.DefaultPullPolicy,
+	}
+
+	if isDualStack {
+		config.ClusterCIDRIPv4 = clusterConfig.Spec.Network.PodCIDR
+		config.ClusterCIDRIPv6 = clusterConfig.Spec.Network.DualStack.IPv6PodCIDR
+	} else if primaryAFIPv4 {
+		config.ClusterCIDRIPv4 = clusterConfig.Spec.Network.PodCIDR
+	} else {
+		config.ClusterCIDRIPv6 = clusterConfig.Spec.Network.PodCIDR
+	}
+
+	return &config
+}
+
+// Stop implements [manager.Component].
+func (c *Cilium) Stop() error {
+	if stop := c.stop; stop != nil {
+		stop()
+	}
+	return nil
+}
+
+// Reconcile detects changes in configuration and applies them to the component
+func (c *Cilium) Reconcile(_ context.Context, cfg *v1beta1.ClusterConfig) error {
+	c.log.Debug("reconcile method called for: Cilium")
+	if cfg.Spec.Network.Provider != constant.CNIProviderCilium {
+		return nil
+	}
+
+	existingCNI := existingCNIProvider(c.manifestsDir)
+	if existingCNI != "" && existingCNI != constant.CNIProviderCilium {
+		return fmt.Errorf("cannot change CNI provider from %s to %s", existingCNI, constant.CNIProviderCilium)
+	}
+
+	c.config.Set(&ciliumReconcileConfig{
+		cluster: c.getConfig(cfg),
+		patches: cfg.Spec.Network.Cilium.Patches,
+	})
+	return nil
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCiliumManifests(t *testing.T) {
+	newClusterConfig := func() *v1beta1.ClusterConfig {
+		clusterConfig := v1beta1.DefaultClusterConfig()
+		clusterConfig.Spec.Network.Provider = "cilium"
+		clusterConfig.Spec.Network.Cilium = v1beta1.DefaultCilium()
+		clusterConfig.Spec.Network.KubeRouter = nil
+		return clusterConfig
+	}
+
+	render := func(t *testing.T, clusterConfig *v1beta1.ClusterConfig) string {
+		k0sVars := &config.CfgVars{
+			CertRootDir:  t.TempDir(),
+			ManifestsDir: t.TempDir(),
+		}
+		cilium, err := NewCilium(k0sVars, clusterConfig)
+		require.NoError(t, err)
+		require.NoError(t, cilium.Init(t.Context()))
+		require.NoError(t, cilium.processConfigChanges(&ciliumConfig{
+			ciliumNodeConfig:    &cilium.nodeConfig,
+			ciliumClusterConfig: cilium.getConfig(clusterConfig),
+		}, clusterConfig.Spec.Network.Cilium.Patches))
+		return filepath.Join(k0sVars.ManifestsDir, "cilium")
+	}
+
+	readConfigMap := func(t *testing.T, dir string) map[string]string {
+		raw, err := os.ReadFile(filepath.Join(dir, "cilium-ConfigMap-cilium-config.yaml"))
+		require.NoError(t, err)
+		var configMap corev1.ConfigMap
+		require.NoError(t, yaml.Unmarshal(raw, &configMap))
+		return configMap.Data
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		dir := render(t, newClusterConfig())
+
+		assert.Equal(t, "cilium", existingCNIProvider(filepath.Dir(dir)))
+
+		data := readConfigMap(t, dir)
+		assert.Equal(t, "true", data["enable-ipv4"])
+		assert.Equal(t, "false", data["enable-ipv6"])
+		assert.Equal(t, "tunnel", data["routing-mode"])
+		assert.Equal(t, "vxlan", data["tunnel-protocol"])
+		assert.Equal(t, "false", data["kube-proxy-replacement"])
+		assert.Equal(t, "false", data["enable-hubble"])
+		assert.NotContains(t, data, "mtu")
+
+		raw, err := os.ReadFile(filepath.Join(dir, "cilium-DaemonSet-cilium.yaml"))
+		require.NoError(t, err)
+		var spec daemonSetContainersEnv
+		require.NoError(t, yaml.Unmarshal(raw, &spec))
+		spec.RequireContainerHasNoEnvVariable(t, "cilium-agent", "KUBERNETES_SERVICE_HOST")
+
+		for _, name := range []string{
+			"cilium-ConfigMap-hubble-relay-config.yaml",
+			"cilium-Deployment-hubble-relay.yaml",
+			"cilium-Secret-hubble-relay-client-certs.yaml",
+			"cilium-Secret-hubble-relay-server-certs.yaml",
+			"cilium-Secret-hubble-server-certs.yaml",
+			"cilium-Service-hubble-peer.yaml",
+			"cilium-Service-hubble-relay.yaml",
+			"cilium-ServiceAccount-hubble-relay.yaml",
+		} {
+			content, err := os.ReadFile(filepath.Join(dir, name))
+			if assert.NoError(t, err, name) {
+				assert.Empty(t, content, name)
+			}
+		}
+	})
+
+	t.Run("native_routing_dual_stack", func(t *testing.T) {
+		clusterConfig := newClusterConfig()
+		clusterConfig.Spec.Network.Cilium.RoutingMode = v1beta1.CiliumRoutingModeNative
+		clusterConfig.Spec.Network.Cilium.MTU = 1400
+		clusterConfig.Spec.Network.DualStack.Enabled = true
+		clusterConfig.Spec.Network.DualStack.IPv6PodCIDR = "fd00::/108"
+
+		data := readConfigMap(t, render(t, clusterConfig))
+		assert.Equal(t, "true", data["enable-ipv4"])
+		assert.Equal(t, "true", data["enable-ipv6"])
+		assert.Equal(t, "native", data["routing-mode"])
+		assert.NotContains(t, data, "tunnel-protocol")
+		assert.Equal(t, "true", data["auto-direct-node-routes"])
+		assert.Equal(t, clusterConfig.Spec.Network.PodCIDR, data["ipv4-native-routing-cidr"])
+		assert.Equal(t, "fd00::/108", data["ipv6-native-routing-cidr"])
+		assert.Equal(t, "1400", data["mtu"])
+	})
+
+	t.Run("kube_proxy_replacement", func(t *testing.T) {
+		clusterConfig := newClusterConfig()
+		clusterConfig.Spec.Network.Cilium.KubeProxyReplacement = true
+		clusterConfig.Spec.Network.KubeProxy.Disabled = true
+		clusterConfig.Spec.API.Address = "192.0.2.10"
+
+		dir := render(t, clusterConfig)
+		assert.Equal(t, "true", readConfigMap(t, dir)["kube-proxy-replacement"])
+
+		for _, manifest := range []struct{ file, container string }{
+			{"cilium-DaemonSet-cilium.yaml", "cilium-agent"},
+			{"cilium-Deployment-cilium-operator.yaml", "cilium-operator"},
+		} {
+			raw, err := os.ReadFile(filepath.Join(dir, manifest.file))
+			require.NoError(t, err)
+			var spec daemonSetContainersEnv
+			require.NoError(t, yaml.Unmarshal(raw, &spec))
+			spec.RequireContainerHasEnvVariable(t, manifest.container, "KUBERNETES_SERVICE_HOST", "192.0.2.10")
+			spec.RequireContainerHasEnvVariable(t, manifest.container, "KUBERNETES_SERVICE_PORT", "6443")
+		}
+	})
+
+	t.Run("hubble", func(t *testing.T) {
+		clusterConfig := newClusterConfig()
+		clusterConfig.Spec.Network.Cilium.Hubble.Enabled = true
+
+		dir := render(t, clusterConfig)
+		data := readConfigMap(t, dir)
+		assert.Equal(t, "true", data["enable-hubble"])
+		assert.NotContains(t, data, "hubble-disable-tls")
+		assert.Equal(t, "/var/lib/cilium/tls/hubble/server.crt", data["hubble-tls-cert-file"])
+
+		raw, err := os.ReadFile(filepath.Join(dir, "cilium-Deployment-hubble-relay.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), clusterConfig.Spec.Images.Cilium.HubbleRelay.URI())
+
+		raw, err = os.ReadFile(filepath.Join(dir, "cilium-ConfigMap-hubble-relay-config.yaml"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "disable-client-tls")
+		assert.NotContains(t, string(raw), "disable-server-tls")
+
+		secret := func(name string) *corev1.Secret {
+			path := filepath.Join(dir, "cilium-Secret-"+name+".yaml")
+			stat, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(constant.CertSecureMode), stat.Mode().Perm())
+			raw, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var secret corev1.Secret
+			require.NoError(t, yaml.Unmarshal(raw, &secret))
+			return &secret
+		}
+
+		server := secret("hubble-server-certs")
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(server.Data["ca.crt"]))
+
+		for name, hostname := range map[string]string{
+			"hubble-server-certs":       "worker-0.default.hubble-grpc.cilium.io",
+			"hubble-relay-client-certs": "ui.hubble-relay.cilium.io",
+			"hubble-relay-server-certs": "hubble-relay.kube-system.svc.cluster.local",
+		} {
+			secret := secret(name)
+			assert.Equal(t, server.Data["ca.crt"], secret.Data["ca.crt"], name)
+			pair, err := tls.X509KeyPair(secret.Data["tls.crt"], secret.Data["tls.key"])
+			require.NoError(t, err, name)
+			_, err = pair.Leaf.Verify(x509.VerifyOptions{
+				DNSName:   hostname,
+				Roots:     roots,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			assert.NoError(t, err, name)
+		}
+	})
+}
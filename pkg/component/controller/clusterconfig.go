@@ -20,6 +20,7 @@ import (
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/leaderelection"
+	"github.com/k0sproject/k0s/pkg/tracing"
 	"github.com/k0sproject/k0s/static"
 
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/yaml"
 )
 
@@ -66,12 +69,7 @@ func (r *ClusterConfigReconciler) Start(ctx context.Context) error {
 					r.log.Debug("config source closed channel")
 					return
 				}
-				err := errors.Join(clusterConfig.Validate()...)
-				if err != nil {
-					err = fmt.Errorf("failed to validate cluster configuration: %w", err)
-				} else {
-					err = r.reconciler.Reconcile(ctx, clusterConfig)
-				}
+				err := r.reconcile(ctx, clusterConfig)
 				r.reportStatus(statusCtx, clusterConfig, err)
 				if err != nil {
 					r.log.WithError(err).Error("Failed to reconcile cluster configuration")
@@ -87,6 +85,19 @@ func (r *ClusterConfigReconciler) Start(ctx context.Context) error {
 	return nil
 }
 
+func (r *ClusterConfigReconciler) reconcile(ctx context.Context, clusterConfig *k0sv1beta1.ClusterConfig) (err error) {
+	ctx, span := tracer.Start(ctx, "Reconcile cluster configuration", trace.WithNewRoot(), trace.WithAttributes(
+		attribute.String("k0s.clusterconfig.resource_version", clusterConfig.ResourceVersion),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	if err := errors.Join(clusterConfig.Validate()...); err != nil {
+		return fmt.Errorf("failed to validate cluster configuration: %w", err)
+	}
+
+	return r.reconciler.Reconcile(ctx, clusterConfig)
+}
+
 // Stop stops
 func (r *ClusterConfigReconciler) Stop() error {
 	// Nothing really to stop, the main ConfigSource "watch" channel go-routine is stopped
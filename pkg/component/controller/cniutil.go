@@ -4,9 +4,11 @@
 package controller
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/k0sproject/k0s/internal/pkg/file"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 )
 
 func existingCNIProvider(manifestDir string) string {
@@ -20,5 +22,74 @@ func existingCNIProvider(manifestDir string) string {
 		return "kuberouter"
 	}
 
+	ciliumManifestPath := filepath.Join(manifestDir, "cilium", "cilium-DaemonSet-cilium.yaml")
+	if file.Exists(ciliumManifestPath) {
+		return "cilium"
+	}
+
 	return ""
 }
+
+// cniNodeAffinity restricts the DaemonSets of a network provider to the nodes
+// whose network provider label matches the given provider.
+type cniNodeAffinity struct {
+	Provider string
+}
+
+// cniDeployment describes what to do with the manifests of a network provider.
+type cniDeployment struct {
+	// Deploy is set if the manifests should be written.
+	Deploy bool
+	// Remove is set if the manifests should be removed.
+	Remove bool
+	// NodeAffinity is set if the network provider should only run on some
+	// nodes, as it's the case during a network provider migration.
+	NodeAffinity *cniNodeAffinity
+}
+
+// planCNIDeployment determines what to do with the manifests of the given
+// network provider, taking network provider migrations into account.
+func planCNIDeployment(manifestsDir, provider string, clusterConfig *v1beta1.ClusterConfig) (*cniDeployment, error) {
+	configured := clusterConfig.Spec.Network.Provider
+
+	// During a migration, both network providers are deployed side by side.
+	// Each node is labeled with the network provider it's supposed to run. The
+	// labels are in place before the migration starts, so that unlabeled nodes
+	// are never picked up by either network provider, not even when the
+	// migration is reverted.
+	if migration := clusterConfig.Status.ActiveNetworkProviderMigration(); migration != nil {
+		switch provider {
+		case migration.To, migration.From:
+			return &cniDeployment{Deploy: true, NodeAffinity: &cniNodeAffinity{provider}}, nil
+		default:
+			return &cniDeployment{}, nil
+		}
+	}
+
+	// Once the migration is completed, the old network provider gets removed.
+	if status := clusterConfig.Status; status != nil {
+		if migration := status.NetworkProviderMigration; migration != nil &&
+			migration.Phase == v1beta1.NetworkProviderMigrationCompleted &&
+			migration.To == configured {
+			switch provider {
+			case configured:
+				return &cniDeployment{Deploy: true}, nil
+			case migration.From:
+				return &cniDeployment{Remove: true}, nil
+			default:
+				return &cniDeployment{}, nil
+			}
+		}
+	}
+
+	if configured != provider {
+		return &cniDeployment{}, nil
+	}
+
+	existingCNI := existingCNIProvider(manifestsDir)
+	if existingCNI != "" && existingCNI != provider {
+		return nil, fmt.Errorf("cannot change CNI provider from %s to %s", existingCNI, provider)
+	}
+
+	return &cniDeployment{Deploy: true}, nil
+}
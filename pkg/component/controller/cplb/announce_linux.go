@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// announceAddress tells the neighbors on the link with the given index and
+// hardware address that ip is now reachable via this link, so that they update
+// their ARP or neighbor caches. IPv4 addresses are announced via gratuitous
+// ARP, IPv6 addresses via unsolicited neighbor advertisements.
+func announceAddress(ifIndex int, hwAddr net.HardwareAddr, ip net.IP) error {
+	if len(hwAddr) != 6 {
+		return fmt.Errorf("link has no Ethernet address: %q", hwAddr)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return sendGratuitousARP(ifIndex, hwAddr, ip4)
+	}
+	return sendUnsolicitedNA(ifIndex, hwAddr, ip)
+}
+
+func sendGratuitousARP(ifIndex int, hwAddr net.HardwareAddr, ip net.IP) (err error) {
+	proto := htons(unix.ETH_P_ARP)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(proto))
+	if err != nil {
+		return os.NewSyscallError("socket", err)
+	}
+	defer func() { err = errors.Join(err, unix.Close(fd)) }()
+
+	addr := unix.SockaddrLinklayer{Protocol: proto, Ifindex: ifIndex, Halen: 6}
+	copy(addr.Addr[:], ethernetBroadcast)
+	return os.NewSyscallError("sendto", unix.Sendto(fd, gratuitousARP(hwAddr, ip), 0, &addr))
+}
+
+var ethernetBroadcast = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// Builds an Ethernet frame containing a gratuitous ARP request as described in
+// RFC 5227, section 3: Both the sender and the target protocol address are set
+// to the announced address.
+func gratuitousARP(hwAddr net.HardwareAddr, ip net.IP) []byte {
+	frame := make([]byte, 0, 42)
+	frame = append(frame, ethernetBroadcast...)                  // destination
+	frame = append(frame, hwAddr...)                             // source
+	frame = binary.BigEndian.AppendUint16(frame, unix.ETH_P_ARP) // EtherType
+	frame = binary.BigEndian.AppendUint16(frame, 1)              // hardware type: Ethernet
+	frame = binary.BigEndian.AppendUint16(frame, unix.ETH_P_IP)  // protocol type: IPv4
+	frame = append(frame, 6, 4)                                  // hardware and protocol address lengths
+	frame = binary.BigEndian.AppendUint16(frame, 1)              // operation: request
+	frame = append(frame, hwAddr...)                             // sender hardware address
+	frame = append(frame, ip.To4()...)                           // sender protocol address
+	frame = append(frame, make([]byte, 6)...)                    // target hardware address
+	return append(frame, ip.To4()...)                            // target protocol address
+}
+
+func sendUnsolicitedNA(ifIndex int, hwAddr net.HardwareAddr, ip net.IP) (err error) {
+	fd, err := unix.Socket(unix.AF_INET6, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.IPPROTO_ICMPV6)
+	if err != nil {
+		return os.NewSyscallError("socket", err)
+	}
+	defer func() { err = errors.Join(err, unix.Close(fd)) }()
+
+	// Neighbor discovery messages are only accepted with a hop limit of 255.
+	// The kernel fills in the ICMPv6 checksum.
+	if err := unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_MULTICAST_HOPS, 255); err != nil {
+		return os.NewSyscallError("setsockopt", err)
+	}
+	if err := unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_MULTICAST_IF, ifIndex); err != nil {
+		return os.NewSyscallError("setsockopt", err)
+	}
+
+	// Send to the all-nodes multicast address.
+	addr := unix.SockaddrInet6{ZoneId: uint32(ifIndex)}
+	copy(addr.Addr[:], net.IPv6linklocalallnodes)
+	return os.NewSyscallError("sendto", unix.Sendto(fd, unsolicitedNA(hwAddr, ip), 0, &addr))
+}
+
+// Builds an ICMPv6 neighbor advertisement message as described in RFC 4861,
+// section 4.4, with the override flag set and the target link-layer address
+// option included, as required for unsolicited advertisements (section 7.2.6).
+// The checksum is left empty.
+func unsolicitedNA(hwAddr net.HardwareAddr, ip net.IP) []byte {
+	msg := make([]byte, 0, 32)
+	msg = append(msg, 136, 0, 0, 0)  // type: neighbor advertisement, code, checksum
+	msg = append(msg, 0x20, 0, 0, 0) // flags: override, reserved
+	msg = append(msg, ip.To16()...)  // target address
+	msg = append(msg, 2, 1)          // option: target link-layer address, 8 bytes long
+	return append(msg, hwAddr...)    // link-layer address
+}
+
+// Converts v to network byte order.
+func htons(v uint16) uint16 {
+	return binary.NativeEndian.Uint16(binary.BigEndian.AppendUint16(nil, v))
+}
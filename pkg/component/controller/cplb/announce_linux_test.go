@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestGratuitousARP(t *testing.T) {
+	mac, err := net.ParseMAC("02:00:5e:10:00:01")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // destination
+		0x02, 0x00, 0x5e, 0x10, 0x00, 0x01, // source
+		0x08, 0x06, // EtherType: ARP
+		0x00, 0x01, // hardware type: Ethernet
+		0x08, 0x00, // protocol type: IPv4
+		6, 4, // address lengths
+		0x00, 0x01, // operation: request
+		0x02, 0x00, 0x5e, 0x10, 0x00, 0x01, // sender hardware address
+		192, 168, 1, 100, // sender protocol address
+		0, 0, 0, 0, 0, 0, // target hardware address
+		192, 168, 1, 100, // target protocol address
+	}, gratuitousARP(mac, net.ParseIP("192.168.1.100")))
+}
+
+func TestUnsolicitedNA(t *testing.T) {
+	mac, err := net.ParseMAC("02:00:5e:10:00:01")
+	require.NoError(t, err)
+	ip := net.ParseIP("2001:db8::100")
+
+	msg := unsolicitedNA(mac, ip)
+	require.Len(t, msg, 32)
+	assert.Equal(t, []byte{136, 0, 0, 0}, msg[:4], "type, code and checksum")
+	assert.Equal(t, []byte{0x20, 0, 0, 0}, msg[4:8], "flags")
+	assert.Equal(t, []byte(ip), msg[8:24], "target address")
+	assert.Equal(t, []byte{2, 1, 0x02, 0x00, 0x5e, 0x10, 0x00, 0x01}, msg[24:], "target link-layer address option")
+}
+
+func TestParseVirtualIPs(t *testing.T) {
+	vips, err := parseVirtualIPs([]string{"192.168.1.100/24", "2001:db8::100/64"})
+	require.NoError(t, err)
+	require.Len(t, vips, 2)
+	assert.Equal(t, "192.168.1.100/24", vips[0].IPNet.String())
+	assert.Zero(t, vips[0].Flags)
+	assert.Equal(t, "2001:db8::100/64", vips[1].IPNet.String())
+	assert.Equal(t, unix.IFA_F_NODAD, vips[1].Flags)
+
+	_, err = parseVirtualIPs([]string{"192.168.1.100"})
+	assert.ErrorContains(t, err, "failed to parse CIDR 192.168.1.100")
+}
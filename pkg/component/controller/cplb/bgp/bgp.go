@@ -0,0 +1,411 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bgp implements a minimal BGP-4 speaker (RFC 4271) that advertises a
+// set of host routes to its peers. It doesn't accept any routes from its peers,
+// so it doesn't need a routing table. IPv6 routes are advertised via the
+// multiprotocol extensions (RFC 4760), over IPv6 sessions only. Sessions may be
+// protected by TCP MD5 signatures (RFC 2385).
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Speaker advertises host routes to its peers. Set the fields before calling
+// Start, and use Advertise to change the advertised routes at any time.
+type Speaker struct {
+	// LocalASN is the autonomous system number of the speaker.
+	LocalASN uint32
+
+	// RouterID is the BGP identifier of the speaker. Needs to be an IPv4
+	// address.
+	RouterID netip.Addr
+
+	// HoldTime is the hold time proposed to the peers. If zero, 90 seconds
+	// are used.
+	HoldTime time.Duration
+
+	// ConnectRetryTime is the time to wait before reconnecting to a peer
+	// after a session failed. If zero, 5 seconds are used.
+	ConnectRetryTime time.Duration
+
+	// Peers is the list of peers to connect to.
+	Peers []Peer
+
+	// Log is the logger of the speaker. If nil, the standard logger is used.
+	Log logrus.FieldLogger
+
+	mu       sync.Mutex
+	prefixes []netip.Prefix
+	sessions map[*session]struct{}
+	stop     func()
+}
+
+// Peer is a BGP peer of a Speaker.
+type Peer struct {
+	Addr netip.AddrPort
+	ASN  uint32
+
+	// Password enables TCP MD5 signatures (RFC 2385) for the session, if
+	// not empty. Only supported on Linux.
+	Password string
+}
+
+// The maximum length of a TCP MD5 signature password, as imposed by Linux.
+const maxPasswordLen = 80
+
+// Start starts connecting to the peers. The speaker keeps reconnecting to
+// them until it's closed.
+func (s *Speaker) Start() error {
+	if s.LocalASN == 0 {
+		return errors.New("local ASN is zero")
+	}
+	if !s.RouterID.Is4() {
+		return fmt.Errorf("router ID is not an IPv4 address: %s", s.RouterID)
+	}
+	if s.HoldTime != 0 && (s.HoldTime < 3*time.Second || s.HoldTime > 0xffff*time.Second) {
+		return fmt.Errorf("hold time out of range: %s", s.HoldTime)
+	}
+	for _, peer := range s.Peers {
+		if len(peer.Password) > maxPasswordLen {
+			return fmt.Errorf("password of peer %s exceeds %d bytes", peer.Addr, maxPasswordLen)
+		}
+	}
+	if s.Log == nil {
+		s.Log = logrus.StandardLogger()
+	}
+
+	s.mu.Lock()
+	s.sessions = make(map[*session]struct{})
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, peer := range s.Peers {
+		wg.Go(func() { s.connect(ctx, peer) })
+	}
+
+	s.stop = func() { cancel(); wg.Wait() }
+	return nil
+}
+
+// Advertise replaces the advertised routes with the given prefixes. Passing no
+// prefixes withdraws all routes.
+func (s *Speaker) Advertise(prefixes ...netip.Prefix) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prefixes = slices.Clone(prefixes)
+	for session := range s.sessions {
+		session.notify()
+	}
+}
+
+// Close shuts down all sessions, which makes the peers withdraw the routes.
+func (s *Speaker) Close() error {
+	if s.stop != nil {
+		s.stop()
+	}
+	return nil
+}
+
+func (s *Speaker) holdTime() time.Duration {
+	if s.HoldTime != 0 {
+		return s.HoldTime
+	}
+	return 90 * time.Second
+}
+
+func (s *Speaker) connectRetryTime() time.Duration {
+	if s.ConnectRetryTime != 0 {
+		return s.ConnectRetryTime
+	}
+	return 5 * time.Second
+}
+
+// Keeps a session to the given peer up until ctx is done.
+func (s *Speaker) connect(ctx context.Context, peer Peer) {
+	log := s.Log.WithField("peer", peer.Addr)
+	for {
+		if err := s.runSession(ctx, peer, log); err != nil {
+			log.WithError(err).Warn("BGP session failed, reconnecting in ", s.connectRetryTime())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.connectRetryTime()):
+		}
+	}
+}
+
+func (s *Speaker) runSession(ctx context.Context, peer Peer, log logrus.FieldLogger) error {
+	dialer := net.Dialer{Timeout: s.connectRetryTime()}
+	if peer.Password != "" {
+		dialer.Control = func(_, _ string, conn syscall.RawConn) error {
+			return setTCPMD5Sig(conn, peer.Addr.Addr(), peer.Password)
+		}
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", peer.Addr.String())
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	defer conn.Close()
+
+	session := session{
+		conn:    conn,
+		peer:    peer,
+		log:     log,
+		updates: make(chan struct{}, 1),
+	}
+	if err := session.open(s); err != nil {
+		return err
+	}
+
+	log.Info("BGP session established")
+	s.mu.Lock()
+	s.sessions[&session] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, &session)
+		s.mu.Unlock()
+	}()
+
+	session.notify() // send the initial routes
+	return session.run(ctx, s)
+}
+
+// The established part of a connection to a peer. Only the goroutine that
+// runs the session writes to the connection.
+type session struct {
+	conn    net.Conn
+	peer    Peer
+	log     logrus.FieldLogger
+	updates chan struct{}
+
+	params     updateParams
+	families   []family      // the address families to advertise
+	holdTime   time.Duration // negotiated, zero means no keepalives
+	advertised []netip.Prefix
+}
+
+// Signals the session that the advertised routes changed.
+func (s *session) notify() {
+	select {
+	case s.updates <- struct{}{}:
+	default:
+	}
+}
+
+// Exchanges OPEN and KEEPALIVE messages with the peer, as described in
+// RFC 4271, section 8.2.2 (OpenSent and OpenConfirm states).
+func (s *session) open(speaker *Speaker) (err error) {
+	local, ok := s.conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		return fmt.Errorf("unexpected local address: %v", s.conn.LocalAddr())
+	}
+	localAddr := local.AddrPort().Addr().Unmap()
+	localFamily := familyOf(localAddr)
+
+	defer func() {
+		var notification *notificationError
+		if errors.As(err, &notification) {
+			s.sendNotification(notification)
+		}
+	}()
+
+	open := openMessage{
+		asn:         speaker.LocalASN,
+		holdTime:    uint16(speaker.holdTime() / time.Second),
+		routerID:    speaker.RouterID,
+		families:    []family{localFamily},
+		fourOctetAS: true,
+	}
+	if err := s.write(msgOpen, open.marshal()); err != nil {
+		return err
+	}
+
+	// RFC 4271 suggests a large hold time while waiting for the OPEN.
+	typ, body, err := s.read(max(4*time.Minute, speaker.holdTime()))
+	if err != nil {
+		return err
+	}
+	if typ == msgNotification {
+		return parseNotification(body)
+	}
+	if typ != msgOpen {
+		return &notificationError{errFSM, 0, nil, fmt.Sprintf("expected OPEN message, got type %d", typ)}
+	}
+	peerOpen, err := parseOpen(body)
+	if err != nil {
+		return err
+	}
+	if peerOpen.asn != s.peer.ASN {
+		return &notificationError{errOpenMessage, errBadPeerAS, nil, fmt.Sprintf("peer has ASN %d, expected %d", peerOpen.asn, s.peer.ASN)}
+	}
+	if peerOpen.holdTime == 1 || peerOpen.holdTime == 2 {
+		return &notificationError{errOpenMessage, errUnacceptableHoldTime, nil, fmt.Sprintf("unacceptable hold time %d", peerOpen.holdTime)}
+	}
+	if peerOpen.supports(localFamily) {
+		s.families = []family{localFamily}
+	} else {
+		s.log.Warnf("Peer doesn't support AFI %d, SAFI %d, not advertising any routes", localFamily.afi, localFamily.safi)
+	}
+
+	s.holdTime = min(speaker.holdTime(), time.Duration(peerOpen.holdTime)*time.Second)
+	s.params = updateParams{
+		localASN:    speaker.LocalASN,
+		ibgp:        speaker.LocalASN == s.peer.ASN,
+		fourOctetAS: peerOpen.fourOctetAS,
+		nextHop:     localAddr,
+	}
+
+	if err := s.write(msgKeepalive, nil); err != nil {
+		return err
+	}
+	typ, body, err = s.read(s.holdTime)
+	if err != nil {
+		return err
+	}
+	if typ == msgNotification {
+		return parseNotification(body)
+	}
+	if typ != msgKeepalive {
+		return &notificationError{errFSM, 0, nil, fmt.Sprintf("expected KEEPALIVE message, got type %d", typ)}
+	}
+
+	return nil
+}
+
+// Keeps the established session alive and sends updates until ctx is done,
+// or the session fails.
+func (s *session) run(ctx context.Context, speaker *Speaker) error {
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			typ, body, err := s.read(s.holdTime)
+			switch {
+			case err != nil:
+			case typ == msgNotification:
+				err = parseNotification(body)
+			case typ == msgOpen:
+				err = &notificationError{errFSM, 0, nil, "unexpected OPEN message"}
+			case typ != msgKeepalive && typ != msgUpdate && typ != msgRouteRefresh:
+				err = &notificationError{errMessageHeader, errMessageBadMessageType, []byte{typ}, fmt.Sprintf("unsupported message type %d", typ)}
+			default:
+				continue // received routes are ignored
+			}
+			readErr <- err
+			return
+		}
+	}()
+
+	var keepalives <-chan time.Time
+	if s.holdTime > 0 {
+		ticker := time.NewTicker(s.holdTime / 3)
+		defer ticker.Stop()
+		keepalives = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Shutting down BGP session")
+			s.sendNotification(&notificationError{code: errCease, subcode: errCeaseAdminShutdown})
+			return nil
+
+		case err := <-readErr:
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				err = &notificationError{errHoldTimerExpired, 0, nil, "hold timer expired"}
+			}
+			var notification *notificationError
+			if errors.As(err, &notification) {
+				s.sendNotification(notification)
+			}
+			return err
+
+		case <-keepalives:
+			if err := s.write(msgKeepalive, nil); err != nil {
+				return err
+			}
+
+		case <-s.updates:
+			speaker.mu.Lock()
+			prefixes := slices.Clone(speaker.prefixes)
+			speaker.mu.Unlock()
+			if err := s.sendUpdates(prefixes); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Sends the UPDATE messages required to make the advertised routes match the
+// given prefixes. Prefixes of address families that aren't advertised over
+// this session are ignored.
+func (s *session) sendUpdates(prefixes []netip.Prefix) error {
+	prefixes = slices.DeleteFunc(prefixes, func(p netip.Prefix) bool {
+		return !slices.Contains(s.families, familyOf(p.Addr()))
+	})
+
+	for _, prefix := range s.advertised {
+		if !slices.Contains(prefixes, prefix) {
+			s.log.Info("Withdrawing ", prefix)
+			if err := s.write(msgUpdate, s.params.withdraw(prefix)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, prefix := range prefixes {
+		if !slices.Contains(s.advertised, prefix) {
+			s.log.Info("Advertising ", prefix)
+			if err := s.write(msgUpdate, s.params.announce(prefix)); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.advertised = prefixes
+	return nil
+}
+
+func (s *session) sendNotification(err *notificationError) {
+	if writeErr := s.write(msgNotification, err.marshal()); writeErr != nil {
+		s.log.WithError(writeErr).Debug("Failed to send NOTIFICATION")
+	}
+}
+
+func (s *session) write(typ uint8, body []byte) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return err
+	}
+	return writeMessage(s.conn, typ, body)
+}
+
+// Reads the next message. A zero timeout means no timeout.
+func (s *session) read(timeout time.Duration) (uint8, []byte, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if err := s.conn.SetReadDeadline(deadline); err != nil {
+		return 0, nil, err
+	}
+	return readMessage(s.conn)
+}
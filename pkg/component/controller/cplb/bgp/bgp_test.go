@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgp
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeaker_IPv4(t *testing.T) {
+	peer := startPeer(t, "127.0.0.1:0", openMessage{asn: 65000, holdTime: 90, families: []family{ipv4Unicast}, fourOctetAS: true})
+	vip := netip.MustParsePrefix("192.0.2.100/32")
+
+	speaker := startSpeaker(t, 65001, peer)
+	speaker.Advertise(vip, netip.MustParsePrefix("2001:db8::100/128"))
+
+	conn := peer.accept(t)
+	assert.Equal(t, uint32(65001), conn.open.asn)
+	assert.Equal(t, netip.MustParseAddr("192.0.2.1"), conn.open.routerID)
+	assert.Equal(t, []family{ipv4Unicast}, conn.open.families)
+
+	update := conn.nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.announced, "Only the IPv4 route should be advertised")
+	assert.Equal(t, []byte{originIGP}, update.attrs[attrOrigin])
+	assert.Equal(t, []byte{asSequence, 1, 0, 0, 0xfd, 0xe9}, update.attrs[attrASPath])
+	assert.Equal(t, []byte{127, 0, 0, 1}, update.attrs[attrNextHop])
+	assert.NotContains(t, update.attrs, uint8(attrLocalPref), "No LOCAL_PREF for eBGP")
+
+	speaker.Advertise()
+	update = conn.nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.withdrawn)
+	assert.Empty(t, update.announced)
+
+	speaker.Advertise(vip)
+	update = conn.nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.announced)
+
+	// The routes are advertised again after reconnecting.
+	require.NoError(t, conn.Close())
+	conn = peer.accept(t)
+	update = conn.nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.announced)
+
+	require.NoError(t, speaker.Close())
+	typ, body := conn.next(t)
+	require.Equal(t, uint8(msgNotification), typ)
+	assert.Equal(t, []byte{errCease, errCeaseAdminShutdown}, body)
+}
+
+func TestSpeaker_IPv6(t *testing.T) {
+	ln, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 loopback not available: ", err)
+	}
+	require.NoError(t, ln.Close())
+
+	peer := startPeer(t, "[::1]:0", openMessage{asn: 65000, holdTime: 90, families: []family{ipv6Unicast}})
+	vip := netip.MustParsePrefix("2001:db8::100/128")
+
+	speaker := startSpeaker(t, 65001, peer)
+	speaker.Advertise(netip.MustParsePrefix("192.0.2.100/32"), vip)
+
+	conn := peer.accept(t)
+	assert.Equal(t, []family{ipv6Unicast}, conn.open.families)
+
+	update := conn.nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.announced, "Only the IPv6 route should be advertised")
+	assert.Equal(t, netip.IPv6Loopback(), update.nextHop)
+	assert.Equal(t, []byte{asSequence, 1, 0xfd, 0xe9}, update.attrs[attrASPath], "Two-octet AS path expected")
+
+	speaker.Advertise()
+	update = conn.nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.withdrawn)
+}
+
+func TestSpeaker_IBGP(t *testing.T) {
+	peer := startPeer(t, "127.0.0.1:0", openMessage{asn: 4200000000, holdTime: 90, fourOctetAS: true})
+	vip := netip.MustParsePrefix("192.0.2.100/32")
+
+	speaker := startSpeaker(t, 4200000000, peer)
+	speaker.Advertise(vip)
+
+	update := peer.accept(t).nextUpdate(t)
+	assert.Equal(t, []netip.Prefix{vip}, update.announced)
+	assert.Empty(t, update.attrs[attrASPath], "AS path should be empty for iBGP")
+	assert.Equal(t, []byte{0, 0, 0, localPrefValue}, update.attrs[attrLocalPref])
+}
+
+func TestSpeaker_FourOctetASNToTwoOctetPeer(t *testing.T) {
+	peer := startPeer(t, "127.0.0.1:0", openMessage{asn: 65000, holdTime: 90})
+	speaker := startSpeaker(t, 4200000000, peer)
+	speaker.Advertise(netip.MustParsePrefix("192.0.2.100/32"))
+
+	update := peer.accept(t).nextUpdate(t)
+	assert.Equal(t, []byte{asSequence, 1, 0x5b, 0xa0}, update.attrs[attrASPath], "AS_TRANS expected")
+	assert.Equal(t, binary.BigEndian.AppendUint32([]byte{asSequence, 1}, 4200000000), update.attrs[attrAS4Path])
+}
+
+func TestSpeaker_BadPeerAS(t *testing.T) {
+	peer := startPeer(t, "127.0.0.1:0", openMessage{asn: 65000, holdTime: 90})
+	startSpeaker(t, 65001, peer)
+	peer.open.asn = 65099 // not what the speaker expects
+
+	conn := peer.acceptOpen(t)
+	typ, body := conn.next(t)
+	require.Equal(t, uint8(msgNotification), typ)
+	assert.Equal(t, []byte{errOpenMessage, errBadPeerAS}, body)
+}
+
+func TestSpeaker_PasswordTooLong(t *testing.T) {
+	speaker := Speaker{
+		LocalASN: 65001,
+		RouterID: netip.MustParseAddr("192.0.2.1"),
+		Peers:    []Peer{{Addr: netip.MustParseAddrPort("192.0.2.254:179"), ASN: 65000, Password: strings.Repeat("x", 81)}},
+	}
+	assert.ErrorContains(t, speaker.Start(), "password of peer 192.0.2.254:179 exceeds 80 bytes")
+}
+
+func TestSpeaker_HoldTimer(t *testing.T) {
+	peer := startPeer(t, "127.0.0.1:0", openMessage{asn: 65000, holdTime: 3})
+	speaker := startSpeaker(t, 65001, peer)
+	speaker.Advertise(netip.MustParsePrefix("192.0.2.100/32"))
+
+	conn := peer.accept(t)
+	conn.nextUpdate(t)
+
+	// The speaker sends keepalives every second, and gives up after three
+	// seconds without hearing from the peer.
+	start := time.Now()
+	var keepalives int
+	for {
+		typ, body := conn.next(t)
+		if typ == msgKeepalive {
+			keepalives++
+			continue
+		}
+		require.Equal(t, uint8(msgNotification), typ)
+		assert.Equal(t, []byte{errHoldTimerExpired, 0}, body)
+		break
+	}
+	assert.GreaterOrEqual(t, keepalives, 2)
+	assert.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+}
+
+func startSpeaker(t *testing.T, asn uint32, peer *testPeer, password ...string) *Speaker {
+	p := Peer{Addr: peer.addr, ASN: peer.open.asn}
+	if len(password) > 0 {
+		p.Password = password[0]
+	}
+	speaker := &Speaker{
+		LocalASN:         asn,
+		RouterID:         netip.MustParseAddr("192.0.2.1"),
+		ConnectRetryTime: 100 * time.Millisecond,
+		Peers:            []Peer{p},
+		Log:              logrus.New(),
+	}
+	require.NoError(t, speaker.Start())
+	t.Cleanup(func() { assert.NoError(t, speaker.Close()) })
+	return speaker
+}
+
+// A stand-in for a BGP router that accepts sessions from a Speaker.
+type testPeer struct {
+	addr netip.AddrPort
+	open openMessage
+	ln   net.Listener
+}
+
+func startPeer(t *testing.T, addr string, open openMessage) *testPeer {
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	open.routerID = netip.MustParseAddr("192.0.2.254")
+	return &testPeer{ln.Addr().(*net.TCPAddr).AddrPort(), open, ln}
+}
+
+type testConn struct {
+	net.Conn
+	open *openMessage // the speaker's OPEN message
+}
+
+// Accepts a connection from the speaker and exchanges OPEN messages.
+func (p *testPeer) acceptOpen(t *testing.T) *testConn {
+	conn, err := p.ln.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	c := &testConn{Conn: conn}
+
+	typ, body := c.next(t)
+	require.Equal(t, uint8(msgOpen), typ)
+	c.open, err = parseOpen(body)
+	require.NoError(t, err)
+
+	require.NoError(t, writeMessage(conn, msgOpen, p.open.marshal()))
+	return c
+}
+
+// Accepts a connection from the speaker and establishes the session.
+func (p *testPeer) accept(t *testing.T) *testConn {
+	c := p.acceptOpen(t)
+	require.NoError(t, writeMessage(c, msgKeepalive, nil))
+	typ, _ := c.next(t)
+	require.Equal(t, uint8(msgKeepalive), typ)
+	return c
+}
+
+func (c *testConn) next(t *testing.T) (uint8, []byte) {
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(10*time.Second)))
+	typ, body, err := readMessage(c)
+	require.NoError(t, err)
+	return typ, body
+}
+
+type testUpdate struct {
+	withdrawn, announced []netip.Prefix
+	nextHop              netip.Addr // from MP_REACH_NLRI
+	attrs                map[uint8][]byte
+}
+
+// Reads the next UPDATE message, skipping keepalives.
+func (c *testConn) nextUpdate(t *testing.T) *testUpdate {
+	typ, body := c.next(t)
+	for typ == msgKeepalive {
+		typ, body = c.next(t)
+	}
+	require.Equal(t, uint8(msgUpdate), typ)
+
+	var u testUpdate
+	withdrawnLen := int(binary.BigEndian.Uint16(body))
+	u.withdrawn = parsePrefixes(t, body[2:2+withdrawnLen], 4)
+	body = body[2+withdrawnLen:]
+	attrsLen := int(binary.BigEndian.Uint16(body))
+	attrs := body[2 : 2+attrsLen]
+	u.announced = parsePrefixes(t, body[2+attrsLen:], 4)
+
+	u.attrs = make(map[uint8][]byte)
+	for len(attrs) > 0 {
+		require.Zero(t, attrs[0]&0x10, "unexpected extended length")
+		typ, value := attrs[1], attrs[3:3+attrs[2]]
+		attrs = attrs[3+attrs[2]:]
+		u.attrs[typ] = value
+
+		switch typ {
+		case attrMPReach:
+			nextHopLen := int(value[3])
+			u.nextHop, _ = netip.AddrFromSlice(value[4 : 4+nextHopLen])
+			u.announced = append(u.announced, parsePrefixes(t, value[5+nextHopLen:], 16)...)
+		case attrMPUnreach:
+			u.withdrawn = append(u.withdrawn, parsePrefixes(t, value[3:], 16)...)
+		}
+	}
+
+	return &u
+}
+
+func parsePrefixes(t *testing.T, b []byte, addrLen int) (prefixes []netip.Prefix) {
+	for len(b) > 0 {
+		bits := int(b[0])
+		n := (bits + 7) / 8
+		addr := make([]byte, addrLen)
+		copy(addr, b[1:1+n])
+		ip, ok := netip.AddrFromSlice(addr)
+		require.True(t, ok)
+		prefixes = append(prefixes, netip.PrefixFrom(ip, bits))
+		b = b[1+n:]
+	}
+	return prefixes
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgp
+
+import (
+	"fmt"
+	"net/netip"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Enables TCP MD5 signatures (RFC 2385) with the given password for the
+// segments exchanged with the peer at addr.
+func setTCPMD5Sig(conn syscall.RawConn, addr netip.Addr, password string) error {
+	sig := unix.TCPMD5Sig{Keylen: uint16(len(password))}
+	copy(sig.Key[:], password)
+
+	addr = addr.Unmap()
+	if addr.Is4() {
+		sa := (*unix.RawSockaddrInet4)(unsafe.Pointer(&sig.Addr))
+		sa.Family = unix.AF_INET
+		sa.Addr = addr.As4()
+	} else {
+		sa := (*unix.RawSockaddrInet6)(unsafe.Pointer(&sig.Addr))
+		sa.Family = unix.AF_INET6
+		sa.Addr = addr.As16()
+	}
+
+	var err error
+	if ctrlErr := conn.Control(func(fd uintptr) {
+		err = unix.SetsockoptTCPMD5Sig(int(fd), unix.IPPROTO_TCP, unix.TCP_MD5SIG, &sig)
+	}); ctrlErr != nil {
+		return ctrlErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to enable TCP MD5 signatures: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestSpeaker_TCPMD5Sig(t *testing.T) {
+	// The peer only accepts segments signed with its password.
+	lc := net.ListenConfig{Control: func(_, _ string, conn syscall.RawConn) error {
+		return setTCPMD5Sig(conn, netip.MustParseAddr("127.0.0.1"), "s3cr3t")
+	}}
+	ln, err := lc.Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if errors.Is(err, unix.ENOPROTOOPT) || errors.Is(err, unix.ENOENT) {
+		t.Skip("TCP MD5 signatures not supported by the kernel: ", err)
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	peer := &testPeer{
+		addr: ln.Addr().(*net.TCPAddr).AddrPort(),
+		open: openMessage{asn: 65000, holdTime: 90, routerID: netip.MustParseAddr("192.0.2.254")},
+		ln:   ln,
+	}
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		speaker := startSpeaker(t, 65001, peer, "wrong")
+		speaker.Advertise(netip.MustParsePrefix("192.0.2.100/32"))
+
+		accepted := make(chan error, 1)
+		go func() {
+			conn, err := ln.Accept()
+			if err == nil {
+				_ = conn.Close()
+			}
+			accepted <- err
+		}()
+
+		select {
+		case err := <-accepted:
+			assert.Fail(t, "Peer accepted a connection with a wrong signature", "%v", err)
+		case <-time.After(2 * time.Second):
+		}
+
+		require.NoError(t, speaker.Close())
+		require.NoError(t, ln.(*net.TCPListener).SetDeadline(time.Now()))
+		assert.ErrorIs(t, <-accepted, os.ErrDeadlineExceeded)
+		require.NoError(t, ln.(*net.TCPListener).SetDeadline(time.Time{}))
+	})
+
+	t.Run("RightPassword", func(t *testing.T) {
+		speaker := startSpeaker(t, 65001, peer, "s3cr3t")
+		speaker.Advertise(netip.MustParsePrefix("192.0.2.100/32"))
+
+		update := peer.accept(t).nextUpdate(t)
+		assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("192.0.2.100/32")}, update.announced)
+	})
+}
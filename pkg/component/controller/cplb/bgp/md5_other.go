@@ -0,0 +1,16 @@
+//go:build !linux
+
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgp
+
+import (
+	"errors"
+	"net/netip"
+	"syscall"
+)
+
+func setTCPMD5Sig(syscall.RawConn, netip.Addr, string) error {
+	return errors.New("TCP MD5 signatures are not supported on this platform")
+}
@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+)
+
+// Message types, RFC 4271, section 4.1.
+const (
+	msgOpen         = 1
+	msgUpdate       = 2
+	msgNotification = 3
+	msgKeepalive    = 4
+	msgRouteRefresh = 5
+)
+
+const (
+	headerLen     = 19
+	maxMessageLen = 4096
+)
+
+// Path attribute flags and type codes, RFC 4271, section 4.3, and the ones
+// from RFC 4760 (multiprotocol extensions) and RFC 6793 (four-octet ASNs).
+const (
+	attrFlagOptional   = 0x80
+	attrFlagTransitive = 0x40
+
+	attrOrigin     = 1
+	attrASPath     = 2
+	attrNextHop    = 3
+	attrLocalPref  = 5
+	attrMPReach    = 14
+	attrMPUnreach  = 15
+	attrAS4Path    = 17
+	originIGP      = 0
+	asSequence     = 2
+	localPrefValue = 100
+)
+
+// Capability codes, RFC 4760 and RFC 6793.
+const (
+	capMultiprotocol = 1
+	capFourOctetAS   = 65
+)
+
+// The ASN used in place of four-octet ASNs towards peers that don't support
+// them, RFC 6793, section 9.
+const asTrans = 23456
+
+// NOTIFICATION error codes and subcodes, RFC 4271, section 4.5.
+const (
+	errMessageHeader         = 1
+	errOpenMessage           = 2
+	errHoldTimerExpired      = 4
+	errFSM                   = 5
+	errCease                 = 6
+	errUnsupportedVersion    = 1
+	errBadPeerAS             = 2
+	errUnacceptableHoldTime  = 6
+	errCeaseAdminShutdown    = 2
+	errMessageBadLength      = 2
+	errMessageConnNotSynced  = 1
+	errMessageBadMessageType = 3
+)
+
+// An address family, identified by its AFI and SAFI (RFC 4760).
+type family struct {
+	afi  uint16
+	safi uint8
+}
+
+var (
+	ipv4Unicast = family{afi: 1, safi: 1}
+	ipv6Unicast = family{afi: 2, safi: 1}
+)
+
+func familyOf(addr netip.Addr) family {
+	if addr.Is4() {
+		return ipv4Unicast
+	}
+	return ipv6Unicast
+}
+
+// Writes a BGP message with the given type and body to w.
+func writeMessage(w io.Writer, typ uint8, body []byte) error {
+	msg := make([]byte, 0, headerLen+len(body))
+	msg = append(msg, bytes.Repeat([]byte{0xff}, 16)...)
+	msg = binary.BigEndian.AppendUint16(msg, uint16(headerLen+len(body)))
+	msg = append(msg, typ)
+	_, err := w.Write(append(msg, body...))
+	return err
+}
+
+// Reads a BGP message from r and returns its type and body.
+func readMessage(r io.Reader) (uint8, []byte, error) {
+	var header [headerLen]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	if !bytes.Equal(header[:16], bytes.Repeat([]byte{0xff}, 16)) {
+		return 0, nil, &notificationError{errMessageHeader, errMessageConnNotSynced, nil, "invalid message marker"}
+	}
+	length := binary.BigEndian.Uint16(header[16:18])
+	if length < headerLen || length > maxMessageLen {
+		return 0, nil, &notificationError{errMessageHeader, errMessageBadLength, header[16:18], fmt.Sprintf("invalid message length %d", length)}
+	}
+	body := make([]byte, length-headerLen)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header[18], body, nil
+}
+
+// An OPEN message, RFC 4271, section 4.2.
+type openMessage struct {
+	asn         uint32 // the four-octet ASN, if supported
+	holdTime    uint16 // seconds
+	routerID    netip.Addr
+	families    []family
+	fourOctetAS bool
+}
+
+func (o *openMessage) marshal() []byte {
+	var caps []byte
+	for _, f := range o.families {
+		caps = append(caps, capMultiprotocol, 4)
+		caps = binary.BigEndian.AppendUint16(caps, f.afi)
+		caps = append(caps, 0, f.safi)
+	}
+	if o.fourOctetAS {
+		caps = append(caps, capFourOctetAS, 4)
+		caps = binary.BigEndian.AppendUint32(caps, o.asn)
+	}
+
+	asn := uint16(asTrans)
+	if o.asn <= 0xffff {
+		asn = uint16(o.asn)
+	}
+
+	body := []byte{4} // version
+	body = binary.BigEndian.AppendUint16(body, asn)
+	body = binary.BigEndian.AppendUint16(body, o.holdTime)
+	body = append(body, o.routerID.AsSlice()...)
+	if len(caps) == 0 {
+		return append(body, 0)
+	}
+	// A single capabilities optional parameter, RFC 5492.
+	body = append(body, byte(len(caps)+2), 2, byte(len(caps)))
+	return append(body, caps...)
+}
+
+func parseOpen(body []byte) (*openMessage, error) {
+	if len(body) < 10 {
+		return nil, &notificationError{errMessageHeader, errMessageBadLength, nil, "OPEN message too short"}
+	}
+	if body[0] != 4 {
+		return nil, &notificationError{errOpenMessage, errUnsupportedVersion, []byte{0, 4}, fmt.Sprintf("unsupported BGP version %d", body[0])}
+	}
+
+	o := openMessage{
+		asn:      uint32(binary.BigEndian.Uint16(body[1:3])),
+		holdTime: binary.BigEndian.Uint16(body[3:5]),
+		routerID: netip.AddrFrom4([4]byte(body[5:9])),
+	}
+
+	params := body[10:]
+	if int(body[9]) != len(params) {
+		return nil, &notificationError{errMessageHeader, errMessageBadLength, nil, "invalid OPEN optional parameters length"}
+	}
+	for len(params) > 0 {
+		if len(params) < 2 || len(params) < 2+int(params[1]) {
+			return nil, errors.New("malformed OPEN optional parameter")
+		}
+		typ, value := params[0], params[2:2+params[1]]
+		params = params[2+params[1]:]
+		if typ != 2 { // only capabilities are of interest
+			continue
+		}
+		for len(value) > 0 {
+			if len(value) < 2 || len(value) < 2+int(value[1]) {
+				return nil, errors.New("malformed OPEN capability")
+			}
+			code, capValue := value[0], value[2:2+value[1]]
+			value = value[2+value[1]:]
+			switch {
+			case code == capMultiprotocol && len(capValue) == 4:
+				o.families = append(o.families, family{binary.BigEndian.Uint16(capValue[:2]), capValue[3]})
+			case code == capFourOctetAS && len(capValue) == 4:
+				o.fourOctetAS = true
+				o.asn = binary.BigEndian.Uint32(capValue)
+			}
+		}
+	}
+
+	return &o, nil
+}
+
+// supports reports whether the peer supports the given address family. Peers
+// that don't send any multiprotocol capabilities support IPv4 unicast only.
+func (o *openMessage) supports(f family) bool {
+	if len(o.families) == 0 {
+		return f == ipv4Unicast
+	}
+	return slices.Contains(o.families, f)
+}
+
+// The parameters of the UPDATE messages sent over a session.
+type updateParams struct {
+	localASN    uint32
+	ibgp        bool
+	fourOctetAS bool       // negotiated with the peer
+	nextHop     netip.Addr // the local address of the session
+}
+
+// Builds an UPDATE message that announces the given host route.
+func (p *updateParams) announce(prefix netip.Prefix) []byte {
+	var attrs []byte
+	attrs = appendAttr(attrs, attrFlagTransitive, attrOrigin, []byte{originIGP})
+
+	// The AS path is empty for iBGP. For eBGP, it contains the local ASN. If
+	// that doesn't fit into two octets, and the peer doesn't support four
+	// octets, AS_TRANS is used, and the real path goes into AS4_PATH.
+	var asPath []byte
+	if !p.ibgp {
+		asPath = []byte{asSequence, 1}
+		switch {
+		case p.fourOctetAS:
+			asPath = binary.BigEndian.AppendUint32(asPath, p.localASN)
+		case p.localASN > 0xffff:
+			asPath = binary.BigEndian.AppendUint16(asPath, asTrans)
+		default:
+			asPath = binary.BigEndian.AppendUint16(asPath, uint16(p.localASN))
+		}
+	}
+	attrs = appendAttr(attrs, attrFlagTransitive, attrASPath, asPath)
+	if !p.ibgp && !p.fourOctetAS && p.localASN > 0xffff {
+		as4Path := binary.BigEndian.AppendUint32([]byte{asSequence, 1}, p.localASN)
+		attrs = appendAttr(attrs, attrFlagOptional|attrFlagTransitive, attrAS4Path, as4Path)
+	}
+
+	if p.ibgp {
+		attrs = appendAttr(attrs, attrFlagTransitive, attrLocalPref, binary.BigEndian.AppendUint32(nil, localPrefValue))
+	}
+
+	if prefix.Addr().Is4() {
+		attrs = appendAttr(attrs, attrFlagTransitive, attrNextHop, p.nextHop.AsSlice())
+		return update(nil, attrs, appendPrefix(nil, prefix))
+	}
+
+	f := familyOf(prefix.Addr())
+	mpReach := binary.BigEndian.AppendUint16(nil, f.afi)
+	mpReach = append(mpReach, f.safi, byte(p.nextHop.BitLen()/8))
+	mpReach = append(mpReach, p.nextHop.AsSlice()...)
+	mpReach = append(mpReach, 0) // reserved
+	mpReach = appendPrefix(mpReach, prefix)
+	attrs = appendAttr(attrs, attrFlagOptional, attrMPReach, mpReach)
+	return update(nil, attrs, nil)
+}
+
+// Builds an UPDATE message that withdraws the given host route.
+func (p *updateParams) withdraw(prefix netip.Prefix) []byte {
+	if prefix.Addr().Is4() {
+		return update(appendPrefix(nil, prefix), nil, nil)
+	}
+
+	f := familyOf(prefix.Addr())
+	mpUnreach := binary.BigEndian.AppendUint16(nil, f.afi)
+	mpUnreach = append(mpUnreach, f.safi)
+	mpUnreach = appendPrefix(mpUnreach, prefix)
+	return update(nil, appendAttr(nil, attrFlagOptional, attrMPUnreach, mpUnreach), nil)
+}
+
+func update(withdrawn, attrs, nlri []byte) []byte {
+	body := binary.BigEndian.AppendUint16(nil, uint16(len(withdrawn)))
+	body = append(body, withdrawn...)
+	body = binary.BigEndian.AppendUint16(body, uint16(len(attrs)))
+	body = append(body, attrs...)
+	return append(body, nlri...)
+}
+
+func appendAttr(b []byte, flags, typ uint8, value []byte) []byte {
+	// The values sent here are always short enough for a one-octet length.
+	return append(append(b, flags, typ, byte(len(value))), value...)
+}
+
+// Appends prefix in the NLRI encoding, i.e. its length in bits followed by
+// the significant octets.
+func appendPrefix(b []byte, prefix netip.Prefix) []byte {
+	bits := prefix.Bits()
+	return append(append(b, byte(bits)), prefix.Addr().AsSlice()[:(bits+7)/8]...)
+}
+
+// An error that gets reported to the peer in a NOTIFICATION message.
+type notificationError struct {
+	code, subcode uint8
+	data          []byte
+	msg           string
+}
+
+func (e *notificationError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	return fmt.Sprintf("BGP error code %d, subcode %d", e.code, e.subcode)
+}
+
+func (e *notificationError) marshal() []byte {
+	return append([]byte{e.code, e.subcode}, e.data...)
+}
+
+// An error that has been reported by the peer in a NOTIFICATION message.
+type peerNotificationError struct {
+	code, subcode uint8
+}
+
+func (e *peerNotificationError) Error() string {
+	return fmt.Sprintf("peer sent NOTIFICATION with error code %d, subcode %d", e.code, e.subcode)
+}
+
+func parseNotification(body []byte) error {
+	if len(body) < 2 {
+		return errors.New("peer sent malformed NOTIFICATION")
+	}
+	return &peerNotificationError{code: body[0], subcode: body[1]}
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bgp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The messages below are written down byte by byte as they appear on the wire,
+// independently of the code under test, so that the encoding is checked
+// against the RFCs rather than against itself.
+
+const marker = "ffffffff ffffffff ffffffff ffffffff"
+
+// Decodes a hex dump, ignoring any whitespace.
+func wire(t *testing.T, dump string) []byte {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(dump), ""))
+	require.NoError(t, err)
+	return b
+}
+
+func TestWriteMessage_Wire(t *testing.T) {
+	ipv4Params := updateParams{
+		localASN:    65001,
+		fourOctetAS: true,
+		nextHop:     netip.MustParseAddr("192.0.2.1"),
+	}
+	ipv6Params := updateParams{
+		localASN: 65001,
+		nextHop:  netip.MustParseAddr("2001:db8::1"),
+	}
+	ipv4VIP := netip.MustParsePrefix("192.0.2.100/32")
+	ipv6VIP := netip.MustParsePrefix("2001:db8::100/128")
+
+	for _, test := range []struct {
+		name string
+		typ  uint8
+		body []byte
+		wire string
+	}{
+		{
+			"Open", msgOpen,
+			(&openMessage{
+				asn:         65001,
+				holdTime:    90,
+				routerID:    netip.MustParseAddr("192.0.2.1"),
+				families:    []family{ipv4Unicast},
+				fourOctetAS: true,
+			}).marshal(),
+			marker + `002b 01
+			04 fde9 005a c0000201
+			0e 02 0c
+				01 04 0001 00 01
+				41 04 0000fde9`,
+		},
+		{
+			"OpenFourOctetASN", msgOpen,
+			(&openMessage{
+				asn:         4200000000,
+				holdTime:    90,
+				routerID:    netip.MustParseAddr("192.0.2.1"),
+				families:    []family{ipv4Unicast, ipv6Unicast},
+				fourOctetAS: true,
+			}).marshal(),
+			marker + `0031 01
+			04 5ba0 005a c0000201
+			14 02 12
+				01 04 0001 00 01
+				01 04 0002 00 01
+				41 04 fa56ea00`,
+		},
+		{
+			"AnnounceIPv4", msgUpdate,
+			ipv4Params.announce(ipv4VIP),
+			marker + `0030 02
+			0000
+			0014
+				40 01 01 00
+				40 02 06 02 01 0000fde9
+				40 03 04 c0000201
+			20 c0000264`,
+		},
+		{
+			"AnnounceIPv4IBGP", msgUpdate,
+			(&updateParams{localASN: 65001, ibgp: true, nextHop: ipv4Params.nextHop}).announce(ipv4VIP),
+			marker + `0031 02
+			0000
+			0015
+				40 01 01 00
+				40 02 00
+				40 05 04 00000064
+				40 03 04 c0000201
+			20 c0000264`,
+		},
+		{
+			"AnnounceIPv4ASTrans", msgUpdate,
+			(&updateParams{localASN: 4200000000, nextHop: ipv4Params.nextHop}).announce(ipv4VIP),
+			marker + `0037 02
+			0000
+			001b
+				40 01 01 00
+				40 02 04 02 01 5ba0
+				c0 11 06 02 01 fa56ea00
+				40 03 04 c0000201
+			20 c0000264`,
+		},
+		{
+			"AnnounceIPv6", msgUpdate,
+			ipv6Params.announce(ipv6VIP),
+			marker + `004b 02
+			0000
+			0034
+				40 01 01 00
+				40 02 04 02 01 fde9
+				80 0e 26 0002 01 10 20010db8000000000000000000000001 00
+					80 20010db8000000000000000000000100`,
+		},
+		{
+			"WithdrawIPv4", msgUpdate,
+			ipv4Params.withdraw(ipv4VIP),
+			marker + `001c 02
+			0005 20 c0000264
+			0000`,
+		},
+		{
+			"WithdrawIPv6", msgUpdate,
+			ipv6Params.withdraw(ipv6VIP),
+			marker + `002e 02
+			0000
+			0017
+				80 0f 14 0002 01
+					80 20010db8000000000000000000000100`,
+		},
+		{
+			"NotificationCease", msgNotification,
+			(&notificationError{code: errCease, subcode: errCeaseAdminShutdown}).marshal(),
+			marker + `0015 03 06 02`,
+		},
+		{
+			"Keepalive", msgKeepalive, nil,
+			marker + `0013 04`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeMessage(&buf, test.typ, test.body))
+			assert.Equal(t, wire(t, test.wire), buf.Bytes())
+		})
+	}
+}
+
+func TestReadMessage_Wire(t *testing.T) {
+	t.Run("Open", func(t *testing.T) {
+		// A router that sends each capability in its own optional parameter,
+		// including some that aren't of interest.
+		typ, body, err := readMessage(bytes.NewReader(wire(t, marker+`003b 01
+			04 fde8 00b4 0a000001
+			1e
+				02 06 01 04 0001 00 01
+				02 02 80 00
+				02 02 02 00
+				02 06 41 04 0000fde8
+				02 04 40 02 0078`)))
+		require.NoError(t, err)
+		require.Equal(t, uint8(msgOpen), typ)
+
+		open, err := parseOpen(body)
+		require.NoError(t, err)
+		assert.Equal(t, &openMessage{
+			asn:         65000,
+			holdTime:    180,
+			routerID:    netip.MustParseAddr("10.0.0.1"),
+			families:    []family{ipv4Unicast},
+			fourOctetAS: true,
+		}, open)
+		assert.True(t, open.supports(ipv4Unicast))
+		assert.False(t, open.supports(ipv6Unicast))
+	})
+
+	t.Run("OpenWithoutCapabilities", func(t *testing.T) {
+		_, body, err := readMessage(bytes.NewReader(wire(t, marker+`001d 01
+			04 fde8 005a 0a000001 00`)))
+		require.NoError(t, err)
+
+		open, err := parseOpen(body)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(65000), open.asn)
+		assert.False(t, open.fourOctetAS)
+		assert.True(t, open.supports(ipv4Unicast), "IPv4 unicast is implied")
+		assert.False(t, open.supports(ipv6Unicast))
+	})
+
+	t.Run("OpenUnsupportedVersion", func(t *testing.T) {
+		_, err := parseOpen(wire(t, `03 fde8 005a 0a000001 00`))
+		var notification *notificationError
+		require.ErrorAs(t, err, &notification)
+		assert.Equal(t, []byte{errOpenMessage, errUnsupportedVersion, 0, 4}, notification.marshal())
+	})
+
+	t.Run("Notification", func(t *testing.T) {
+		typ, body, err := readMessage(bytes.NewReader(wire(t, marker+`0015 03 06 02`)))
+		require.NoError(t, err)
+		require.Equal(t, uint8(msgNotification), typ)
+		assert.Equal(t, &peerNotificationError{errCease, errCeaseAdminShutdown}, parseNotification(body))
+	})
+
+	t.Run("BadMarker", func(t *testing.T) {
+		_, _, err := readMessage(bytes.NewReader(wire(t, `ffffffff ffffffff ffffffff ffffff00 0013 04`)))
+		var notification *notificationError
+		require.ErrorAs(t, err, &notification)
+		assert.Equal(t, []byte{errMessageHeader, errMessageConnNotSynced}, notification.marshal())
+	})
+
+	t.Run("BadLength", func(t *testing.T) {
+		_, _, err := readMessage(bytes.NewReader(wire(t, marker+`0012 04`)))
+		var notification *notificationError
+		require.ErrorAs(t, err, &notification)
+		assert.Equal(t, []byte{errMessageHeader, errMessageBadLength, 0x00, 0x12}, notification.marshal())
+	})
+}
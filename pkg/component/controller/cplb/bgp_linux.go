@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	k0sAPI "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/cplb/bgp"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// How often the local API server is checked, and how many checks in a
+	// row need to fail until the virtual IPs get withdrawn.
+	bgpHealthCheckInterval = 2 * time.Second
+	bgpHealthCheckFailures = 3
+
+	// The interface the virtual IPs are added to, so that the controller
+	// accepts the traffic that gets routed to it.
+	bgpVirtualIPsLinkName = "lo"
+
+	bgpPeerConnectRetryTime = 5 * time.Second
+)
+
+// BGP is the controller for the "BGP" type of control plane load balancing.
+// The virtual IPs are added to the loopback interface of every controller,
+// and advertised as host routes to the BGP peers for as long as the local API
+// server is healthy.
+type BGP struct {
+	Config         *k0sAPI.BGPSpec
+	APIAddress     string // the default router ID
+	KubeConfigPath string
+
+	log        logrus.FieldLogger
+	stop       func()
+	advertised atomic.Bool
+}
+
+var _ manager.Component = (*BGP)(nil)
+
+func (b *BGP) Init(context.Context) error {
+	b.log = logrus.WithField("component", "CPLB")
+	return nil
+}
+
+// Start adds the virtual IPs to the loopback interface, connects to the BGP
+// peers and starts checking the local API server.
+func (b *BGP) Start(context.Context) (err error) {
+	if b.Config == nil || len(b.Config.VirtualIPs) == 0 {
+		b.log.Warn("No virtual IPs defined, skipping BGP control plane load balancing")
+		return nil
+	}
+
+	routerID, err := netip.ParseAddr(b.Config.RouterID)
+	if b.Config.RouterID == "" {
+		routerID, err = netip.ParseAddr(b.APIAddress)
+	}
+	if err != nil || !routerID.Is4() {
+		return fmt.Errorf("no IPv4 router ID available, please specify one explicitly (API address: %s)", b.APIAddress)
+	}
+
+	prefixes := make([]netip.Prefix, len(b.Config.VirtualIPs))
+	for i, vip := range b.Config.VirtualIPs {
+		addr, err := netip.ParseAddr(vip)
+		if err != nil {
+			return fmt.Errorf("failed to parse virtual IP %s: %w", vip, err)
+		}
+		prefixes[i] = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	peers := make([]bgp.Peer, len(b.Config.Peers))
+	for i, peer := range b.Config.Peers {
+		addr, err := netip.ParseAddr(peer.Address)
+		if err != nil {
+			return fmt.Errorf("failed to parse address of BGP peer %s: %w", peer.Address, err)
+		}
+		peers[i] = bgp.Peer{
+			Addr:     netip.AddrPortFrom(addr, uint16(peer.Port)),
+			ASN:      peer.ASN,
+			Password: peer.Password,
+		}
+	}
+
+	kubeClient, err := kubeutil.NewClientFromFile(b.KubeConfigPath)
+	if err != nil {
+		return err
+	}
+
+	if err := b.addVirtualIPs(prefixes); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, b.removeVirtualIPs(prefixes))
+		}
+	}()
+
+	speaker := &bgp.Speaker{
+		LocalASN:         b.Config.LocalASN,
+		RouterID:         routerID,
+		HoldTime:         time.Duration(b.Config.HoldTimeSeconds) * time.Second,
+		ConnectRetryTime: bgpPeerConnectRetryTime,
+		Peers:            peers,
+		Log:              b.log,
+	}
+	if err := speaker.Start(); err != nil {
+		return fmt.Errorf("failed to start BGP speaker: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { b.advertiseWhileHealthy(ctx, kubeClient, speaker, prefixes) })
+
+	b.stop = func() {
+		cancel()
+		wg.Wait()
+		b.advertised.Store(false)
+		if err := speaker.Close(); err != nil {
+			b.log.WithError(err).Error("Failed to stop BGP speaker")
+		}
+		if err := b.removeVirtualIPs(prefixes); err != nil {
+			b.log.WithError(err).Error("Failed to remove the virtual IPs")
+		}
+	}
+
+	return nil
+}
+
+// Stop shuts down the BGP sessions, so that the peers withdraw the virtual IPs
+// immediately, and removes the virtual IPs from the loopback interface.
+func (b *BGP) Stop() error {
+	if b.stop != nil {
+		b.stop()
+	}
+	return nil
+}
+
+// Checks the local API server periodically. Advertises the virtual IPs as soon
+// as it's ready, and withdraws them after some failed checks in a row.
+func (b *BGP) advertiseWhileHealthy(ctx context.Context, client kubernetes.Interface, speaker *bgp.Speaker, prefixes []netip.Prefix) {
+	ticker := time.NewTicker(bgpHealthCheckInterval)
+	defer ticker.Stop()
+
+	var advertised bool
+	failures := bgpHealthCheckFailures // not advertised initially
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, bgpHealthCheckInterval)
+		err := client.Discovery().RESTClient().Get().AbsPath("/readyz").Do(checkCtx).Error()
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == nil:
+			failures = 0
+			if !advertised {
+				b.log.Info("API server is ready, advertising the virtual IPs")
+				speaker.Advertise(prefixes...)
+				advertised = true
+				b.advertised.Store(true)
+			}
+		default:
+			failures++
+			b.log.WithError(err).Debugf("API server health check failed (%d in a row)", failures)
+			if advertised && failures >= bgpHealthCheckFailures {
+				b.log.WithError(err).Warn("API server isn't ready, withdrawing the virtual IPs")
+				speaker.Advertise()
+				advertised = false
+				b.advertised.Store(false)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *BGP) addVirtualIPs(prefixes []netip.Prefix) error {
+	link, err := netlink.LinkByName(bgpVirtualIPsLinkName)
+	if err != nil {
+		return fmt.Errorf("failed to get link %s: %w", bgpVirtualIPsLinkName, err)
+	}
+
+	for _, prefix := range prefixes {
+		if prefix.Addr().Is6() {
+			if err := setAddressLabel(prefix.Addr().AsSlice(), virtualIPAddressLabel); err != nil {
+				return fmt.Errorf("failed to set address label for %s: %w", prefix.Addr(), err)
+			}
+		}
+		// AddrReplace doesn't fail if the address is already present.
+		if err := netlink.AddrReplace(link, hostAddr(prefix)); err != nil {
+			return fmt.Errorf("failed to add %s to link %s: %w", prefix, bgpVirtualIPsLinkName, err)
+		}
+	}
+
+	return nil
+}
+
+func (b *BGP) removeVirtualIPs(prefixes []netip.Prefix) error {
+	link, err := netlink.LinkByName(bgpVirtualIPsLinkName)
+	if err != nil {
+		return fmt.Errorf("failed to get link %s: %w", bgpVirtualIPsLinkName, err)
+	}
+
+	var errs []error
+	for _, prefix := range prefixes {
+		if err := netlink.AddrDel(link, hostAddr(prefix)); err != nil && !errors.Is(err, unix.EADDRNOTAVAIL) {
+			errs = append(errs, fmt.Errorf("failed to remove %s from link %s: %w", prefix, bgpVirtualIPsLinkName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func hostAddr(prefix netip.Prefix) *netlink.Addr {
+	bits := prefix.Addr().BitLen()
+	return &netlink.Addr{IPNet: &net.IPNet{IP: prefix.Addr().AsSlice(), Mask: net.CIDRMask(bits, bits)}}
+}
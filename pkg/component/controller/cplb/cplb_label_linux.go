@@ -12,6 +12,11 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+// The address label for the IPv6 virtual IPs that k0s manages itself, so that
+// the real addresses are preferred as source addresses. Same as the VRRP
+// instances' default.
+const virtualIPAddressLabel = 10000
+
 func setAddressLabel(ip net.IP, label uint32) error {
 	// Use iproute2 as reference for setting the address label.
 	// https://git.kernel.org/pub/scm/network/iproute2/iproute2.git/tree/ip/ipaddrlabel.c?h=v4.0.0#n178
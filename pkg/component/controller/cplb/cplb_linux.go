@@ -10,10 +10,8 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"slices"
-	"strconv"
 	"strings"
 	"syscall"
 	"text/template"
@@ -23,7 +21,6 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/users"
 	k0sAPI "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/assets"
-	"github.com/k0sproject/k0s/pkg/component/controller/cplb/tcpproxy"
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	"github.com/k0sproject/k0s/pkg/supervisor"
@@ -31,11 +28,6 @@ import (
 	"github.com/vishvananda/netlink"
 )
 
-const (
-	iptablesCommandAppend = "-A"
-	iptablesCommandDelete = "-D"
-)
-
 // Keepalived is the controller for the keepalived process in the control plane load balancing
 type Keepalived struct {
 	K0sVars         *config.CfgVars
@@ -55,7 +47,8 @@ type Keepalived struct {
 	reconciler             *CPLBReconciler
 	updateCh               chan struct{}
 	reconcilerDone         chan struct{}
-	proxy                  tcpproxy.Proxy
+	proxy                  *userSpaceProxy
+	loadBalancer           loadBalancerStatus
 }
 
 // Init extracts the needed binaries and creates the directories
@@ -160,6 +153,7 @@ func (k *Keepalived) Start(ctx context.Context) error {
 		reconcilerDone := make(chan struct{})
 		k.reconcilerDone = reconcilerDone
 		if len(k.Config.VirtualServers) > 0 {
+			k.loadBalancer.set(k.reconciler, nil)
 			templ, err := k.getTemplate(k.Config.ConfigTemplateVS, KeepalivedVirtualServersConfigTemplate, "keepalived-virtualservers")
 			if err != nil {
 				return fmt.Errorf("failed to parse keepalived template: %w", err)
@@ -174,12 +168,26 @@ func (k *Keepalived) Start(ctx context.Context) error {
 				k.watchReconcilerUpdatesKeepalived(templ)
 			}()
 		} else {
-			if err := k.startReverseProxy(); err != nil {
+			var vips []string
+			for _, vrrp := range k.Config.VRRPInstances {
+				vips = append(vips, vrrp.VirtualIPs...)
+			}
+			proxy := &userSpaceProxy{
+				log:        k.log,
+				binDir:     k.K0sVars.BinDir,
+				virtualIPs: vips,
+				apiPort:    k.APIPort,
+				bindPort:   k.Config.UserSpaceProxyPort,
+				balancing:  k.Config.UserSpaceProxyBalancing,
+			}
+			if err := proxy.start(); err != nil {
 				return fmt.Errorf("failed to start reverse proxy: %w", err)
 			}
+			k.proxy = proxy
+			k.loadBalancer.set(k.reconciler, proxy)
 			go func() {
 				defer close(reconcilerDone)
-				k.watchReconcilerUpdatesReverseProxy(ctx)
+				proxy.watchReconcilerUpdates(ctx, k.reconciler, k.updateCh)
 			}()
 		}
 	}
@@ -192,6 +200,7 @@ func (k *Keepalived) Start(ctx context.Context) error {
 func (k *Keepalived) Stop() error {
 	if k.reconciler != nil {
 		k.log.Info("Stopping cplb-reconciler")
+		k.loadBalancer.set(nil, nil)
 		k.reconciler.Stop()
 		close(k.updateCh)
 		<-k.reconcilerDone
@@ -218,12 +227,12 @@ func (k *Keepalived) Stop() error {
 		}
 		return netlink.LinkDel(link)
 	}
-	if err := k.proxy.Close(); err != nil {
-		return fmt.Errorf("failed to close proxy: %w", err)
-	}
 
 	// Only clean iptables rules if we are using the userspace reverse proxy
-	return k.redirectToProxyIPTables(iptablesCommandDelete)
+	if k.proxy != nil {
+		return k.proxy.stop()
+	}
+	return nil
 }
 
 // configureDummy creates the dummy interface and sets the virtual IPs on it.
@@ -397,76 +406,6 @@ func (k *Keepalived) generateTemplate(templ *template.Template, path string) err
 	return nil
 }
 
-func (k *Keepalived) startReverseProxy() error {
-	k.proxy = tcpproxy.Proxy{}
-	// We don't know how long until we get the first update, so initially we
-	// forward everything to localhost
-	k.proxy.SetRoutes(fmt.Sprintf(":%d", k.Config.UserSpaceProxyPort), []tcpproxy.Route{tcpproxy.To(fmt.Sprintf("127.0.0.1:%d", k.APIPort))})
-	if err := k.proxy.Start(); err != nil {
-		return fmt.Errorf("failed to start proxy: %w", err)
-	}
-	return k.redirectToProxyIPTables(iptablesCommandAppend)
-}
-
-func (k *Keepalived) watchReconcilerUpdatesReverseProxy(ctx context.Context) {
-	k.log.Info("Waiting for the first cplb-reconciler update")
-	select {
-	case <-ctx.Done():
-		k.log.Error("context canceled while starting the reverse proxy")
-	case <-k.updateCh:
-	}
-	k.setProxyRoutes()
-	for range k.updateCh {
-		k.setProxyRoutes()
-	}
-}
-
-func (k *Keepalived) setProxyRoutes() {
-	routes := []tcpproxy.Route{}
-	port := strconv.Itoa(k.APIPort)
-	for _, addr := range k.reconciler.GetIPs() {
-		routes = append(routes, tcpproxy.To(net.JoinHostPort(addr, port)))
-	}
-
-	if len(routes) == 0 {
-		k.log.Error("No API servers available, leave previous configuration")
-		return
-	}
-	k.proxy.SetRoutes(fmt.Sprintf(":%d", k.Config.UserSpaceProxyPort), routes)
-}
-
-func (k *Keepalived) redirectToProxyIPTables(op string) error {
-	for _, vrrp := range k.Config.VRRPInstances {
-		for _, vipCIDR := range vrrp.VirtualIPs {
-			vip, _, _ := strings.Cut(vipCIDR, "/")
-
-			cmdArgs := []string{
-				"-t", "nat", op, "PREROUTING", "-p", "tcp",
-				"-d", vip, "--dport", strconv.Itoa(k.APIPort),
-				"-j", "REDIRECT", "--to-port", strconv.Itoa(k.Config.UserSpaceProxyPort),
-			}
-
-			switch op {
-			case iptablesCommandAppend:
-				k.log.Infof("Adding iptables rule to redirect %s", vip)
-			case iptablesCommandDelete:
-				k.log.Infof("Deleting iptables rule to redirect %s", vip)
-			}
-
-			iptablesBin := "iptables"
-			if ip := net.ParseIP(vip); ip != nil && ip.To4() == nil {
-				iptablesBin = "ip6tables"
-			}
-			cmd := exec.Command(filepath.Join(k.K0sVars.BinDir, iptablesBin), cmdArgs...)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				return fmt.Errorf("failed to execute iptables command: %w, output: %s", err, output)
-			}
-		}
-	}
-	return nil
-}
-
 func (k *Keepalived) watchReconcilerUpdatesKeepalived(templ *template.Template) {
 	// Wait for the supervisor to start keepalived before
 	// watching for endpoint changes
@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"runtime"
 
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	k0sAPI "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/config"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
 )
 
 // Keepalived doesn't work on windows, so we cannot implement it at all.
@@ -37,3 +39,55 @@ func (k *Keepalived) Start(context.Context) error {
 func (k *Keepalived) Stop() error {
 	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
 }
+
+func (k *Keepalived) Status() *apv1beta2.CPLBStatus {
+	return nil
+}
+
+// LeaseVIP manages virtual IPs via netlink, which is only available on Linux.
+type LeaseVIP struct {
+	K0sVars           *config.CfgVars
+	Config            *k0sAPI.LeaseVIPSpec
+	APIPort           int
+	KubeConfigPath    string
+	KubeClientFactory kubeutil.ClientFactoryInterface
+}
+
+func (l *LeaseVIP) Init(context.Context) error {
+	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
+}
+
+func (l *LeaseVIP) Start(context.Context) error {
+	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
+}
+
+func (l *LeaseVIP) Stop() error {
+	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
+}
+
+func (l *LeaseVIP) Status() *apv1beta2.CPLBStatus {
+	return nil
+}
+
+// BGP manages virtual IPs via netlink, which is only available on Linux.
+type BGP struct {
+	Config         *k0sAPI.BGPSpec
+	APIAddress     string
+	KubeConfigPath string
+}
+
+func (b *BGP) Init(context.Context) error {
+	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
+}
+
+func (b *BGP) Start(context.Context) error {
+	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
+}
+
+func (b *BGP) Stop() error {
+	return fmt.Errorf("%w: CPLB is not supported on %s", errors.ErrUnsupported, runtime.GOOS)
+}
+
+func (b *BGP) Status() *apv1beta2.CPLBStatus {
+	return nil
+}
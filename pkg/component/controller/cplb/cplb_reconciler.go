@@ -169,6 +169,20 @@ func (r *CPLBReconciler) GetIPs() []string {
 	return healthyAddrs
 }
 
+// Backends returns the health of all known API server addresses.
+func (r *CPLBReconciler) Backends() map[string]bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	backends := make(map[string]bool, len(r.healthCheckers))
+	for addr, hc := range r.healthCheckers {
+		hc.mu.Lock()
+		backends[addr] = hc.healthy
+		hc.mu.Unlock()
+	}
+	return backends
+}
+
 // healthChecker provides health checking functionality for Kubernetes API servers
 type healthChecker struct {
 	mu            sync.Mutex
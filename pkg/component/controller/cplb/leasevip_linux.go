@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	k0sAPI "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// The name of the Lease in the kube-node-lease namespace that determines
+	// which controller holds the virtual IPs.
+	leaseVIPLeaseName = "k0s-cplb-leasevip"
+
+	// How often the virtual IPs are announced after taking the lead, and the
+	// interval between the announcements.
+	leaseVIPAnnouncements        = 3
+	leaseVIPAnnouncementInterval = 1 * time.Second
+
+	// How often the leader checks that the virtual IPs are still present on
+	// the interface after the announcements have been sent.
+	leaseVIPCheckInterval = 10 * time.Second
+)
+
+// LeaseVIP is the controller for the "LeaseVIP" type of control plane load
+// balancing. The controllers elect a leader using a Kubernetes Lease, which
+// then holds the virtual IPs. In contrast to the Keepalived type, no VRRP
+// traffic and no external binary is involved.
+type LeaseVIP struct {
+	K0sVars           *config.CfgVars
+	Config            *k0sAPI.LeaseVIPSpec
+	APIPort           int
+	KubeConfigPath    string
+	KubeClientFactory kubeutil.ClientFactoryInterface
+
+	log          logrus.FieldLogger
+	stop         func()
+	loadBalancer loadBalancerStatus
+}
+
+var _ manager.Component = (*LeaseVIP)(nil)
+
+func (l *LeaseVIP) Init(context.Context) error {
+	l.log = logrus.WithField("component", "CPLB")
+	return nil
+}
+
+// Start releases any virtual IPs that might have been left over and starts the
+// leader election. The userspace proxy is started, unless it's disabled.
+func (l *LeaseVIP) Start(ctx context.Context) (err error) {
+	if l.Config == nil || len(l.Config.VirtualIPs) == 0 {
+		l.log.Warn("No virtual IPs defined, skipping lease-based control plane load balancing")
+		return nil
+	}
+
+	vips, err := parseVirtualIPs(l.Config.VirtualIPs)
+	if err != nil {
+		return err
+	}
+
+	for _, vip := range vips {
+		if vip.IP.To4() == nil {
+			if err := setAddressLabel(vip.IP, virtualIPAddressLabel); err != nil {
+				return fmt.Errorf("failed to set address label for %s: %w", vip.IP, err)
+			}
+		}
+	}
+
+	// A previous run might not have released the virtual IPs.
+	if err := l.removeVirtualIPs(vips); err != nil {
+		return err
+	}
+
+	kubeClient, err := l.KubeClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+	client, err := leaderelection.NewClient(&leaderelection.LeaseConfig{
+		Namespace:     corev1.NamespaceNodeLease,
+		Name:          leaseVIPLeaseName,
+		Identity:      l.K0sVars.InvocationID,
+		Client:        kubeClient.CoordinationV1(),
+		LeaseDuration: time.Duration(l.Config.LeaseDurationSeconds) * time.Second,
+	})
+	if err != nil {
+		return err
+	}
+
+	var stops []func()
+	defer func() {
+		if err != nil {
+			for _, stop := range stops {
+				stop()
+			}
+		}
+	}()
+
+	if !l.Config.DisableLoadBalancer {
+		updateCh := make(chan struct{}, 1)
+		reconciler := NewCPLBReconciler(l.KubeConfigPath, l.APIPort, updateCh)
+		if err := reconciler.Start(); err != nil {
+			return fmt.Errorf("failed to start CPLB reconciler: %w", err)
+		}
+
+		proxy := &userSpaceProxy{
+			log:        l.log,
+			binDir:     l.K0sVars.BinDir,
+			virtualIPs: l.Config.VirtualIPs,
+			apiPort:    l.APIPort,
+			bindPort:   l.Config.UserSpaceProxyPort,
+			balancing:  l.Config.UserSpaceProxyBalancing,
+		}
+		if err := proxy.start(); err != nil {
+			reconciler.Stop()
+			return fmt.Errorf("failed to start reverse proxy: %w", err)
+		}
+
+		proxyDone := make(chan struct{})
+		go func() {
+			defer close(proxyDone)
+			proxy.watchReconcilerUpdates(ctx, reconciler, updateCh)
+		}()
+		l.loadBalancer.set(reconciler, proxy)
+
+		stops = append(stops, func() {
+			l.loadBalancer.set(nil, nil)
+			reconciler.Stop()
+			close(updateCh)
+			<-proxyDone
+			if err := proxy.stop(); err != nil {
+				l.log.WithError(err).Error("Failed to stop reverse proxy")
+			}
+		})
+	}
+
+	electionCtx, cancelElection := context.WithCancel(context.Background())
+	electionDone := make(chan struct{})
+	go func() {
+		defer close(electionDone)
+		l.runLeaderElection(electionCtx, client, vips)
+	}()
+	stops = append(stops, func() { cancelElection(); <-electionDone })
+
+	l.stop = func() {
+		// Stop in reverse order, i.e. release the virtual IPs first.
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+
+	return nil
+}
+
+// Stop gives up the lead, so that another controller can take over the virtual
+// IPs immediately, and stops the userspace proxy.
+func (l *LeaseVIP) Stop() error {
+	if l.stop != nil {
+		l.stop()
+	}
+	return nil
+}
+
+func (l *LeaseVIP) runLeaderElection(ctx context.Context, client *leaderelection.Client, vips []*netlink.Addr) {
+	var stopHolding func()
+
+	l.log.Info("Trying to acquire the lead to hold the virtual IPs")
+	client.Run(ctx, func(status leaderelection.Status) {
+		if status == leaderelection.StatusLeading {
+			l.log.Info("Took the lead, holding the virtual IPs")
+			holdCtx, cancel := context.WithCancel(ctx)
+			var wg sync.WaitGroup
+			wg.Go(func() { l.holdVirtualIPs(holdCtx, vips) })
+			stopHolding = func() { cancel(); wg.Wait() }
+			return
+		}
+
+		if stopHolding != nil {
+			stopHolding()
+			stopHolding = nil
+		}
+		l.log.Info("Lost the lead, releasing the virtual IPs")
+		if err := l.removeVirtualIPs(vips); err != nil {
+			l.log.WithError(err).Error("Failed to release the virtual IPs")
+		}
+	})
+}
+
+// Adds the virtual IPs to the interface and announces them to the network, a
+// few times in a row. Afterwards, ensures that the virtual IPs stay on the
+// interface until ctx is done.
+func (l *LeaseVIP) holdVirtualIPs(ctx context.Context, vips []*netlink.Addr) {
+	var announcements int
+	for {
+		interval := leaseVIPCheckInterval
+		if link, err := l.addVirtualIPs(vips); err != nil {
+			l.log.WithError(err).Error("Failed to add the virtual IPs")
+			interval = leaseVIPAnnouncementInterval
+		} else if announcements < leaseVIPAnnouncements {
+			announcements++
+			for _, vip := range vips {
+				if err := announceAddress(link.Attrs().Index, link.Attrs().HardwareAddr, vip.IP); err != nil {
+					l.log.WithError(err).Warn("Failed to announce ", vip.IP)
+				}
+			}
+			if announcements < leaseVIPAnnouncements {
+				interval = leaseVIPAnnouncementInterval
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (l *LeaseVIP) addVirtualIPs(vips []*netlink.Addr) (netlink.Link, error) {
+	link, err := netlink.LinkByName(l.Config.Interface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link %s: %w", l.Config.Interface, err)
+	}
+
+	for _, vip := range vips {
+		// AddrReplace doesn't fail if the address is already present.
+		if err := netlink.AddrReplace(link, vip); err != nil {
+			return nil, fmt.Errorf("failed to add %s to link %s: %w", vip.IPNet, l.Config.Interface, err)
+		}
+	}
+
+	return link, nil
+}
+
+func (l *LeaseVIP) removeVirtualIPs(vips []*netlink.Addr) error {
+	link, err := netlink.LinkByName(l.Config.Interface)
+	if err != nil {
+		return fmt.Errorf("failed to get link %s: %w", l.Config.Interface, err)
+	}
+
+	var errs []error
+	for _, vip := range vips {
+		if err := netlink.AddrDel(link, vip); err != nil && !errors.Is(err, unix.EADDRNOTAVAIL) {
+			errs = append(errs, fmt.Errorf("failed to remove %s from link %s: %w", vip.IPNet, l.Config.Interface, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Parses the virtual IP CIDRs into netlink addresses. IPv6 addresses skip
+// duplicate address detection, since they are supposed to move between nodes
+// and need to be usable immediately after a takeover.
+func parseVirtualIPs(cidrs []string) ([]*netlink.Addr, error) {
+	vips := make([]*netlink.Addr, len(cidrs))
+	for i, cidr := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CIDR %s: %w", cidr, err)
+		}
+		ipNet.IP = ip
+		vips[i] = &netlink.Addr{IPNet: ipNet}
+		if ip.To4() == nil {
+			vips[i].Flags = unix.IFA_F_NODAD
+		}
+	}
+	return vips, nil
+}
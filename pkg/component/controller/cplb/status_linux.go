@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"maps"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/k0sproject/k0s/internal/pkg/net/tcpproxy"
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	k0sAPI "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/vishvananda/netlink"
+)
+
+// The VRRP instance states, as reported in the CPLB status. They're named
+// after the keepalived states, but inferred from the interface.
+const (
+	vrrpStateMaster = "MASTER"
+	vrrpStateBackup = "BACKUP"
+	vrrpStateFault  = "FAULT"
+)
+
+// Status returns the VRRP instances' state and the virtual IPs held by this
+// controller, along with the health of the API servers.
+func (k *Keepalived) Status() *apv1beta2.CPLBStatus {
+	status := apv1beta2.CPLBStatus{Type: string(k0sAPI.CPLBTypeKeepalived)}
+	if k.Config == nil {
+		return &status
+	}
+
+	for _, vrrp := range k.Config.VRRPInstances {
+		up, addrs := linkState(vrrp.Interface)
+		state := vrrpStateMaster
+		for _, vip := range vrrp.VirtualIPs {
+			held := containsIP(addrs, vip)
+			status.VirtualIPs = append(status.VirtualIPs, apv1beta2.CPLBVirtualIPStatus{Address: vip, Held: held})
+			if !held {
+				state = vrrpStateBackup
+			}
+		}
+		if !up {
+			state = vrrpStateFault
+		}
+		status.VRRPInstances = append(status.VRRPInstances, apv1beta2.CPLBVRRPInstanceStatus{
+			VirtualRouterID: vrrp.VirtualRouterID,
+			Interface:       vrrp.Interface,
+			State:           state,
+		})
+	}
+
+	status.Backends = k.loadBalancer.backends()
+	return &status
+}
+
+// Status returns the virtual IPs held by this controller, along with the
+// health of the API servers.
+func (l *LeaseVIP) Status() *apv1beta2.CPLBStatus {
+	status := apv1beta2.CPLBStatus{Type: string(k0sAPI.CPLBTypeLeaseVIP)}
+	if l.Config == nil {
+		return &status
+	}
+
+	_, addrs := linkState(l.Config.Interface)
+	for _, vip := range l.Config.VirtualIPs {
+		status.VirtualIPs = append(status.VirtualIPs, apv1beta2.CPLBVirtualIPStatus{Address: vip, Held: containsIP(addrs, vip)})
+	}
+
+	status.Backends = l.loadBalancer.backends()
+	return &status
+}
+
+// Status returns the virtual IPs, which are held by this controller as long
+// as they are advertised to the BGP peers.
+func (b *BGP) Status() *apv1beta2.CPLBStatus {
+	status := apv1beta2.CPLBStatus{Type: string(k0sAPI.CPLBTypeBGP)}
+	if b.Config == nil {
+		return &status
+	}
+
+	advertised := b.advertised.Load()
+	for _, vip := range b.Config.VirtualIPs {
+		status.VirtualIPs = append(status.VirtualIPs, apv1beta2.CPLBVirtualIPStatus{Address: vip, Held: advertised})
+	}
+	return &status
+}
+
+// Returns whether the given link is up, along with its addresses. A link that
+// can't be inspected is reported as down, without any addresses.
+func linkState(name string) (bool, []netlink.Addr) {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return false, nil
+	}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		addrs = nil
+	}
+
+	attrs := link.Attrs()
+	up := attrs.Flags&net.FlagUp != 0 &&
+		attrs.OperState != netlink.OperDown &&
+		attrs.OperState != netlink.OperLowerLayerDown
+	return up, addrs
+}
+
+// Reports whether addrs contain the IP of the given virtual IP, which may be
+// given either with or without a prefix length.
+func containsIP(addrs []netlink.Addr, vip string) bool {
+	ip, _, err := net.ParseCIDR(vip)
+	if err != nil {
+		if ip = net.ParseIP(vip); ip == nil {
+			return false
+		}
+	}
+	return slices.ContainsFunc(addrs, func(addr netlink.Addr) bool {
+		return addr.IPNet != nil && addr.IP.Equal(ip)
+	})
+}
+
+// Keeps track of the API server health checks and the userspace proxy, if
+// any, so that their state can be reported while they're running.
+type loadBalancerStatus struct {
+	mu         sync.RWMutex
+	reconciler *CPLBReconciler
+	proxy      *userSpaceProxy
+}
+
+func (s *loadBalancerStatus) set(reconciler *CPLBReconciler, proxy *userSpaceProxy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reconciler, s.proxy = reconciler, proxy
+}
+
+// Returns the API servers known to the reconciler, sorted by address, along
+// with their state in the userspace proxy.
+func (s *loadBalancerStatus) backends() []apv1beta2.CPLBBackendStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.reconciler == nil {
+		return nil
+	}
+
+	var proxied []tcpproxy.BackendStatus
+	if s.proxy != nil {
+		proxied = s.proxy.proxy.Backends()
+	}
+
+	health := s.reconciler.Backends()
+	backends := make([]apv1beta2.CPLBBackendStatus, 0, len(health))
+	for _, addr := range slices.Sorted(maps.Keys(health)) {
+		backend := apv1beta2.CPLBBackendStatus{Address: addr, Healthy: health[addr]}
+		if s.proxy != nil {
+			hostPort := net.JoinHostPort(addr, strconv.Itoa(s.proxy.apiPort))
+			if i := slices.IndexFunc(proxied, func(b tcpproxy.BackendStatus) bool { return b.Addr == hostPort }); i >= 0 {
+				backend.Ejected = proxied[i].Ejected
+				backend.ActiveConnections = int32(min(proxied[i].ActiveConnections, math.MaxInt32))
+			}
+		}
+		backends = append(backends, backend)
+	}
+	return backends
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"net"
+	"testing"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+)
+
+func TestContainsIP(t *testing.T) {
+	addrs := []netlink.Addr{
+		{IPNet: &net.IPNet{IP: net.ParseIP("192.0.2.1"), Mask: net.CIDRMask(24, 32)}},
+		{IPNet: &net.IPNet{IP: net.ParseIP("2001:db8::100"), Mask: net.CIDRMask(128, 128)}},
+	}
+
+	assert.True(t, containsIP(addrs, "192.0.2.1/16"), "Prefix length should be ignored")
+	assert.True(t, containsIP(addrs, "2001:db8::100"))
+	assert.False(t, containsIP(addrs, "192.0.2.2/24"))
+	assert.False(t, containsIP(addrs, "not an IP"))
+	assert.False(t, containsIP(nil, "192.0.2.1"))
+}
+
+func TestLoadBalancerStatus_Backends(t *testing.T) {
+	var status loadBalancerStatus
+	assert.Nil(t, status.backends(), "No backends without a reconciler")
+
+	reconciler := &CPLBReconciler{healthCheckers: map[string]*healthChecker{
+		"192.0.2.2": {healthy: false},
+		"192.0.2.1": {healthy: true},
+	}}
+	status.set(reconciler, nil)
+	assert.Equal(t, []apv1beta2.CPLBBackendStatus{
+		{Address: "192.0.2.1", Healthy: true},
+		{Address: "192.0.2.2"},
+	}, status.backends())
+
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sirupsen/logrus"
+)
+
+const statusReportInterval = 10 * time.Second
+
+// StatusSource is implemented by the control plane load balancers that can
+// report their status.
+type StatusSource interface {
+	Status() *apv1beta2.CPLBStatus
+}
+
+// StatusReporter periodically writes the status of the control plane load
+// balancer into the controller's ControlNode object. The status is only
+// written if it changed.
+type StatusReporter struct {
+	Source            StatusSource
+	KubeClientFactory kubeutil.ClientFactoryInterface
+	ControlNodeName   string
+
+	log  logrus.FieldLogger
+	stop func()
+}
+
+var _ manager.Component = (*StatusReporter)(nil)
+
+func (r *StatusReporter) Init(context.Context) error {
+	r.log = logrus.WithField("component", "cplb-status-reporter")
+	return nil
+}
+
+func (r *StatusReporter) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		ticker := time.NewTicker(statusReportInterval)
+		defer ticker.Stop()
+
+		var reported *apv1beta2.CPLBStatus
+		for {
+			status := r.Source.Status()
+			if !equality.Semantic.DeepEqual(status, reported) {
+				switch err := r.report(ctx, status); {
+				case err == nil:
+					reported = status
+				case apierrors.IsNotFound(err):
+					// The ControlNode gets created by autopilot, which may
+					// not have happened yet.
+					r.log.WithError(err).Debug("Cannot report status yet, retrying in ", statusReportInterval)
+				case ctx.Err() == nil:
+					r.log.WithError(err).Warn("Failed to report status, retrying in ", statusReportInterval)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+
+	r.stop = func() { cancel(); wg.Wait() }
+	return nil
+}
+
+// Stop stops reporting and removes the status from the ControlNode, since the
+// controller won't hold any virtual IPs anymore.
+func (r *StatusReporter) Stop() error {
+	if r.stop != nil {
+		r.stop()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.report(ctx, nil); err != nil && !apierrors.IsNotFound(err) {
+		r.log.WithError(err).Warn("Failed to remove status")
+	}
+	return nil
+}
+
+func (r *StatusReporter) report(ctx context.Context, status *apv1beta2.CPLBStatus) error {
+	client, err := r.KubeClientFactory.GetK0sClient()
+	if err != nil {
+		return err
+	}
+	controlNodes := client.AutopilotV1beta2().ControlNodes()
+
+	node, err := controlNodes.Get(ctx, r.ControlNodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get ControlNode %s: %w", r.ControlNodeName, err)
+	}
+
+	if equality.Semantic.DeepEqual(node.Status.CPLB, status) {
+		return nil
+	}
+	node.Status.CPLB = status
+	if _, err := controlNodes.UpdateStatus(ctx, node, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update status of ControlNode %s: %w", r.ControlNodeName, err)
+	}
+
+	return nil
+}
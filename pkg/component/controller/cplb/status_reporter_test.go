@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusReporter_Report(t *testing.T) {
+	addresses := []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "192.0.2.1"}}
+	clients := testutil.NewFakeClientFactory(&apv1beta2.ControlNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "controller-0"},
+		Status:     apv1beta2.ControlNodeStatus{Addresses: addresses, K0sVersion: "v1.2.3"},
+	})
+	reporter := &StatusReporter{
+		KubeClientFactory: clients,
+		ControlNodeName:   "controller-0",
+		log:               logrus.New(),
+	}
+
+	status := &apv1beta2.CPLBStatus{
+		Type:       "LeaseVIP",
+		VirtualIPs: []apv1beta2.CPLBVirtualIPStatus{{Address: "192.0.2.100/24", Held: true}},
+		Backends: []apv1beta2.CPLBBackendStatus{
+			{Address: "192.0.2.1", Healthy: true, ActiveConnections: 3},
+			{Address: "192.0.2.2", Ejected: true},
+		},
+	}
+	require.NoError(t, reporter.report(t.Context(), status))
+
+	controlNodes := clients.K0sClient.AutopilotV1beta2().ControlNodes()
+	node, err := controlNodes.Get(t.Context(), "controller-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, status, node.Status.CPLB)
+	assert.Equal(t, addresses, node.Status.Addresses, "Addresses should be preserved")
+	assert.Equal(t, "v1.2.3", node.Status.K0sVersion, "K0s version should be preserved")
+
+	require.NoError(t, reporter.report(t.Context(), nil))
+	node, err = controlNodes.Get(t.Context(), "controller-0", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, node.Status.CPLB)
+}
+
+func TestStatusReporter_ReportNotFound(t *testing.T) {
+	reporter := &StatusReporter{
+		KubeClientFactory: testutil.NewFakeClientFactory(),
+		ControlNodeName:   "controller-0",
+		log:               logrus.New(),
+	}
+
+	err := reporter.report(t.Context(), &apv1beta2.CPLBStatus{Type: "BGP"})
+	assert.True(t, apierrors.IsNotFound(err), "Expected a NotFound error, got %v", err)
+}
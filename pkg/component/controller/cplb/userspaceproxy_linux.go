@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cplb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/k0sproject/k0s/internal/pkg/net/tcpproxy"
+	k0sAPI "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	iptablesCommandAppend = "-A"
+	iptablesCommandDelete = "-D"
+)
+
+// userSpaceProxy load balances the connections to the virtual IPs among all
+// API servers. It runs in the k0s process. The connections to the virtual IPs
+// on the API server port are redirected to it via iptables.
+type userSpaceProxy struct {
+	log        logrus.FieldLogger
+	binDir     string
+	virtualIPs []string // CIDRs
+	apiPort    int
+	bindPort   int
+	balancing  k0sAPI.UserSpaceProxyBalancing
+
+	proxy tcpproxy.Proxy
+}
+
+func (p *userSpaceProxy) start() error {
+	p.proxy = tcpproxy.Proxy{Name: "cplb", Policy: proxyPolicy(p.balancing)}
+	// We don't know how long until we get the first update, so initially we
+	// forward everything to localhost
+	p.proxy.SetRoutes(fmt.Sprintf(":%d", p.bindPort), []tcpproxy.Route{tcpproxy.To(fmt.Sprintf("127.0.0.1:%d", p.apiPort))})
+	if err := p.proxy.Start(); err != nil {
+		return fmt.Errorf("failed to start proxy: %w", err)
+	}
+	return p.redirectToProxyIPTables(iptablesCommandAppend)
+}
+
+func (p *userSpaceProxy) stop() error {
+	if err := p.proxy.Close(); err != nil {
+		return fmt.Errorf("failed to close proxy: %w", err)
+	}
+	return p.redirectToProxyIPTables(iptablesCommandDelete)
+}
+
+func proxyPolicy(balancing k0sAPI.UserSpaceProxyBalancing) tcpproxy.Policy {
+	switch balancing {
+	case k0sAPI.UserSpaceProxyBalancingPowerOfTwoChoices:
+		return tcpproxy.PowerOfTwoChoices
+	case k0sAPI.UserSpaceProxyBalancingRoundRobin:
+		return tcpproxy.RoundRobin
+	default:
+		return tcpproxy.LeastConnections
+	}
+}
+
+// Updates the proxy routes whenever the reconciler signals an update, until
+// updateCh is closed.
+func (p *userSpaceProxy) watchReconcilerUpdates(ctx context.Context, reconciler *CPLBReconciler, updateCh <-chan struct{}) {
+	p.log.Info("Waiting for the first cplb-reconciler update")
+	select {
+	case <-ctx.Done():
+		p.log.Error("context canceled while starting the reverse proxy")
+	case <-updateCh:
+	}
+	p.setRoutes(reconciler.GetIPs())
+	for range updateCh {
+		p.setRoutes(reconciler.GetIPs())
+	}
+}
+
+func (p *userSpaceProxy) setRoutes(ips []string) {
+	routes := []tcpproxy.Route{}
+	port := strconv.Itoa(p.apiPort)
+	for _, addr := range ips {
+		routes = append(routes, tcpproxy.To(net.JoinHostPort(addr, port)))
+	}
+
+	if len(routes) == 0 {
+		p.log.Error("No API servers available, leave previous configuration")
+		return
+	}
+	p.proxy.SetRoutes(fmt.Sprintf(":%d", p.bindPort), routes)
+}
+
+func (p *userSpaceProxy) redirectToProxyIPTables(op string) error {
+	for _, vipCIDR := range p.virtualIPs {
+		vip, _, _ := strings.Cut(vipCIDR, "/")
+
+		cmdArgs := []string{
+			"-t", "nat", op, "PREROUTING", "-p", "tcp",
+			"-d", vip, "--dport", strconv.Itoa(p.apiPort),
+			"-j", "REDIRECT", "--to-port", strconv.Itoa(p.bindPort),
+		}
+
+		switch op {
+		case iptablesCommandAppend:
+			p.log.Infof("Adding iptables rule to redirect %s", vip)
+		case iptablesCommandDelete:
+			p.log.Infof("Deleting iptables rule to redirect %s", vip)
+		}
+
+		iptablesBin := "iptables"
+		if ip := net.ParseIP(vip); ip != nil && ip.To4() == nil {
+			iptablesBin = "ip6tables"
+		}
+		cmd := exec.Command(filepath.Join(p.binDir, iptablesBin), cmdArgs...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to execute iptables command: %w, output: %s", err, output)
+		}
+	}
+	return nil
+}
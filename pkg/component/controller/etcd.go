@@ -144,6 +144,15 @@ func (e *Etcd) syncEtcdConfig(ctx context.Context, etcdRequest v1beta1.EtcdReque
 	return etcdResponse.InitialCluster, nil
 }
 
+// MemberName returns the name of the etcd member, which defaults to the
+// hostname.
+func (e *Etcd) MemberName() (string, error) {
+	if name, ok := e.Config.ExtraArgs["name"]; ok {
+		return name, nil
+	}
+	return os.Hostname()
+}
+
 // Run runs etcd if external cluster is not configured
 func (e *Etcd) Start(ctx context.Context) error {
 	etcdCaCert := filepath.Join(e.K0sVars.EtcdCertDir, "ca.crt")
@@ -157,13 +166,9 @@ func (e *Etcd) Start(ctx context.Context) error {
 
 	logrus.Info("Starting etcd")
 
-	var name string
-	if etcdName, ok := e.Config.ExtraArgs["name"]; ok {
-		name = etcdName
-	} else if hostName, err := os.Hostname(); err != nil {
+	name, err := e.MemberName()
+	if err != nil {
 		return err
-	} else {
-		name = hostName
 	}
 
 	peerURL := e.Config.GetPeerURL()
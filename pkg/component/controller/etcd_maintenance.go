@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/config"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+)
+
+// EtcdMaintenance takes care of the etcd member that runs on this controller.
+// K0s-managed etcd members only serve clients on the loopback interface, so
+// every controller maintains its own member:
+//
+//   - It periodically publishes a report about the member, so that the other
+//     controllers and the k0s etcd commands learn about its status.
+//   - It defragments the member whenever its database is fragmented beyond
+//     the configured threshold, one member at a time and the leader last.
+//   - It warns about the member's database approaching the backend quota.
+//
+// Additionally, the leading controller compacts the keyspace, if configured.
+type EtcdMaintenance struct {
+	K0sVars       *config.CfgVars
+	Config        *v1beta1.EtcdConfig
+	LeaderElector leaderelector.Interface
+
+	log       logrus.FieldLogger
+	newClient func() (etcdMaintenanceClient, error)
+	stop      func()
+}
+
+type etcdMaintenanceClient interface {
+	etcd.MaintenanceClient
+	LocalMemberMetrics(context.Context) (*etcd.MemberMetrics, error)
+	PublishMemberReport(context.Context, *etcd.MemberReport, time.Duration) error
+	Compact(ctx context.Context, retainedRevisions int64) (int64, error)
+	Close() error
+}
+
+// How often the member reports are published. The reports vanish if they
+// aren't republished for three times as long.
+const etcdMemberReportInterval = 15 * time.Second
+
+var _ manager.Component = (*EtcdMaintenance)(nil)
+
+// Init implements [manager.Component].
+func (m *EtcdMaintenance) Init(context.Context) error {
+	m.log = logrus.WithField("component", "etcd-maintenance")
+	if m.newClient == nil {
+		m.newClient = func() (etcdMaintenanceClient, error) {
+			return etcd.NewClient(m.K0sVars.CertRootDir, m.K0sVars.EtcdCertDir, m.Config)
+		}
+	}
+	return nil
+}
+
+// Start implements [manager.Component].
+func (m *EtcdMaintenance) Start(context.Context) error {
+	defrag := m.Config.Defrag
+	if defrag == nil {
+		defrag = v1beta1.DefaultEtcdDefrag()
+	}
+
+	client, err := m.newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create etcd client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			if err := m.report(ctx, client); err != nil {
+				m.log.WithError(err).Error("Failed to publish etcd member report")
+			}
+		}, etcdMemberReportInterval, 0.1, false)
+	})
+
+	wg.Go(func() {
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			if err := m.maintain(ctx, client, defrag); err != nil {
+				m.log.WithError(err).Error("Etcd maintenance failed")
+			}
+		}, defrag.Interval.Duration, 0.1, false)
+	})
+
+	if compaction := m.Config.Compaction; compaction.IsEnabled() {
+		wg.Go(func() {
+			wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+				if !m.LeaderElector.IsLeader() {
+					m.log.Debug("Not the leader, skipping etcd compaction")
+					return
+				}
+				if err := m.compact(ctx, client, compaction); err != nil {
+					m.log.WithError(err).Error("Etcd compaction failed")
+				}
+			}, compaction.Interval.Duration, 0.1, false)
+		})
+	}
+
+	m.stop = func() {
+		cancel(errors.New("etcd maintenance is stopping"))
+		wg.Wait()
+		if err := client.Close(); err != nil {
+			m.log.WithError(err).Error("Failed to close etcd client")
+		}
+	}
+
+	return nil
+}
+
+// Stop implements [manager.Component].
+func (m *EtcdMaintenance) Stop() error {
+	if m.stop != nil {
+		m.stop()
+	}
+	return nil
+}
+
+// Publishes a report about the local member.
+func (m *EtcdMaintenance) report(ctx context.Context, client etcdMaintenanceClient) error {
+	ctx, cancel := context.WithTimeout(ctx, etcdMemberReportInterval)
+	defer cancel()
+
+	status, err := client.LocalMemberStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := etcd.MemberReport{Status: *status, WALFsyncP99: -1, BackendCommitP99: -1}
+	if metrics, err := client.LocalMemberMetrics(ctx); err != nil {
+		m.log.WithError(err).Debug("Failed to scrape etcd metrics")
+	} else {
+		report.WALFsyncP99 = metrics.WALFsyncP99
+		report.BackendCommitP99 = metrics.BackendCommitP99
+	}
+	report.ReportedAt = time.Now()
+
+	return client.PublishMemberReport(ctx, &report, 3*etcdMemberReportInterval)
+}
+
+func (m *EtcdMaintenance) maintain(ctx context.Context, client etcdMaintenanceClient, settings *v1beta1.EtcdDefrag) error {
+	status, err := func() (*etcd.MemberStatus, error) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+		return client.LocalMemberStatus(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+	m.checkQuota(status, settings)
+
+	if settings.Disabled {
+		return nil
+	}
+
+	// Defragmentation of big databases may take a while.
+	ctx, cancel := context.WithTimeout(ctx, settings.Interval.Duration)
+	defer cancel()
+
+	result, err := etcd.DefragmentLocalMember(ctx, client, m.log, etcd.DefragOptions{
+		Select: func(status *etcd.MemberStatus) bool {
+			return status.DBSize >= settings.MinDBSize &&
+				status.FragmentedPercent() >= int64(settings.Threshold)
+		},
+	})
+	switch {
+	case errors.Is(err, etcd.ErrLocked), errors.Is(err, etcd.ErrDefragInProgress), errors.Is(err, etcd.ErrLeaderDeferred):
+		m.log.WithError(err).Info("Postponing etcd defragmentation")
+		return nil
+	case errors.Is(err, etcd.ErrNoQuorum):
+		m.log.WithError(err).Warn("Skipping etcd defragmentation")
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if result != nil {
+		m.log.Infof(
+			"Defragmented etcd member, database size went from %s to %s",
+			humanize.IBytes(uint64(result.SizeBefore)), humanize.IBytes(uint64(result.SizeAfter)),
+		)
+
+		// Let the others know about the new size right away.
+		if err := m.report(ctx, client); err != nil {
+			m.log.WithError(err).Error("Failed to publish etcd member report")
+		}
+	}
+
+	return nil
+}
+
+// Warns if the local member's database is approaching the backend quota, or
+// if it reports any errors, such as active alarms.
+func (m *EtcdMaintenance) checkQuota(status *etcd.MemberStatus, settings *v1beta1.EtcdDefrag) {
+	quota := m.Config.GetQuotaBackendBytes()
+	if status.DBSize*100 >= quota*int64(settings.QuotaWarningThreshold) {
+		m.log.Warnf(
+			"Etcd database size is approaching the backend quota: %s of %s used (%s in use)",
+			humanize.IBytes(uint64(status.DBSize)), humanize.IBytes(uint64(quota)), humanize.IBytes(uint64(status.DBSizeInUse)),
+		)
+	}
+	for _, err := range status.Errors {
+		m.log.Warn("Etcd member reports an error: ", err)
+	}
+}
+
+func (m *EtcdMaintenance) compact(ctx context.Context, client etcdMaintenanceClient, settings *v1beta1.EtcdCompaction) error {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	revision, err := client.Compact(ctx, settings.RetainedRevisions)
+	if err != nil {
+		return err
+	}
+	if revision > 0 {
+		m.log.Debug("Compacted etcd keyspace up to revision ", revision)
+	}
+	return nil
+}
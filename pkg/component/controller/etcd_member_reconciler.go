@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	nodeutil "k8s.io/component-helpers/node/util"
 )
 
@@ -123,7 +124,7 @@ func (e *EtcdMemberReconciler) resync(ctx context.Context, client etcdclient.Etc
 	// Loop through all the members and reconcile them
 	var failed bool
 	for _, member := range members.Items {
-		if !e.reconcileMember(ctx, etcdClient, client, clusterMembers, &member) {
+		if !e.reconcileMember(ctx, etcdClient, client, clusterMembers, members.Items, &member) {
 			failed = true
 		}
 	}
@@ -492,6 +493,7 @@ func (e *EtcdMemberReconciler) createMemberObject(ctx context.Context, client et
 	})
 	delete(em.Labels, shutdownLabelName) // Clear any lingering shutdown request on re-join.
 	em.Spec.Leave = false
+	em.Spec.Replace = false // This is the replacement, if any.
 
 	log.Debug("EtcdMember object already exists, updating it")
 	// Update the object if it already exists
@@ -502,6 +504,10 @@ func (e *EtcdMemberReconciler) createMemberObject(ctx context.Context, client et
 	em.Status.PeerAddress = e.etcdConfig.PeerAddress
 	em.Status.MemberID = memberIDStr
 	em.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionTrue, "Member joined", time.Now())
+	if em.Status.GetCondition(etcdv1beta1.ConditionTypeCaughtUp) != nil {
+		// The member joined as a learner, and has been promoted in the meantime.
+		em.Status.SetCondition(etcdv1beta1.ConditionTypeCaughtUp, etcdv1beta1.ConditionTrue, learnerCaughtUpMsg, time.Now())
+	}
 	if _, err := client.UpdateStatus(ctx, em, metav1.UpdateOptions{}); err != nil {
 		return fmt.Errorf("failed to update EtcdMember status: %w", err)
 	}
@@ -511,8 +517,9 @@ func (e *EtcdMemberReconciler) createMemberObject(ctx context.Context, client et
 
 // Applies the leave/shutdown protocol for a single EtcdMember. It prevents the
 // last controller from leaving, waits for active controllers to stop, then
-// removes the etcd member and updates status accordingly.
-func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *etcd.Client, client etcdclient.EtcdMemberInterface, clusterMembers []etcd.Member, member *etcdv1beta1.EtcdMember) bool {
+// removes the etcd member and updates status accordingly. Members marked for
+// replacement are handed over to replaceMember.
+func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *etcd.Client, client etcdclient.EtcdMemberInterface, clusterMembers []etcd.Member, crs []etcdv1beta1.EtcdMember, member *etcdv1beta1.EtcdMember) bool {
 	log := logrus.WithFields(logrus.Fields{
 		"component":   "etcdMemberReconciler",
 		"phase":       "reconcile",
@@ -565,6 +572,9 @@ func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *
 	}
 
 	if !member.Spec.Leave {
+		if member.Spec.Replace {
+			return e.replaceMember(ctx, etcdClient, client, clusterMembers, crs, member, memberID, log)
+		}
 		log.Debug("member not marked for leave, no action needed")
 		return true
 	}
@@ -617,10 +627,7 @@ func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *
 			return false
 		}
 
-		memberLeaseName := member.Labels[controllerLeaseLabelName]
-		if memberLeaseName == "" {
-			memberLeaseName = "k0s-ctrl-" + member.Name
-		}
+		memberLeaseName := controllerLeaseNameOf(member)
 		if memberLease, err := clients.CoordinationV1().Leases(corev1.NamespaceNodeLease).Get(ctx, memberLeaseName, metav1.GetOptions{}); err != nil {
 			log.WithError(err).Error("Failed to get k0s controller lease")
 			msg := "Failed to get k0s controller lease: " + err.Error()
@@ -671,7 +678,36 @@ func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *
 	}
 
 	log.Debug("Deleting member from cluster")
-	err = retry.Do(func() error {
+	if err := removeMember(ctx, etcdClient, memberID); err != nil {
+		log.WithError(err).Error("Failed to delete member from cluster")
+		member.Status.ReconcileStatus = etcdv1beta1.ReconcileStatusFailed
+		member.Status.Message = "Failed to delete member from cluster: " + err.Error()
+		if _, err := client.UpdateStatus(ctx, member, metav1.UpdateOptions{}); err != nil {
+			log.WithError(err).Error("Failed to update EtcdMember status")
+		}
+		return false
+	}
+
+	// Peer removed successfully, update status
+	log.Info("Member has been deleted from cluster")
+	member.Status.ReconcileStatus = etcdv1beta1.ReconcileStatusSuccess
+	member.Status.Message = "Member removed from cluster"
+	member.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionFalse, member.Status.Message, time.Now())
+	if _, err := client.UpdateStatus(ctx, member, metav1.UpdateOptions{}); err != nil {
+		log.WithError(err).Error("Failed to update EtcdMember status")
+		return false
+	}
+
+	return true
+}
+
+type memberRemover interface {
+	DeleteMember(ctx context.Context, memberID uint64) error
+}
+
+// Removes a member from the etcd cluster, retrying on transient errors.
+func removeMember(ctx context.Context, etcdClient memberRemover, memberID uint64) error {
+	return retry.Do(func() error {
 		return etcdClient.DeleteMember(ctx, memberID)
 	},
 		retry.Delay(5*time.Second),
@@ -690,8 +726,68 @@ func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *
 			return false
 		}),
 	)
+}
 
+// Removes a failed member that has been marked for replacement from the etcd
+// cluster, so that a new controller with the same name can take its place.
+// The member is only removed if its controller is gone and the remaining
+// members are able to maintain the quorum. The EtcdMember object is kept and
+// the removed member is recorded in its status, so that the replacement
+// inherits the object's history when it joins.
+func (e *EtcdMemberReconciler) replaceMember(ctx context.Context, etcdClient memberRemover, client etcdclient.EtcdMemberInterface, clusterMembers []etcd.Member, crs []etcdv1beta1.EtcdMember, member *etcdv1beta1.EtcdMember, memberID uint64, log logrus.FieldLogger) bool {
+	setPending := func(msg string) bool {
+		log.Info(msg)
+		member.Status.Message = msg
+		member.Status.ReconcileStatus = ""
+		if _, err := client.UpdateStatus(ctx, member, metav1.UpdateOptions{}); err != nil {
+			log.WithError(err).Error("Failed to update EtcdMember status")
+		}
+		return false
+	}
+
+	kubeClient, err := e.clientFactory.GetClient()
+	if err != nil {
+		log.WithError(err).Error("Failed to get Kubernetes client")
+		return false
+	}
+	leases := kubeClient.CoordinationV1().Leases(corev1.NamespaceNodeLease)
+
+	if active, err := isControllerActive(ctx, leases, controllerLeaseNameOf(member)); err != nil {
+		log.WithError(err).Error("Failed to get k0s controller lease")
+		return setPending("Failed to get k0s controller lease: " + err.Error())
+	} else if active {
+		return setPending("Member is still active; shut it down before replacing it, or use spec.leave instead")
+	}
+
+	crByID := make(map[uint64]*etcdv1beta1.EtcdMember, len(crs))
+	for i := range crs {
+		if id, err := strconv.ParseUint(crs[i].Status.MemberID, 16, 64); err == nil {
+			crByID[id] = &crs[i]
+		}
+	}
+
+	var lookupErr error
+	err = etcd.CheckQuorumWithout(clusterMembers, memberID, func(m etcd.Member) bool {
+		cr, ok := crByID[m.ID]
+		if !ok {
+			return false
+		}
+		active, err := isControllerActive(ctx, leases, controllerLeaseNameOf(cr))
+		if err != nil {
+			lookupErr = errors.Join(lookupErr, err)
+		}
+		return active
+	})
+	if lookupErr != nil {
+		log.WithError(lookupErr).Error("Failed to get k0s controller leases")
+		return setPending("Failed to get k0s controller leases: " + lookupErr.Error())
+	}
 	if err != nil {
+		return setPending("Cannot replace member: " + err.Error())
+	}
+
+	log.Info("Removing member from cluster for replacement")
+	if err := removeMember(ctx, etcdClient, memberID); err != nil {
 		log.WithError(err).Error("Failed to delete member from cluster")
 		member.Status.ReconcileStatus = etcdv1beta1.ReconcileStatusFailed
 		member.Status.Message = "Failed to delete member from cluster: " + err.Error()
@@ -701,11 +797,15 @@ func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *
 		return false
 	}
 
-	// Peer removed successfully, update status
-	log.Info("Member has been deleted from cluster")
+	now := time.Now()
+	member.Status.ReplacedMembers = append(member.Status.ReplacedMembers, etcdv1beta1.ReplacedMember{
+		PeerAddress: member.Status.PeerAddress,
+		MemberID:    member.Status.MemberID,
+		RemovalTime: metav1.NewTime(now),
+	})
 	member.Status.ReconcileStatus = etcdv1beta1.ReconcileStatusSuccess
-	member.Status.Message = "Member removed from cluster"
-	member.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionFalse, member.Status.Message, time.Now())
+	member.Status.Message = "Member removed from cluster; waiting for the replacement to join"
+	member.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionFalse, member.Status.Message, now)
 	if _, err := client.UpdateStatus(ctx, member, metav1.UpdateOptions{}); err != nil {
 		log.WithError(err).Error("Failed to update EtcdMember status")
 		return false
@@ -714,11 +814,41 @@ func (e *EtcdMemberReconciler) reconcileMember(ctx context.Context, etcdClient *
 	return true
 }
 
+// Returns the name of the lease held by the controller of the given EtcdMember.
+func controllerLeaseNameOf(member *etcdv1beta1.EtcdMember) string {
+	if leaseName := member.Labels[controllerLeaseLabelName]; leaseName != "" {
+		return leaseName
+	}
+	return "k0s-ctrl-" + member.Name
+}
+
+// Checks whether the controller lease with the given name is valid.
+func isControllerActive(ctx context.Context, leases coordinationv1client.LeaseInterface, leaseName string) (bool, error) {
+	lease, err := leases.Get(ctx, leaseName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return kubeutil.IsValidLease(*lease), nil
+}
+
+// Messages of the CaughtUp condition of learners.
+const (
+	learnerUnstartedMsg  = "Waiting for the learner to start"
+	learnerCatchingUpMsg = "Learner is catching up with the leader"
+	learnerCaughtUpMsg   = "Learner caught up with the leader"
+)
+
 // promoteLearners walks the etcd member list and tries to promote at most
-// one learner per call to a voting member. On success, the matching
-// EtcdMember CR's Joined condition is flipped to True — "Joined" only
-// becomes True once the member is a full voting peer, not while it's still
-// a non-voting learner.
+// one learner per call to a voting member. A learner is only promoted once it
+// has caught up with the leader's log: etcd itself rejects the promotion of
+// learners that are lagging behind. The progress is reflected in the matching
+// EtcdMember CR's CaughtUp condition. On success, the Joined condition is
+// flipped to True — "Joined" only becomes True once the member is a full
+// voting peer, not while it's still a non-voting learner.
 //
 // Promoting gradually (one per tick) keeps raft config changes serialized.
 // Members are shuffled so that successive ticks don't retry the same stuck
@@ -745,8 +875,29 @@ func (e *EtcdMemberReconciler) promoteLearners(
 		return false
 	}
 
+	return e.promoteLearner(ctx, etcdClient, client, members, crs, log)
+}
+
+type learnerPromoter interface {
+	PromoteMember(ctx context.Context, memberID uint64) error
+}
+
+func (e *EtcdMemberReconciler) promoteLearner(
+	ctx context.Context,
+	etcdClient learnerPromoter,
+	client etcdclient.EtcdMemberInterface,
+	members []etcd.Member,
+	crs []etcdv1beta1.EtcdMember,
+	log logrus.FieldLogger,
+) bool {
+	// The k0s join API records learners by their member ID before they have
+	// been started, i.e. before they have reported their names.
+	crByID := make(map[uint64]*etcdv1beta1.EtcdMember, len(crs))
 	crByName := make(map[string]*etcdv1beta1.EtcdMember, len(crs))
 	for i := range crs {
+		if id, err := strconv.ParseUint(crs[i].Status.MemberID, 16, 64); err == nil {
+			crByID[id] = &crs[i]
+		}
 		crByName[crs[i].Name] = &crs[i]
 	}
 
@@ -761,21 +912,28 @@ func (e *EtcdMemberReconciler) promoteLearners(
 			"memberName": m.Name,
 		})
 
+		// The matching CR may not exist — the k0s join API creates it on a
+		// best-effort basis, and the joining controller creates it only
+		// after its own etcd component reports ready, which can't happen
+		// while it is a learner (a learner cannot serve the readiness
+		// Range). We still attempt promotion; CR status is updated only
+		// when the CR exists.
+		cr, ok := crByID[m.ID]
+		if !ok {
+			cr = crByName[m.Name]
+		}
+
 		if m.Name == "" {
 			// etcdctl reports this state as "unstarted": the member has
 			// been added to the cluster but hasn't reported its name back
 			// yet. Try another learner on this tick.
 			log.Debug("member is unstarted, skipping")
+			e.updateConditions(ctx, client, cr, log, etcdv1beta1.JoinCondition{
+				Type: etcdv1beta1.ConditionTypeCaughtUp, Status: etcdv1beta1.ConditionFalse, Message: learnerUnstartedMsg,
+			})
 			continue
 		}
 
-		// The matching CR may not exist yet — the joining controller
-		// creates it only after its own etcd component reports ready,
-		// which can't happen while it is a learner (a learner cannot
-		// serve the readiness Range). We still attempt promotion; CR
-		// status is updated only when the CR exists.
-		cr := crByName[m.Name]
-
 		switch err := etcdClient.PromoteMember(ctx, m.ID); {
 		case err == nil:
 			log.Info("promoted learner to voting member")
@@ -790,6 +948,9 @@ func (e *EtcdMemberReconciler) promoteLearners(
 
 		case errors.Is(err, rpctypes.ErrMemberLearnerNotReady):
 			log.Debug("learner not ready for promotion yet")
+			e.updateConditions(ctx, client, cr, log, etcdv1beta1.JoinCondition{
+				Type: etcdv1beta1.ConditionTypeCaughtUp, Status: etcdv1beta1.ConditionFalse, Message: learnerCatchingUpMsg,
+			})
 			// Pending learner — return false so resync re-runs in 10s.
 			return false
 
@@ -809,16 +970,39 @@ func (e *EtcdMemberReconciler) setJoined(
 	client etcdclient.EtcdMemberInterface,
 	cr *etcdv1beta1.EtcdMember,
 	log logrus.FieldLogger,
+) {
+	e.updateConditions(ctx, client, cr, log,
+		etcdv1beta1.JoinCondition{Type: etcdv1beta1.ConditionTypeCaughtUp, Status: etcdv1beta1.ConditionTrue, Message: learnerCaughtUpMsg},
+		etcdv1beta1.JoinCondition{Type: etcdv1beta1.ConditionTypeJoined, Status: etcdv1beta1.ConditionTrue, Message: "Member joined"},
+	)
+}
+
+// Sets the given conditions on the CR and updates its status, unless the
+// conditions are already in place. No-op if the CR is nil.
+func (e *EtcdMemberReconciler) updateConditions(
+	ctx context.Context,
+	client etcdclient.EtcdMemberInterface,
+	cr *etcdv1beta1.EtcdMember,
+	log logrus.FieldLogger,
+	conditions ...etcdv1beta1.JoinCondition,
 ) {
 	if cr == nil {
 		return
 	}
-	existing := cr.Status.GetCondition(etcdv1beta1.ConditionTypeJoined)
-	if existing != nil && existing.Status == etcdv1beta1.ConditionTrue {
+
+	var changed bool
+	for _, c := range conditions {
+		if existing := cr.Status.GetCondition(c.Type); existing != nil && existing.Status == c.Status && existing.Message == c.Message {
+			continue
+		}
+		cr.Status.SetCondition(c.Type, c.Status, c.Message, time.Now())
+		changed = true
+	}
+	if !changed {
 		return
 	}
-	cr.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionTrue, "Member joined", time.Now())
+
 	if _, err := client.UpdateStatus(ctx, cr, metav1.UpdateOptions{}); err != nil {
-		log.WithError(err).Warn("failed to update Joined condition")
+		log.WithError(err).Warn("failed to update EtcdMember conditions")
 	}
 }
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	etcdv1beta1 "github.com/k0sproject/k0s/pkg/apis/etcd/v1beta1"
+	"github.com/k0sproject/k0s/pkg/etcd"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEtcdMemberReconciler_PromoteLearner(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	learner := &etcdv1beta1.EtcdMember{
+		ObjectMeta: metav1.ObjectMeta{Name: "controller-2"},
+		Status: etcdv1beta1.Status{
+			PeerAddress: "https://10.0.0.2:2380",
+			MemberID:    "2a",
+			Conditions: []etcdv1beta1.JoinCondition{
+				{Type: etcdv1beta1.ConditionTypeJoined, Status: etcdv1beta1.ConditionFalse, Message: "Member added as learner"},
+				{Type: etcdv1beta1.ConditionTypeCaughtUp, Status: etcdv1beta1.ConditionFalse, Message: learnerUnstartedMsg},
+			},
+		},
+	}
+
+	clients := testutil.NewFakeClientFactory(learner)
+	client, err := clients.GetEtcdMemberClient()
+	require.NoError(t, err)
+
+	promote := func(t *testing.T, promoter *fakeLearnerPromoter, members ...etcd.Member) (bool, *etcdv1beta1.EtcdMember) {
+		list, err := client.List(t.Context(), metav1.ListOptions{})
+		require.NoError(t, err)
+		ok := (&EtcdMemberReconciler{}).promoteLearner(t.Context(), promoter, client, members, list.Items, log)
+		cr, err := client.Get(t.Context(), learner.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return ok, cr
+	}
+
+	voter := etcd.Member{ID: 0x1f, Name: "controller-1"}
+
+	t.Run("Unstarted", func(t *testing.T) {
+		promoter := &fakeLearnerPromoter{}
+		ok, cr := promote(t, promoter, voter, etcd.Member{ID: 0x2a, IsLearner: true})
+		assert.True(t, ok)
+		assert.Empty(t, promoter.promoted)
+		assert.Equal(t, learnerUnstartedMsg, cr.Status.GetCondition(etcdv1beta1.ConditionTypeCaughtUp).Message)
+	})
+
+	t.Run("CatchingUp", func(t *testing.T) {
+		promoter := &fakeLearnerPromoter{err: rpctypes.ErrMemberLearnerNotReady}
+		ok, cr := promote(t, promoter, voter, etcd.Member{ID: 0x2a, Name: "controller-2", IsLearner: true})
+		assert.False(t, ok, "pending learners should trigger a resync")
+		if caughtUp := cr.Status.GetCondition(etcdv1beta1.ConditionTypeCaughtUp); assert.NotNil(t, caughtUp) {
+			assert.Equal(t, etcdv1beta1.ConditionFalse, caughtUp.Status)
+			assert.Equal(t, learnerCatchingUpMsg, caughtUp.Message)
+		}
+		assert.Equal(t, etcdv1beta1.ConditionFalse, cr.Status.GetCondition(etcdv1beta1.ConditionTypeJoined).Status)
+	})
+
+	t.Run("Promoted", func(t *testing.T) {
+		promoter := &fakeLearnerPromoter{}
+		ok, cr := promote(t, promoter, voter, etcd.Member{ID: 0x2a, Name: "controller-2", IsLearner: true})
+		assert.True(t, ok)
+		assert.Equal(t, []uint64{0x2a}, promoter.promoted)
+		if caughtUp := cr.Status.GetCondition(etcdv1beta1.ConditionTypeCaughtUp); assert.NotNil(t, caughtUp) {
+			assert.Equal(t, etcdv1beta1.ConditionTrue, caughtUp.Status)
+			assert.Equal(t, learnerCaughtUpMsg, caughtUp.Message)
+		}
+		assert.Equal(t, etcdv1beta1.ConditionTrue, cr.Status.GetCondition(etcdv1beta1.ConditionTypeJoined).Status)
+	})
+
+	t.Run("NoLearners", func(t *testing.T) {
+		promoter := &fakeLearnerPromoter{}
+		ok, _ := promote(t, promoter, voter, etcd.Member{ID: 0x2a, Name: "controller-2"})
+		assert.True(t, ok)
+		assert.Empty(t, promoter.promoted)
+	})
+}
+
+type fakeLearnerPromoter struct {
+	err      error
+	promoted []uint64
+}
+
+func (p *fakeLearnerPromoter) PromoteMember(_ context.Context, memberID uint64) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.promoted = append(p.promoted, memberID)
+	return nil
+}
+
+func TestEtcdMemberReconciler_ReplaceMember(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	clusterMembers := []etcd.Member{
+		{ID: 0x1f, Name: "controller-1"},
+		{ID: 0x2a, Name: "controller-2"},
+		{ID: 0x3c, Name: "controller-3"},
+	}
+
+	newMember := func(name, memberID string) *etcdv1beta1.EtcdMember {
+		member := &etcdv1beta1.EtcdMember{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: etcdv1beta1.Status{
+				PeerAddress: "10.0.0." + name[len(name)-1:],
+				MemberID:    memberID,
+			},
+		}
+		member.Status.SetCondition(etcdv1beta1.ConditionTypeJoined, etcdv1beta1.ConditionTrue, "Member joined", time.Now())
+		return member
+	}
+
+	newLease := func(name string, renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "k0s-ctrl-" + name, Namespace: corev1.NamespaceNodeLease},
+			Spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: ptr.To[int32](60),
+				RenewTime:            &metav1.MicroTime{Time: renewed},
+			},
+		}
+	}
+
+	run := func(t *testing.T, remover *fakeMemberRemover, leases ...*coordinationv1.Lease) (bool, *etcdv1beta1.EtcdMember) {
+		crs := []etcdv1beta1.EtcdMember{
+			*newMember("controller-1", "1f"),
+			*newMember("controller-2", "2a"),
+			*newMember("controller-3", "3c"),
+		}
+		crs[2].Spec.Replace = true
+
+		objects := []runtime.Object{&crs[0], &crs[1], &crs[2]}
+		for _, lease := range leases {
+			objects = append(objects, lease)
+		}
+		clients := testutil.NewFakeClientFactory(objects...)
+		client, err := clients.GetEtcdMemberClient()
+		require.NoError(t, err)
+
+		underTest := &EtcdMemberReconciler{clientFactory: clients}
+		ok := underTest.replaceMember(t.Context(), remover, client, clusterMembers, crs, &crs[2], 0x3c, log)
+
+		member, err := client.Get(t.Context(), "controller-3", metav1.GetOptions{})
+		require.NoError(t, err)
+		return ok, member
+	}
+
+	t.Run("Replaces", func(t *testing.T) {
+		remover := &fakeMemberRemover{}
+		now := time.Now()
+		ok, member := run(t, remover,
+			newLease("controller-1", now), newLease("controller-2", now), newLease("controller-3", now.Add(-10*time.Minute)),
+		)
+
+		assert.True(t, ok)
+		assert.Equal(t, []uint64{0x3c}, remover.removed)
+		assert.True(t, member.Spec.Replace, "replace flag should be kept until the replacement joins")
+		assert.Equal(t, etcdv1beta1.ReconcileStatusSuccess, member.Status.ReconcileStatus)
+		assert.Equal(t, etcdv1beta1.ConditionFalse, member.Status.GetCondition(etcdv1beta1.ConditionTypeJoined).Status)
+		if assert.Len(t, member.Status.ReplacedMembers, 1) {
+			assert.Equal(t, "3c", member.Status.ReplacedMembers[0].MemberID)
+			assert.Equal(t, "10.0.0.3", member.Status.ReplacedMembers[0].PeerAddress)
+		}
+	})
+
+	t.Run("MemberStillActive", func(t *testing.T) {
+		remover := &fakeMemberRemover{}
+		now := time.Now()
+		ok, member := run(t, remover,
+			newLease("controller-1", now), newLease("controller-2", now), newLease("controller-3", now),
+		)
+
+		assert.False(t, ok)
+		assert.Empty(t, remover.removed)
+		assert.Contains(t, member.Status.Message, "Member is still active")
+		assert.Empty(t, member.Status.ReplacedMembers)
+	})
+
+	t.Run("NoQuorum", func(t *testing.T) {
+		remover := &fakeMemberRemover{}
+		ok, member := run(t, remover, newLease("controller-1", time.Now()))
+
+		assert.False(t, ok)
+		assert.Empty(t, remover.removed)
+		assert.Equal(t, "Cannot replace member: not enough healthy voting members to retain quorum (1 of 2 other voting members healthy, 2 required)", member.Status.Message)
+		assert.Equal(t, etcdv1beta1.ConditionTrue, member.Status.GetCondition(etcdv1beta1.ConditionTypeJoined).Status)
+	})
+
+	t.Run("RemovalFails", func(t *testing.T) {
+		remover := &fakeMemberRemover{err: errors.New("boom")}
+		now := time.Now()
+		ok, member := run(t, remover, newLease("controller-1", now), newLease("controller-2", now))
+
+		assert.False(t, ok)
+		assert.Equal(t, etcdv1beta1.ReconcileStatusFailed, member.Status.ReconcileStatus)
+		assert.Equal(t, "Failed to delete member from cluster: boom", member.Status.Message)
+		assert.Empty(t, member.Status.ReplacedMembers)
+	})
+}
+
+type fakeMemberRemover struct {
+	err     error
+	removed []uint64
+}
+
+func (r *fakeMemberRemover) DeleteMember(_ context.Context, memberID uint64) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.removed = append(r.removed, memberID)
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/k0sproject/k0s/pkg/helm"
 	"github.com/k0sproject/k0s/pkg/kubernetes"
 	"github.com/k0sproject/k0s/pkg/leaderelection"
+	"github.com/k0sproject/k0s/pkg/tracing"
 	"github.com/k0sproject/k0s/static"
 
 	corev1 "k8s.io/api/core/v1"
@@ -44,6 +45,8 @@ import (
 	"github.com/Masterminds/sprig"
 	"github.com/bombsimon/logrusr/v4"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
@@ -268,10 +271,17 @@ type ChartReconciler struct {
 	L               *logrus.Entry
 }
 
-func (cr *ChartReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+func (cr *ChartReconciler) Reconcile(ctx context.Context, req reconcile.Request) (_ reconcile.Result, err error) {
 	if !cr.leaderElector.IsLeader() {
 		return reconcile.Result{}, nil
 	}
+
+	ctx, span := tracer.Start(ctx, "Reconcile Helm chart", trace.WithAttributes(
+		attribute.String("k0s.chart.namespace", req.Namespace),
+		attribute.String("k0s.chart.name", req.Name),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	cr.L.Tracef("Got helm chart reconciliation request: %s", req)
 	defer cr.L.Tracef("Finished processing helm chart reconciliation request: %s", req)
 
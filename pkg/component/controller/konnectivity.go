@@ -86,7 +86,7 @@ func (k *Konnectivity) Init(ctx context.Context) error {
 
 // Run ..
 func (k *Konnectivity) Start(ctx context.Context) error {
-	serverCount, serverCountChanged := k.ServerCount()
+	serverCount, serverCountChanged := k.serverCount()
 
 	if err := k.runServer(ctx, serverCount); err != nil {
 		k.EmitWithPayload("failed to start konnectivity server", err)
@@ -103,7 +103,7 @@ func (k *Konnectivity) Start(ctx context.Context) error {
 			select {
 			case <-serverCountChanged:
 				prevServerCount := serverCount
-				serverCount, serverCountChanged = k.ServerCount()
+				serverCount, serverCountChanged = k.serverCount()
 				// Never drop below one server: the agent treats zero as one internally anyways.
 				serverCount = max(1, serverCount)
 				// restart only if the server count actually changed
@@ -137,6 +137,16 @@ func (k *Konnectivity) Start(ctx context.Context) error {
 	return nil
 }
 
+// Returns the server count to be advertised to the agents. If the agents
+// connect to each server directly, every agent only needs to reach a single
+// server, so the count is fixed to one.
+func (k *Konnectivity) serverCount() (uint, <-chan struct{}) {
+	if k.Spec.IsPerServer() {
+		return 1, nil
+	}
+	return k.ServerCount()
+}
+
 func (k *Konnectivity) serverArgs(count uint) []string {
 	return stringmap.StringMap{
 		"--uds-name":                  filepath.Join(k.K0sVars.KonnectivitySocketDir, "konnectivity-server.sock"),
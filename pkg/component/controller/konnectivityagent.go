@@ -5,8 +5,14 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/netip"
+	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -14,17 +20,41 @@ import (
 	"github.com/k0sproject/k0s/internal/pkg/dir"
 	k0snet "github.com/k0sproject/k0s/internal/pkg/net"
 	"github.com/k0sproject/k0s/internal/pkg/templatewriter"
+	"github.com/k0sproject/k0s/internal/sync/value"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/applier"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/component/prober"
+	"github.com/k0sproject/k0s/pkg/component/status"
 	"github.com/k0sproject/k0s/pkg/constant"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/kubernetes/watch"
+	"github.com/k0sproject/k0s/pkg/leaderelection"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+// The name of the stack that contains the konnectivity agents.
+const KonnectivityAgentStackName = "konnectivity"
+
 type KonnectivityAgent struct {
 	ManifestsDir           string
 	KonnectivityServerHost string
 	ServerCount            func() (uint, <-chan struct{})
 
+	// Used to discover the addresses of the konnectivity servers, in case the
+	// agents connect to each server directly.
+	KubeClientFactory kubeutil.ClientFactoryInterface
+
+	// Whether the API servers are exposed via an external address. The
+	// konnectivity servers can't be discovered in this case, since the
+	// endpoints of the kubernetes service contain the external address.
+	HasExternalAPIAddress bool
+
 	configChangeChan chan *v1beta1.ClusterConfig
 	log              *logrus.Entry
 	previousConfig   konnectivityAgentConfig
@@ -43,9 +73,18 @@ func (k *KonnectivityAgent) Init(_ context.Context) error {
 }
 
 func (k *KonnectivityAgent) Start(ctx context.Context) error {
+	var servers value.Latest[[]string]
+	if k.KubeClientFactory != nil {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := k.watchServers(ctx, servers.Set); err != nil && ctx.Err() == nil {
+				k.log.WithError(err).Error("Failed to watch konnectivity server addresses")
+			}
+		}, 10*time.Second)
+	}
 
 	go func() {
 		serverCount, serverCountChanged := k.ServerCount()
+		serverAddrs, serversChanged := servers.Peek()
 
 		var clusterConfig *v1beta1.ClusterConfig
 		var retry <-chan time.Time
@@ -64,6 +103,14 @@ func (k *KonnectivityAgent) Start(ctx context.Context) error {
 					continue
 				}
 
+			case <-serversChanged:
+				prevServerAddrs := serverAddrs
+				serverAddrs, serversChanged = servers.Peek()
+				// write only if the servers actually changed
+				if slices.Equal(serverAddrs, prevServerAddrs) {
+					continue
+				}
+
 			case <-retry:
 				k.log.Info("Retrying to write konnectivity agent manifest")
 
@@ -78,7 +125,7 @@ func (k *KonnectivityAgent) Start(ctx context.Context) error {
 				continue
 			}
 
-			if err := k.writeKonnectivityAgent(clusterConfig, serverCount); err != nil {
+			if err := k.writeKonnectivityAgent(clusterConfig, serverCount, serverAddrs); err != nil {
 				k.log.Errorf("failed to write konnectivity agent manifest: %v", err)
 				retry = time.After(10 * time.Second)
 				continue
@@ -100,8 +147,8 @@ func (k *KonnectivityAgent) Stop() error {
 	return nil
 }
 
-func (k *KonnectivityAgent) writeKonnectivityAgent(clusterConfig *v1beta1.ClusterConfig, serverCount uint) error {
-	konnectivityDir := filepath.Join(k.ManifestsDir, "konnectivity")
+func (k *KonnectivityAgent) writeKonnectivityAgent(clusterConfig *v1beta1.ClusterConfig, serverCount uint, servers []string) error {
+	konnectivityDir := filepath.Join(k.ManifestsDir, KonnectivityAgentStackName)
 	err := dir.Init(konnectivityDir, constant.ManifestsDirMode)
 	if err != nil {
 		return err
@@ -115,7 +162,25 @@ func (k *KonnectivityAgent) writeKonnectivityAgent(clusterConfig *v1beta1.Cluste
 		PullPolicy:      clusterConfig.Spec.Images.DefaultPullPolicy,
 	}
 
-	if externalAddress := clusterConfig.Spec.Konnectivity.ExternalAddress; externalAddress != "" {
+	if clusterConfig.Spec.Konnectivity.IsPerServer() {
+		// The agents connect to each server directly, so there's no need for
+		// any load balancing, and the server count doesn't matter.
+		if k.HasExternalAPIAddress {
+			return errors.New("agent connection mode PerServer cannot be used in conjunction with an external Kubernetes API server address")
+		}
+		if len(servers) == 0 {
+			k.log.Info("Konnectivity server addresses not yet discovered")
+			return nil
+		}
+		cfg.ServerCount = 0
+		for _, server := range servers {
+			cfg.DaemonSets = append(cfg.DaemonSets, konnectivityAgentDaemonSet{
+				Name:            konnectivityAgentName(server),
+				ServerLabel:     konnectivityServerLabelValue(server),
+				ProxyServerHost: server,
+			})
+		}
+	} else if externalAddress := clusterConfig.Spec.Konnectivity.ExternalAddress; externalAddress != "" {
 		serverHostPort, err := k0snet.ParseHostPortWithDefault(externalAddress, cfg.ProxyServerPort)
 		if err != nil {
 			return fmt.Errorf("failed to determine proxy server host and port (%q, %d): %w", externalAddress, cfg.ProxyServerPort, err)
@@ -166,13 +231,29 @@ func (k *KonnectivityAgent) writeKonnectivityAgent(clusterConfig *v1beta1.Cluste
 					cfg.ProxyServerPort = uint16(*v1beta1.DefaultTraefik().KonnectivityServerBindPort)
 				}
 
+			case v1beta1.NllbTypeTCPProxy:
+				k.log.Debug("Enabling node-local load balancing via ", nllb.Type)
+
+				if nllb.TCPProxy.KonnectivityServerBindPort != nil {
+					cfg.ProxyServerPort = uint16(*nllb.TCPProxy.KonnectivityServerBindPort)
+				} else {
+					cfg.ProxyServerPort = uint16(*v1beta1.DefaultTCPProxy().KonnectivityServerBindPort)
+				}
+
 			default:
 				return fmt.Errorf("unsupported node-local load balancer type: %q", clusterConfig.Spec.Network.NodeLocalLoadBalancing.Type)
 			}
 		}
 	}
 
-	if cfg == k.previousConfig {
+	if cfg.DaemonSets == nil {
+		cfg.DaemonSets = []konnectivityAgentDaemonSet{{
+			Name:            "konnectivity-agent",
+			ProxyServerHost: cfg.ProxyServerHost,
+		}}
+	}
+
+	if reflect.DeepEqual(cfg, k.previousConfig) {
 		k.log.Debug("agent configs match, no need to reconcile")
 		return nil
 	}
@@ -200,6 +281,182 @@ type konnectivityAgentConfig struct {
 	ServerCount     uint
 	PullPolicy      string
 	HostNetwork     bool
+	DaemonSets      []konnectivityAgentDaemonSet
+}
+
+type konnectivityAgentDaemonSet struct {
+	Name            string
+	ServerLabel     string // only set if the agents connect to a single server
+	ProxyServerHost string
+}
+
+// The label that identifies the agents that connect to a single konnectivity
+// server. Its value is derived from the server's address.
+const konnectivityServerLabel = "k0s.k0sproject.io/konnectivity-server"
+
+func konnectivityAgentName(server string) string {
+	return "konnectivity-agent-" + konnectivityServerLabelValue(server)
+}
+
+// Converts the server address into something that's usable both in label
+// values and in object names. IPv6 addresses are expanded, so that they
+// neither start nor end with a dash.
+func konnectivityServerLabelValue(server string) string {
+	value := server
+	if addr, err := netip.ParseAddr(server); err == nil && addr.Is6() {
+		value = addr.StringExpanded()
+	}
+	return strings.NewReplacer(".", "-", ":", "-").Replace(value)
+}
+
+// KonnectivityAgentsStatus returns the nodes whose konnectivity agents connect
+// to the konnectivity server at the given address. Returns nil if there are no
+// agents that connect to this server directly.
+func KonnectivityAgentsStatus(ctx context.Context, clientFactory kubeutil.ClientFactoryInterface, server string) *status.KonnectivityAgentsStatus {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	agentsStatus := status.KonnectivityAgentsStatus{Server: server}
+	if err := func() error {
+		client, err := clientFactory.GetClient()
+		if err != nil {
+			return err
+		}
+
+		_, err = client.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(ctx, konnectivityAgentName(server), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		pods, err := client.CoreV1().Pods(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{
+			LabelSelector: konnectivityServerLabel + "=" + konnectivityServerLabelValue(server),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == "" {
+				continue
+			}
+			// The agents only have a single server to connect to, hence
+			// they're ready if and only if they're connected to it.
+			if slices.ContainsFunc(pod.Status.Conditions, func(c corev1.PodCondition) bool {
+				return c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue
+			}) {
+				agentsStatus.Connected = append(agentsStatus.Connected, pod.Spec.NodeName)
+			} else {
+				agentsStatus.Disconnected = append(agentsStatus.Disconnected, pod.Spec.NodeName)
+			}
+		}
+		slices.Sort(agentsStatus.Connected)
+		slices.Sort(agentsStatus.Disconnected)
+		return nil
+	}(); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		agentsStatus.Error = err.Error()
+	}
+
+	return &agentsStatus
+}
+
+// Watches the endpoints of the kubernetes service. The API server addresses
+// are the konnectivity server addresses as well, since both of them run on
+// each controller.
+func (k *KonnectivityAgent) watchServers(ctx context.Context, update func([]string)) error {
+	client, err := k.KubeClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	return watch.Endpoints(client.CoreV1().Endpoints(metav1.NamespaceDefault)).
+		WithObjectName("kubernetes").
+		Until(ctx, func(endpoints *corev1.Endpoints) (bool, error) {
+			var servers []string
+			for _, subset := range endpoints.Subsets {
+				for _, address := range subset.Addresses {
+					if address.IP != "" && !slices.Contains(servers, address.IP) {
+						servers = append(servers, address.IP)
+					}
+				}
+			}
+			if len(servers) > 0 {
+				slices.Sort(servers)
+				update(servers)
+			}
+			return false, nil
+		})
+}
+
+// KonnectivityAgentRemoval removes the konnectivity agents from the cluster
+// after konnectivity has been disabled. The applier manager only deletes the
+// stacks whose manifests are removed while it's running, so the stack is
+// deleted explicitly by the leading controller.
+type KonnectivityAgentRemoval struct {
+	ManifestsDir      string
+	KubeClientFactory kubeutil.ClientFactoryInterface
+	LeaderElector     leaderelector.Interface
+
+	stop func()
+}
+
+var _ manager.Component = (*KonnectivityAgentRemoval)(nil)
+
+// Init implements [manager.Component]. It removes the agent manifests, so that
+// the applier manager won't apply them again.
+func (k *KonnectivityAgentRemoval) Init(context.Context) error {
+	return os.RemoveAll(filepath.Join(k.ManifestsDir, KonnectivityAgentStackName))
+}
+
+// Start implements [manager.Component]. It deletes the agent stack when
+// becoming the leader.
+func (k *KonnectivityAgentRemoval) Start(context.Context) error {
+	log := logrus.WithField("component", "konnectivity-agent-removal")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		leaderelection.RunLeaderTasks(ctx, k.LeaderElector.CurrentStatus, func(ctx context.Context) {
+			for {
+				err := k.removeStack(ctx)
+				if err == nil {
+					return
+				}
+
+				log.WithError(err).Error("Failed to remove konnectivity agents, retrying in 30 seconds")
+
+				select {
+				case <-time.After(30 * time.Second):
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}()
+
+	k.stop = func() { cancel(errors.New("konnectivity agent removal is stopping")); <-done }
+
+	return nil
+}
+
+// Stop implements [manager.Component].
+func (k *KonnectivityAgentRemoval) Stop() error {
+	if stop := k.stop; stop != nil {
+		stop()
+	}
+	return nil
+}
+
+// Applies an empty agent stack, which prunes all of the stack's resources.
+func (k *KonnectivityAgentRemoval) removeStack(ctx context.Context) error {
+	stack := applier.Stack{
+		Name:    KonnectivityAgentStackName,
+		Clients: k.KubeClientFactory,
+	}
+	return stack.Apply(ctx, true)
 }
 
 const konnectivityAgentTemplate = `
@@ -225,6 +482,7 @@ metadata:
   namespace: kube-system
   labels:
     kubernetes.io/cluster-service: "true"
+{{- range .DaemonSets }}
 ---
 apiVersion: apps/v1
 # Alternatively, you can deploy the agents as Deployments. It is not necessary
@@ -233,16 +491,25 @@ kind: DaemonSet
 metadata:
   labels:
     k8s-app: konnectivity-agent
+    {{- with .ServerLabel }}
+    ` + konnectivityServerLabel + `: {{ . }}
+    {{- end }}
   namespace: kube-system
-  name: konnectivity-agent
+  name: {{ .Name }}
 spec:
   selector:
     matchLabels:
       k8s-app: konnectivity-agent
+      {{- with .ServerLabel }}
+      ` + konnectivityServerLabel + `: {{ . }}
+      {{- end }}
   template:
     metadata:
       labels:
         k8s-app: konnectivity-agent
+        {{- with .ServerLabel }}
+        ` + konnectivityServerLabel + `: {{ . }}
+        {{- end }}
       annotations:
         prometheus.io/scrape: 'true'
         prometheus.io/port: '8093'
@@ -250,7 +517,7 @@ spec:
       securityContext:
         runAsNonRoot: true
         supplementalGroups: [0]` /* in order to read the projected service account token */ + `
-        {{- if .HostNetwork }}
+        {{- if $.HostNetwork }}
         windowsOptions:
           hostProcess: true
           runAsUserName: NT AUTHORITY\Local service
@@ -258,18 +525,20 @@ spec:
       priorityClassName: system-cluster-critical
       tolerations:
         - operator: Exists
-      {{- if .HostNetwork }}
+      {{- if $.HostNetwork }}
       hostNetwork: true
       {{- end }}
       containers:
-        - image: {{ .Image }}
-          imagePullPolicy: {{ .PullPolicy }}
+        - image: {{ $.Image }}
+          imagePullPolicy: {{ $.PullPolicy }}
           name: konnectivity-agent
           env:
+              {{- if not .ServerLabel }}
               # the variable is not in a use
               # we need it to have agent restarted on server count change
               - name: K0S_CONTROLLER_COUNT
-                value: "{{ .ServerCount }}"
+                value: "{{ $.ServerCount }}"
+              {{- end }}
 
               - name: NODE_IP
                 valueFrom:
@@ -279,7 +548,7 @@ spec:
             - --logtostderr=true
             - --ca-cert=/var/run/secrets/kubernetes.io/serviceaccount/ca.crt
             - --proxy-server-host={{ .ProxyServerHost }}
-            - --proxy-server-port={{ .ProxyServerPort }}
+            - --proxy-server-port={{ $.ProxyServerPort }}
             - --service-account-token-path=/var/run/secrets/tokens/konnectivity-agent-token
             - --agent-identifiers=host=$(NODE_IP)
             - --agent-id=$(NODE_IP)
@@ -312,4 +581,5 @@ spec:
               - serviceAccountToken:
                   path: konnectivity-agent-token
                   audience: system:konnectivity-server
+{{- end }}
 `
@@ -5,17 +5,25 @@ package controller
 
 import (
 	"cmp"
+	"path/filepath"
 	"testing"
 
+	"github.com/k0sproject/k0s/internal/testutil"
 	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/applier"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
 	"github.com/k0sproject/k0s/pkg/component/prober"
+	"github.com/k0sproject/k0s/pkg/component/status"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/resource"
 	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -63,7 +71,7 @@ func TestKonnectivityAgent_ProxyServerHostPort(t *testing.T) {
 							AgentPort:       9876,
 						},
 					},
-				}, 1))
+				}, 1, nil))
 
 				daemonSet := loadDaemonSet(t, manifestsDir)
 				containers := daemonSet.Spec.Template.Spec.Containers
@@ -77,7 +85,148 @@ func TestKonnectivityAgent_ProxyServerHostPort(t *testing.T) {
 	}
 }
 
+func TestKonnectivityAgent_PerServer(t *testing.T) {
+	manifestsDir := t.TempDir()
+	underTest := KonnectivityAgent{
+		ManifestsDir:           manifestsDir,
+		KonnectivityServerHost: "api.example.com",
+		EventEmitter:           prober.NewEventEmitter(),
+		log:                    logrus.NewEntry(logrus.New()),
+	}
+
+	clusterConfig := &k0sv1beta1.ClusterConfig{
+		Spec: &k0sv1beta1.ClusterSpec{
+			Images: k0sv1beta1.DefaultClusterImages(),
+			Konnectivity: &k0sv1beta1.KonnectivitySpec{
+				AgentPort:           9876,
+				AgentConnectionMode: k0sv1beta1.KonnectivityAgentConnectionModePerServer,
+			},
+			Network: &k0sv1beta1.Network{
+				NodeLocalLoadBalancing: &k0sv1beta1.NodeLocalLoadBalancing{
+					Enabled: true,
+					Type:    k0sv1beta1.NllbTypeEnvoyProxy,
+				},
+			},
+		},
+	}
+
+	t.Run("no_servers", func(t *testing.T) {
+		require.NoError(t, underTest.writeKonnectivityAgent(clusterConfig, 2, nil))
+		assert.NoFileExists(t, filepath.Join(manifestsDir, "konnectivity", "konnectivity-agent.yaml"))
+	})
+
+	t.Run("servers", func(t *testing.T) {
+		require.NoError(t, underTest.writeKonnectivityAgent(clusterConfig, 2, []string{"10.0.0.1", "fd00::2"}))
+
+		daemonSets := loadDaemonSets(t, manifestsDir)
+		require.Len(t, daemonSets, 2)
+		for i, expected := range []struct{ name, label, host string }{
+			{"konnectivity-agent-10-0-0-1", "10-0-0-1", "10.0.0.1"},
+			{"konnectivity-agent-fd00-0000-0000-0000-0000-0000-0000-0002", "fd00-0000-0000-0000-0000-0000-0000-0002", "fd00::2"},
+		} {
+			daemonSet := daemonSets[i]
+			assert.Equal(t, expected.name, daemonSet.Name)
+			assert.Equal(t, expected.label, daemonSet.Spec.Selector.MatchLabels[konnectivityServerLabel])
+			assert.Equal(t, expected.label, daemonSet.Spec.Template.Labels[konnectivityServerLabel])
+			assert.False(t, daemonSet.Spec.Template.Spec.HostNetwork)
+
+			containers := daemonSet.Spec.Template.Spec.Containers
+			require.Len(t, containers, 1)
+			args := containers[0].Args
+			require.Len(t, args, 7)
+			assert.Equal(t, "--proxy-server-host="+expected.host, args[2])
+			assert.Equal(t, "--proxy-server-port=9876", args[3])
+			assert.NotContains(t, containers[0].Env, corev1.EnvVar{Name: "K0S_CONTROLLER_COUNT", Value: "2"})
+		}
+	})
+
+	t.Run("external_api_address", func(t *testing.T) {
+		underTest := underTest
+		underTest.ManifestsDir = t.TempDir()
+		underTest.HasExternalAPIAddress = true
+
+		err := underTest.writeKonnectivityAgent(clusterConfig, 2, []string{"192.0.2.100"})
+		assert.ErrorContains(t, err, "agent connection mode PerServer cannot be used in conjunction with an external Kubernetes API server address")
+		assert.NoFileExists(t, filepath.Join(underTest.ManifestsDir, "konnectivity", "konnectivity-agent.yaml"))
+	})
+}
+
+func TestKonnectivityAgentRemoval(t *testing.T) {
+	manifestsDir := t.TempDir()
+	clients := testutil.NewFakeClientFactory()
+
+	// Konnectivity is enabled, and the agents have been applied.
+	agent := KonnectivityAgent{
+		ManifestsDir:           manifestsDir,
+		KonnectivityServerHost: "10.0.0.1",
+		EventEmitter:           prober.NewEventEmitter(),
+	}
+	require.NoError(t, agent.writeKonnectivityAgent(&k0sv1beta1.ClusterConfig{
+		Spec: &k0sv1beta1.ClusterSpec{
+			Images:       k0sv1beta1.DefaultClusterImages(),
+			Konnectivity: &k0sv1beta1.KonnectivitySpec{AgentPort: 8132},
+		},
+	}, 1, nil))
+	stackDir := filepath.Join(manifestsDir, KonnectivityAgentStackName)
+	stackApplier := applier.NewApplier(stackDir, clients)
+	require.NoError(t, stackApplier.Apply(t.Context()))
+
+	daemonSets := clients.Client.AppsV1().DaemonSets(metav1.NamespaceSystem)
+	_, err := daemonSets.Get(t.Context(), "konnectivity-agent", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// Konnectivity gets disabled.
+	underTest := KonnectivityAgentRemoval{
+		ManifestsDir:      manifestsDir,
+		KubeClientFactory: clients,
+		LeaderElector:     leaderelector.Off(),
+	}
+	require.NoError(t, underTest.Init(t.Context()))
+	assert.NoDirExists(t, stackDir)
+
+	require.NoError(t, underTest.removeStack(t.Context()))
+	_, err = daemonSets.Get(t.Context(), "konnectivity-agent", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "Expected the agent DaemonSet to be deleted: %v", err)
+	_, err = clients.Client.CoreV1().ServiceAccounts(metav1.NamespaceSystem).Get(t.Context(), "konnectivity-agent", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "Expected the agent ServiceAccount to be deleted: %v", err)
+}
+
+func TestKonnectivityAgentsStatus(t *testing.T) {
+	agent := func(node string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      "konnectivity-agent-" + node,
+				Labels:    map[string]string{konnectivityServerLabel: "10-0-0-1"},
+			},
+			Spec:   corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+
+	clients := testutil.NewFakeClientFactory(
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: "konnectivity-agent-10-0-0-1"}},
+		agent("worker-1", corev1.ConditionTrue),
+		agent("worker-0", corev1.ConditionTrue),
+		agent("worker-2", corev1.ConditionFalse),
+	)
+
+	assert.Equal(t, &status.KonnectivityAgentsStatus{
+		Server:       "10.0.0.1",
+		Connected:    []string{"worker-0", "worker-1"},
+		Disconnected: []string{"worker-2"},
+	}, KonnectivityAgentsStatus(t.Context(), clients, "10.0.0.1"))
+
+	assert.Nil(t, KonnectivityAgentsStatus(t.Context(), clients, "10.0.0.2"), "Expected no status for a server without agents")
+}
+
 func loadDaemonSet(t *testing.T, manifestsDir string) *appsv1.DaemonSet {
+	daemonSets := loadDaemonSets(t, manifestsDir)
+	require.Len(t, daemonSets, 1)
+	return daemonSets[0]
+}
+
+func loadDaemonSets(t *testing.T, manifestsDir string) []*appsv1.DaemonSet {
 	objects, err := resource.NewLocalBuilder().
 		WithScheme(kubernetesscheme.Scheme,
 			corev1.SchemeGroupVersion,
@@ -90,8 +239,13 @@ func loadDaemonSet(t *testing.T, manifestsDir string) *appsv1.DaemonSet {
 		Infos()
 	require.NoError(t, err)
 
-	require.Len(t, objects, 3)
-	daemonSet, ok := objects[2].Object.(*appsv1.DaemonSet)
-	require.Truef(t, ok, "unexpected type: %T", objects[2].Object)
-	return daemonSet
+	// The first two objects are the ClusterRoleBinding and the ServiceAccount.
+	require.Greater(t, len(objects), 2)
+	var daemonSets []*appsv1.DaemonSet
+	for _, object := range objects[2:] {
+		daemonSet, ok := object.Object.(*appsv1.DaemonSet)
+		require.Truef(t, ok, "unexpected type: %T", object.Object)
+		daemonSets = append(daemonSets, daemonSet)
+	}
+	return daemonSets
 }
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/component/prober"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/sirupsen/logrus"
+)
+
+// KubeletReachability checks if this controller is able to connect to the
+// kubelets of all nodes. Without konnectivity, the API server needs to connect
+// to the kubelets directly, e.g. in order to retrieve logs or to exec into
+// containers.
+type KubeletReachability struct {
+	ClientFactory kubeutil.ClientFactoryInterface
+
+	log  logrus.FieldLogger
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+	stop func()
+
+	mu  sync.Mutex
+	err error
+}
+
+var _ manager.Component = (*KubeletReachability)(nil)
+var _ prober.Healthz = (*KubeletReachability)(nil)
+
+const (
+	kubeletReachabilityInterval    = 1 * time.Minute
+	kubeletReachabilityDialTimeout = 3 * time.Second
+)
+
+func (k *KubeletReachability) Init(context.Context) error {
+	k.log = logrus.WithField("component", "kubelet-reachability")
+	if k.dial == nil {
+		k.dial = (&net.Dialer{Timeout: kubeletReachabilityDialTimeout}).DialContext
+	}
+	return nil
+}
+
+func (k *KubeletReachability) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		var lastErr string
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			err := k.check(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			k.mu.Lock()
+			k.err = err
+			k.mu.Unlock()
+
+			switch {
+			case err != nil && err.Error() != lastErr:
+				k.log.WithError(err).Warn("Kubelets are not reachable from this controller")
+			case err == nil && lastErr != "":
+				k.log.Info("All kubelets are reachable from this controller")
+			}
+			lastErr = ""
+			if err != nil {
+				lastErr = err.Error()
+			}
+		}, kubeletReachabilityInterval)
+	})
+
+	k.stop = func() { cancel(); wg.Wait() }
+	return nil
+}
+
+func (k *KubeletReachability) Stop() error {
+	if k.stop != nil {
+		k.stop()
+	}
+	return nil
+}
+
+// Healthy implements [prober.Healthz]. It reports the result of the latest
+// check, so that unreachable kubelets show up in the component status.
+func (k *KubeletReachability) Healthy() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.err
+}
+
+// Connects to the kubelets of all nodes and returns an error for each of the
+// kubelets that couldn't be reached.
+func (k *KubeletReachability) check(ctx context.Context) error {
+	client, err := k.ClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	errs := make([]error, len(nodes.Items))
+	var wg sync.WaitGroup
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		wg.Go(func() {
+			address, ok := kubeletAddress(node)
+			if !ok {
+				errs[i] = fmt.Errorf("node %s has no usable address", node.Name)
+				return
+			}
+
+			conn, err := k.dial(ctx, "tcp", address)
+			if err != nil {
+				errs[i] = fmt.Errorf("kubelet on node %s is unreachable: %w", node.Name, err)
+				return
+			}
+			if err := conn.Close(); err != nil {
+				k.log.WithError(err).Debug("Failed to close connection to kubelet on node ", node.Name)
+			}
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Returns the address that the API server would use to connect to the
+// kubelet, honoring the order of the kubelet-preferred-address-types flag.
+func kubeletAddress(node *corev1.Node) (string, bool) {
+	port := cmp.Or(node.Status.DaemonEndpoints.KubeletEndpoint.Port, 10250)
+	for _, addrType := range []corev1.NodeAddressType{corev1.NodeInternalIP, corev1.NodeExternalIP, corev1.NodeHostName} {
+		if i := slices.IndexFunc(node.Status.Addresses, func(addr corev1.NodeAddress) bool {
+			return addr.Type == addrType && addr.Address != ""
+		}); i >= 0 {
+			return net.JoinHostPort(node.Status.Addresses[i].Address, strconv.Itoa(int(port))), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKubeletReachability_Check(t *testing.T) {
+	node := func(name string, port int32, addresses ...corev1.NodeAddress) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Addresses:       addresses,
+				DaemonEndpoints: corev1.NodeDaemonEndpoints{KubeletEndpoint: corev1.DaemonEndpoint{Port: port}},
+			},
+		}
+	}
+
+	var mu sync.Mutex
+	var dialed []string
+	underTest := KubeletReachability{
+		ClientFactory: testutil.NewFakeClientFactory(
+			node("reachable", 0,
+				corev1.NodeAddress{Type: corev1.NodeHostName, Address: "reachable.example.com"},
+				corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "192.0.2.1"},
+			),
+			node("unreachable", 10251,
+				corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "2001:db8::2"},
+			),
+			node("unaddressable", 0),
+		),
+		log: logrus.New(),
+		dial: func(_ context.Context, network, address string) (net.Conn, error) {
+			assert.Equal(t, "tcp", network)
+			mu.Lock()
+			dialed = append(dialed, address)
+			mu.Unlock()
+			if address == "[2001:db8::2]:10251" {
+				return nil, errors.New("connection refused")
+			}
+			client, server := net.Pipe()
+			t.Cleanup(func() { _ = server.Close() })
+			return client, nil
+		},
+	}
+
+	err := underTest.check(t.Context())
+	assert.ErrorContains(t, err, "kubelet on node unreachable is unreachable: connection refused")
+	assert.ErrorContains(t, err, "node unaddressable has no usable address")
+	assert.NotContains(t, err.Error(), "node reachable ")
+	assert.ElementsMatch(t, []string{"192.0.2.1:10250", "[2001:db8::2]:10251"}, dialed)
+}
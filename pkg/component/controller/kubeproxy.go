@@ -250,6 +250,12 @@ func (k *KubeProxy) getConfig(clusterConfig *v1beta1.ClusterConfig) *proxyConfig
 			// solution would be to convert kube-proxy to a static Pod as well.
 			controlPlaneEndpoint = fmt.Sprintf("https://localhost:%d", nllb.Traefik.APIServerBindPort)
 
+		case v1beta1.NllbTypeTCPProxy:
+			k.log.Debug("Enabling node-local load balancing via ", nllb.Type)
+
+			// FIXME: Same limitations as above.
+			controlPlaneEndpoint = fmt.Sprintf("https://localhost:%d", nllb.TCPProxy.APIServerBindPort)
+
 		default:
 			k.log.Warnf("Unsupported node-local load balancer type (%q), using %q as control plane endpoint", nllb.Type, controlPlaneEndpoint)
 		}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
@@ -50,6 +51,7 @@ type kubeRouterConfig struct {
 	PeerRouterASNs    string
 	PullPolicy        string
 	Args              []string
+	NodeAffinity      *cniNodeAffinity
 }
 
 // NewKubeRouter creates new KubeRouter reconciler component
@@ -96,16 +98,26 @@ func getHairpinConfig(krc *v1beta1.KubeRouter) (cniHairpin bool, globalHairpin b
 // Reconcile detects changes in configuration and applies them to the component
 func (k *KubeRouter) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterConfig) error {
 	logrus.Debug("reconcile method called for: KubeRouter")
-	if clusterConfig.Spec.Network.Provider != constant.CNIProviderKubeRouter {
+	deployment, err := planCNIDeployment(k.k0sVars.ManifestsDir, constant.CNIProviderKubeRouter, clusterConfig)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case deployment.Remove:
+		return k.removeManifests()
+	case !deployment.Deploy:
 		return nil
 	}
 
-	existingCNI := existingCNIProvider(k.k0sVars.ManifestsDir)
-	if existingCNI != "" && existingCNI != constant.CNIProviderKubeRouter {
-		return fmt.Errorf("cannot change CNI provider from %s to %s", existingCNI, constant.CNIProviderKubeRouter)
+	// The config section may be absent when kube-router is the network
+	// provider that is being migrated away from.
+	kubeRouter := clusterConfig.Spec.Network.KubeRouter
+	if kubeRouter == nil {
+		kubeRouter = v1beta1.DefaultKubeRouter()
 	}
 
-	cniHairpin, globalHairpin := getHairpinConfig(clusterConfig.Spec.Network.KubeRouter)
+	cniHairpin, globalHairpin := getHairpinConfig(kubeRouter)
 
 	isSingleStackIPv6 := clusterConfig.Spec.Network.IsSingleStackIPv6()
 	args := stringmap.StringMap{
@@ -117,8 +129,8 @@ func (k *KubeRouter) Reconcile(_ context.Context, clusterConfig *v1beta1.Cluster
 		// Args from config values
 		"enable-ipv4":              strconv.FormatBool(!isSingleStackIPv6),
 		"enable-ipv6":              strconv.FormatBool(clusterConfig.Spec.Network.DualStack.Enabled || isSingleStackIPv6),
-		"auto-mtu":                 strconv.FormatBool(clusterConfig.Spec.Network.KubeRouter.IsAutoMTU()),
-		"metrics-port":             strconv.Itoa(clusterConfig.Spec.Network.KubeRouter.MetricsPort),
+		"auto-mtu":                 strconv.FormatBool(kubeRouter.IsAutoMTU()),
+		"metrics-port":             strconv.Itoa(kubeRouter.MetricsPort),
 		"hairpin-mode":             strconv.FormatBool(globalHairpin),
 		"service-cluster-ip-range": k.serviceCIDRs,
 	}
@@ -129,29 +141,30 @@ func (k *KubeRouter) Reconcile(_ context.Context, clusterConfig *v1beta1.Cluster
 	}
 
 	// We should not add peering flags if the values are empty
-	if clusterConfig.Spec.Network.KubeRouter.PeerRouterASNs != "" {
-		args["peer-router-asns"] = clusterConfig.Spec.Network.KubeRouter.PeerRouterASNs
+	if kubeRouter.PeerRouterASNs != "" {
+		args["peer-router-asns"] = kubeRouter.PeerRouterASNs
 	}
-	if clusterConfig.Spec.Network.KubeRouter.PeerRouterIPs != "" {
-		args["peer-router-ips"] = clusterConfig.Spec.Network.KubeRouter.PeerRouterIPs
+	if kubeRouter.PeerRouterIPs != "" {
+		args["peer-router-ips"] = kubeRouter.PeerRouterIPs
 	}
 
 	// Override or add args from config
-	args.Merge(clusterConfig.Spec.Network.KubeRouter.ExtraArgs)
+	args.Merge(kubeRouter.ExtraArgs)
 
 	cfg := kubeRouterConfig{
-		AutoMTU:           clusterConfig.Spec.Network.KubeRouter.IsAutoMTU(),
-		MTU:               clusterConfig.Spec.Network.KubeRouter.MTU,
-		MetricsPort:       clusterConfig.Spec.Network.KubeRouter.MetricsPort,
-		IPMasq:            clusterConfig.Spec.Network.KubeRouter.IPMasq,
+		AutoMTU:           kubeRouter.IsAutoMTU(),
+		MTU:               kubeRouter.MTU,
+		MetricsPort:       kubeRouter.MetricsPort,
+		IPMasq:            kubeRouter.IPMasq,
 		CNIHairpin:        cniHairpin,
 		CNIImage:          clusterConfig.Spec.Images.KubeRouter.CNI.URI(),
 		CNIInstallerImage: clusterConfig.Spec.Images.KubeRouter.CNIInstaller.URI(),
 		PullPolicy:        clusterConfig.Spec.Images.DefaultPullPolicy,
-		Args:              append(args.ToDashedArgs(), clusterConfig.Spec.Network.KubeRouter.RawArgs...),
+		Args:              append(args.ToDashedArgs(), kubeRouter.RawArgs...),
+		NodeAffinity:      deployment.NodeAffinity,
 	}
 
-	patches := clusterConfig.Spec.Network.KubeRouter.Patches
+	patches := kubeRouter.Patches
 	if reflect.DeepEqual(k.previousConfig, cfg) &&
 		reflect.DeepEqual(k.previousPatches, patches) {
 		k.log.Info("config matches with previous, not reconciling anything")
@@ -166,11 +179,17 @@ func (k *KubeRouter) Reconcile(_ context.Context, clusterConfig *v1beta1.Cluster
 		Patches:  patches,
 	}
 
-	err := tw.WriteToBuffer(output)
+	err = tw.WriteToBuffer(output)
 	if err != nil {
 		return fmt.Errorf("error writing kube-router manifests, will NOT retry: %w", err)
 	}
 
+	// The directory is gone if kube-router has been replaced by another
+	// network provider in the meantime.
+	if err := dir.Init(filepath.Join(k.k0sVars.ManifestsDir, "kuberouter"), constant.ManifestsDirMode); err != nil {
+		return err
+	}
+
 	if err := file.AtomicWithTarget(filepath.Join(k.k0sVars.ManifestsDir, "kuberouter", "kube-router.yaml")).
 		WithPermissions(constant.CertMode).
 		Write(output.Bytes()); err != nil {
@@ -182,6 +201,18 @@ func (k *KubeRouter) Reconcile(_ context.Context, clusterConfig *v1beta1.Cluster
 	return nil
 }
 
+// removeManifests removes the kube-router manifests, so that the applier
+// deletes the corresponding resources from the cluster.
+func (k *KubeRouter) removeManifests() error {
+	if err := os.RemoveAll(filepath.Join(k.k0sVars.ManifestsDir, "kuberouter")); err != nil {
+		return err
+	}
+
+	k.previousConfig = kubeRouterConfig{}
+	k.previousPatches = nil
+	return nil
+}
+
 // Start implements [manager.Component].
 func (k *KubeRouter) Start(context.Context) error {
 	return nil
@@ -255,6 +286,16 @@ spec:
       serviceAccountName: kube-router
       nodeSelector:
         kubernetes.io/os: linux
+      {{- with .NodeAffinity }}
+      affinity:
+        nodeAffinity:
+          requiredDuringSchedulingIgnoredDuringExecution:
+            nodeSelectorTerms:
+            - matchExpressions:
+              - key: k0s.k0sproject.io/network-provider
+                operator: In
+                values: [{{ .Provider }}]
+      {{- end }}
       initContainers:
         - name: install-cni-bins
           image: {{ .CNIInstallerImage }}
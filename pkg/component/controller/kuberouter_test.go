@@ -307,3 +307,74 @@ func findDaemonset(resources []*unstructured.Unstructured) (appsv1.DaemonSet, er
 
 	return ds, errors.New("kube-router ds not found in manifests")
 }
+
+func TestKubeRouterNetworkProviderMigration(t *testing.T) {
+	k0sVars, err := config.NewCfgVars(nil, t.TempDir())
+	require.NoError(t, err)
+	manifestPath := filepath.Join(k0sVars.ManifestsDir, "kuberouter", "kube-router.yaml")
+
+	cfg := v1beta1.DefaultClusterConfig()
+	cfg.Spec.Network.Calico = nil
+	cfg.Spec.Network.Provider = "kuberouter"
+	cfg.Spec.Network.KubeRouter = v1beta1.DefaultKubeRouter()
+	ctx := t.Context()
+	kr := NewKubeRouter(k0sVars, v1beta1.PrimaryFamilyIPv4, cfg.Spec.Network.BuildServiceCIDR(cfg.Spec.PrimaryAddressFamily()))
+	require.NoError(t, kr.Init(ctx))
+	require.NoError(t, kr.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, kr.Stop()) })
+	require.NoError(t, kr.Reconcile(ctx, cfg))
+
+	// Switch to Calico, which leaves out the kube-router config section.
+	cfg.Spec.Network.Provider = "calico"
+	cfg.Spec.Network.KubeRouter = nil
+	cfg.Status = &v1beta1.ClusterConfigStatus{
+		NetworkProviderMigration: &v1beta1.NetworkProviderMigrationStatus{
+			From:  "kuberouter",
+			To:    "calico",
+			Phase: v1beta1.NetworkProviderMigrationInProgress,
+		},
+	}
+
+	// kube-router must only ever select the nodes labeled with it, so that
+	// nodes that are not part of the migration remain untouched.
+	requireKubeRouterNodesOnly := func(t *testing.T) {
+		manifestData, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		resources, err := testutil.ParseManifests(manifestData)
+		require.NoError(t, err)
+		ds, err := findDaemonset(resources)
+		require.NoError(t, err)
+
+		affinity := ds.Spec.Template.Spec.Affinity
+		require.NotNil(t, affinity)
+		require.NotNil(t, affinity.NodeAffinity)
+		terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		assert.Equal(t, []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{
+				Key:      "k0s.k0sproject.io/network-provider",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"kuberouter"},
+			}},
+		}}, terms)
+	}
+
+	t.Run("in_progress", func(t *testing.T) {
+		require.NoError(t, kr.Reconcile(ctx, cfg))
+		requireKubeRouterNodesOnly(t)
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		cfg := cfg.DeepCopy()
+		cfg.Spec.Network.Provider = "kuberouter"
+		cfg.Status.NetworkProviderMigration.From = "calico"
+		cfg.Status.NetworkProviderMigration.To = "kuberouter"
+		require.NoError(t, kr.Reconcile(ctx, cfg))
+		requireKubeRouterNodesOnly(t)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		cfg.Status.NetworkProviderMigration.Phase = v1beta1.NetworkProviderMigrationCompleted
+		require.NoError(t, kr.Reconcile(ctx, cfg))
+		assert.NoFileExists(t, manifestPath)
+	})
+}
@@ -5,17 +5,22 @@ package controller
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
-	"k8s.io/client-go/rest"
-
 	"github.com/k0sproject/k0s/internal/pkg/dir"
 	"github.com/k0sproject/k0s/internal/pkg/file"
 	"github.com/k0sproject/k0s/internal/pkg/templatewriter"
@@ -24,259 +29,414 @@ import (
 	"github.com/k0sproject/k0s/pkg/config"
 	"github.com/k0sproject/k0s/pkg/constant"
 	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	k0smetrics "github.com/k0sproject/k0s/pkg/metrics"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/apis/apiserver"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	namespace       = "k0s-system"
-	pushGatewayName = "k0s-pushgateway"
-)
+// The name of the Service and Endpoints objects through which the controller
+// metrics endpoints can be discovered.
+const metricsServiceName = "k0s-metrics"
 
-// Metrics is the reconciler implementation for metrics server
+// Metrics serves the metrics of the controller components on an authenticated
+// endpoint, so that they can be scraped by Prometheus directly. Each controller
+// adds itself to the k0s-metrics Endpoints object in the kube-system
+// namespace, and removes the controllers whose controller lease has expired.
 type Metrics struct {
-	log logrus.FieldLogger
+	K0sVars       *config.CfgVars
+	ClientFactory kubeutil.ClientFactoryInterface
+	Storage       *v1beta1.StorageSpec
+	NodeName      apitypes.NodeName
+	Address       string // The address under which this controller is reachable.
+	Port          int
+	Insecure      bool // Whether to serve plain HTTP instead of HTTPS.
+
+	log     logrus.FieldLogger
+	targets map[string]*scrapeTarget
+	stop    func() error
+}
 
-	hostname    string
-	K0sVars     *config.CfgVars
-	restClient  rest.Interface
-	storageType v1beta1.StorageType
+var _ manager.Component = (*Metrics)(nil)
 
-	clusterConfig *v1beta1.ClusterConfig
-	tickerDone    context.CancelFunc
-	jobs          []*job
+// A component whose metrics are proxied by the metrics endpoint.
+type scrapeTarget struct {
+	url    string
+	client *http.Client
 }
 
-var _ manager.Component = (*Metrics)(nil)
-var _ manager.Reconciler = (*Metrics)(nil)
+// Init implements [manager.Component].
+func (m *Metrics) Init(context.Context) error {
+	m.log = logrus.WithField("component", "metrics")
 
-// NewMetrics creates new Metrics reconciler
-func NewMetrics(k0sVars *config.CfgVars, clientCF kubeutil.ClientFactoryInterface, storageType v1beta1.StorageType) (*Metrics, error) {
-	hostname, err := os.Hostname()
-	if err != nil {
-		return nil, err
+	if err := dir.Init(filepath.Join(m.K0sVars.ManifestsDir, "metrics"), constant.ManifestsDirMode); err != nil {
+		return err
 	}
 
-	var restClient rest.Interface
-	if client, err := clientCF.GetDiscoveryClient(); err != nil {
-		return nil, fmt.Errorf("error getting REST client for metrics: %w", err)
-	} else {
-		restClient = client.RESTClient()
+	m.targets = make(map[string]*scrapeTarget)
+	adminCert := filepath.Join(m.K0sVars.CertRootDir, "admin.crt")
+	adminKey := filepath.Join(m.K0sVars.CertRootDir, "admin.key")
+	for name, url := range map[string]string{
+		"kube-scheduler":          "https://localhost:10259/metrics",
+		"kube-controller-manager": "https://localhost:10257/metrics",
+	} {
+		if err := m.addTarget(name, url, adminCert, adminKey); err != nil {
+			return err
+		}
 	}
-	if restClient == nil {
-		return nil, errors.New("no REST client for metrics")
+
+	switch m.Storage.Type {
+	case v1beta1.EtcdStorageType:
+		if !m.Storage.Etcd.IsExternalClusterUsed() {
+			certFile := filepath.Join(m.K0sVars.CertRootDir, "apiserver-etcd-client.crt")
+			keyFile := filepath.Join(m.K0sVars.CertRootDir, "apiserver-etcd-client.key")
+			if err := m.addTarget("etcd", "https://localhost:2379/metrics", certFile, keyFile); err != nil {
+				return err
+			}
+		}
+	case v1beta1.KineStorageType:
+		if err := m.addTarget("kine", "http://localhost:2380/metrics", "", ""); err != nil {
+			return err
+		}
 	}
 
-	return &Metrics{
-		log:         logrus.WithFields(logrus.Fields{"component": "metrics"}),
-		storageType: storageType,
-		hostname:    hostname,
-		K0sVars:     k0sVars,
-		restClient:  restClient,
-	}, nil
+	return nil
 }
 
-// Init implements [manager.Component].
-func (m *Metrics) Init(context.Context) error {
-	if err := dir.Init(filepath.Join(m.K0sVars.ManifestsDir, "metrics"), constant.ManifestsDirMode); err != nil {
+func (m *Metrics) addTarget(name, url, certFile, keyFile string) error {
+	client, err := getClient(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics client for %s: %w", name, err)
+	}
+	m.targets[name] = &scrapeTarget{url, client}
+	return nil
+}
+
+// Start implements [manager.Component].
+func (m *Metrics) Start(context.Context) error {
+	if err := m.writeManifests(); err != nil {
 		return err
 	}
 
-	var j *job
-	j, err := m.newJob("kube-scheduler", "https://localhost:10259/metrics")
+	handler, err := m.newHandler()
 	if err != nil {
 		return err
 	}
-	m.jobs = append(m.jobs, j)
 
-	j, err = m.newJob("kube-controller-manager", "https://localhost:10257/metrics")
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(m.Port)))
 	if err != nil {
 		return err
 	}
-	m.jobs = append(m.jobs, j)
-
-	if m.storageType == v1beta1.EtcdStorageType {
-		etcdJob, err := m.newEtcdJob()
+	if !m.Insecure {
+		cert, err := tls.LoadX509KeyPair(
+			filepath.Join(m.K0sVars.CertRootDir, "k0s-api.crt"),
+			filepath.Join(m.K0sVars.CertRootDir, "k0s-api.key"),
+		)
 		if err != nil {
-			return err
+			return errors.Join(err, listener.Close())
 		}
-		m.jobs = append(m.jobs, etcdJob)
+		listener = tls.NewListener(listener, &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+			// Client certificates are verified by the authenticator.
+			ClientAuth: tls.RequestClientCert,
+		})
 	}
 
-	if m.storageType == v1beta1.KineStorageType {
-		kineJob, err := m.newKineJob()
-		if err != nil {
-			return err
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+		m.log.Info("Serving metrics on ", listener.Addr())
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			m.log.WithError(err).Error("Failed to serve metrics")
 		}
-		m.jobs = append(m.jobs, kineJob)
-	}
-
-	return nil
-}
-
-// Start implements [manager.Component].
-// Starts the metric server reconciler.
-func (m *Metrics) Start(ctx context.Context) error {
-	ctx, m.tickerDone = context.WithCancel(ctx)
-
-	for _, j := range m.jobs {
-		go j.Run(ctx)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	registrationDone := make(chan struct{})
+	go func() {
+		defer close(registrationDone)
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := m.reconcileEndpoints(ctx, true); err != nil {
+				m.log.WithError(err).Error("Failed to reconcile metrics endpoints")
+			}
+		}, 10*time.Second)
+	}()
+
+	m.stop = func() error {
+		cancel()
+		<-registrationDone
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		deregisterErr := m.reconcileEndpoints(ctx, false)
+		if deregisterErr != nil {
+			deregisterErr = fmt.Errorf("failed to remove metrics endpoint: %w", deregisterErr)
+		}
+		shutdownErr := server.Shutdown(ctx)
+		<-serverDone
+		return errors.Join(deregisterErr, shutdownErr)
 	}
 
 	return nil
 }
 
 // Stop implements [manager.Component].
-// Stops the metric server reconciler.
 func (m *Metrics) Stop() error {
-	if m.tickerDone != nil {
-		m.tickerDone()
+	if m.stop != nil {
+		return m.stop()
 	}
 	return nil
 }
 
-// Reconcile detects changes in configuration and applies them to the component
-func (m *Metrics) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterConfig) error {
-	m.log.Debug("reconcile method called for: Metrics")
-
-	if m.clusterConfig == nil || clusterConfig.Spec.Images.PushGateway.URI() != m.clusterConfig.Spec.Images.PushGateway.URI() {
-		tw := templatewriter.TemplateWriter{
-			Name:     "pushgateway-with-ttl",
-			Template: pushGatewayTemplate,
-			Data: map[string]string{
-				"Namespace": namespace,
-				"Name":      pushGatewayName,
-				"Image":     clusterConfig.Spec.Images.PushGateway.URI(),
-			},
-		}
-		output := bytes.NewBuffer([]byte{})
-		err := tw.WriteToBuffer(output)
-		if err != nil {
-			return err
-		}
-		err = file.AtomicWithTarget(filepath.Join(m.K0sVars.ManifestsDir, "metrics", "pushgateway.yaml")).
-			WithPermissions(constant.CertMode).
-			Write(output.Bytes())
-		if err != nil {
-			return err
-		}
+func (m *Metrics) writeManifests() error {
+	tw := templatewriter.TemplateWriter{
+		Name:     "k0s-metrics",
+		Template: metricsServiceTemplate,
+		Data: map[string]any{
+			"Namespace": metav1.NamespaceSystem,
+			"Name":      metricsServiceName,
+			"Port":      m.Port,
+		},
+	}
+	var output bytes.Buffer
+	if err := tw.WriteToBuffer(&output); err != nil {
+		return err
 	}
 
-	// We just store the last known config
-	for _, j := range m.jobs {
-		j.clusterConfig = clusterConfig
+	manifestsDir := filepath.Join(m.K0sVars.ManifestsDir, "metrics")
+	if err := file.AtomicWithTarget(filepath.Join(manifestsDir, "k0s-metrics.yaml")).
+		WithPermissions(constant.CertMode).
+		Write(output.Bytes()); err != nil {
+		return err
 	}
-	m.clusterConfig = clusterConfig
-	return nil
-}
 
-type job struct {
-	log logrus.FieldLogger
+	// Previous k0s versions pushed the metrics into a pushgateway. Remove its
+	// manifest so that it gets pruned.
+	if err := os.Remove(filepath.Join(manifestsDir, "pushgateway.yaml")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
 
-	scrapeURL     string
-	name          string
-	hostname      string
-	clusterConfig *v1beta1.ClusterConfig
-	scrapeClient  *http.Client
-	restClient    rest.Interface
+	return nil
 }
 
-func (m *Metrics) newEtcdJob() (*job, error) {
-	certFile := filepath.Join(m.K0sVars.CertRootDir, "apiserver-etcd-client.crt")
-	keyFile := filepath.Join(m.K0sVars.CertRootDir, "apiserver-etcd-client.key")
+// Builds the metrics handler. Requests need to be authenticated via bearer
+// tokens or, if TLS is used, via client certificates signed by the cluster CA.
+// They are authorized via subject access reviews for the requested path.
+func (m *Metrics) newHandler() (http.Handler, error) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics/k0s", k0smetrics.Handler())
+	for name, target := range m.targets {
+		mux.Handle("GET /metrics/"+name, target)
+	}
 
-	httpClient, err := getClient(certFile, keyFile)
+	clients, err := m.ClientFactory.GetClient()
 	if err != nil {
 		return nil, err
 	}
 
-	return &job{
-		log:          m.log.WithField("metrics_job", "etcd"),
-		scrapeURL:    "https://localhost:2379/metrics",
-		name:         "etcd",
-		hostname:     m.hostname,
-		scrapeClient: httpClient,
-		restClient:   m.restClient,
-	}, nil
-}
+	backoff := &wait.Backoff{
+		Duration: 500 * time.Millisecond,
+		Factor:   1.5,
+		Jitter:   0.2,
+		Steps:    5,
+	}
 
-func (m *Metrics) newKineJob() (*job, error) {
-	httpClient, err := getClient("", "")
+	authenticatorConfig := authenticatorfactory.DelegatingAuthenticatorConfig{
+		Anonymous:                &apiserver.AnonymousAuthConfig{Enabled: false},
+		CacheTTL:                 1 * time.Minute,
+		TokenAccessReviewClient:  clients.AuthenticationV1(),
+		TokenAccessReviewTimeout: 10 * time.Second,
+		WebhookRetryBackoff:      backoff,
+	}
+	if !m.Insecure {
+		caContent, err := dynamiccertificates.NewDynamicCAContentFromFile("client-ca", filepath.Join(m.K0sVars.CertRootDir, "ca.crt"))
+		if err != nil {
+			return nil, err
+		}
+		authenticatorConfig.ClientCertificateCAContentProvider = caContent
+	}
+	authenticator, _, err := authenticatorConfig.New()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
 	}
 
-	return &job{
-		log:          m.log.WithField("metrics_job", "kine"),
-		scrapeURL:    "http://localhost:2380/metrics",
-		name:         "kine",
-		hostname:     m.hostname,
-		scrapeClient: httpClient,
-		restClient:   m.restClient,
-	}, nil
+	authorizerConfig := authorizerfactory.DelegatingAuthorizerConfig{
+		SubjectAccessReviewClient: clients.AuthorizationV1(),
+		AllowCacheTTL:             5 * time.Minute,
+		DenyCacheTTL:              30 * time.Second,
+		WebhookRetryBackoff:       backoff,
+	}
+	authz, err := authorizerConfig.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorizer: %w", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		resp, ok, err := authenticator.AuthenticateRequest(req)
+		if err != nil {
+			m.log.WithError(err).Debug("Authentication failed")
+		}
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		decision, reason, err := authz.Authorize(req.Context(), authorizer.AttributesRecord{
+			User: resp.User,
+			Verb: strings.ToLower(req.Method),
+			Path: req.URL.Path,
+		})
+		if err != nil {
+			m.log.WithError(err).Errorf("Authorization for user %s failed", resp.User.GetName())
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if decision != authorizer.DecisionAllow {
+			m.log.Debugf("Authorization denied for user %s: %s", resp.User.GetName(), reason)
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		mux.ServeHTTP(w, req)
+	}), nil
 }
 
-func (m *Metrics) newJob(name, scrapeURL string) (*job, error) {
-	certFile := filepath.Join(m.K0sVars.CertRootDir, "admin.crt")
-	keyFile := filepath.Join(m.K0sVars.CertRootDir, "admin.key")
+// Scrapes the target's metrics and passes them through.
+func (t *scrapeTarget) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	scrapeReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, t.url, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if accept := req.Header.Get("Accept"); accept != "" {
+		scrapeReq.Header.Set("Accept", accept)
+	}
 
-	httpClient, err := getClient(certFile, keyFile)
+	resp, err := t.client.Do(scrapeReq)
 	if err != nil {
-		return nil, err
+		http.Error(w, fmt.Sprintf("error collecting metrics from %s: %v", t.url, err), http.StatusBadGateway)
+		return
 	}
+	defer resp.Body.Close()
 
-	return &job{
-		log:          m.log.WithField("metrics_job", name),
-		scrapeURL:    scrapeURL,
-		name:         name,
-		hostname:     m.hostname,
-		scrapeClient: httpClient,
-		restClient:   m.restClient,
-	}, nil
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
 }
 
-func (j *job) Run(ctx context.Context) {
-	j.log.Debugf("Running %s job", j.name)
+// Adds or removes this controller from the metrics Endpoints object. Removes
+// all other controllers whose controller lease is no longer valid.
+func (m *Metrics) reconcileEndpoints(ctx context.Context, register bool) error {
+	clients, err := m.ClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+	endpoints := clients.CoreV1().Endpoints(metav1.NamespaceSystem)
 
-	t := time.NewTicker(time.Second * 30)
-	defer t.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-t.C:
-			if j.clusterConfig == nil {
-				continue
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ep, err := endpoints.Get(ctx, metricsServiceName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			if !register {
+				return nil
 			}
-
-			err := j.collectAndPush(ctx)
-			if err != nil {
-				j.log.Error(err)
+			ep = &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metricsServiceName,
+					Namespace: metav1.NamespaceSystem,
+					Labels:    metricsLabels(),
+				},
+				Subsets: m.desiredSubsets(ctx, clients, nil, true),
+			}
+			_, err := endpoints.Create(ctx, ep, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Another controller was faster, try again.
+				return apierrors.NewConflict(corev1.Resource("endpoints"), metricsServiceName, err)
 			}
+			return err
+		} else if err != nil {
+			return err
 		}
-	}
-}
-func (j *job) pushURL() string {
-	pushAddress := fmt.Sprintf("/api/v1/namespaces/%s/services/http:%s:http/proxy", namespace, pushGatewayName)
-	return fmt.Sprintf("%s/metrics/job/%s/instance/%s", pushAddress, j.name, j.hostname)
+
+		subsets := m.desiredSubsets(ctx, clients, ep.Subsets, register)
+		if equality.Semantic.DeepEqual(subsets, ep.Subsets) {
+			return nil
+		}
+
+		ep.Subsets = subsets
+		_, err = endpoints.Update(ctx, ep, metav1.UpdateOptions{})
+		return err
+	})
 }
 
-func (j *job) collectAndPush(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.scrapeURL, nil)
-	if err != nil {
-		return fmt.Errorf("error creating GET request for %s: %w", j.scrapeURL, err)
+// Calculates the endpoint subsets, grouped by port and sorted by address.
+func (m *Metrics) desiredSubsets(ctx context.Context, clients kubernetes.Interface, current []corev1.EndpointSubset, register bool) []corev1.EndpointSubset {
+	leases := clients.CoordinationV1().Leases(corev1.NamespaceNodeLease)
+	addressesByPort := make(map[int32][]corev1.EndpointAddress)
+	for _, subset := range current {
+		for _, address := range subset.Addresses {
+			if address.NodeName == nil || *address.NodeName == string(m.NodeName) {
+				continue
+			}
+			// Controllers are considered active if their lease can't be
+			// retrieved, so that they're not removed because of transient
+			// errors.
+			if active, err := isControllerActive(ctx, leases, "k0s-ctrl-"+*address.NodeName); err != nil {
+				m.log.WithError(err).Warn("Failed to get controller lease for ", *address.NodeName)
+			} else if !active {
+				continue
+			}
+			for _, port := range subset.Ports {
+				addressesByPort[port.Port] = append(addressesByPort[port.Port], address)
+			}
+		}
+	}
+	if register {
+		nodeName := string(m.NodeName)
+		port := int32(m.Port)
+		addressesByPort[port] = append(addressesByPort[port], corev1.EndpointAddress{
+			IP:       m.Address,
+			NodeName: &nodeName,
+		})
 	}
 
-	resp, err := j.scrapeClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("error collecting metrics from %s: %w", j.scrapeURL, err)
+	var subsets []corev1.EndpointSubset
+	for _, port := range slices.Sorted(maps.Keys(addressesByPort)) {
+		addresses := addressesByPort[port]
+		slices.SortFunc(addresses, func(l, r corev1.EndpointAddress) int { return cmp.Compare(l.IP, r.IP) })
+		subsets = append(subsets, corev1.EndpointSubset{
+			Addresses: addresses,
+			Ports:     []corev1.EndpointPort{{Name: "metrics", Port: port, Protocol: corev1.ProtocolTCP}},
+		})
 	}
-	defer resp.Body.Close()
 
-	res := j.restClient.Post().AbsPath(j.pushURL()).Body(resp.Body).Do(ctx)
-	if res.Error() != nil {
-		return fmt.Errorf("error sending POST request for job %s: %w", j.name, res.Error())
+	return subsets
+}
+
+func metricsLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":      "k0s",
+		"app.kubernetes.io/component": "metrics",
 	}
-	return nil
 }
 
 func getClient(certFile, keyFile string) (*http.Client, error) {
@@ -298,87 +458,21 @@ func getClient(certFile, keyFile string) (*http.Client, error) {
 	}, nil
 }
 
-const pushGatewayTemplate = `
----
-apiVersion: v1
-kind: Namespace
-metadata:
-  name: {{ .Namespace }}
+// A Service without selector. The Endpoints are maintained by the controllers.
+const metricsServiceTemplate = `
 ---
 apiVersion: v1
 kind: Service
 metadata:
-  annotations:
-    prometheus.io/port: "9091"
-    prometheus.io/scrape: "true"
-  labels:
-    component: "pushgateway"
-    app: k0s-observability
   name: {{ .Name }}
   namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: k0s
+    app.kubernetes.io/component: metrics
 spec:
+  clusterIP: None
   ports:
-    - name: http
-      port: 9091
+    - name: metrics
+      port: {{ .Port }}
       protocol: TCP
-      targetPort: 9091
-  selector:
-    component: "pushgateway"
-    app: k0s-observability
-  type: "ClusterIP"
----
-apiVersion: apps/v1
-kind: Deployment
-metadata:
-  labels:
-    component: "pushgateway"
-    app: k0s-observability
-  name: {{ .Name }}
-  namespace: {{ .Namespace }}
-spec:
-  selector:
-    matchLabels:
-      component: "pushgateway"
-      app: k0s-observability
-  replicas: 1
-  template:
-    metadata:
-      labels:
-        component: "pushgateway"
-        app: k0s-observability
-    spec:
-      nodeSelector:
-        kubernetes.io/os: linux
-      tolerations:
-        - key: node-role.kubernetes.io/master
-          operator: Exists
-          effect: NoSchedule
-        - key: node-role.kubernetes.io/control-plane
-          operator: Exists
-          effect: NoSchedule
-      containers:
-        - name: prometheus-pushgateway
-          image: {{ .Image }}
-          imagePullPolicy: "IfNotPresent"
-          args: 
-          - --metric.timetolive=120s
-          ports:
-            - containerPort: 9091
-          livenessProbe:
-            httpGet:
-              path: /-/healthy
-              port: 9091
-            initialDelaySeconds: 10
-            timeoutSeconds: 10
-          readinessProbe:
-            httpGet:
-              path: /-/ready
-              port: 9091
-            initialDelaySeconds: 10
-            timeoutSeconds: 10
-          resources:
-            {}
-      securityContext:
-        runAsNonRoot: true
-        runAsUser: 65534
 `
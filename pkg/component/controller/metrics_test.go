@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/config"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_ReconcileEndpoints(t *testing.T) {
+	newLease := func(name string, renewed time.Time) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "k0s-ctrl-" + name, Namespace: corev1.NamespaceNodeLease},
+			Spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: ptr.To[int32](60),
+				RenewTime:            &metav1.MicroTime{Time: renewed},
+			},
+		}
+	}
+
+	newAddress := func(ip, nodeName string) corev1.EndpointAddress {
+		return corev1.EndpointAddress{IP: ip, NodeName: &nodeName}
+	}
+
+	newPort := func(port int32) []corev1.EndpointPort {
+		return []corev1.EndpointPort{{Name: "metrics", Port: port, Protocol: corev1.ProtocolTCP}}
+	}
+
+	newMetrics := func(clients *testutil.FakeClientFactory) *Metrics {
+		return &Metrics{
+			ClientFactory: clients,
+			NodeName:      "controller-1",
+			Address:       "10.0.0.1",
+			Port:          9444,
+			log:           logrus.New(),
+		}
+	}
+
+	getEndpoints := func(t *testing.T, clients *testutil.FakeClientFactory) *corev1.Endpoints {
+		ep, err := clients.Client.CoreV1().Endpoints(metav1.NamespaceSystem).Get(t.Context(), "k0s-metrics", metav1.GetOptions{})
+		require.NoError(t, err)
+		return ep
+	}
+
+	t.Run("CreatesEndpoints", func(t *testing.T) {
+		clients := testutil.NewFakeClientFactory()
+
+		require.NoError(t, newMetrics(clients).reconcileEndpoints(t.Context(), true))
+
+		ep := getEndpoints(t, clients)
+		assert.Equal(t, "k0s", ep.Labels["app.kubernetes.io/name"])
+		assert.Equal(t, []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{newAddress("10.0.0.1", "controller-1")},
+			Ports:     newPort(9444),
+		}}, ep.Subsets)
+	})
+
+	t.Run("KeepsActiveAndRemovesExpiredControllers", func(t *testing.T) {
+		clients := testutil.NewFakeClientFactory(
+			newLease("controller-2", time.Now()),
+			newLease("controller-3", time.Now().Add(-1*time.Hour)),
+			&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Name: "k0s-metrics", Namespace: metav1.NamespaceSystem},
+				Subsets: []corev1.EndpointSubset{{
+					Addresses: []corev1.EndpointAddress{
+						newAddress("10.0.0.2", "controller-2"),
+						newAddress("10.0.0.3", "controller-3"),
+						newAddress("10.0.0.4", "controller-4"), // no lease
+						{IP: "10.0.0.5"},                       // no node name
+						newAddress("10.0.0.99", "controller-1"),
+					},
+					Ports: newPort(9444),
+				}, {
+					Addresses: []corev1.EndpointAddress{newAddress("10.0.0.2", "controller-2")},
+					Ports:     newPort(9555),
+				}},
+			},
+		)
+
+		require.NoError(t, newMetrics(clients).reconcileEndpoints(t.Context(), true))
+
+		assert.Equal(t, []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{
+				newAddress("10.0.0.1", "controller-1"),
+				newAddress("10.0.0.2", "controller-2"),
+			},
+			Ports: newPort(9444),
+		}, {
+			Addresses: []corev1.EndpointAddress{newAddress("10.0.0.2", "controller-2")},
+			Ports:     newPort(9555),
+		}}, getEndpoints(t, clients).Subsets)
+	})
+
+	t.Run("Deregisters", func(t *testing.T) {
+		clients := testutil.NewFakeClientFactory(
+			newLease("controller-2", time.Now()),
+			&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Name: "k0s-metrics", Namespace: metav1.NamespaceSystem},
+				Subsets: []corev1.EndpointSubset{{
+					Addresses: []corev1.EndpointAddress{
+						newAddress("10.0.0.1", "controller-1"),
+						newAddress("10.0.0.2", "controller-2"),
+					},
+					Ports: newPort(9444),
+				}},
+			},
+		)
+
+		require.NoError(t, newMetrics(clients).reconcileEndpoints(t.Context(), false))
+
+		assert.Equal(t, []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{newAddress("10.0.0.2", "controller-2")},
+			Ports:     newPort(9444),
+		}}, getEndpoints(t, clients).Subsets)
+	})
+
+	t.Run("DeregisterWithoutEndpoints", func(t *testing.T) {
+		clients := testutil.NewFakeClientFactory()
+		require.NoError(t, newMetrics(clients).reconcileEndpoints(t.Context(), false))
+	})
+}
+
+func TestScrapeTarget(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = io.WriteString(w, "accept: "+r.Header.Get("Accept"))
+	}))
+	t.Cleanup(upstream.Close)
+
+	t.Run("PassesThrough", func(t *testing.T) {
+		target := &scrapeTarget{upstream.URL, upstream.Client()}
+		req := httptest.NewRequest(http.MethodGet, "/metrics/etcd", nil)
+		req.Header.Set("Accept", "application/openmetrics-text")
+		rec := httptest.NewRecorder()
+
+		target.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/plain; version=0.0.4", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "accept: application/openmetrics-text", rec.Body.String())
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		target := &scrapeTarget{"http://127.0.0.1:0/metrics", upstream.Client()}
+		rec := httptest.NewRecorder()
+
+		target.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics/kine", nil))
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Contains(t, rec.Body.String(), "error collecting metrics from http://127.0.0.1:0/metrics")
+	})
+}
+
+func TestMetrics_WriteManifests(t *testing.T) {
+	k0sVars, err := config.NewCfgVars(nil, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(k0sVars.ManifestsDir, "metrics"), 0755))
+	underTest := Metrics{K0sVars: k0sVars, Port: 9555}
+
+	require.NoError(t, underTest.writeManifests())
+
+	manifest, err := os.ReadFile(filepath.Join(k0sVars.ManifestsDir, "metrics", "k0s-metrics.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "port: 9555\n", "expected the configured port")
+}
@@ -0,0 +1,361 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/templatewriter"
+	"github.com/k0sproject/k0s/internal/sync/value"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/constant"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/kubernetes/watch"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NetworkPolicies deploys the baseline network policies into the namespaces
+// that have been created after the policies were enabled. Namespaces that
+// existed before need to opt in, all others may opt out. The system namespaces
+// are always exempt. The namespaces are watched, so that new ones get covered
+// right away.
+type NetworkPolicies struct {
+	log           logrus.FieldLogger
+	clientFactory kubeutil.ClientFactoryInterface
+	manifestsDir  string
+
+	enabled value.Latest[*bool]
+	stop    func()
+}
+
+var _ manager.Component = (*NetworkPolicies)(nil)
+var _ manager.Reconciler = (*NetworkPolicies)(nil)
+
+// The ConfigMap whose creation timestamp records when the network policies
+// have been enabled. This way, it's based on the API server's clock, just as
+// the namespaces' creation timestamps, and it survives controller restarts.
+const networkPoliciesConfigMapName = "k0s-network-policies"
+
+// The namespaces that host the cluster's system components. They're exempt
+// from the network policies.
+var networkPoliciesExemptNamespaces = []string{
+	metav1.NamespaceDefault,
+	metav1.NamespacePublic,
+	corev1.NamespaceNodeLease,
+	metav1.NamespaceSystem,
+}
+
+type networkPoliciesConfig struct {
+	Namespaces []string
+}
+
+// NewNetworkPolicies creates new NetworkPolicies reconciler component
+func NewNetworkPolicies(clientFactory kubeutil.ClientFactoryInterface, manifestsDir string) *NetworkPolicies {
+	return &NetworkPolicies{
+		log:           logrus.WithFields(logrus.Fields{"component": "network-policies"}),
+		clientFactory: clientFactory,
+		manifestsDir:  filepath.Join(manifestsDir, "networkpolicies"),
+	}
+}
+
+// Init implements [manager.Component].
+func (n *NetworkPolicies) Init(context.Context) error {
+	return nil
+}
+
+// Start implements [manager.Component].
+func (n *NetworkPolicies) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			var retry <-chan time.Time
+			enabled, enabledChanged := n.enabled.Peek()
+			switch {
+			case enabled == nil:
+				n.log.Debug("Waiting for configuration")
+
+			case !*enabled:
+				if err := n.disable(ctx); err != nil {
+					retry = time.After(10 * time.Second)
+					n.log.WithError(err).Error("Failed to remove network policies, retrying in 10 seconds")
+				}
+
+			default:
+				// Watch the namespaces until the configuration changes.
+				watchCtx, cancelWatch := context.WithCancel(ctx)
+				go func() {
+					select {
+					case <-enabledChanged:
+					case <-watchCtx.Done():
+					}
+					cancelWatch()
+				}()
+				err := n.watchNamespaces(watchCtx)
+				cancelWatch()
+				if err != nil && watchCtx.Err() == nil {
+					retry = time.After(10 * time.Second)
+					n.log.WithError(err).Error("Failed to update network policies, retrying in 10 seconds")
+				}
+			}
+
+			select {
+			case <-enabledChanged:
+			case <-retry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	n.stop = func() { cancel(); <-done }
+	return nil
+}
+
+// Stop implements [manager.Component].
+func (n *NetworkPolicies) Stop() error {
+	if stop := n.stop; stop != nil {
+		stop()
+	}
+	return nil
+}
+
+// Reconcile detects changes in configuration and applies them to the component
+func (n *NetworkPolicies) Reconcile(_ context.Context, clusterConfig *v1beta1.ClusterConfig) error {
+	n.log.Debug("reconcile method called for: NetworkPolicies")
+	n.enabled.Set(new(clusterConfig.Spec.Network.Policies.IsEnabled()))
+	return nil
+}
+
+// Removes the network policies and forgets when they have been enabled, so
+// that enabling them again won't affect the namespaces created in between.
+func (n *NetworkPolicies) disable(ctx context.Context) error {
+	if dir.IsDirectory(n.manifestsDir) {
+		if err := os.RemoveAll(n.manifestsDir); err != nil {
+			return err
+		}
+		n.log.Info("Removed network policies")
+	}
+
+	client, err := n.clientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+	err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Delete(ctx, networkPoliciesConfigMapName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Returns the time at which the network policies have been enabled, recording
+// it if they have just been enabled.
+func (n *NetworkPolicies) enabledSince(ctx context.Context, client kubernetes.Interface) (time.Time, error) {
+	configMaps := client.CoreV1().ConfigMaps(metav1.NamespaceSystem)
+	configMap, err := configMaps.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: networkPoliciesConfigMapName},
+	}, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		configMap, err = configMaps.Get(ctx, networkPoliciesConfigMapName, metav1.GetOptions{})
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return configMap.CreationTimestamp.Time, nil
+}
+
+// Keeps the network policies in sync with the namespaces until the context is
+// done.
+func (n *NetworkPolicies) watchNamespaces(ctx context.Context) error {
+	client, err := n.clientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	enabledSince, err := n.enabledSince(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed to determine when network policies have been enabled: %w", err)
+	}
+
+	covered := make(map[string]struct{})
+	observe := func(namespace *corev1.Namespace) {
+		if coveredByNetworkPolicies(namespace, enabledSince) {
+			covered[namespace.Name] = struct{}{}
+		} else {
+			delete(covered, namespace.Name)
+		}
+	}
+
+	var previousConfig *networkPoliciesConfig
+	update := func() error {
+		config := networkPoliciesConfig{Namespaces: slices.Sorted(maps.Keys(covered))}
+		if config.Namespaces == nil {
+			config.Namespaces = []string{}
+		}
+		if updateIfChanged(&previousConfig, &config) {
+			if err := n.writeManifests(&config); err != nil {
+				previousConfig = nil
+				return err
+			}
+			n.log.WithField("namespaces", len(config.Namespaces)).Info("Updated network policies")
+		}
+		return nil
+	}
+
+	watcher := watch.Namespaces(client.CoreV1().Namespaces()).IncludingDeletions()
+
+	// Start over whenever the namespaces are listed, so that namespaces that
+	// have been deleted in the meantime are dropped. Update the policies only
+	// once all namespaces are known.
+	list := watcher.List
+	watcher.List = func(ctx context.Context, opts metav1.ListOptions) (string, []corev1.Namespace, error) {
+		resourceVersion, namespaces, err := list(ctx, opts)
+		if err != nil {
+			return "", nil, err
+		}
+		clear(covered)
+		for i := range namespaces {
+			observe(&namespaces[i])
+		}
+		return resourceVersion, namespaces, update()
+	}
+
+	// Namespaces usually go through termination before they're deleted. Treat
+	// deleted namespaces as terminating ones in any case.
+	watchFunc := watcher.Watch
+	watcher.Watch = func(ctx context.Context, opts metav1.ListOptions) (apiwatch.Interface, error) {
+		w, err := watchFunc(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return apiwatch.Filter(w, func(event apiwatch.Event) (apiwatch.Event, bool) {
+			if namespace, ok := event.Object.(*corev1.Namespace); ok && event.Type == apiwatch.Deleted && namespace.DeletionTimestamp == nil {
+				namespace = namespace.DeepCopy()
+				namespace.DeletionTimestamp = new(metav1.Now())
+				event.Object = namespace
+			}
+			return event, true
+		}), nil
+	}
+
+	return watcher.
+		WithErrorCallback(func(err error) (time.Duration, error) {
+			if retryAfter, e := watch.IsRetryable(err); e == nil {
+				n.log.WithError(err).Debug("Transient error while watching namespaces, retrying in ", retryAfter)
+				return retryAfter, nil
+			}
+			return 0, err
+		}).
+		Until(ctx, func(namespace *corev1.Namespace) (bool, error) {
+			observe(namespace)
+			return false, update()
+		})
+}
+
+// Tells whether the network policies should be deployed into the given
+// namespace, given the time at which they have been enabled.
+func coveredByNetworkPolicies(namespace *corev1.Namespace, enabledSince time.Time) bool {
+	// Resources can't be created in terminating namespaces.
+	if namespace.DeletionTimestamp != nil || slices.Contains(networkPoliciesExemptNamespaces, namespace.Name) {
+		return false
+	}
+
+	switch namespace.Labels[constant.NetworkPoliciesLabel] {
+	case "enabled":
+		return true
+	case "disabled":
+		return false
+	}
+
+	return !namespace.CreationTimestamp.Time.Before(enabledSince)
+}
+
+func (n *NetworkPolicies) writeManifests(config *networkPoliciesConfig) error {
+	if err := dir.Init(n.manifestsDir, constant.ManifestsDirMode); err != nil {
+		return err
+	}
+
+	tw := templatewriter.TemplateWriter{
+		Name:     "network-policies",
+		Template: networkPoliciesTemplate,
+		Data:     config,
+		Path:     filepath.Join(n.manifestsDir, "network-policies.yaml"),
+	}
+	if err := tw.Write(); err != nil {
+		return fmt.Errorf("error writing network policy manifests: %w", err)
+	}
+	return nil
+}
+
+const networkPoliciesTemplate = `
+{{- range .Namespaces }}
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: k0s-default-deny
+  namespace: {{ . }}
+spec:
+  podSelector: {}
+  policyTypes:
+    - Ingress
+    - Egress
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: k0s-allow-dns
+  namespace: {{ . }}
+spec:
+  podSelector: {}
+  policyTypes:
+    - Egress
+  egress:
+    - to:
+        - namespaceSelector:
+            matchLabels:
+              kubernetes.io/metadata.name: kube-system
+          podSelector:
+            matchLabels:
+              k8s-app: kube-dns
+      ports:
+        - protocol: UDP
+          port: 53
+        - protocol: TCP
+          port: 53
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: k0s-allow-from-kube-system
+  namespace: {{ . }}
+spec:
+  podSelector: {}
+  policyTypes:
+    - Ingress
+  ingress:
+    - from:
+        - namespaceSelector:
+            matchLabels:
+              kubernetes.io/metadata.name: kube-system
+{{- end }}
+`
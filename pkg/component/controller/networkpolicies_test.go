@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkPolicies(t *testing.T) {
+	existing := metav1.NewTime(time.Now().Add(-time.Hour))
+	newNamespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            labels,
+			CreationTimestamp: existing,
+		}}
+	}
+
+	terminating := newNamespace("terminating", map[string]string{constant.NetworkPoliciesLabel: "enabled"})
+	terminating.DeletionTimestamp = new(metav1.Now())
+	terminating.Finalizers = []string{"kubernetes"}
+
+	client := fake.NewClientset(
+		newNamespace("default", nil),
+		newNamespace("kube-node-lease", nil),
+		newNamespace("kube-public", nil),
+		newNamespace("kube-system", map[string]string{constant.NetworkPoliciesLabel: "enabled"}),
+		newNamespace("existing", nil),
+		newNamespace("opted-in", map[string]string{constant.NetworkPoliciesLabel: "enabled"}),
+		terminating,
+	)
+	// The API server sets the creation timestamp and the resource version, the
+	// fake client doesn't.
+	var resourceVersion atomic.Uint64
+	client.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		var object runtime.Object
+		switch action := action.(type) {
+		case k8stesting.CreateAction:
+			object = action.GetObject()
+		case k8stesting.UpdateAction:
+			object = action.GetObject()
+		default:
+			return false, nil, nil
+		}
+		if object, ok := object.(metav1.Object); ok {
+			if object.GetCreationTimestamp().Time.IsZero() {
+				object.SetCreationTimestamp(metav1.Now())
+			}
+			object.SetResourceVersion(strconv.FormatUint(resourceVersion.Add(1), 10))
+		}
+		return false, nil, nil
+	})
+	clients := testutil.NewFakeClientFactory()
+	clients.Client = client
+
+	manifestsDir := t.TempDir()
+	manifestPath := filepath.Join(manifestsDir, "networkpolicies", "network-policies.yaml")
+	underTest := NewNetworkPolicies(clients, manifestsDir)
+	require.NoError(t, underTest.Init(t.Context()))
+	require.NoError(t, underTest.Start(t.Context()))
+	t.Cleanup(func() { assert.NoError(t, underTest.Stop()) })
+
+	// Returns the names of the network policies per namespace.
+	readPolicies := func(t assert.TestingT) map[string][]string {
+		manifestData, err := os.ReadFile(manifestPath)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		resources, err := testutil.ParseManifests(manifestData)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+
+		policies := make(map[string][]string)
+		for _, resource := range resources {
+			assert.Equal(t, "NetworkPolicy", resource.GetKind())
+			policies[resource.GetNamespace()] = append(policies[resource.GetNamespace()], resource.GetName())
+		}
+		return policies
+	}
+
+	baseline := []string{
+		"k0s-default-deny",
+		"k0s-allow-dns",
+		"k0s-allow-from-kube-system",
+	}
+
+	cfg := v1beta1.DefaultClusterConfig()
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg.Spec.Network.Policies = &v1beta1.NetworkPolicies{Enabled: true}
+		require.NoError(t, underTest.Reconcile(t.Context(), cfg))
+
+		// Namespaces that existed before are only covered if they opted in.
+		// System namespaces are always exempt.
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			assert.Equal(t, map[string][]string{"opted-in": baseline}, readPolicies(t))
+		}, 5*time.Second, 10*time.Millisecond)
+
+		_, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(t.Context(), networkPoliciesConfigMapName, metav1.GetOptions{})
+		assert.NoError(t, err, "the time the policies have been enabled must be recorded")
+	})
+
+	t.Run("namespace_created_after_enabling", func(t *testing.T) {
+		for _, namespace := range []*corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: "created"}},
+			{ObjectMeta: metav1.ObjectMeta{
+				Name:   "created-opted-out",
+				Labels: map[string]string{constant.NetworkPoliciesLabel: "disabled"},
+			}},
+		} {
+			_, err := client.CoreV1().Namespaces().Create(t.Context(), namespace, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			assert.Equal(t, map[string][]string{
+				"created":  baseline,
+				"opted-in": baseline,
+			}, readPolicies(t))
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("namespace_deleted", func(t *testing.T) {
+		require.NoError(t, client.CoreV1().Namespaces().Delete(t.Context(), "created", metav1.DeleteOptions{}))
+
+		assert.EventuallyWithT(t, func(t *assert.CollectT) {
+			assert.Equal(t, map[string][]string{"opted-in": baseline}, readPolicies(t))
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg.Spec.Network.Policies.Enabled = false
+		require.NoError(t, underTest.Reconcile(t.Context(), cfg))
+
+		assert.EventuallyWithT(t, func(ct *assert.CollectT) {
+			assert.NoDirExists(ct, filepath.Dir(manifestPath))
+			_, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(t.Context(), networkPoliciesConfigMapName, metav1.GetOptions{})
+			assert.True(ct, apierrors.IsNotFound(err), "the time the policies have been enabled must be forgotten, got %v", err)
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
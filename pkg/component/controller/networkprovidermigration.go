@@ -0,0 +1,526 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	k0sclient "github.com/k0sproject/k0s/pkg/client/clientset/typed/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/component/controller/leaderelector"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/constant"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+	"github.com/k0sproject/k0s/pkg/kubernetes/watch"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kubectl/pkg/drain"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NetworkProviderMigration migrates the cluster to another network provider
+// whenever spec.network.provider is changed after installation. The leading
+// controller deploys the new network provider next to the old one and then
+// migrates the nodes one at a time. Once all nodes are migrated, the old
+// network provider gets removed. The progress is reported in the status of the
+// cluster configuration.
+//
+// Migrations are disruptive, since pods on different nodes may get the same IP
+// address until all nodes are migrated. Hence they need to be allowed
+// explicitly via spec.network.allowProviderMigration.
+//
+// The network provider a node is supposed to run is determined by the node's
+// network provider label. The DaemonSets of both network providers only select
+// the nodes that are labeled with them during the migration. All nodes are
+// labeled before the migration starts.
+type NetworkProviderMigration struct {
+	ClientFactory kubeutil.ClientFactoryInterface
+	LeaderElector leaderelector.Interface
+	ManifestsDir  string
+
+	log  logrus.FieldLogger
+	stop func()
+}
+
+var _ manager.Component = (*NetworkProviderMigration)(nil)
+
+type migratableNetworkProvider struct {
+	daemonSet   string
+	podSelector string
+}
+
+// The network providers that support migrations between each other.
+var migratableNetworkProviders = map[string]migratableNetworkProvider{
+	constant.CNIProviderCalico: {
+		daemonSet:   "calico-node",
+		podSelector: "k8s-app in (calico-node, calico-node-windows)",
+	},
+	constant.CNIProviderKubeRouter: {
+		daemonSet:   "kube-router",
+		podSelector: "k8s-app=kube-router",
+	},
+}
+
+// Init implements [manager.Component].
+func (m *NetworkProviderMigration) Init(context.Context) error {
+	m.log = logrus.WithField("component", "network-provider-migration")
+	return nil
+}
+
+// Start implements [manager.Component].
+func (m *NetworkProviderMigration) Start(context.Context) error {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			if !m.LeaderElector.IsLeader() {
+				m.log.Debug("Not the leader, skipping network provider migration")
+				return
+			}
+
+			if err := m.reconcile(ctx); err != nil {
+				m.log.WithError(err).Error("Failed to reconcile network provider migration")
+			}
+		}, 10*time.Second, 0.1, false)
+	}()
+
+	m.stop = func() {
+		cancel(errors.New("network provider migration is stopping"))
+		<-done
+	}
+
+	return nil
+}
+
+// Stop implements [manager.Component].
+func (m *NetworkProviderMigration) Stop() error {
+	if m.stop != nil {
+		m.stop()
+	}
+	return nil
+}
+
+// Performs the next step of the network provider migration, if any.
+func (m *NetworkProviderMigration) reconcile(ctx context.Context) error {
+	k0sClient, err := m.ClientFactory.GetK0sClient()
+	if err != nil {
+		return err
+	}
+	client, err := m.ClientFactory.GetClient()
+	if err != nil {
+		return err
+	}
+
+	configs := k0sClient.K0sV1beta1().ClusterConfigs(constant.ClusterConfigNamespace)
+	clusterConfig, err := configs.Get(ctx, constant.ClusterConfigObjectName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get cluster configuration: %w", err)
+	}
+
+	if migration := clusterConfig.Status.ActiveNetworkProviderMigration(); migration != nil {
+		return m.proceed(ctx, configs, client, clusterConfig, migration)
+	}
+
+	return m.begin(ctx, configs, client, clusterConfig)
+}
+
+// Checks if the configured network provider differs from the deployed one and
+// starts a migration if possible.
+func (m *NetworkProviderMigration) begin(ctx context.Context, configs k0sclient.ClusterConfigInterface, client kubernetes.Interface, clusterConfig *v1beta1.ClusterConfig) error {
+	var last *v1beta1.NetworkProviderMigrationStatus
+	if clusterConfig.Status != nil {
+		last = clusterConfig.Status.NetworkProviderMigration
+	}
+
+	provider := clusterConfig.Spec.Network.Provider
+
+	// The manifests of the old network provider may not have been removed yet.
+	if last != nil && last.Phase == v1beta1.NetworkProviderMigrationCompleted && last.To == provider {
+		return nil
+	}
+
+	existing := existingCNIProvider(m.ManifestsDir)
+	if existing == "" || existing == provider {
+		// Clear any previous failure once the provider has been reverted.
+		if last != nil && last.Phase == v1beta1.NetworkProviderMigrationFailed {
+			_, err := m.updateStatus(ctx, configs, clusterConfig, nil)
+			return err
+		}
+		return nil
+	}
+
+	fail := func(message string) error {
+		if last != nil && last.Phase == v1beta1.NetworkProviderMigrationFailed &&
+			last.From == existing && last.To == provider && last.Message == message {
+			return nil
+		}
+
+		m.log.Errorf("Cannot migrate network provider from %s to %s: %s", existing, provider, message)
+		_, err := m.updateStatus(ctx, configs, clusterConfig, &v1beta1.NetworkProviderMigrationStatus{
+			From:               existing,
+			To:                 provider,
+			Phase:              v1beta1.NetworkProviderMigrationFailed,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+		return err
+	}
+
+	_, fromSupported := migratableNetworkProviders[existing]
+	_, toSupported := migratableNetworkProviders[provider]
+	if !fromSupported || !toSupported {
+		return fail(fmt.Sprintf("migrations from %s to %s are not supported", existing, provider))
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	if provider == constant.CNIProviderKubeRouter {
+		if slices.ContainsFunc(nodes.Items, func(node corev1.Node) bool {
+			return node.Labels[corev1.LabelOSStable] == string(corev1.Windows)
+		}) {
+			return fail("kube-router doesn't support Windows nodes")
+		}
+	}
+
+	if !clusterConfig.Spec.Network.AllowProviderMigration {
+		return fail("pods may get conflicting IP addresses during the migration, set spec.network.allowProviderMigration to true to allow it")
+	}
+
+	// Nodes that are already labeled with the new network provider have been
+	// migrated before and are skipped.
+	if err := labelUnlabeledNodes(ctx, client, nodes.Items, existing); err != nil {
+		return err
+	}
+
+	m.log.Infof("Starting network provider migration from %s to %s", existing, provider)
+	_, err = m.updateStatus(ctx, configs, clusterConfig, &v1beta1.NetworkProviderMigrationStatus{
+		From:               existing,
+		To:                 provider,
+		Phase:              v1beta1.NetworkProviderMigrationInProgress,
+		MigratedNodes:      countMigratedNodes(nodes.Items, provider),
+		TotalNodes:         len(nodes.Items),
+		LastTransitionTime: metav1.Now(),
+	})
+	return err
+}
+
+// Migrates the next node, or completes the migration if there are no more
+// nodes to migrate.
+func (m *NetworkProviderMigration) proceed(ctx context.Context, configs k0sclient.ClusterConfigInterface, client kubernetes.Interface, clusterConfig *v1beta1.ClusterConfig, migration *v1beta1.NetworkProviderMigrationStatus) error {
+	status := migration.DeepCopy()
+
+	switch provider := clusterConfig.Spec.Network.Provider; provider {
+	case status.To:
+		// Carry on.
+
+	case status.From:
+		// Migrate the nodes back to the old network provider.
+		m.log.Infof("Reverting network provider migration from %s to %s", status.From, status.To)
+		status.From, status.To = status.To, status.From
+		status.Message = ""
+		status.LastTransitionTime = metav1.Now()
+		_, err := m.updateStatus(ctx, configs, clusterConfig, status)
+		return err
+
+	default:
+		message := fmt.Sprintf(
+			"network provider changed to %s during the migration from %s to %s, change it back to one of the latter",
+			provider, status.From, status.To,
+		)
+		if status.Message == message {
+			return nil
+		}
+		m.log.Error(message)
+		status.Message = message
+		_, err := m.updateStatus(ctx, configs, clusterConfig, status)
+		return err
+	}
+
+	target := migratableNetworkProviders[status.To]
+	if _, err := client.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(ctx, target.daemonSet, metav1.GetOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		m.log.Infof("Waiting for %s to be deployed", status.To)
+		return nil
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+	slices.SortFunc(nodes.Items, func(l, r corev1.Node) int { return strings.Compare(l.Name, r.Name) })
+
+	// Nodes that joined the cluster in the meantime are neither running the
+	// old nor the new network provider. Label them, so that they get migrated
+	// as well, including the cleanup of whatever has been running before.
+	if err := labelUnlabeledNodes(ctx, client, nodes.Items, status.From); err != nil {
+		return err
+	}
+
+	status.TotalNodes = len(nodes.Items)
+	status.MigratedNodes = countMigratedNodes(nodes.Items, status.To)
+
+	// Resume the migration of the current node, if any. It might have been
+	// interrupted, e.g. by a change of the leading controller.
+	resuming := true
+	idx := slices.IndexFunc(nodes.Items, func(node corev1.Node) bool { return node.Name == status.CurrentNode })
+	if idx < 0 {
+		resuming = false
+		idx = slices.IndexFunc(nodes.Items, func(node corev1.Node) bool {
+			return node.Labels[constant.NetworkProviderLabel] != status.To
+		})
+	}
+
+	if idx < 0 {
+		m.log.Infof("Completed network provider migration from %s to %s", status.From, status.To)
+		status.Phase = v1beta1.NetworkProviderMigrationCompleted
+		status.CurrentNode = ""
+		status.Message = ""
+		status.LastTransitionTime = metav1.Now()
+		_, err := m.updateStatus(ctx, configs, clusterConfig, status)
+		return err
+	}
+
+	node := &nodes.Items[idx]
+	if !resuming {
+		status.CurrentNode = node.Name
+		status.Message = ""
+		if clusterConfig, err = m.updateStatus(ctx, configs, clusterConfig, status); err != nil {
+			return err
+		}
+	}
+
+	log := m.log.WithField("node", node.Name)
+	log.Infof("Migrating node from %s to %s", status.From, status.To)
+	if err := m.migrateNode(ctx, client, node, status.From, status.To); err != nil {
+		err = fmt.Errorf("failed to migrate node %s: %w", node.Name, err)
+		status.Message = err.Error()
+		_, statusErr := m.updateStatus(ctx, configs, clusterConfig, status)
+		return errors.Join(err, statusErr)
+	}
+	log.Infof("Migrated node from %s to %s", status.From, status.To)
+
+	status.CurrentNode = ""
+	status.Message = ""
+	if node.Labels[constant.NetworkProviderLabel] != status.To {
+		status.MigratedNodes++
+	}
+	_, err = m.updateStatus(ctx, configs, clusterConfig, status)
+	return err
+}
+
+// Migrates a single node to the given network provider. The steps are
+// idempotent, so that an interrupted migration can simply be resumed.
+func (m *NetworkProviderMigration) migrateNode(ctx context.Context, client kubernetes.Interface, node *corev1.Node, from, to string) error {
+	log := m.log.WithFields(logrus.Fields{"node": node.Name, "stream": "drainer"})
+	out, errOut := log.Writer(), log.WriterLevel(logrus.ErrorLevel)
+	defer func() { _, _ = out.Close(), errOut.Close() }()
+
+	drainer := &drain.Helper{
+		Client: client,
+		Force:  true,
+		// negative value to use the pod's terminationGracePeriodSeconds
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		Ctx:                 ctx,
+		Out:                 out,
+		ErrOut:              errOut,
+		// We want to proceed even when pods are using emptyDir volumes
+		DeleteEmptyDirData: true,
+		Timeout:            120 * time.Second,
+	}
+
+	// Leave nodes cordoned that have been cordoned before the migration. Record
+	// that before cordoning the node, so that it survives failed attempts.
+	cordoned, recorded := node.Annotations[constant.NetworkProviderCordonedAnnotation]
+	if !recorded {
+		cordoned = strconv.FormatBool(node.Spec.Unschedulable)
+		if err := patchNodeAnnotation(ctx, client, node.Name, constant.NetworkProviderCordonedAnnotation, &cordoned); err != nil {
+			return fmt.Errorf("failed to record cordon state: %w", err)
+		}
+	}
+
+	if err := drain.RunCordonOrUncordon(drainer, node, true); err != nil {
+		return fmt.Errorf("failed to cordon: %w", err)
+	}
+	if err := drain.RunNodeDrain(drainer, node.Name); err != nil {
+		return fmt.Errorf("failed to drain: %w", err)
+	}
+
+	// Switch the node over to the new network provider and ask the worker to
+	// clean up the leftovers of the old one.
+	if node.Labels[constant.NetworkProviderLabel] != to {
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"labels":      map[string]string{constant.NetworkProviderLabel: to},
+				"annotations": map[string]string{constant.NetworkProviderCleanupAnnotation: from},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := client.CoreV1().Nodes().Patch(ctx, node.Name, apitypes.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return fmt.Errorf("failed to label: %w", err)
+		}
+	}
+
+	if err := waitFor(ctx, "the cleanup of "+from, watch.Nodes(client.CoreV1().Nodes()).
+		WithObjectName(node.Name), func(node *corev1.Node) (bool, error) {
+		_, pending := node.Annotations[constant.NetworkProviderCleanupAnnotation]
+		return !pending, nil
+	}); err != nil {
+		return err
+	}
+
+	selector, err := labels.Parse(migratableNetworkProviders[to].podSelector)
+	if err != nil {
+		return err
+	}
+	if err := waitFor(ctx, to+" to become ready", watch.Pods(client.CoreV1().Pods(metav1.NamespaceSystem)).
+		WithLabelSelector(selector).
+		WithFieldSelector(fields.OneTermEqualSelector("spec.nodeName", node.Name)), func(pod *corev1.Pod) (bool, error) {
+		return pod.DeletionTimestamp == nil && slices.ContainsFunc(pod.Status.Conditions, func(c corev1.PodCondition) bool {
+			return c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue
+		}), nil
+	}); err != nil {
+		return err
+	}
+
+	// Restart the remaining pods, so that they get connected to the new
+	// network provider. Those are usually managed by DaemonSets.
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.HostNetwork || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if _, isMirrorPod := pod.Annotations[corev1.MirrorPodAnnotationKey]; isMirrorPod {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if err := client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to restart pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	if cordoned != "true" {
+		// The cordon helper requires an up-to-date node.
+		node, err = client.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := drain.RunCordonOrUncordon(drainer, node, false); err != nil {
+			return fmt.Errorf("failed to uncordon: %w", err)
+		}
+	}
+
+	if err := patchNodeAnnotation(ctx, client, node.Name, constant.NetworkProviderCordonedAnnotation, nil); err != nil {
+		return fmt.Errorf("failed to remove cordon state: %w", err)
+	}
+
+	return nil
+}
+
+// Sets the given annotation on the node, or removes it if value is nil.
+func patchNodeAnnotation(ctx context.Context, client kubernetes.Interface, nodeName, key string, value *string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, nodeName, apitypes.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// Waits up to five minutes until the condition is met.
+func waitFor[T any](ctx context.Context, what string, watcher *watch.Watcher[T], condition watch.Condition[T]) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	err := watcher.WithErrorCallback(watch.IsRetryable).Until(ctx, condition)
+	if err != nil {
+		return fmt.Errorf("while waiting for %s: %w", what, err)
+	}
+	return nil
+}
+
+// Labels all nodes that don't have a network provider label with the given
+// network provider. Updates the nodes in place.
+func labelUnlabeledNodes(ctx context.Context, client kubernetes.Interface, nodes []corev1.Node, provider string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{constant.NetworkProviderLabel: provider},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range nodes {
+		if _, labeled := nodes[i].Labels[constant.NetworkProviderLabel]; labeled {
+			continue
+		}
+		node, err := client.CoreV1().Nodes().Patch(ctx, nodes[i].Name, apitypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to label node %s: %w", nodes[i].Name, err)
+		}
+		nodes[i] = *node
+	}
+
+	return nil
+}
+
+func countMigratedNodes(nodes []corev1.Node, provider string) (count int) {
+	for _, node := range nodes {
+		if node.Labels[constant.NetworkProviderLabel] == provider {
+			count++
+		}
+	}
+	return count
+}
+
+// Updates the network provider migration status of the cluster configuration
+// and returns the updated object.
+func (m *NetworkProviderMigration) updateStatus(ctx context.Context, configs k0sclient.ClusterConfigInterface, clusterConfig *v1beta1.ClusterConfig, status *v1beta1.NetworkProviderMigrationStatus) (*v1beta1.ClusterConfig, error) {
+	clusterConfig = clusterConfig.DeepCopy()
+	if clusterConfig.Status == nil {
+		clusterConfig.Status = &v1beta1.ClusterConfigStatus{}
+	}
+	clusterConfig.Status.NetworkProviderMigration = status
+
+	updated, err := configs.UpdateStatus(ctx, clusterConfig, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cluster configuration status: %w", err)
+	}
+	return updated, nil
+}
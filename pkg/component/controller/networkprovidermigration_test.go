@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/testutil"
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProviderMigration(t *testing.T) {
+	newClusterConfig := func(provider string, migration *v1beta1.NetworkProviderMigrationStatus) *v1beta1.ClusterConfig {
+		return &v1beta1.ClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constant.ClusterConfigObjectName,
+				Namespace: constant.ClusterConfigNamespace,
+			},
+			Spec: &v1beta1.ClusterSpec{
+				Network: &v1beta1.Network{Provider: provider, AllowProviderMigration: true},
+			},
+			Status: &v1beta1.ClusterConfigStatus{NetworkProviderMigration: migration},
+		}
+	}
+
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	newDaemonSet := func(name string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem}}
+	}
+
+	// Runs a single reconciliation and returns the resulting migration status.
+	reconcileWith := func(t *testing.T, clients *testutil.FakeClientFactory, existingProvider string) *v1beta1.NetworkProviderMigrationStatus {
+		manifestsDir := t.TempDir()
+		if existingProvider != "" {
+			manifestPath := map[string]string{
+				"calico":     filepath.Join("calico", "calico-DaemonSet-calico-node.yaml"),
+				"cilium":     filepath.Join("cilium", "cilium-DaemonSet-cilium.yaml"),
+				"kuberouter": filepath.Join("kuberouter", "kube-router.yaml"),
+			}[existingProvider]
+			require.NoError(t, os.MkdirAll(filepath.Join(manifestsDir, filepath.Dir(manifestPath)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, manifestPath), nil, 0644))
+		}
+
+		underTest := &NetworkProviderMigration{ClientFactory: clients, ManifestsDir: manifestsDir}
+		require.NoError(t, underTest.Init(t.Context()))
+		require.NoError(t, underTest.reconcile(t.Context()))
+
+		k0sClient, err := clients.GetK0sClient()
+		require.NoError(t, err)
+		clusterConfig, err := k0sClient.K0sV1beta1().ClusterConfigs(constant.ClusterConfigNamespace).Get(t.Context(), constant.ClusterConfigObjectName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotNil(t, clusterConfig.Status)
+		return clusterConfig.Status.NetworkProviderMigration
+	}
+
+	reconcile := func(t *testing.T, existingProvider string, objects ...runtime.Object) *v1beta1.NetworkProviderMigrationStatus {
+		return reconcileWith(t, testutil.NewFakeClientFactory(objects...), existingProvider)
+	}
+
+	t.Run("nothing_to_do", func(t *testing.T) {
+		status := reconcile(t, "kuberouter", newClusterConfig("kuberouter", nil), newNode("a", nil))
+		assert.Nil(t, status)
+	})
+
+	t.Run("starts_migration", func(t *testing.T) {
+		clients := testutil.NewFakeClientFactory(
+			newClusterConfig("calico", nil),
+			newNode("a", nil), newNode("b", nil),
+			newNode("c", map[string]string{constant.NetworkProviderLabel: "calico"}),
+		)
+		status := reconcileWith(t, clients, "kuberouter")
+		if assert.NotNil(t, status) {
+			assert.Equal(t, "kuberouter", status.From)
+			assert.Equal(t, "calico", status.To)
+			assert.Equal(t, v1beta1.NetworkProviderMigrationInProgress, status.Phase)
+			assert.Equal(t, 1, status.MigratedNodes)
+			assert.Equal(t, 3, status.TotalNodes)
+		}
+
+		// Untouched nodes are labeled with the old network provider, so that
+		// neither network provider's DaemonSet selects unlabeled nodes. Nodes
+		// that are running the new network provider already are left alone.
+		client, err := clients.GetClient()
+		require.NoError(t, err)
+		for name, provider := range map[string]string{"a": "kuberouter", "b": "kuberouter", "c": "calico"} {
+			node, err := client.CoreV1().Nodes().Get(t.Context(), name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, provider, node.Labels[constant.NetworkProviderLabel], name)
+		}
+	})
+
+	t.Run("requires_migrations_to_be_allowed", func(t *testing.T) {
+		clusterConfig := newClusterConfig("calico", nil)
+		clusterConfig.Spec.Network.AllowProviderMigration = false
+		clients := testutil.NewFakeClientFactory(clusterConfig, newNode("a", nil))
+		status := reconcileWith(t, clients, "kuberouter")
+		if assert.NotNil(t, status) {
+			assert.Equal(t, v1beta1.NetworkProviderMigrationFailed, status.Phase)
+			assert.Contains(t, status.Message, "spec.network.allowProviderMigration")
+		}
+
+		client, err := clients.GetClient()
+		require.NoError(t, err)
+		node, err := client.CoreV1().Nodes().Get(t.Context(), "a", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, node.Labels, constant.NetworkProviderLabel, "nodes must not be touched")
+	})
+
+	t.Run("rejects_unsupported_providers", func(t *testing.T) {
+		status := reconcile(t, "cilium", newClusterConfig("calico", nil), newNode("a", nil))
+		if assert.NotNil(t, status) {
+			assert.Equal(t, v1beta1.NetworkProviderMigrationFailed, status.Phase)
+			assert.Equal(t, "migrations from cilium to calico are not supported", status.Message)
+		}
+	})
+
+	t.Run("rejects_windows_nodes_for_kuberouter", func(t *testing.T) {
+		status := reconcile(t, "calico",
+			newClusterConfig("kuberouter", nil),
+			newNode("a", nil), newNode("b", map[string]string{corev1.LabelOSStable: "windows"}),
+		)
+		if assert.NotNil(t, status) {
+			assert.Equal(t, v1beta1.NetworkProviderMigrationFailed, status.Phase)
+			assert.Equal(t, "kube-router doesn't support Windows nodes", status.Message)
+		}
+	})
+
+	t.Run("clears_failure_when_reverted", func(t *testing.T) {
+		status := reconcile(t, "cilium",
+			newClusterConfig("cilium", &v1beta1.NetworkProviderMigrationStatus{
+				From: "cilium", To: "calico", Phase: v1beta1.NetworkProviderMigrationFailed,
+			}),
+		)
+		assert.Nil(t, status)
+	})
+
+	t.Run("waits_for_new_provider", func(t *testing.T) {
+		migration := &v1beta1.NetworkProviderMigrationStatus{
+			From: "kuberouter", To: "calico", Phase: v1beta1.NetworkProviderMigrationInProgress, TotalNodes: 1,
+		}
+		status := reconcile(t, "kuberouter", newClusterConfig("calico", migration), newNode("a", nil))
+		assert.Equal(t, migration, status)
+	})
+
+	t.Run("reverts_migration", func(t *testing.T) {
+		status := reconcile(t, "kuberouter",
+			newClusterConfig("kuberouter", &v1beta1.NetworkProviderMigrationStatus{
+				From: "kuberouter", To: "calico", Phase: v1beta1.NetworkProviderMigrationInProgress,
+			}),
+		)
+		if assert.NotNil(t, status) {
+			assert.Equal(t, "calico", status.From)
+			assert.Equal(t, "kuberouter", status.To)
+			assert.Equal(t, v1beta1.NetworkProviderMigrationInProgress, status.Phase)
+		}
+	})
+
+	t.Run("completes_migration", func(t *testing.T) {
+		labels := map[string]string{constant.NetworkProviderLabel: "calico"}
+		status := reconcile(t, "calico",
+			newClusterConfig("calico", &v1beta1.NetworkProviderMigrationStatus{
+				From: "kuberouter", To: "calico", Phase: v1beta1.NetworkProviderMigrationInProgress,
+			}),
+			newDaemonSet("calico-node"),
+			newNode("a", labels), newNode("b", labels),
+		)
+		if assert.NotNil(t, status) {
+			assert.Equal(t, v1beta1.NetworkProviderMigrationCompleted, status.Phase)
+			assert.Equal(t, 2, status.MigratedNodes)
+			assert.Equal(t, 2, status.TotalNodes)
+		}
+	})
+
+	t.Run("restores_cordon_state", func(t *testing.T) {
+		for _, test := range []struct {
+			name       string
+			recorded   *string
+			unschedule bool
+		}{
+			{"cordoned_before", new("true"), true},
+			{"cordoned_by_migration", new("false"), false},
+			{"not_yet_recorded", nil, true},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				// The node has been switched over to calico by a previous
+				// attempt, which failed afterwards.
+				node := newNode("a", map[string]string{constant.NetworkProviderLabel: "calico"})
+				node.Spec.Unschedulable = true
+				if test.recorded != nil {
+					node.Annotations = map[string]string{constant.NetworkProviderCordonedAnnotation: *test.recorded}
+				}
+				calicoNode := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "calico-node-a", Namespace: metav1.NamespaceSystem,
+						Labels: map[string]string{"k8s-app": "calico-node"},
+						OwnerReferences: []metav1.OwnerReference{{
+							APIVersion: "apps/v1", Kind: "DaemonSet", Name: "calico-node", Controller: new(true),
+						}},
+					},
+					Spec:   corev1.PodSpec{NodeName: "a"},
+					Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+				}
+				clients := testutil.NewFakeClientFactory(
+					newClusterConfig("calico", &v1beta1.NetworkProviderMigrationStatus{
+						From: "kuberouter", To: "calico", Phase: v1beta1.NetworkProviderMigrationInProgress,
+						CurrentNode: "a",
+					}),
+					newDaemonSet("calico-node"), calicoNode, node,
+				)
+
+				status := reconcileWith(t, clients, "calico")
+				if assert.NotNil(t, status) {
+					assert.Empty(t, status.CurrentNode)
+					assert.Empty(t, status.Message)
+				}
+
+				node, err := clients.Client.CoreV1().Nodes().Get(t.Context(), "a", metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, test.unschedule, node.Spec.Unschedulable)
+				assert.NotContains(t, node.Annotations, constant.NetworkProviderCordonedAnnotation)
+			})
+		}
+	})
+}
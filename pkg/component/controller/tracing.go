@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("github.com/k0sproject/k0s/pkg/component/controller")
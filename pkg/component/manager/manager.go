@@ -14,10 +14,17 @@ import (
 
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/performance"
+	"github.com/k0sproject/k0s/pkg/tracing"
+
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
+var tracer = otel.Tracer("github.com/k0sproject/k0s/pkg/component/manager")
+
 type prober interface {
 	Register(name string, component any)
 	Run(context.Context)
@@ -54,7 +61,10 @@ func (m *Manager) Add(ctx context.Context, component Component) {
 }
 
 // Init initializes all managed components
-func (m *Manager) Init(ctx context.Context) error {
+func (m *Manager) Init(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "Init components")
+	defer func() { tracing.End(span, err) }()
+
 	g, _ := errgroup.WithContext(ctx)
 
 	for _, comp := range m.Components {
@@ -62,38 +72,58 @@ func (m *Manager) Init(ctx context.Context) error {
 		logrus.Infof("initializing %v", compName)
 		c := comp
 		// init this async
-		g.Go(func() error {
+		g.Go(func() (err error) {
+			ctx, span := tracer.Start(ctx, "Init "+compName, withComponent(compName))
+			start := time.Now()
+			defer func() {
+				componentInitDuration.WithLabelValues(compName).Set(time.Since(start).Seconds())
+				tracing.End(span, err)
+			}()
 			return c.Init(ctx)
 		})
 	}
-	err := g.Wait()
-	return err
+	return g.Wait()
 }
 
 // Start starts all managed components
-func (m *Manager) Start(ctx context.Context) error {
+func (m *Manager) Start(ctx context.Context) (err error) {
 	go m.prober.Run(ctx)
+
+	startCtx, span := tracer.Start(ctx, "Start components")
+	defer func() { tracing.End(span, err) }()
+
 	perfTimer := performance.NewTimer("component-start").Buffer().Start()
 	for _, comp := range m.Components {
 		compName := reflect.TypeOf(comp).Elem().Name()
 		perfTimer.Checkpoint("running-" + compName)
 		logrus.Infof("starting %v", compName)
-		if err := comp.Start(ctx); err != nil {
+		if err := m.startComponent(startCtx, comp, compName); err != nil {
 			_ = m.Stop()
 			return err
 		}
-		m.started.PushFront(comp)
 		perfTimer.Checkpoint(fmt.Sprintf("running-%s-done", compName))
-		if err := waitForReady(ctx, comp, compName, m.ReadyWaitDuration); err != nil {
-			_ = m.Stop()
-			return err
-		}
 		m.prober.Register(compName, comp)
 	}
 	perfTimer.Output()
 	return nil
 }
 
+func (m *Manager) startComponent(ctx context.Context, comp Component, compName string) (err error) {
+	ctx, span := tracer.Start(ctx, "Start "+compName, withComponent(compName))
+	defer func() { tracing.End(span, err) }()
+
+	start := time.Now()
+	if err := comp.Start(ctx); err != nil {
+		return err
+	}
+	m.started.PushFront(comp)
+	if err := waitForReady(ctx, comp, compName, m.ReadyWaitDuration); err != nil {
+		return err
+	}
+	componentStartDuration.WithLabelValues(compName).Set(time.Since(start).Seconds())
+	return nil
+}
+
 // Stop stops all managed components
 func (m *Manager) Stop() error {
 	var ret error
@@ -160,13 +190,23 @@ func (m *Manager) reconcileComponent(ctx context.Context, comp Component, cfg *v
 		return nil
 	}
 	logrus.Infof("starting to reconcile %s", compName)
-	if err := clusterComponent.Reconcile(ctx, cfg); err != nil {
+	metricsName := reflect.TypeOf(comp).Elem().Name()
+	componentReconciles.WithLabelValues(metricsName).Inc()
+	ctx, span := tracer.Start(ctx, "Reconcile "+metricsName, withComponent(metricsName))
+	err := clusterComponent.Reconcile(ctx, cfg)
+	tracing.End(span, err)
+	if err != nil {
+		componentReconcileErrors.WithLabelValues(metricsName).Inc()
 		logrus.Errorf("failed to reconcile component %s: %s", compName, err.Error())
 		return err
 	}
 	return nil
 }
 
+func withComponent(name string) trace.SpanStartEventOption {
+	return trace.WithAttributes(attribute.String("k0s.component", name))
+}
+
 func isReconcileComponent(comp Component) bool {
 	_, ok := comp.(Reconciler)
 	return ok
@@ -8,7 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	proberPackage "github.com/k0sproject/k0s/pkg/component/prober"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -152,3 +155,40 @@ func TestManagerHealthyFail(t *testing.T) {
 	require.True(t, f2.StopCalled)
 	require.False(t, f3.StopCalled)
 }
+
+type FakeReconciler struct {
+	Fake
+}
+
+func (f *FakeReconciler) Reconcile(context.Context, *v1beta1.ClusterConfig) error {
+	return f.ReconcileErr
+}
+
+func TestManagerMetrics(t *testing.T) {
+	m := New(proberPackage.NopProber{})
+	ctx := t.Context()
+
+	f := &FakeReconciler{}
+	m.Add(ctx, f)
+
+	require.NoError(t, m.Init(ctx))
+	require.NoError(t, m.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, m.Stop()) })
+
+	assert.Positive(t, promtestutil.ToFloat64(componentInitDuration.WithLabelValues("FakeReconciler")))
+	assert.Positive(t, promtestutil.ToFloat64(componentStartDuration.WithLabelValues("FakeReconciler")))
+
+	componentReconciles.DeleteLabelValues("FakeReconciler")
+	componentReconcileErrors.DeleteLabelValues("FakeReconciler")
+	reconciles := componentReconciles.WithLabelValues("FakeReconciler")
+	reconcileErrors := componentReconcileErrors.WithLabelValues("FakeReconciler")
+
+	require.NoError(t, m.Reconcile(ctx, &v1beta1.ClusterConfig{}))
+	assert.InDelta(t, 1, promtestutil.ToFloat64(reconciles), 0)
+	assert.InDelta(t, 0, promtestutil.ToFloat64(reconcileErrors), 0)
+
+	f.ReconcileErr = assert.AnError
+	require.Error(t, m.Reconcile(ctx, &v1beta1.ClusterConfig{}))
+	assert.InDelta(t, 2, promtestutil.ToFloat64(reconciles), 0)
+	assert.InDelta(t, 1, promtestutil.ToFloat64(reconcileErrors), 0)
+}
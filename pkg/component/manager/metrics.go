@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"github.com/k0sproject/k0s/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	componentInitDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "component",
+		Name:      "init_duration_seconds",
+		Help:      "Time it took to initialize a component.",
+	}, []string{"component"})
+
+	componentStartDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "component",
+		Name:      "start_duration_seconds",
+		Help:      "Time it took to start a component, including the time until it became ready.",
+	}, []string{"component"})
+
+	componentReconciles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "component",
+		Name:      "reconciles_total",
+		Help:      "Number of cluster configuration reconciliations of a component.",
+	}, []string{"component"})
+
+	componentReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "component",
+		Name:      "reconcile_errors_total",
+		Help:      "Number of failed cluster configuration reconciliations of a component.",
+	}, []string{"component"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		componentInitDuration,
+		componentStartDuration,
+		componentReconciles,
+		componentReconcileErrors,
+	)
+}
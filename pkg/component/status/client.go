@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	"github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
 	"github.com/k0sproject/k0s/pkg/component/prober"
 	"github.com/k0sproject/k0s/pkg/config"
@@ -29,6 +30,18 @@ type K0sStatus struct {
 	WorkerToAPIConnectionStatus ProbeStatus
 	ClusterConfig               *v1beta1.ClusterConfig
 	K0sVars                     *config.CfgVars
+	CPLB                        *apv1beta2.CPLBStatus     `json:",omitempty"`
+	KonnectivityAgents          *KonnectivityAgentsStatus `json:",omitempty"`
+}
+
+// KonnectivityAgentsStatus lists the nodes whose konnectivity agents connect
+// to a controller's konnectivity server. It's only available if the agents
+// connect to each konnectivity server directly.
+type KonnectivityAgentsStatus struct {
+	Server       string
+	Connected    []string
+	Disconnected []string
+	Error        string `json:",omitempty"`
 }
 type ProbeStatus struct {
 	Message string
@@ -36,18 +49,18 @@ type ProbeStatus struct {
 }
 
 // GetStatus returns the status of the k0s process using the status socket
-func GetStatusInfo(socketPath string) (*K0sStatus, error) {
+func GetStatusInfo(ctx context.Context, socketPath string) (*K0sStatus, error) {
 	status := &K0sStatus{}
-	if err := doStatusHTTPRequest(socketPath, "status", status); err != nil {
+	if err := doStatusHTTPRequest(ctx, socketPath, "status", status); err != nil {
 		return nil, err
 	}
 	return status, nil
 }
 
 // GetComponentStatus returns the per-component events and health-checks
-func GetComponentStatus(socketPath string, maxCount int) (*prober.State, error) {
+func GetComponentStatus(ctx context.Context, socketPath string, maxCount int) (*prober.State, error) {
 	status := &prober.State{}
-	if err := doStatusHTTPRequest(socketPath,
+	if err := doStatusHTTPRequest(ctx, socketPath,
 		fmt.Sprintf("components?maxCount=%d", maxCount),
 		status); err != nil {
 		return nil, err
@@ -55,7 +68,7 @@ func GetComponentStatus(socketPath string, maxCount int) (*prober.State, error)
 	return status, nil
 }
 
-func doStatusHTTPRequest(socketPath string, path string, tgt any) error {
+func doStatusHTTPRequest(ctx context.Context, socketPath string, path string, tgt any) error {
 	httpc := &http.Client{
 		Transport: &http.Transport{
 			DisableKeepAlives: true,
@@ -65,7 +78,12 @@ func doStatusHTTPRequest(socketPath string, path string, tgt any) error {
 		},
 	}
 
-	response, err := httpc.Get("http://localhost/" + path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/"+path, nil)
+	if err != nil {
+		return fmt.Errorf("status: can't get %q via %q: %w", path, socketPath, err)
+	}
+
+	response, err := httpc.Do(req)
 	if err != nil {
 		return fmt.Errorf("status: can't get %q via %q: %w", path, socketPath, err)
 	}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"time"
 
+	apv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
 	"github.com/k0sproject/k0s/pkg/component/manager"
 	"github.com/k0sproject/k0s/pkg/component/prober"
 	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
@@ -31,6 +32,14 @@ type Status struct {
 	L                 *logrus.Entry
 	httpserver        http.Server
 	CertManager       certManager
+
+	// CPLBStatus returns the status of the control plane load balancing, if
+	// it's enabled.
+	CPLBStatus func() *apv1beta2.CPLBStatus
+
+	// KonnectivityAgents returns the konnectivity agents connecting to this
+	// controller's konnectivity server, if they connect to it directly.
+	KonnectivityAgents func(context.Context) *KonnectivityAgentsStatus
 }
 
 type certManager interface {
@@ -112,6 +121,12 @@ const (
 
 func (sh *statusHandler) getCurrentStatus(ctx context.Context) K0sStatus {
 	status := sh.Status.StatusInformation
+	if sh.Status.CPLBStatus != nil {
+		status.CPLB = sh.Status.CPLBStatus()
+	}
+	if sh.Status.KonnectivityAgents != nil {
+		status.KonnectivityAgents = sh.Status.KonnectivityAgents(ctx)
+	}
 	if !status.Workloads {
 		return status
 	}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/cleanup"
+	"github.com/k0sproject/k0s/pkg/component/manager"
+	"github.com/k0sproject/k0s/pkg/constant"
+	"github.com/k0sproject/k0s/pkg/kubernetes/watch"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Cleanup removes the leftovers of a network provider from this node once it
+// has been replaced by another one. The network provider
+// migration requests this via an annotation on the node, which is removed
+// after the cleanup succeeded.
+type Cleanup struct {
+	NodeName    apitypes.NodeName
+	CertManager interface {
+		GetRestConfig(ctx context.Context) (*rest.Config, error)
+	}
+
+	log       logrus.FieldLogger
+	newClient func(context.Context) (kubernetes.Interface, error)
+	cleanup   func(provider string) error
+	stop      func()
+}
+
+var _ manager.Component = (*Cleanup)(nil)
+
+// Init implements [manager.Component].
+func (c *Cleanup) Init(context.Context) error {
+	c.log = logrus.WithField("component", "network-provider-cleanup")
+	if c.newClient == nil {
+		c.newClient = func(ctx context.Context) (kubernetes.Interface, error) {
+			config, err := c.CertManager.GetRestConfig(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return kubernetes.NewForConfig(config)
+		}
+	}
+	if c.cleanup == nil {
+		c.cleanup = func(provider string) error {
+			return cleanup.NewNetworkProviderConfig(provider).Cleanup()
+		}
+	}
+	return nil
+}
+
+// Start implements [manager.Component].
+func (c *Cleanup) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Go(func() { c.run(ctx) })
+	c.stop = func() { cancel(); wg.Wait() }
+	return nil
+}
+
+// Stop implements [manager.Component].
+func (c *Cleanup) Stop() error {
+	if c.stop != nil {
+		c.stop()
+	}
+	return nil
+}
+
+func (c *Cleanup) run(ctx context.Context) {
+	client, err := c.newClient(ctx)
+	if err != nil {
+		c.log.WithError(err).Error("Failed to create Kubernetes client")
+		return
+	}
+
+	nodes := client.CoreV1().Nodes()
+	_ = watch.Nodes(nodes).
+		WithObjectName(string(c.NodeName)).
+		WithErrorCallback(func(err error) (time.Duration, error) {
+			if retryAfter, e := watch.IsRetryable(err); e == nil {
+				c.log.WithError(err).Debug("Transient error while watching node, retrying in ", retryAfter)
+				return retryAfter, nil
+			}
+			retryAfter := 10 * time.Second
+			c.log.WithError(err).Error("Failed to clean up network provider leftovers, retrying in ", retryAfter)
+			return retryAfter, nil
+		}).
+		Until(ctx, func(node *corev1.Node) (bool, error) {
+			provider, ok := node.Annotations[constant.NetworkProviderCleanupAnnotation]
+			if !ok {
+				return false, nil
+			}
+
+			c.log.Info("Cleaning up leftovers of network provider ", provider)
+			if err := c.cleanup(provider); err != nil {
+				return false, err
+			}
+
+			// Only remove the annotation if it hasn't been changed in the meantime.
+			path := "/metadata/annotations/" + strings.ReplaceAll(constant.NetworkProviderCleanupAnnotation, "/", "~1")
+			patch := fmt.Sprintf(`[{"op":"test","path":%q,"value":%q},{"op":"remove","path":%q}]`, path, provider, path)
+			if _, err := nodes.Patch(ctx, node.Name, apitypes.JSONPatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+				return false, errors.Join(errors.New("failed to remove cleanup annotation"), err)
+			}
+
+			c.log.Info("Cleaned up leftovers of network provider ", provider)
+			return false, nil
+		})
+}
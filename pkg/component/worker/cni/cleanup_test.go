@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cni
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/constant"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanup(t *testing.T) {
+	client := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "worker",
+			Annotations: map[string]string{constant.NetworkProviderCleanupAnnotation: "kuberouter"},
+		},
+	})
+
+	cleaned := make(chan string, 1)
+	underTest := Cleanup{
+		NodeName:  "worker",
+		newClient: func(context.Context) (kubernetes.Interface, error) { return client, nil },
+		cleanup: func(provider string) error {
+			cleaned <- provider
+			return nil
+		},
+	}
+
+	require.NoError(t, underTest.Init(t.Context()))
+	require.NoError(t, underTest.Start(t.Context()))
+	t.Cleanup(func() { assert.NoError(t, underTest.Stop()) })
+
+	select {
+	case provider := <-cleaned:
+		assert.Equal(t, "kuberouter", provider)
+	case <-time.After(30 * time.Second):
+		require.Fail(t, "Timed out waiting for the cleanup")
+	}
+
+	assert.EventuallyWithT(t, func(ct *assert.CollectT) {
+		node, err := client.CoreV1().Nodes().Get(t.Context(), "worker", metav1.GetOptions{})
+		if assert.NoError(ct, err) {
+			assert.NotContains(ct, node.Annotations, constant.NetworkProviderCleanupAnnotation)
+		}
+	}, 10*time.Second, 50*time.Millisecond)
+}
@@ -56,7 +56,7 @@ func NewComponent(logLevel string, vars *config.CfgVars, profile *workerconfig.P
 		LogLevel:    logLevel,
 		K0sVars:     vars,
 		Profile:     profile,
-		confPath:    defaultConfPath,
+		confPath:    DefaultConfPath,
 		importsPath: DefaultImportsPath,
 	}
 	return c
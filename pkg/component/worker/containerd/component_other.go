@@ -10,7 +10,8 @@ import (
 )
 
 const (
-	defaultConfPath = "/etc/k0s/containerd.toml"
+	// DefaultConfPath is the path of the containerd config file managed by k0s.
+	DefaultConfPath = "/etc/k0s/containerd.toml"
 
 	// DefaultImportsPath is the default directory for containerd config drop-in files.
 	DefaultImportsPath = "/etc/k0s/containerd.d/"
@@ -13,7 +13,8 @@ import (
 )
 
 const (
-	defaultConfPath = `C:\Program Files\containerd\config.toml`
+	// DefaultConfPath is the path of the containerd config file managed by k0s.
+	DefaultConfPath = `C:\Program Files\containerd\config.toml`
 
 	// DefaultImportsPath is the default directory for containerd config drop-in files.
 	DefaultImportsPath = `C:\etc\k0s\containerd.d\`
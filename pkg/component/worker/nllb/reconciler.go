@@ -123,6 +123,11 @@ func NewReconciler(
 			dir:        filepath.Join(runtimeDir, "traefik"),
 			staticPods: staticPods,
 		}
+	case v1beta1.NllbTypeTCPProxy:
+		loadBalancer = &tcpProxy{
+			log:            logrus.WithFields(logrus.Fields{"component": "nllb.tcpProxy"}),
+			kubeconfigPath: k0sVars.KubeletAuthConfigPath,
+		}
 	default:
 		return nil, fmt.Errorf("unsupported node-local load balancing type: %q", workerProfile.NodeLocalLoadBalancing.Type)
 	}
@@ -0,0 +1,372 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nllb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	k0snet "github.com/k0sproject/k0s/internal/pkg/net"
+	"github.com/k0sproject/k0s/internal/pkg/net/tcpproxy"
+	workerconfig "github.com/k0sproject/k0s/pkg/component/worker/config"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The health check parameters, in line with the ones used for Envoy.
+const (
+	healthCheckInterval       = 5 * time.Second
+	healthCheckTimeout        = 1 * time.Second
+	healthCheckHealthyAfter   = 3
+	healthCheckUnhealthyAfter = 5
+)
+
+// tcpProxy is a load balancer backend that runs k0s's built-in TCP proxy
+// inside the k0s process. In contrast to the other backends, it doesn't
+// require a static Pod, and hence no image and no running kubelet.
+type tcpProxy struct {
+	log            logrus.FieldLogger
+	kubeconfigPath string
+
+	// valid when started
+	config           *tcpProxyConfig
+	proxy            *tcpproxy.Proxy
+	apiServers       *upstreamGroup
+	konnectivity     *upstreamGroup
+	stopHealthChecks func()
+}
+
+var _ backend = (*tcpProxy)(nil)
+
+// The proxy only opens its listeners for routes with at least one upstream.
+var errNoAPIServers = errors.New("no API servers to proxy to")
+
+type tcpProxyConfig struct {
+	bindIP                     net.IP
+	apiServerBindPort          uint16
+	konnectivityServerBindPort uint16
+	konnectivityServerPort     uint16
+}
+
+func (*tcpProxy) init(context.Context) error {
+	return nil
+}
+
+func (t *tcpProxy) start(ctx context.Context, profile workerconfig.Profile, apiServers []k0snet.HostPort) error {
+	if t.config != nil {
+		return errors.New("already started")
+	}
+	if len(apiServers) < 1 {
+		return errNoAPIServers
+	}
+
+	loopbackIP, err := getLoopbackIP(ctx)
+	if err != nil {
+		if errors.Is(err, ctx.Err()) {
+			return err
+		}
+		t.log.WithError(err).Infof("Falling back to %s as bind address", loopbackIP)
+	}
+
+	nllb := profile.NodeLocalLoadBalancing
+	config := &tcpProxyConfig{
+		bindIP:                 loopbackIP,
+		apiServerBindPort:      uint16(nllb.TCPProxy.APIServerBindPort),
+		konnectivityServerPort: profile.Konnectivity.AgentPort,
+	}
+	if nllb.TCPProxy.KonnectivityServerBindPort != nil {
+		config.konnectivityServerBindPort = uint16(*nllb.TCPProxy.KonnectivityServerBindPort)
+	}
+
+	proxy := &tcpproxy.Proxy{Name: "nllb"}
+	groups := []*upstreamGroup{{
+		log:        t.log.WithField("upstream", "apiserver"),
+		proxy:      proxy,
+		listenAddr: bindAddress(config.bindIP, config.apiServerBindPort),
+		check:      t.checkAPIServer,
+	}}
+	if config.konnectivityServerBindPort != 0 {
+		groups = append(groups, &upstreamGroup{
+			log:        t.log.WithField("upstream", "konnectivity"),
+			proxy:      proxy,
+			listenAddr: bindAddress(config.bindIP, config.konnectivityServerBindPort),
+			check:      checkTCP,
+		})
+	}
+
+	t.config = config
+	t.apiServers = groups[0]
+	if len(groups) > 1 {
+		t.konnectivity = groups[1]
+	}
+	t.setUpstreams(apiServers)
+
+	if err := proxy.Start(); err != nil {
+		t.config, t.apiServers, t.konnectivity = nil, nil, nil
+		return err
+	}
+
+	healthCtx, cancelHealthChecks := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, group := range groups {
+		wg.Go(func() { group.runHealthChecks(healthCtx) })
+	}
+
+	t.proxy = proxy
+	t.stopHealthChecks = func() { cancelHealthChecks(); wg.Wait() }
+	t.log.Info("Started TCP proxy on ", config.bindIP)
+	return nil
+}
+
+func (t *tcpProxy) getAPIServerAddress() (*k0snet.HostPort, error) {
+	if t.config == nil {
+		return nil, errors.New("not yet started")
+	}
+	return k0snet.NewHostPort(t.config.bindIP.String(), t.config.apiServerBindPort)
+}
+
+func (t *tcpProxy) updateAPIServers(apiServers []k0snet.HostPort) error {
+	if t.config == nil {
+		return errors.New("not yet started")
+	}
+	if len(apiServers) < 1 {
+		return errNoAPIServers
+	}
+	t.setUpstreams(apiServers)
+	return nil
+}
+
+func (t *tcpProxy) setUpstreams(apiServers []k0snet.HostPort) {
+	addrs := make([]string, len(apiServers))
+	for i := range apiServers {
+		addrs[i] = apiServers[i].String()
+	}
+	t.apiServers.setUpstreams(addrs)
+
+	if t.konnectivity != nil {
+		port := strconv.FormatUint(uint64(t.config.konnectivityServerPort), 10)
+		for i := range apiServers {
+			addrs[i] = net.JoinHostPort(apiServers[i].Host(), port)
+		}
+		t.konnectivity.setUpstreams(addrs)
+	}
+}
+
+func (t *tcpProxy) stop() {
+	if t.config == nil {
+		return
+	}
+
+	t.stopHealthChecks()
+	_ = t.proxy.Close()
+	_ = t.proxy.Wait()
+
+	t.config, t.proxy, t.apiServers, t.konnectivity, t.stopHealthChecks = nil, nil, nil, nil, nil
+}
+
+// Checks the readiness of an API server. The TLS configuration is loaded for
+// each check, so that rotated kubelet client certificates are picked up.
+func (t *tcpProxy) checkAPIServer(ctx context.Context, addr string) error {
+	restConfig, err := kubeutil.ClientConfig(kubeutil.KubeconfigFromFile(t.kubeconfigPath))
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := rest.TLSConfigFor(restConfig)
+	if err != nil {
+		return err
+	}
+	// Clients reach the API servers via the load balancer on the loopback
+	// interface, so verify the certificates in the same way.
+	tlsConfig.ServerName = "localhost"
+
+	client := http.Client{Transport: &http.Transport{
+		TLSClientConfig:   tlsConfig,
+		DisableKeepAlives: true,
+	}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+addr+"/readyz", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP response status: %s", resp.Status)
+	}
+	return nil
+}
+
+func checkTCP(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func bindAddress(ip net.IP, port uint16) string {
+	return net.JoinHostPort(ip.String(), strconv.FormatUint(uint64(port), 10))
+}
+
+// A group of upstream servers that the proxy forwards the connections of a
+// single listen address to. Only healthy upstreams get connections, unless
+// none of them is healthy.
+type upstreamGroup struct {
+	log        logrus.FieldLogger
+	proxy      *tcpproxy.Proxy
+	listenAddr string
+	check      func(ctx context.Context, addr string) error
+
+	mu        sync.Mutex
+	upstreams []*upstream
+}
+
+type upstream struct {
+	addr                string
+	healthy             bool
+	successes, failures uint
+}
+
+// Replaces the upstreams of this group. Upstreams that were already known
+// retain their health state, new ones are considered healthy.
+func (g *upstreamGroup) setUpstreams(addrs []string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	upstreams := make([]*upstream, 0, len(addrs))
+	for _, addr := range addrs {
+		idx := slices.IndexFunc(g.upstreams, func(u *upstream) bool { return u.addr == addr })
+		if idx >= 0 {
+			upstreams = append(upstreams, g.upstreams[idx])
+		} else {
+			upstreams = append(upstreams, &upstream{addr: addr, healthy: true})
+		}
+	}
+
+	g.upstreams = upstreams
+	g.updateRoutes()
+}
+
+// Updates the proxy's routes for this group. Needs to be called with the lock
+// held.
+func (g *upstreamGroup) updateRoutes() {
+	var routes, fallback []tcpproxy.Route
+	for _, u := range g.upstreams {
+		route := tcpproxy.To(u.addr)
+		if u.healthy {
+			routes = append(routes, route)
+		}
+		fallback = append(fallback, route)
+	}
+
+	if len(routes) < 1 {
+		if len(fallback) < 1 {
+			return
+		}
+		g.log.Warn("No healthy upstreams, using all of them")
+		routes = fallback
+	}
+
+	g.proxy.SetRoutes(g.listenAddr, routes)
+}
+
+func (g *upstreamGroup) runHealthChecks(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.checkHealth(ctx)
+		}
+	}
+}
+
+// Checks all upstreams concurrently and updates the routes if the health of
+// any upstream has changed.
+func (g *upstreamGroup) checkHealth(ctx context.Context) {
+	g.mu.Lock()
+	addrs := make([]string, len(g.upstreams))
+	for i, u := range g.upstreams {
+		addrs[i] = u.addr
+	}
+	g.mu.Unlock()
+
+	results := make([]error, len(addrs))
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			results[i] = g.check(ctx, addr)
+		})
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var changed bool
+	for i, addr := range addrs {
+		idx := slices.IndexFunc(g.upstreams, func(u *upstream) bool { return u.addr == addr })
+		if idx < 0 {
+			continue // removed in the meantime
+		}
+		if g.upstreams[idx].record(results[i]) {
+			changed = true
+			if err := results[i]; err != nil {
+				g.log.WithError(err).Warn("Upstream ", addr, " became unhealthy")
+			} else {
+				g.log.Info("Upstream ", addr, " became healthy")
+			}
+		}
+	}
+
+	if changed {
+		g.updateRoutes()
+	}
+}
+
+// Records the result of a health check and returns whether the health of the
+// upstream has changed.
+func (u *upstream) record(err error) bool {
+	if err != nil {
+		u.successes = 0
+		u.failures++
+		if u.healthy && u.failures >= healthCheckUnhealthyAfter {
+			u.healthy = false
+			return true
+		}
+	} else {
+		u.failures = 0
+		u.successes++
+		if !u.healthy && u.successes >= healthCheckHealthyAfter {
+			u.healthy = true
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nllb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/k0sproject/k0s/internal/pkg/net/tcpproxy"
+	workerconfig "github.com/k0sproject/k0s/pkg/component/worker/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamGroup_HealthChecks(t *testing.T) {
+	foo, bar := newUpstreamServer(t, "foo"), newUpstreamServer(t, "bar")
+
+	front, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	proxy := &tcpproxy.Proxy{
+		ListenFunc: func(string, string) (net.Listener, error) { return front, nil },
+	}
+
+	var mu sync.Mutex
+	failing := make(map[string]bool)
+	setFailing := func(addr string, fail bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		failing[addr] = fail
+	}
+
+	underTest := upstreamGroup{
+		log:        logrus.New(),
+		proxy:      proxy,
+		listenAddr: "lb",
+		check: func(_ context.Context, addr string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if failing[addr] {
+				return errors.New("failing")
+			}
+			return nil
+		},
+	}
+
+	underTest.setUpstreams([]string{foo, bar})
+	require.NoError(t, proxy.Start())
+	t.Cleanup(func() { assert.NoError(t, proxy.Close()) })
+
+	checkHealth := func(times int) {
+		for range times {
+			underTest.checkHealth(t.Context())
+		}
+	}
+	dial := func() map[string]bool {
+		seen := make(map[string]bool)
+		for range 4 {
+			conn, err := net.Dial("tcp", front.Addr().String())
+			require.NoError(t, err)
+			data, err := io.ReadAll(conn)
+			assert.NoError(t, conn.Close())
+			require.NoError(t, err)
+			seen[string(data)] = true
+		}
+		return seen
+	}
+
+	assert.Equal(t, map[string]bool{"foo": true, "bar": true}, dial(), "Both upstreams should be used initially")
+
+	setFailing(bar, true)
+	checkHealth(healthCheckUnhealthyAfter - 1)
+	assert.Equal(t, map[string]bool{"foo": true, "bar": true}, dial(), "Upstreams should only be ejected after enough failed checks")
+	checkHealth(1)
+	assert.Equal(t, map[string]bool{"foo": true}, dial(), "Failing upstream should have been ejected")
+
+	setFailing(foo, true)
+	checkHealth(healthCheckUnhealthyAfter)
+	assert.Equal(t, map[string]bool{"foo": true, "bar": true}, dial(), "All upstreams should be used if none is healthy")
+
+	setFailing(bar, false)
+	checkHealth(healthCheckHealthyAfter)
+	assert.Equal(t, map[string]bool{"bar": true}, dial(), "Recovered upstream should be used exclusively")
+
+	underTest.setUpstreams([]string{foo})
+	assert.Equal(t, map[string]bool{"foo": true}, dial(), "Removed upstream shouldn't be used anymore")
+	underTest.mu.Lock()
+	assert.False(t, underTest.upstreams[0].healthy, "Health state should be retained")
+	underTest.mu.Unlock()
+}
+
+func TestTCPProxy_RejectsEmptyAPIServers(t *testing.T) {
+	underTest := tcpProxy{log: logrus.New()}
+
+	err := underTest.start(t.Context(), workerconfig.Profile{}, nil)
+	assert.ErrorIs(t, err, errNoAPIServers)
+	assert.Nil(t, underTest.config, "Proxy shouldn't have been started")
+
+	// Stopping a proxy that hasn't been started mustn't block.
+	underTest.stop()
+}
+
+// Starts a TCP server that writes its name to each connection and closes it.
+func newUpstreamServer(t *testing.T, name string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, ln.Close()) })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = io.WriteString(conn, name)
+			_ = conn.Close()
+		}
+	}()
+
+	return ln.Addr().String()
+}
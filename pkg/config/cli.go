@@ -56,6 +56,10 @@ type ControllerOptions struct {
 	NodeComponents                  *manager.Manager
 	EnableDynamicConfig             bool
 	EnableMetricsScraper            bool
+	MetricsEndpointPort             int
+	MetricsEndpointInsecure         bool
+	TracingEndpoint                 string
+	TracingFile                     string
 	KubeControllerManagerExtraArgs  string
 	FeatureGates                    featuregate.FeatureGates
 	APIServerStopTimeout            time.Duration
@@ -76,6 +80,11 @@ type WorkerOptions struct {
 	TokenArg              string
 	WorkerProfile         string
 	IPTablesMode          string
+	MetricsBindAddress    string
+	LogFormat             string
+	ComponentLogFiles     bool
+	ComponentLogMaxSize   int
+	ComponentLogBackups   int
 }
 
 func (m ControllerMode) WorkloadsEnabled() bool {
@@ -260,6 +269,11 @@ func GetWorkerFlags() *pflag.FlagSet {
 	flagset.StringVar(&workerOpts.KubeletExtraArgs, "kubelet-extra-args", "", "extra args for kubelet")
 	flagset.StringVar(&workerOpts.IPTablesMode, "iptables-mode", "", "iptables mode (valid values: nft, legacy, auto). default: auto")
 	flagset.BoolVar(&workerOpts.IgnorePreFlightChecks, "ignore-pre-flight-checks", false, "continue even if pre-flight checks fail")
+	flagset.StringVar(&workerOpts.LogFormat, "log-format", "text", "log format (valid values: text, json)")
+	flagset.BoolVar(&workerOpts.ComponentLogFiles, "component-log-files", false, "additionally write the logs of each component into a separate file in <data-dir>/logs")
+	flagset.IntVar(&workerOpts.ComponentLogMaxSize, "component-log-max-size", 100, "size in megabytes after which component log files are rotated")
+	flagset.IntVar(&workerOpts.ComponentLogBackups, "component-log-max-backups", 3, "number of rotated component log files to keep")
+	flagset.StringVar(&workerOpts.MetricsBindAddress, "metrics-bind-address", "", "address on which k0s serves its own metrics without authentication, e.g. 127.0.0.1:9445 (disabled if empty)")
 	flagset.AddFlagSet(GetCriSocketFlag())
 
 	return flagset
@@ -302,7 +316,11 @@ func GetControllerFlags(controllerOpts *ControllerOptions) *pflag.FlagSet {
 	flagset.DurationVar(&controllerOpts.K0sCloudProviderUpdateFrequency, "k0s-cloud-provider-update-frequency", 2*time.Minute, "the frequency of k0s-cloud-provider node updates")
 	flagset.IntVar(&controllerOpts.K0sCloudProviderPort, "k0s-cloud-provider-port", k0scloudprovider.DefaultBindPort, "the port that k0s-cloud-provider binds on")
 	flagset.BoolVar(&controllerOpts.EnableDynamicConfig, "enable-dynamic-config", false, "enable cluster-wide dynamic config based on custom resource")
-	flagset.BoolVar(&controllerOpts.EnableMetricsScraper, "enable-metrics-scraper", false, "enable scraping metrics from the controller components (kube-scheduler, kube-controller-manager)")
+	flagset.BoolVar(&controllerOpts.EnableMetricsScraper, "enable-metrics-scraper", false, "enable the metrics endpoint for the controller components (etcd/kine, kube-scheduler, kube-controller-manager, k0s)")
+	flagset.IntVar(&controllerOpts.MetricsEndpointPort, "metrics-endpoint-port", constant.MetricsEndpointPort, "the port on which the controller metrics endpoint is served")
+	flagset.BoolVar(&controllerOpts.MetricsEndpointInsecure, "metrics-endpoint-insecure", false, "serve the controller metrics endpoint via plain HTTP instead of HTTPS")
+	flagset.StringVar(&controllerOpts.TracingEndpoint, "tracing-endpoint", "", "URL of an OTLP/gRPC endpoint to export traces to, e.g. http://localhost:4317 (tracing is disabled if neither this nor --tracing-file is set)")
+	flagset.StringVar(&controllerOpts.TracingFile, "tracing-file", "", "path of a file to which traces are written as JSON")
 	flagset.StringVar(&controllerOpts.KubeControllerManagerExtraArgs, "kube-controller-manager-extra-args", "", "extra args for kube-controller-manager")
 	flagset.BoolVar(&controllerOpts.InitOnly, "init-only", false, "only initialize controller and exit")
 	flagset.Var(&controllerOpts.FeatureGates, "feature-gates", "feature gates to enable (comma separated list of key=value pairs)")
@@ -13,6 +13,7 @@ import (
 // Network providers
 const (
 	CNIProviderCalico     = "calico"
+	CNIProviderCilium     = "cilium"
 	CNIProviderKubeRouter = "kuberouter"
 )
 
@@ -62,6 +63,9 @@ const (
 	// KeepalivedUser defines the user to use for running keepalived
 	KeepalivedUser = "keepalived"
 
+	// MetricsEndpointPort is the default port of the controller metrics endpoint
+	MetricsEndpointPort = 9444
+
 	// KubernetesMajorMinorVersion defines the current embedded major.minor version info
 	KubernetesMajorMinorVersion = "1.37"
 
@@ -97,6 +101,12 @@ const (
 	CalicoNodeWindowsImageVersion         = "v3.32.1"
 	CalicoKubeControllersImage            = "quay.io/k0sproject/calico-kube-controllers"
 	CalicoKubeControllersImageVersion     = "v3.32.1-2"
+	CiliumAgentImage                      = "quay.io/k0sproject/cilium"
+	CiliumAgentImageVersion               = "v1.18.2"
+	CiliumOperatorImage                   = "quay.io/k0sproject/cilium-operator-generic"
+	CiliumOperatorImageVersion            = "v1.18.2"
+	CiliumHubbleRelayImage                = "quay.io/k0sproject/hubble-relay"
+	CiliumHubbleRelayImageVersion         = "v1.18.2"
 	KubeRouterCNIImage                    = "quay.io/k0sproject/kube-router"
 	KubeRouterCNIImageVersion             = "v2.10.0-iptables1.8.13-k0s.1"
 	KubeRouterCNIInstallerImage           = "quay.io/k0sproject/cni-node"
@@ -129,6 +139,20 @@ const (
 	ClusterConfigObjectName = "k0s"
 
 	K0SNodeRoleLabel = "node.k0sproject.io/role"
+
+	// The node label that indicates which network provider is serving a node
+	// while migrating between network providers.
+	NetworkProviderLabel = "k0s.k0sproject.io/network-provider"
+	// The node annotation that asks the k0s worker to remove the leftovers of
+	// the network provider given in its value from the node.
+	NetworkProviderCleanupAnnotation = "k0s.k0sproject.io/network-provider-cleanup"
+	// The node annotation that records whether a node has been cordoned before
+	// it got migrated to another network provider.
+	NetworkProviderCordonedAnnotation = "k0s.k0sproject.io/network-provider-cordoned"
+	// The namespace label that opts a namespace out of the baseline network
+	// policies, if set to "disabled", or opts a namespace in that existed
+	// before they have been enabled, if set to "enabled".
+	NetworkPoliciesLabel = "k0s.k0sproject.io/network-policies"
 )
 
 // The list of allowed TLS v1.2 cipher suites. Those should be used for k0s
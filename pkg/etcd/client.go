@@ -28,6 +28,7 @@ type Member struct {
 	ID        uint64
 	Name      string
 	PeerURL   string
+	ClientURL string // Empty if the member hasn't been started yet.
 	IsLearner bool
 }
 
@@ -107,6 +108,70 @@ func (c *Client) Status(ctx context.Context) (*EndpointStatus, error) {
 	return &EndpointStatus{resp.Header.MemberId, role}, nil
 }
 
+// The status of an etcd cluster member, as reported by the member itself.
+type MemberStatus struct {
+	ID          uint64   `json:"id"`                // Member ID in the etcd cluster.
+	Leader      uint64   `json:"leader"`            // Member ID of the current leader.
+	IsLearner   bool     `json:"learner,omitempty"` // Whether the member is a non-voting learner.
+	Version     string   `json:"version"`           // The etcd version of the member.
+	DBSize      int64    `json:"dbSize"`            // Physically allocated size of the backend database in bytes.
+	DBSizeInUse int64    `json:"dbSizeInUse"`       // Logically used size of the backend database in bytes.
+	RaftTerm    uint64   `json:"raftTerm"`          // The member's current raft term.
+	RaftIndex   uint64   `json:"raftIndex"`         // The member's current raft committed index.
+	Errors      []string `json:"errors,omitempty"`  // Errors reported by the member, e.g. active alarms.
+}
+
+// IsLeader returns whether the member is the current leader.
+func (s *MemberStatus) IsLeader() bool {
+	return s.ID != 0 && s.ID == s.Leader
+}
+
+// Queries the status of the member that serves the given client endpoint.
+func (c *Client) MemberStatus(ctx context.Context, endpoint string) (*MemberStatus, error) {
+	resp, err := c.client.Status(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberStatus{
+		ID:          resp.Header.MemberId,
+		Leader:      resp.Leader,
+		IsLearner:   resp.IsLearner,
+		Version:     resp.Version,
+		DBSize:      resp.DbSize,
+		DBSizeInUse: resp.DbSizeInUse,
+		RaftTerm:    resp.RaftTerm,
+		RaftIndex:   resp.RaftIndex,
+		Errors:      resp.Errors,
+	}, nil
+}
+
+// Defragments the backend database of the member that serves the given client
+// endpoint. The member won't serve any requests while being defragmented.
+func (c *Client) Defragment(ctx context.Context, endpoint string) error {
+	_, err := c.client.Defragment(ctx, endpoint)
+	return err
+}
+
+// An alarm that has been raised by an etcd cluster member.
+type Alarm struct {
+	MemberID uint64 // Member ID of the member that raised the alarm.
+	Type     string // The alarm type, e.g. NOSPACE or CORRUPT.
+}
+
+// ListAlarms lists all the alarms that are currently active in the cluster.
+func (c *Client) ListAlarms(ctx context.Context) ([]Alarm, error) {
+	resp, err := c.client.AlarmList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("etcd alarm list failed: %w", err)
+	}
+	alarms := make([]Alarm, 0, len(resp.Alarms))
+	for _, a := range resp.Alarms {
+		alarms = append(alarms, Alarm{MemberID: a.MemberID, Type: a.Alarm.String()})
+	}
+	return alarms, nil
+}
+
 // ListMembers gets a list of current etcd members.
 func (c *Client) ListMembers(ctx context.Context) ([]Member, error) {
 	resp, err := c.client.MemberList(ctx)
@@ -115,14 +180,18 @@ func (c *Client) ListMembers(ctx context.Context) ([]Member, error) {
 	}
 	members := make([]Member, 0, len(resp.Members))
 	for _, m := range resp.Members {
-		var peerURL string
+		var peerURL, clientURL string
 		if len(m.PeerURLs) > 0 {
 			peerURL = m.PeerURLs[0]
 		}
+		if len(m.ClientURLs) > 0 {
+			clientURL = m.ClientURLs[0]
+		}
 		members = append(members, Member{
 			ID:        m.ID,
 			Name:      m.Name,
 			PeerURL:   peerURL,
+			ClientURL: clientURL,
 			IsLearner: m.IsLearner,
 		})
 	}
@@ -155,12 +224,12 @@ func (c *Client) AddMember(ctx context.Context, name, peerAddress string) ([]str
 }
 
 // AddMemberAsLearner adds a new learner member to the etcd cluster.
-// Returns an initial-cluster list (name=peerURL pairs) suitable for the
-// joining etcd's --initial-cluster flag.
-func (c *Client) AddMemberAsLearner(ctx context.Context, name, peerAddress string) ([]string, error) {
+// Returns the new member's ID along with an initial-cluster list
+// (name=peerURL pairs) suitable for the joining etcd's --initial-cluster flag.
+func (c *Client) AddMemberAsLearner(ctx context.Context, name, peerAddress string) (uint64, []string, error) {
 	addResp, err := c.client.MemberAddAsLearner(ctx, []string{peerAddress})
 	if err != nil {
-		return nil, fmt.Errorf("etcd member add as learner failed: %w", err)
+		return 0, nil, fmt.Errorf("etcd member add as learner failed: %w", err)
 	}
 
 	newID := addResp.Member.ID
@@ -176,7 +245,7 @@ func (c *Client) AddMemberAsLearner(ctx context.Context, name, peerAddress strin
 		memberList = append(memberList, fmt.Sprintf("%s=%s", memberName, m.PeerURLs[0]))
 	}
 
-	return memberList, nil
+	return newID, memberList, nil
 }
 
 // GetPeerIDByAddress looks up peer id by peer url
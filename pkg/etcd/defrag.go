@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaintenanceClient is the subset of the [Client] that is required to perform
+// maintenance tasks on the local member of an etcd cluster.
+type MaintenanceClient interface {
+	ListMembers(ctx context.Context) ([]Member, error)
+	LocalMemberStatus(ctx context.Context) (*MemberStatus, error)
+	MemberReports(ctx context.Context) (map[uint64]*MemberReport, error)
+	DefragmentLocalMember(ctx context.Context) error
+	TryLock(ctx context.Context, name string) (func(context.Context) error, error)
+	DefragmentingMembers(ctx context.Context) ([]uint64, error)
+	MarkDefragmenting(ctx context.Context, id uint64) error
+	UnmarkDefragmenting(ctx context.Context, id uint64) error
+}
+
+var _ MaintenanceClient = (*Client)(nil)
+
+// Returns the statuses of the etcd cluster members, keyed by member ID. The
+// status of the local member is taken as is, the statuses of the other members
+// are taken from their reports. Members without a report are omitted.
+func ReportedStatuses(local *MemberStatus, reports map[uint64]*MemberReport) map[uint64]*MemberStatus {
+	statuses := make(map[uint64]*MemberStatus, len(reports)+1)
+	for id, report := range reports {
+		statuses[id] = &report.Status
+	}
+	statuses[local.ID] = local
+	return statuses
+}
+
+// FragmentedPercent returns the percentage of the member's database that is
+// allocated but unused.
+func (s *MemberStatus) FragmentedPercent() int64 {
+	if s.DBSize <= 0 || s.DBSizeInUse >= s.DBSize {
+		return 0
+	}
+	return (s.DBSize - s.DBSizeInUse) * 100 / s.DBSize
+}
+
+// DefragOptions control if the local member is defragmented by
+// [DefragmentLocalMember].
+type DefragOptions struct {
+	// Selects the members that need to be defragmented. All fragmented
+	// members are selected if nil.
+	Select func(*MemberStatus) bool
+
+	// Defragment voting members even if this means that the cluster
+	// temporarily loses its quorum.
+	AllowQuorumLoss bool
+}
+
+// The outcome of defragmenting a single member.
+type DefragResult struct {
+	Member     Member
+	SizeBefore int64 // The size of the member's database before defragmentation.
+	SizeAfter  int64 // The size of the member's database after defragmentation, if known.
+}
+
+// ErrLeaderDeferred indicates that the local member is the leader, and that it
+// won't be defragmented before the other members that need to be
+// defragmented.
+var ErrLeaderDeferred = errors.New("the leader is defragmented last")
+
+// ErrDefragInProgress indicates that another member is still being
+// defragmented.
+var ErrDefragInProgress = errors.New("another member is still being defragmented")
+
+// The name of the cluster-wide lock that is held while defragmenting.
+const defragLockName = "defrag"
+
+// DefragmentLocalMember defragments the etcd member that serves the client's
+// endpoint, if selected. The defragmentation happens under a cluster-wide
+// lock, so that only one member is defragmented at a time. The leader goes
+// last: it's only defragmented if no other member is selected. A voting member
+// is only defragmented if the other voting members that are required for a
+// quorum are healthy, since the member won't participate in the cluster while
+// it's being defragmented. The only exception are single-member clusters,
+// which can't retain their quorum anyway. Returns nil if the local member
+// isn't selected.
+//
+// K0s-managed etcd members only serve clients on the loopback interface, so
+// each controller is responsible for defragmenting its own member. The health
+// of the other members is determined from their reports.
+//
+// The lock is bound to a lease that's kept alive via the local member. That
+// member doesn't serve requests while it's being defragmented, so the lease
+// may expire during long defragmentations. Therefore, members are marked while
+// they're being defragmented, and no other member is defragmented as long as
+// there's such a mark. Since each member is only defragmented by its own
+// controller, a mark of the local member is stale at this point, e.g. left
+// behind by a crash, and gets removed.
+func DefragmentLocalMember(ctx context.Context, client MaintenanceClient, log logrus.FieldLogger, opts DefragOptions) (*DefragResult, error) {
+	selected := func(status *MemberStatus) bool {
+		return status.FragmentedPercent() > 0 && (opts.Select == nil || opts.Select(status))
+	}
+
+	status, err := client.LocalMemberStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status of local etcd member: %w", err)
+	}
+
+	defragmenting, err := client.DefragmentingMembers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get defragmenting etcd members: %w", err)
+	}
+	if slices.Contains(defragmenting, status.ID) {
+		log.Info("Removing stale defragmentation mark of local etcd member")
+		if err := client.UnmarkDefragmenting(ctx, status.ID); err != nil {
+			return nil, fmt.Errorf("failed to remove stale defragmentation mark: %w", err)
+		}
+	}
+
+	if !selected(status) {
+		return nil, nil
+	}
+
+	unlock, err := client.TryLock(ctx, defragLockName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire defragmentation lock: %w", err)
+	}
+	defer func() {
+		if err := unlock(context.WithoutCancel(ctx)); err != nil {
+			log.WithError(err).Warn("Failed to release defragmentation lock")
+		}
+	}()
+
+	// Re-check everything while holding the lock: another member might have
+	// been defragmented in the meantime.
+	members, err := client.ListMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := client.MemberReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etcd member reports: %w", err)
+	}
+	if status, err = client.LocalMemberStatus(ctx); err != nil {
+		return nil, fmt.Errorf("failed to query status of local etcd member: %w", err)
+	}
+	if !selected(status) {
+		return nil, nil
+	}
+
+	idx := slices.IndexFunc(members, func(m Member) bool { return m.ID == status.ID })
+	if idx < 0 {
+		return nil, fmt.Errorf("local etcd member %x isn't a cluster member", status.ID)
+	}
+	member := members[idx]
+	statuses := ReportedStatuses(status, reports)
+
+	// The lock might have been taken over while another member was still
+	// being defragmented. Wait for that member to finish.
+	if defragmenting, err = client.DefragmentingMembers(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get defragmenting etcd members: %w", err)
+	}
+	for _, other := range members {
+		if other.ID != member.ID && slices.Contains(defragmenting, other.ID) {
+			return nil, fmt.Errorf("%w, waiting for %s", ErrDefragInProgress, other.Name)
+		}
+	}
+
+	if status.IsLeader() {
+		for _, other := range members {
+			if s, ok := statuses[other.ID]; ok && other.ID != member.ID && selected(s) {
+				return nil, fmt.Errorf("%w, waiting for %s", ErrLeaderDeferred, other.Name)
+			}
+		}
+	}
+
+	if !member.IsLearner && !opts.AllowQuorumLoss && countVoters(members) > 1 {
+		if err := CheckQuorumWithout(members, member.ID, func(m Member) bool {
+			s, ok := statuses[m.ID]
+			return ok && len(s.Errors) == 0
+		}); err != nil {
+			return nil, fmt.Errorf("won't defragment etcd member %s: %w", member.Name, err)
+		}
+	}
+
+	if err := client.MarkDefragmenting(ctx, member.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark etcd member %s as defragmenting: %w", member.Name, err)
+	}
+	defer func() {
+		if err := client.UnmarkDefragmenting(context.WithoutCancel(ctx), member.ID); err != nil {
+			log.WithError(err).Warn("Failed to remove defragmentation mark")
+		}
+	}()
+
+	log.Infof("Defragmenting etcd member %s (%d%% of %d bytes unused)", member.Name, status.FragmentedPercent(), status.DBSize)
+	if err := client.DefragmentLocalMember(ctx); err != nil {
+		return nil, fmt.Errorf("failed to defragment etcd member %s: %w", member.Name, err)
+	}
+
+	result := DefragResult{Member: member, SizeBefore: status.DBSize}
+	if status, err := client.LocalMemberStatus(ctx); err != nil {
+		log.WithError(err).Warn("Failed to query etcd member status after defragmentation")
+	} else {
+		result.SizeAfter = status.DBSize
+	}
+
+	return &result, nil
+}
+
+func countVoters(members []Member) (voters int) {
+	for _, member := range members {
+		if !member.IsLearner {
+			voters++
+		}
+	}
+	return voters
+}
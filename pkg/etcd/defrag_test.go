@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A fake etcd cluster. Each member is only reachable via the client of the
+// controller that runs it, just like k0s-managed etcd members.
+type fakeCluster struct {
+	members       []Member
+	statuses      map[uint64]*MemberStatus
+	reports       map[uint64]*MemberReport
+	lockHolder    uint64
+	defragmenting []uint64
+	defragmented  []string
+
+	// Called while the local member is being defragmented.
+	onDefragment func(local uint64)
+}
+
+func newFakeCluster() *fakeCluster {
+	cluster := &fakeCluster{
+		members: []Member{
+			{ID: 1, Name: "c"},
+			{ID: 2, Name: "b"},
+			{ID: 3, Name: "a"},
+		},
+		statuses: map[uint64]*MemberStatus{
+			1: {ID: 1, Leader: 3, DBSize: 1000, DBSizeInUse: 400},
+			2: {ID: 2, Leader: 3, DBSize: 1000, DBSizeInUse: 900},
+			3: {ID: 3, Leader: 3, DBSize: 1000, DBSizeInUse: 200},
+		},
+	}
+	cluster.publishReports()
+	return cluster
+}
+
+// Lets every member's controller publish a report about its member.
+func (c *fakeCluster) publishReports() {
+	c.reports = make(map[uint64]*MemberReport, len(c.statuses))
+	for id, status := range c.statuses {
+		c.reports[id] = &MemberReport{Status: *status}
+	}
+}
+
+// Returns the client of the controller running the given member.
+func (c *fakeCluster) client(id uint64) *fakeMaintenanceClient {
+	return &fakeMaintenanceClient{c, id}
+}
+
+type fakeMaintenanceClient struct {
+	cluster *fakeCluster
+	local   uint64
+}
+
+func (c *fakeMaintenanceClient) ListMembers(context.Context) ([]Member, error) {
+	return slices.Clone(c.cluster.members), nil
+}
+
+func (c *fakeMaintenanceClient) LocalMemberStatus(context.Context) (*MemberStatus, error) {
+	status := *c.cluster.statuses[c.local]
+	return &status, nil
+}
+
+func (c *fakeMaintenanceClient) MemberReports(context.Context) (map[uint64]*MemberReport, error) {
+	reports := make(map[uint64]*MemberReport, len(c.cluster.reports))
+	for id, report := range c.cluster.reports {
+		clone := *report
+		reports[id] = &clone
+	}
+	return reports, nil
+}
+
+func (c *fakeMaintenanceClient) DefragmentLocalMember(context.Context) error {
+	if c.cluster.lockHolder != c.local {
+		panic("defragmenting without holding the lock")
+	}
+	status := c.cluster.statuses[c.local]
+	status.DBSize = status.DBSizeInUse
+	idx := slices.IndexFunc(c.cluster.members, func(m Member) bool { return m.ID == c.local })
+	c.cluster.defragmented = append(c.cluster.defragmented, c.cluster.members[idx].Name)
+	if c.cluster.onDefragment != nil {
+		c.cluster.onDefragment(c.local)
+	}
+	return nil
+}
+
+func (c *fakeMaintenanceClient) TryLock(context.Context, string) (func(context.Context) error, error) {
+	if c.cluster.lockHolder != 0 {
+		return nil, ErrLocked
+	}
+	c.cluster.lockHolder = c.local
+	return func(context.Context) error { c.cluster.lockHolder = 0; return nil }, nil
+}
+
+func (c *fakeMaintenanceClient) DefragmentingMembers(context.Context) ([]uint64, error) {
+	return slices.Clone(c.cluster.defragmenting), nil
+}
+
+func (c *fakeMaintenanceClient) MarkDefragmenting(_ context.Context, id uint64) error {
+	c.cluster.defragmenting = append(c.cluster.defragmenting, id)
+	return nil
+}
+
+func (c *fakeMaintenanceClient) UnmarkDefragmenting(_ context.Context, id uint64) error {
+	c.cluster.defragmenting = slices.DeleteFunc(c.cluster.defragmenting, func(marked uint64) bool { return marked == id })
+	return nil
+}
+
+func TestDefragmentLocalMember(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	t.Run("LeaderLast", func(t *testing.T) {
+		cluster := newFakeCluster()
+
+		// The leader waits for the others.
+		result, err := DefragmentLocalMember(t.Context(), cluster.client(3), log, DefragOptions{})
+		assert.ErrorIs(t, err, ErrLeaderDeferred)
+		assert.ErrorContains(t, err, "the leader is defragmented last, waiting for c")
+		assert.Nil(t, result)
+
+		for _, id := range []uint64{2, 1} {
+			result, err := DefragmentLocalMember(t.Context(), cluster.client(id), log, DefragOptions{})
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, id, result.Member.ID)
+			cluster.publishReports()
+		}
+
+		// The others have been defragmented, the leader may go.
+		result, err = DefragmentLocalMember(t.Context(), cluster.client(3), log, DefragOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, &DefragResult{Member: cluster.members[2], SizeBefore: 1000, SizeAfter: 200}, result)
+
+		assert.Equal(t, []string{"b", "c", "a"}, cluster.defragmented)
+		assert.Zero(t, cluster.lockHolder)
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		cluster := newFakeCluster()
+		opts := DefragOptions{Select: func(s *MemberStatus) bool { return s.FragmentedPercent() >= 50 }}
+
+		result, err := DefragmentLocalMember(t.Context(), cluster.client(2), log, opts)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+
+		_, err = DefragmentLocalMember(t.Context(), cluster.client(3), log, opts)
+		assert.ErrorIs(t, err, ErrLeaderDeferred)
+
+		result, err = DefragmentLocalMember(t.Context(), cluster.client(1), log, opts)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
+		assert.Equal(t, []string{"c"}, cluster.defragmented)
+	})
+
+	t.Run("OneAtATime", func(t *testing.T) {
+		cluster := newFakeCluster()
+		cluster.lockHolder = 2
+
+		_, err := DefragmentLocalMember(t.Context(), cluster.client(1), log, DefragOptions{})
+		assert.ErrorIs(t, err, ErrLocked)
+		assert.Empty(t, cluster.defragmented)
+	})
+
+	t.Run("LeaseExpiresDuringDefrag", func(t *testing.T) {
+		cluster := newFakeCluster()
+
+		// The lock's lease expires while c is being defragmented, so that b
+		// acquires the lock. It must not defragment its member anyway.
+		var errDuringDefrag error
+		cluster.onDefragment = func(local uint64) {
+			cluster.onDefragment = nil
+			cluster.lockHolder = 0
+			_, errDuringDefrag = DefragmentLocalMember(t.Context(), cluster.client(2), log, DefragOptions{})
+			cluster.lockHolder = local
+		}
+
+		_, err := DefragmentLocalMember(t.Context(), cluster.client(1), log, DefragOptions{})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, errDuringDefrag, ErrDefragInProgress)
+		assert.ErrorContains(t, errDuringDefrag, "another member is still being defragmented, waiting for c")
+		assert.Equal(t, []string{"c"}, cluster.defragmented)
+		assert.Empty(t, cluster.defragmenting)
+		assert.Zero(t, cluster.lockHolder)
+
+		// Once c is done, b may go.
+		cluster.publishReports()
+		_, err = DefragmentLocalMember(t.Context(), cluster.client(2), log, DefragOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c", "b"}, cluster.defragmented)
+	})
+
+	t.Run("StaleMark", func(t *testing.T) {
+		cluster := newFakeCluster()
+		cluster.defragmenting = []uint64{1, 42}
+
+		// The local member's mark is stale, as is the one of a former member.
+		_, err := DefragmentLocalMember(t.Context(), cluster.client(1), log, DefragOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c"}, cluster.defragmented)
+		assert.Equal(t, []uint64{42}, cluster.defragmenting)
+	})
+
+	t.Run("RetainsQuorum", func(t *testing.T) {
+		cluster := newFakeCluster()
+		cluster.statuses[2].Errors = []string{"alarm:NOSPACE"}
+		cluster.publishReports()
+
+		_, err := DefragmentLocalMember(t.Context(), cluster.client(1), log, DefragOptions{})
+		assert.ErrorIs(t, err, ErrNoQuorum)
+		assert.ErrorContains(t, err, "won't defragment etcd member c: not enough healthy voting members to retain quorum (1 of 2 other voting members healthy, 2 required)")
+		assert.Empty(t, cluster.defragmented)
+		assert.Zero(t, cluster.lockHolder)
+
+		_, err = DefragmentLocalMember(t.Context(), cluster.client(1), log, DefragOptions{AllowQuorumLoss: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c"}, cluster.defragmented)
+	})
+
+	t.Run("UnreportedMembers", func(t *testing.T) {
+		cluster := newFakeCluster()
+		delete(cluster.reports, 2)
+
+		// Without a report, a member is considered unhealthy.
+		_, err := DefragmentLocalMember(t.Context(), cluster.client(1), log, DefragOptions{})
+		assert.ErrorIs(t, err, ErrNoQuorum)
+		assert.Empty(t, cluster.defragmented)
+	})
+
+	t.Run("LearnersDontCount", func(t *testing.T) {
+		cluster := newFakeCluster()
+		cluster.members[0].IsLearner = true
+		cluster.members[1].IsLearner = true
+
+		// The learners may be defragmented, even without the others.
+		delete(cluster.reports, 3)
+		_, err := DefragmentLocalMember(t.Context(), cluster.client(2), log, DefragOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b"}, cluster.defragmented)
+	})
+
+	t.Run("SingleMember", func(t *testing.T) {
+		cluster := newFakeCluster()
+		cluster.members = cluster.members[2:]
+
+		// There's no way to retain the quorum, so don't even try.
+		result, err := DefragmentLocalMember(t.Context(), cluster.client(3), log, DefragOptions{})
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, []string{"a"}, cluster.defragmented)
+	})
+}
+
+func TestCheckQuorumWithout(t *testing.T) {
+	members := []Member{
+		{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4, IsLearner: true},
+	}
+	healthySet := func(ids ...uint64) func(Member) bool {
+		return func(m Member) bool { return slices.Contains(ids, m.ID) }
+	}
+
+	assert.NoError(t, CheckQuorumWithout(members, 3, healthySet(1, 2)))
+	assert.NoError(t, CheckQuorumWithout(members, 4, healthySet()), "learners don't count")
+	assert.EqualError(t, CheckQuorumWithout(members, 3, healthySet(1, 4)),
+		"not enough healthy voting members to retain quorum (1 of 2 other voting members healthy, 2 required)")
+	assert.EqualError(t, CheckQuorumWithout(members[:1], 1, healthySet()),
+		"not enough healthy voting members to retain quorum (0 of 0 other voting members healthy, 1 required)")
+	assert.EqualError(t, CheckQuorumWithout(members[:2], 2, healthySet(1)),
+		"not enough healthy voting members to retain quorum (1 of 1 other voting members healthy, 2 required)")
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+// Diagnostic values obtained from an etcd member's metrics endpoint.
+type MemberMetrics struct {
+	// The 99th percentile of the member's WAL fsync durations, or a negative
+	// value if unknown. Covers the time since the member has been started.
+	WALFsyncP99 time.Duration
+	// The 99th percentile of the member's backend commit durations, or a
+	// negative value if unknown. Covers the time since the member has been
+	// started.
+	BackendCommitP99 time.Duration
+}
+
+// Scrapes the metrics endpoint of the member that serves the given client
+// endpoint.
+func (c *Client) MemberMetrics(ctx context.Context, endpoint string) (*MemberMetrics, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/metrics", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.Client{Transport: &http.Transport{TLSClientConfig: c.Config.TLS}}
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	metrics, err := parseMemberMetrics(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+func parseMemberMetrics(in io.Reader) (*MemberMetrics, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(in)
+	if err != nil {
+		return nil, err
+	}
+
+	p99 := func(name string) time.Duration {
+		family, ok := families[name]
+		if !ok || family.GetType() != dto.MetricType_HISTOGRAM {
+			return -1
+		}
+		seconds := histogramQuantile(0.99, family.GetMetric())
+		if math.IsNaN(seconds) {
+			return -1
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	return &MemberMetrics{
+		WALFsyncP99:      p99("etcd_disk_wal_fsync_duration_seconds"),
+		BackendCommitP99: p99("etcd_disk_backend_commit_duration_seconds"),
+	}, nil
+}
+
+// Estimates the given quantile of the merged histograms by linear
+// interpolation within the bucket in which the quantile falls, the same way as
+// Prometheus's histogram_quantile function does. Returns NaN if the histograms
+// don't contain any observations.
+func histogramQuantile(q float64, metrics []*dto.Metric) float64 {
+	var (
+		total  uint64
+		bounds []float64
+		counts = map[float64]uint64{}
+	)
+	for _, metric := range metrics {
+		histogram := metric.GetHistogram()
+		total += histogram.GetSampleCount()
+		for _, bucket := range histogram.GetBucket() {
+			bound := bucket.GetUpperBound()
+			if _, ok := counts[bound]; !ok {
+				bounds = append(bounds, bound)
+			}
+			counts[bound] += bucket.GetCumulativeCount()
+		}
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+
+	rank := q * float64(total)
+	var lowerBound float64
+	var lowerCount uint64
+	slices.Sort(bounds)
+	for _, bound := range bounds {
+		if math.IsInf(bound, +1) {
+			break
+		}
+		count := counts[bound]
+		if float64(count) >= rank {
+			if count == lowerCount {
+				return bound
+			}
+			return lowerBound + (bound-lowerBound)*(rank-float64(lowerCount))/float64(count-lowerCount)
+		}
+		lowerBound, lowerCount = bound, count
+	}
+
+	// The quantile falls into the +Inf bucket. Like Prometheus, return the
+	// highest finite upper bound.
+	return lowerBound
+}
+
+// The offset of an etcd member's clock in relation to the local clock.
+type ClockOffset struct {
+	// The estimated offset. Positive values indicate that the member's clock
+	// is ahead.
+	Offset time.Duration
+	// The maximum error of Offset. The member's clock is reported with a
+	// resolution of one second only, and the request's round trip time adds
+	// some more uncertainty.
+	Uncertainty time.Duration
+}
+
+// Estimates the offset of the clock of the etcd member listening on the given
+// peer URL, based on the Date header of the member's version endpoint. Other
+// than the client URLs, the peer URLs of k0s-managed etcd members are
+// reachable from all controllers.
+func (c *Client) PeerClockOffset(ctx context.Context, peerURL string) (*ClockOffset, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(peerURL, "/")+"/version", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The response is only used to look at the member's clock.
+	client := http.Client{Transport: &http.Transport{TLSClientConfig: c.insecurePeerTLSConfig()}}
+	defer client.CloseIdleConnections()
+
+	sent := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	received := time.Now()
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the member's clock: %w", err)
+	}
+
+	// The member's time lies somewhere within the second denoted by the Date
+	// header. Compare its midpoint to the midpoint of the request.
+	rtt := received.Sub(sent)
+	return &ClockOffset{
+		Offset:      date.Add(500 * time.Millisecond).Sub(sent.Add(rtt / 2)).Round(time.Millisecond),
+		Uncertainty: (500*time.Millisecond + rtt/2).Round(time.Millisecond),
+	}, nil
+}
+
+// Returns the certificate that's presented by the etcd member listening on the
+// given peer URL. The certificate is returned without verifying it, so that
+// callers may diagnose its issues. Returns nil if the peer URL doesn't use TLS.
+func (c *Client) PeerCertificate(ctx context.Context, peerURL string) (*x509.Certificate, error) {
+	u, err := url.Parse(peerURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, nil
+	}
+
+	dialer := tls.Dialer{NetDialer: &net.Dialer{Timeout: 10 * time.Second}, Config: c.insecurePeerTLSConfig()}
+	conn, err := dialer.DialContext(ctx, "tcp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) < 1 {
+		return nil, errors.New("no certificate presented")
+	}
+	return certs[0], nil
+}
+
+// Returns a TLS configuration to connect to peer URLs that presents the
+// client's certificate, but doesn't verify the peer's certificate.
+func (c *Client) insecurePeerTLSConfig() *tls.Config {
+	var config *tls.Config
+	if c.Config.TLS != nil {
+		config = c.Config.TLS.Clone()
+	} else {
+		config = &tls.Config{}
+	}
+	config.InsecureSkipVerify = true //nolint:gosec // Used for diagnostics only.
+	return config
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const testMetrics = `# HELP etcd_disk_wal_fsync_duration_seconds The latency distributions of fsync called by WAL.
+# TYPE etcd_disk_wal_fsync_duration_seconds histogram
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.001"} 0
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.002"} 50
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.004"} 90
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.008"} 98
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.016"} 100
+etcd_disk_wal_fsync_duration_seconds_bucket{le="+Inf"} 100
+etcd_disk_wal_fsync_duration_seconds_sum 0.3
+etcd_disk_wal_fsync_duration_seconds_count 100
+# HELP etcd_disk_backend_commit_duration_seconds The latency distributions of commit called by backend.
+# TYPE etcd_disk_backend_commit_duration_seconds histogram
+etcd_disk_backend_commit_duration_seconds_bucket{le="0.001"} 0
+etcd_disk_backend_commit_duration_seconds_bucket{le="0.002"} 0
+etcd_disk_backend_commit_duration_seconds_bucket{le="+Inf"} 0
+etcd_disk_backend_commit_duration_seconds_sum 0
+etcd_disk_backend_commit_duration_seconds_count 0
+`
+
+func TestClient_MemberMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testMetrics))
+	}))
+	t.Cleanup(server.Close)
+
+	underTest := Client{Config: &clientv3.Config{}}
+	metrics, err := underTest.MemberMetrics(t.Context(), server.URL)
+	require.NoError(t, err)
+
+	// 99 observations fall into the (8ms, 16ms] bucket, 98 of them are below.
+	assert.Equal(t, 12*time.Millisecond, metrics.WALFsyncP99)
+	assert.Negative(t, metrics.BackendCommitP99, "no observations, so it should be unknown")
+}
+
+func TestClient_PeerClockOffset(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Date", time.Now().Add(-1*time.Hour).UTC().Format(http.TimeFormat))
+		_, _ = w.Write([]byte(`{"etcdserver":"3.6.5","etcdcluster":"3.6.0"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// The server's certificate isn't trusted, but that doesn't matter.
+	underTest := Client{Config: &clientv3.Config{}}
+	clock, err := underTest.PeerClockOffset(t.Context(), server.URL)
+	require.NoError(t, err)
+
+	assert.InDelta(t, -1*time.Hour, clock.Offset, float64(clock.Uncertainty))
+	assert.GreaterOrEqual(t, clock.Uncertainty, 500*time.Millisecond)
+}
+
+func TestParseMemberMetrics_Unknown(t *testing.T) {
+	metrics, err := parseMemberMetrics(strings.NewReader("# TYPE up gauge\nup 1\n"))
+	require.NoError(t, err)
+	assert.Negative(t, metrics.WALFsyncP99)
+	assert.Negative(t, metrics.BackendCommitP99)
+}
+
+func TestClient_PeerCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	underTest := Client{Config: &clientv3.Config{}}
+	cert, err := underTest.PeerCertificate(t.Context(), server.URL)
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+	assert.Equal(t, server.Certificate().Raw, cert.Raw)
+
+	cert, err = underTest.PeerCertificate(t.Context(), "http://127.0.0.1:2380")
+	assert.NoError(t, err)
+	assert.Nil(t, cert, "expected no certificate for plain HTTP")
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoQuorum indicates that the etcd cluster would lose its quorum without a
+// certain member.
+var ErrNoQuorum = errors.New("not enough healthy voting members to retain quorum")
+
+// CheckQuorumWithout checks whether the voting members other than the one with
+// the given ID form a quorum on their own, i.e. whether the cluster retains its
+// quorum while the member is unavailable or after it has been removed. A
+// removal needs to be committed by a majority of the current voting members,
+// which is also sufficient for the quorum afterwards. Learners don't count
+// towards the quorum, so there's no requirement if the member is a learner.
+func CheckQuorumWithout(members []Member, memberID uint64, isHealthy func(Member) bool) error {
+	var voters, healthy int
+	var isVoter bool
+	for _, member := range members {
+		if member.IsLearner {
+			continue
+		}
+		voters++
+		if member.ID == memberID {
+			isVoter = true
+		} else if isHealthy(member) {
+			healthy++
+		}
+	}
+
+	if !isVoter {
+		return nil
+	}
+
+	if quorum := voters/2 + 1; healthy < quorum {
+		return fmt.Errorf("%w (%d of %d other voting members healthy, %d required)", ErrNoQuorum, healthy, voters-1, quorum)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// The keys below which k0s stores its etcd maintenance data.
+const (
+	memberReportsPrefix = "/k0s/etcd/member-reports/"
+	locksPrefix         = "/k0s/etcd/locks/"
+	defragmentingPrefix = "/k0s/etcd/defragmenting/"
+)
+
+// A report about an etcd member, published by the k0s controller that runs
+// the member. K0s-managed etcd members only serve clients on the loopback
+// interface, so the reports are the only way for the other controllers to
+// learn about a member's status.
+type MemberReport struct {
+	Status MemberStatus `json:"status"`
+
+	// The 99th percentile of the member's WAL fsync durations, or a negative
+	// value if unknown.
+	WALFsyncP99 time.Duration `json:"walFsyncP99"`
+	// The 99th percentile of the member's backend commit durations, or a
+	// negative value if unknown.
+	BackendCommitP99 time.Duration `json:"backendCommitP99"`
+
+	// The time at which the report has been published, according to the
+	// publishing controller's clock.
+	ReportedAt time.Time `json:"reportedAt"`
+}
+
+// ErrLocked indicates that a lock is held by someone else.
+var ErrLocked = concurrency.ErrLocked
+
+// Queries the status of the member that serves the client's endpoint, i.e. the
+// local member in case of k0s-managed etcd clusters.
+func (c *Client) LocalMemberStatus(ctx context.Context) (*MemberStatus, error) {
+	return c.MemberStatus(ctx, c.Config.Endpoints[0])
+}
+
+// Scrapes the metrics endpoint of the member that serves the client's
+// endpoint.
+func (c *Client) LocalMemberMetrics(ctx context.Context) (*MemberMetrics, error) {
+	return c.MemberMetrics(ctx, c.Config.Endpoints[0])
+}
+
+// Defragments the backend database of the member that serves the client's
+// endpoint. The member won't serve any requests while being defragmented.
+func (c *Client) DefragmentLocalMember(ctx context.Context) error {
+	return c.Defragment(ctx, c.Config.Endpoints[0])
+}
+
+// Publishes the report of a member. The report vanishes if it isn't
+// republished within the given time to live.
+func (c *Client) PublishMemberReport(ctx context.Context, report *MemberReport, ttl time.Duration) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	lease, err := c.client.Grant(ctx, int64(ttl.Seconds()))
+	if err != nil {
+		return fmt.Errorf("can't get TTL lease: %w", err)
+	}
+
+	key := memberReportsPrefix + strconv.FormatUint(report.Status.ID, 16)
+	if _, err := c.client.Put(ctx, key, string(data), clientv3.WithLease(lease.ID)); err != nil {
+		return fmt.Errorf("can't write to etcd: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the current reports of all members, keyed by member ID.
+func (c *Client) MemberReports(ctx context.Context) (map[uint64]*MemberReport, error) {
+	resp, err := c.client.Get(ctx, memberReportsPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("can't read from etcd: %w", err)
+	}
+
+	reports := make(map[uint64]*MemberReport, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		id, err := strconv.ParseUint(strings.TrimPrefix(string(kv.Key), memberReportsPrefix), 16, 64)
+		if err != nil {
+			continue
+		}
+		var report MemberReport
+		if err := json.Unmarshal(kv.Value, &report); err != nil {
+			return nil, fmt.Errorf("invalid report for member %x: %w", id, err)
+		}
+		reports[id] = &report
+	}
+
+	return reports, nil
+}
+
+// Tries to acquire the cluster-wide lock with the given name. Fails with
+// [ErrLocked] if the lock is held by someone else. The lock is released
+// automatically if the returned unlock function isn't called in time, e.g.
+// because the process crashed.
+func (c *Client) TryLock(ctx context.Context, name string) (unlock func(context.Context) error, _ error) {
+	session, err := concurrency.NewSession(c.client, concurrency.WithTTL(60))
+	if err != nil {
+		return nil, err
+	}
+
+	mutex := concurrency.NewMutex(session, locksPrefix+name)
+	if err := mutex.TryLock(ctx); err != nil {
+		return nil, errors.Join(err, session.Close())
+	}
+
+	return func(ctx context.Context) error {
+		return errors.Join(mutex.Unlock(ctx), session.Close())
+	}, nil
+}
+
+// Marks the given member as being defragmented. Unlike locks, marks aren't
+// bound to a lease. A lease is kept alive via the local member, which doesn't
+// serve any requests while being defragmented. The mark on the other hand
+// persists until it's removed via [Client.UnmarkDefragmenting].
+func (c *Client) MarkDefragmenting(ctx context.Context, id uint64) error {
+	key := defragmentingPrefix + strconv.FormatUint(id, 16)
+	if _, err := c.client.Put(ctx, key, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("can't write to etcd: %w", err)
+	}
+	return nil
+}
+
+// Removes the mark that has been placed via [Client.MarkDefragmenting].
+func (c *Client) UnmarkDefragmenting(ctx context.Context, id uint64) error {
+	if _, err := c.client.Delete(ctx, defragmentingPrefix+strconv.FormatUint(id, 16)); err != nil {
+		return fmt.Errorf("can't write to etcd: %w", err)
+	}
+	return nil
+}
+
+// Returns the IDs of all members that are marked as being defragmented.
+func (c *Client) DefragmentingMembers(ctx context.Context) ([]uint64, error) {
+	resp, err := c.client.Get(ctx, defragmentingPrefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, fmt.Errorf("can't read from etcd: %w", err)
+	}
+
+	ids := make([]uint64, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if id, err := strconv.ParseUint(strings.TrimPrefix(string(kv.Key), defragmentingPrefix), 16, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// Compacts the keyspace, discarding all but the given number of most recent
+// revisions. Returns the revision up to which the keyspace has been compacted,
+// or zero if there was nothing to compact.
+func (c *Client) Compact(ctx context.Context, retainedRevisions int64) (int64, error) {
+	resp, err := c.client.Status(ctx, c.Config.Endpoints[0])
+	if err != nil {
+		return 0, err
+	}
+
+	revision := resp.Header.Revision - retainedRevisions
+	if revision <= 0 {
+		return 0, nil
+	}
+
+	if _, err := c.client.Compact(ctx, revision); err != nil {
+		if errors.Is(err, rpctypes.ErrCompacted) {
+			return 0, nil // Someone else has already compacted the keyspace.
+		}
+		return 0, err
+	}
+
+	return revision, nil
+}
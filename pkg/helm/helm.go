@@ -505,3 +505,67 @@ func (hc *Commands) UninstallRelease(ctx context.Context, releaseName, namespace
 	_, err = helmAction.Run(releaseName)
 	return err
 }
+
+// RollbackRelease rolls back a release to the given revision. It doesn't wait
+// for the resources of the rolled back release to become ready.
+func (hc *Commands) RollbackRelease(ctx context.Context, releaseName, namespace string, revision int) error {
+	// The Helm rollback action doesn't offer RunWithContext. Instead, use ctx
+	// for action configuration and transport control directly.
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(errHelmOperationInterrupted)
+
+	cfg, err := hc.getActionCfg(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("can't create action configuration: %w", err)
+	}
+
+	rollback := action.NewRollback(cfg)
+	rollback.Version = revision
+	rollback.CleanupOnFail = true
+
+	if err := rollback.Run(releaseName); err != nil {
+		return fmt.Errorf("can't roll back release `%s/%s` to revision %d: %w", namespace, releaseName, revision, err)
+	}
+
+	return nil
+}
+
+// IsReleaseReady checks if a release has been deployed successfully and all of
+// its resources are ready, using the same readiness checks that are used when
+// waiting for installs and upgrades.
+func (hc *Commands) IsReleaseReady(ctx context.Context, releaseName, namespace string) (bool, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(errHelmOperationInterrupted)
+
+	cfg, err := hc.getActionCfg(ctx, namespace)
+	if err != nil {
+		return false, fmt.Errorf("can't create action configuration: %w", err)
+	}
+
+	rel, err := action.NewGet(cfg).Run(releaseName)
+	if err != nil {
+		return false, err
+	}
+	if rel.Info == nil || rel.Info.Status != release.StatusDeployed {
+		return false, nil
+	}
+
+	resources, err := cfg.KubeClient.Build(strings.NewReader(rel.Manifest), false)
+	if err != nil {
+		return false, fmt.Errorf("can't build resources of release `%s/%s`: %w", namespace, releaseName, err)
+	}
+
+	clients, err := hc.clients.GetClient()
+	if err != nil {
+		return false, err
+	}
+
+	checker := kube.NewReadyChecker(clients, cfg.Log, kube.PausedAsReady(true), kube.CheckJobs(true))
+	for _, resource := range resources {
+		if ready, err := checker.IsReady(ctx, resource); err != nil || !ready {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -15,6 +15,10 @@ func Endpoints(client Provider[*corev1.EndpointsList]) *Watcher[corev1.Endpoints
 	return FromClient[*corev1.EndpointsList, corev1.Endpoints](client)
 }
 
+func Namespaces(client Provider[*corev1.NamespaceList]) *Watcher[corev1.Namespace] {
+	return FromClient[*corev1.NamespaceList, corev1.Namespace](client)
+}
+
 func Nodes(client Provider[*corev1.NodeList]) *Watcher[corev1.Node] {
 	return FromClient[*corev1.NodeList, corev1.Node](client)
 }
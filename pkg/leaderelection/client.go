@@ -51,9 +51,22 @@ type LeaseConfig struct {
 	// The Kubernetes client used to manage the Lease resource.
 	Client coordinationv1client.LeasesGetter
 
+	// The duration that non-leading clients wait before they try to take over
+	// the lead from a leader that stopped renewing its lease. The renew
+	// deadline and the retry period are shortened accordingly. Defaults to 60
+	// seconds.
+	LeaseDuration time.Duration
+
 	internalConfig
 }
 
+// Implements [Config].
+func (c *LeaseConfig) internal() internalConfig {
+	ic := c.internalConfig
+	ic.leaseDuration = c.LeaseDuration
+	return ic
+}
+
 // Implements [Config].
 func (c *LeaseConfig) buildLock() (resourcelock.Interface, error) {
 	if c.Namespace == "" {
@@ -92,7 +105,7 @@ type Config interface {
 }
 
 // Default durations for leader election.
-// Not publicly configurable at the moment.
+// Only the lease duration is publicly configurable at the moment.
 const (
 	defaultLeaseDuration = 60 * time.Second
 	defaultRenewDeadline = 15 * time.Second
@@ -107,27 +120,29 @@ func NewClient(c Config) (*Client, error) {
 	}
 
 	ic := c.internal()
+	leaseDuration := cmp.Or(ic.leaseDuration, defaultLeaseDuration)
+	renewDeadline := cmp.Or(ic.renewDeadline, min(defaultRenewDeadline, leaseDuration/2))
 	leaderElector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
 		Lock:            lock,
 		ReleaseOnCancel: true,
-		LeaseDuration:   defaultLeaseDuration,
+		LeaseDuration:   leaseDuration,
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) { k0scontext.Value[onStartedLeadingFunc](ctx)() },
 			OnStoppedLeading: func() { /* handled in runLeaderElectionRound */ },
 		},
-		RenewDeadline: cmp.Or(ic.renewDeadline, defaultRenewDeadline),
-		RetryPeriod:   cmp.Or(ic.retryPeriod, defaultRetryPeriod),
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   cmp.Or(ic.retryPeriod, min(defaultRetryPeriod, renewDeadline/3)),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{leaderElector}, nil
+	return &Client{lock.Describe(), leaderElector}, nil
 }
 
 // Internal, non-exposed leader election configuration settings.
 type internalConfig struct {
-	renewDeadline, retryPeriod time.Duration
+	leaseDuration, renewDeadline, retryPeriod time.Duration
 }
 
 // Implements [Config].
@@ -135,6 +150,7 @@ func (c *internalConfig) internal() internalConfig { return *c }
 
 // A leader election client.
 type Client struct {
+	name          string
 	leaderElector *leaderelection.LeaderElector
 }
 
@@ -142,6 +158,20 @@ type Client struct {
 // the status changes. It will be called sequentially, but not necessarily from
 // the same goroutine. Run returns when ctx is done.
 func (c *Client) Run(ctx context.Context, changed func(Status)) {
+	status := leaderElectionStatus.WithLabelValues(c.name)
+	status.Set(0)
+	defer leaderElectionStatus.DeleteLabelValues(c.name)
+	changed = func(changed func(Status)) func(Status) {
+		return func(s Status) {
+			if s == StatusLeading {
+				status.Set(1)
+			} else {
+				status.Set(0)
+			}
+			changed(s)
+		}
+	}(changed)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -53,6 +53,20 @@ func TestLeaseConfig_Client(t *testing.T) {
 	assert.ErrorContains(t, err, "client may not be nil")
 }
 
+func TestLeaseConfig_LeaseDuration(t *testing.T) {
+	for _, leaseDuration := range []time.Duration{0, 2 * time.Second, 10 * time.Second, 5 * time.Minute} {
+		client, err := NewClient(&LeaseConfig{
+			Namespace:     "foo",
+			Name:          "bar",
+			Identity:      "baz",
+			Client:        fake.NewSimpleClientset().CoordinationV1(),
+			LeaseDuration: leaseDuration,
+		})
+		assert.NoError(t, err, "For lease duration %s", leaseDuration)
+		assert.NotNil(t, client, "For lease duration %s", leaseDuration)
+	}
+}
+
 func TestClient_Reacquisition(t *testing.T) {
 	fakeClient := fake.NewSimpleClientset()
 	ctx, cancel := context.WithCancel(t.Context())
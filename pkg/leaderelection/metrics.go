@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leaderelection
+
+import (
+	"github.com/k0sproject/k0s/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var leaderElectionStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "leader_election",
+	Name:      "leading",
+	Help:      "Whether this k0s process holds the lead of a leader election, by lease.",
+}, []string{"lease"})
+
+func init() {
+	metrics.Registry.MustRegister(leaderElectionStatus)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics holds the Prometheus metrics of the k0s process itself.
+// Packages define their metrics themselves and register them in [Registry].
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The namespace of all k0s metrics.
+const Namespace = "k0s"
+
+// The registry for the metrics of the k0s process.
+var Registry = prometheus.NewRegistry()
+
+// Gathers the metrics of the k0s process. Those include the metrics that
+// controller-runtime and client-go register in controller-runtime's registry.
+// The Go runtime and process metrics are registered there, too, as soon as
+// controller-runtime's controller package is in use.
+var Gatherer prometheus.Gatherer = prometheus.Gatherers{Registry, ctrlmetrics.Registry}
+
+// Returns an HTTP handler that serves the metrics of the k0s process.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Gatherer, promhttp.HandlerOpts{})
+}
+
+// Server is a component that serves the metrics of the k0s process on the
+// given address, without any authentication. It's meant to be bound to a local
+// address.
+type Server struct {
+	BindAddress string
+
+	log      logrus.FieldLogger
+	server   *http.Server
+	listener net.Listener
+	done     chan struct{}
+}
+
+// Init initializes the server.
+func (s *Server) Init(context.Context) error {
+	s.log = logrus.WithField("component", "k0s-metrics")
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", Handler())
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return nil
+}
+
+// Start starts serving the metrics.
+func (s *Server) Start(context.Context) error {
+	listener, err := net.Listen("tcp", s.BindAddress)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		s.log.Info("Serving k0s metrics on ", listener.Addr())
+		if err := s.server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			s.log.WithError(err).Error("Failed to serve k0s metrics")
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops serving the metrics.
+func (s *Server) Stop() error {
+	if s.done == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := s.server.Shutdown(ctx)
+	<-s.done
+	return err
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "test_total",
+		Help:      "A counter for testing.",
+	})
+	require.NoError(t, Registry.Register(counter))
+	t.Cleanup(func() { Registry.Unregister(counter) })
+	counter.Inc()
+
+	underTest := Server{BindAddress: "127.0.0.1:0"}
+	require.NoError(t, underTest.Init(t.Context()))
+	require.NoError(t, underTest.Start(t.Context()))
+	t.Cleanup(func() { assert.NoError(t, underTest.Stop()) })
+
+	addr := underTest.listener.Addr().String()
+	resp, err := http.Get("http://" + addr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "k0s_test_total 1\n")
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Raises the revision of a kine database to the given revision, and marks all
+// revisions up to there as compacted. Kine needs to have initialized the
+// database before, but it mustn't be running while the revision is raised.
+//
+// Kine's revisions are the IDs of the rows in its table. The revision is
+// raised by inserting a gap row, just like the ones kine inserts itself to
+// fill gaps in its log. The compacted revision is stored in the row of the
+// compact_rev_key.
+func BumpKineRevision(ctx context.Context, db *sql.DB, revision int64) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	var current int64
+	if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM kine").Scan(&current); err != nil {
+		return fmt.Errorf("failed to get current revision: %w", err)
+	}
+	if current >= revision {
+		return fmt.Errorf("current revision %d isn't below %d", current, revision)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO kine(id, name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES(?, ?, 0, 1, 0, 0, 0, NULL, NULL)",
+		revision, fmt.Sprintf("gap-%d", revision),
+	); err != nil {
+		return fmt.Errorf("failed to insert revision: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE kine SET prev_revision = ? WHERE name = 'compact_rev_key'", revision)
+	if err != nil {
+		return fmt.Errorf("failed to mark revision as compacted: %w", err)
+	}
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows != 1 {
+		return errors.New("kine hasn't initialized the database")
+	}
+
+	return tx.Commit()
+}
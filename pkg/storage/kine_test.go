@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"database/sql"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestBumpKineRevision(t *testing.T) {
+	open := func(t *testing.T) *sql.DB {
+		path := filepath.Join(t.TempDir(), "kine.db")
+		db, err := sql.Open("sqlite", (&url.URL{Scheme: "file", Path: path}).String())
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, db.Close()) })
+
+		// The schema of kine's SQLite driver.
+		_, err = db.Exec(`CREATE TABLE kine (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name INTEGER,
+			created INTEGER,
+			deleted INTEGER,
+			create_revision INTEGER,
+			prev_revision INTEGER,
+			lease INTEGER,
+			value BLOB,
+			old_value BLOB
+		)`)
+		require.NoError(t, err)
+		return db
+	}
+
+	insert := func(t *testing.T, db *sql.DB, name string) (revision int64) {
+		res, err := db.Exec("INSERT INTO kine(name, created, deleted, create_revision, prev_revision, lease, value, old_value) VALUES(?, 1, 0, 0, 0, 0, '', '')", name)
+		require.NoError(t, err)
+		revision, err = res.LastInsertId()
+		require.NoError(t, err)
+		return revision
+	}
+
+	t.Run("Succeeds", func(t *testing.T) {
+		db := open(t)
+		insert(t, db, "compact_rev_key")
+
+		require.NoError(t, BumpKineRevision(t.Context(), db, 5678))
+
+		// New keys are created after the bumped revision.
+		assert.Equal(t, int64(5679), insert(t, db, "/registry/namespaces/default"))
+
+		var compacted int64
+		require.NoError(t, db.QueryRow("SELECT prev_revision FROM kine WHERE name = 'compact_rev_key'").Scan(&compacted))
+		assert.Equal(t, int64(5678), compacted)
+	})
+
+	t.Run("Uninitialized", func(t *testing.T) {
+		db := open(t)
+
+		assert.EqualError(t, BumpKineRevision(t.Context(), db, 5678), "kine hasn't initialized the database")
+
+		var count int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM kine").Scan(&count))
+		assert.Zero(t, count, "the gap row should have been rolled back")
+	})
+
+	t.Run("RevisionNotBelow", func(t *testing.T) {
+		db := open(t)
+		insert(t, db, "compact_rev_key")
+		insert(t, db, "/registry/namespaces/default")
+
+		assert.EqualError(t, BumpKineRevision(t.Context(), db, 2), "current revision 2 isn't below 2")
+	})
+}
@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storage implements the migration of the Kubernetes API server's data
+// between the storage backends supported by k0s. All of them speak the etcd
+// API, either natively or via kine, so the data is copied key by key through
+// that API.
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// The prefix of all keys stored by the Kubernetes API server.
+const KeyPrefix = "/registry/"
+
+// The TTL of migrated keys that were attached to a lease in the source
+// backend. Those are mostly events, which expire after one hour by default.
+const LeasedKeyTTL = 1 * time.Hour
+
+// The number of keys that are listed at once.
+const listPageSize = 500
+
+// A single key as stored in a backend.
+type KeyValue struct {
+	Key    string
+	Value  []byte
+	Leased bool // Whether the key is attached to a lease, i.e. expires.
+}
+
+// A storage backend that can be migrated from or to.
+type Backend interface {
+	// Lists at most limit keys that start with prefix, beginning with the key
+	// start, at the given revision. Lists the latest revision if rev is zero.
+	// Returns the listed revision and whether there are more keys.
+	List(ctx context.Context, prefix, start string, limit, rev int64) (_ []KeyValue, revision int64, more bool, _ error)
+
+	// Creates the given key. Fails if the key already exists.
+	Create(ctx context.Context, kv *KeyValue) error
+
+	// Returns the backend's current revision.
+	Revision(ctx context.Context) (int64, error)
+}
+
+// Returned when migrating into a backend that already contains data.
+var ErrTargetNotEmpty = errors.New("target storage isn't empty")
+
+// Returned when migrating away from an etcd cluster with multiple members.
+// The other members would keep on running with the old data.
+var ErrMultipleMembers = errors.New("etcd cluster has multiple members")
+
+// A consistent copy of all keys of a backend.
+type Snapshot struct {
+	Revision  int64      // The revision at which the keys have been read.
+	KeyValues []KeyValue // All keys, sorted.
+}
+
+// Returns the total size of all keys and values in bytes.
+func (s *Snapshot) Size() (size int64) {
+	for i := range s.KeyValues {
+		size += int64(len(s.KeyValues[i].Key) + len(s.KeyValues[i].Value))
+	}
+	return size
+}
+
+// Reads all keys with the given prefix from the backend at a single revision.
+func TakeSnapshot(ctx context.Context, backend Backend, prefix string) (*Snapshot, error) {
+	var snapshot Snapshot
+	err := list(ctx, backend, prefix, func(kv *KeyValue) error {
+		snapshot.KeyValues = append(snapshot.KeyValues, *kv)
+		return nil
+	}, &snapshot.Revision)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// The outcome of a copy operation.
+type CopyResult struct {
+	Keys           int   // The number of copied keys.
+	RevisionBefore int64 // The target's revision before copying.
+	RevisionAfter  int64 // The target's revision after copying.
+}
+
+// Copies all keys of the snapshot into the target backend. The target backend
+// needs to be empty, i.e. it mustn't contain any keys with the given prefix.
+func Copy(ctx context.Context, snapshot *Snapshot, target Backend, prefix string) (*CopyResult, error) {
+	existing, _, _, err := target.List(ctx, prefix, prefix, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target keys: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%w: found key %s", ErrTargetNotEmpty, existing[0].Key)
+	}
+
+	var result CopyResult
+	if result.RevisionBefore, err = target.Revision(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get target revision: %w", err)
+	}
+
+	for i := range snapshot.KeyValues {
+		if err := target.Create(ctx, &snapshot.KeyValues[i]); err != nil {
+			return &result, fmt.Errorf("failed to create key %s: %w", snapshot.KeyValues[i].Key, err)
+		}
+		result.Keys++
+	}
+
+	if result.RevisionAfter, err = target.Revision(ctx); err != nil {
+		return &result, fmt.Errorf("failed to get target revision: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Verifies that the target backend contains exactly the keys of the snapshot
+// after they have been copied. Every key creation increments the revision by
+// one. Any other difference in the target's revisions indicates concurrent
+// writes during the copy. The target's revisions need to start at the
+// snapshot's revision, so that resource versions don't go backwards.
+func Verify(ctx context.Context, snapshot *Snapshot, target Backend, prefix string, result *CopyResult) error {
+	if want, got := int64(len(snapshot.KeyValues)), result.RevisionAfter-result.RevisionBefore; got != want {
+		return fmt.Errorf("target revision advanced by %d during the copy of %d keys, was there a concurrent write?", got, want)
+	}
+
+	var count int
+	expected := digest()
+	for i := range snapshot.KeyValues {
+		expected.add(&snapshot.KeyValues[i])
+	}
+	actual := digest()
+	if err := list(ctx, target, prefix, func(kv *KeyValue) error {
+		count++
+		actual.add(kv)
+		return nil
+	}, nil); err != nil {
+		return err
+	}
+
+	if count != len(snapshot.KeyValues) {
+		return fmt.Errorf("target contains %d keys, expected %d", count, len(snapshot.KeyValues))
+	}
+	if !bytes.Equal(expected.Sum(nil), actual.Sum(nil)) {
+		return errors.New("target keys or values differ from the source")
+	}
+
+	if result.RevisionBefore < snapshot.Revision {
+		return fmt.Errorf("target revision %d is below source revision %d, resource versions would go backwards", result.RevisionBefore, snapshot.Revision)
+	}
+
+	return nil
+}
+
+// Lists all keys with the given prefix at a single revision and passes them
+// to the given callback in order. Stores the listed revision in rev, if given.
+func list(ctx context.Context, backend Backend, prefix string, fn func(*KeyValue) error, rev *int64) error {
+	var revision int64
+	for start := prefix; ; {
+		kvs, listedRevision, more, err := backend.List(ctx, prefix, start, listPageSize, revision)
+		if err != nil {
+			return fmt.Errorf("failed to list keys: %w", err)
+		}
+		revision = listedRevision
+
+		for i := range kvs {
+			if err := fn(&kvs[i]); err != nil {
+				return err
+			}
+		}
+
+		if !more || len(kvs) == 0 {
+			break
+		}
+		// Continue right after the last key.
+		start = kvs[len(kvs)-1].Key + "\x00"
+	}
+
+	if rev != nil {
+		*rev = revision
+	}
+	return nil
+}
+
+// Hashes keys, values and their lease state in order.
+type digester struct{ hash.Hash }
+
+func digest() *digester {
+	return &digester{sha256.New()}
+}
+
+func (d *digester) add(kv *KeyValue) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(len(kv.Key)))
+	_, _ = d.Write(buf[:])
+	_, _ = d.Write([]byte(kv.Key))
+	binary.BigEndian.PutUint64(buf[:], uint64(len(kv.Value)))
+	_, _ = d.Write(buf[:])
+	_, _ = d.Write(kv.Value)
+	if kv.Leased {
+		_, _ = d.Write([]byte{1})
+	} else {
+		_, _ = d.Write([]byte{0})
+	}
+}
+
+// A backend that is accessed via an etcd client.
+type ClientBackend struct {
+	client  *clientv3.Client
+	leaseID clientv3.LeaseID
+}
+
+var _ Backend = (*ClientBackend)(nil)
+
+// Creates a backend that connects to the given etcd API endpoint.
+func NewClientBackend(config clientv3.Config) (*ClientBackend, error) {
+	client, err := clientv3.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("can't build etcd client: %w", err)
+	}
+	return &ClientBackend{client: client}, nil
+}
+
+// List implements [Backend].
+func (b *ClientBackend) List(ctx context.Context, prefix, start string, limit, rev int64) ([]KeyValue, int64, bool, error) {
+	opts := []clientv3.OpOption{
+		clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefix)),
+		clientv3.WithLimit(limit),
+	}
+	if rev != 0 {
+		opts = append(opts, clientv3.WithRev(rev))
+	}
+
+	resp, err := b.client.Get(ctx, start, opts...)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	kvs := make([]KeyValue, len(resp.Kvs))
+	for i, kv := range resp.Kvs {
+		kvs[i] = KeyValue{Key: string(kv.Key), Value: kv.Value, Leased: kv.Lease != 0}
+	}
+	return kvs, resp.Header.Revision, resp.More, nil
+}
+
+// Create implements [Backend].
+func (b *ClientBackend) Create(ctx context.Context, kv *KeyValue) error {
+	var opts []clientv3.OpOption
+	if kv.Leased {
+		if b.leaseID == 0 {
+			lease, err := b.client.Grant(ctx, int64(LeasedKeyTTL.Seconds()))
+			if err != nil {
+				return fmt.Errorf("can't get TTL lease: %w", err)
+			}
+			b.leaseID = lease.ID
+		}
+		opts = append(opts, clientv3.WithLease(b.leaseID))
+	}
+
+	// Always use a transaction, as kine doesn't implement plain puts.
+	resp, err := b.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(kv.Key), "=", 0),
+	).Then(
+		clientv3.OpPut(kv.Key, string(kv.Value), opts...),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return errors.New("key already exists")
+	}
+	return nil
+}
+
+// Revision implements [Backend].
+func (b *ClientBackend) Revision(ctx context.Context) (int64, error) {
+	// Kine doesn't support count-only or keys-only requests for arbitrary
+	// keys, so simply get some key that's not expected to exist.
+	resp, err := b.client.Get(ctx, "/k0s-storage-migration")
+	if err != nil {
+		return 0, err
+	}
+	return resp.Header.Revision, nil
+}
+
+// Checks that the backend is an etcd cluster that consists of a single member.
+// The member list is read from the local member without consulting the others.
+func (b *ClientBackend) CheckSingleMember(ctx context.Context) error {
+	resp, err := b.client.MemberList(ctx, clientv3.WithSerializable())
+	if err != nil {
+		return fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	return checkSingleMember(resp.Members)
+}
+
+func checkSingleMember(members []*etcdserverpb.Member) error {
+	if len(members) <= 1 {
+		return nil
+	}
+
+	names := make([]string, len(members))
+	for i, member := range members {
+		names[i] = member.Name
+		if names[i] == "" {
+			names[i] = strconv.FormatUint(member.ID, 16)
+		}
+	}
+	slices.Sort(names)
+	return fmt.Errorf("%w: %s", ErrMultipleMembers, strings.Join(names, ", "))
+}
+
+// Closes the underlying client.
+func (b *ClientBackend) Close() error {
+	return b.client.Close()
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestMigrate(t *testing.T) {
+	newSource := func() *fakeBackend {
+		source := &fakeBackend{revision: 100}
+		for i := range 1234 {
+			source.put(KeyValue{Key: fmt.Sprintf("/registry/pods/default/pod-%04d", i), Value: []byte(fmt.Sprint(i))})
+		}
+		source.put(KeyValue{Key: "/registry/events/default/pod-0000.1", Value: []byte("event"), Leased: true})
+		source.put(KeyValue{Key: "/k0s-health-check", Value: []byte("value")})
+		return source
+	}
+
+	t.Run("Succeeds", func(t *testing.T) {
+		source := newSource()
+		target := &fakeBackend{revision: source.revision}
+
+		snapshot, err := TakeSnapshot(t.Context(), source, KeyPrefix)
+		require.NoError(t, err)
+		assert.Len(t, snapshot.KeyValues, 1235, "keys outside the prefix shouldn't be part of the snapshot")
+		assert.Equal(t, source.revision, snapshot.Revision)
+		assert.True(t, slices.IsSortedFunc(snapshot.KeyValues, func(l, r KeyValue) int { return strings.Compare(l.Key, r.Key) }))
+
+		result, err := Copy(t.Context(), snapshot, target, KeyPrefix)
+		require.NoError(t, err)
+		assert.Equal(t, &CopyResult{Keys: 1235, RevisionBefore: 1336, RevisionAfter: 2571}, result)
+		assert.True(t, target.kvs["/registry/events/default/pod-0000.1"].Leased)
+
+		assert.NoError(t, Verify(t.Context(), snapshot, target, KeyPrefix, result))
+	})
+
+	t.Run("TargetNotEmpty", func(t *testing.T) {
+		source, target := newSource(), &fakeBackend{}
+		target.put(KeyValue{Key: "/registry/namespaces/default"})
+
+		snapshot, err := TakeSnapshot(t.Context(), source, KeyPrefix)
+		require.NoError(t, err)
+
+		_, err = Copy(t.Context(), snapshot, target, KeyPrefix)
+		assert.ErrorIs(t, err, ErrTargetNotEmpty)
+		assert.ErrorContains(t, err, "/registry/namespaces/default")
+		assert.Len(t, target.kvs, 1)
+	})
+
+	t.Run("ConcurrentWrite", func(t *testing.T) {
+		source, target := newSource(), &fakeBackend{}
+
+		snapshot, err := TakeSnapshot(t.Context(), source, KeyPrefix)
+		require.NoError(t, err)
+		result, err := Copy(t.Context(), snapshot, target, KeyPrefix)
+		require.NoError(t, err)
+
+		target.put(KeyValue{Key: "/registry/leases/kube-system/foo"})
+		result.RevisionAfter = target.revision
+		assert.ErrorContains(t, Verify(t.Context(), snapshot, target, KeyPrefix, result),
+			"target revision advanced by 1236 during the copy of 1235 keys, was there a concurrent write?")
+	})
+
+	t.Run("DifferentValue", func(t *testing.T) {
+		source, target := newSource(), &fakeBackend{}
+
+		snapshot, err := TakeSnapshot(t.Context(), source, KeyPrefix)
+		require.NoError(t, err)
+		result, err := Copy(t.Context(), snapshot, target, KeyPrefix)
+		require.NoError(t, err)
+
+		target.kvs["/registry/pods/default/pod-0042"].Value = []byte("tampered")
+		assert.EqualError(t, Verify(t.Context(), snapshot, target, KeyPrefix, result),
+			"target keys or values differ from the source")
+	})
+
+	t.Run("RevisionBelowSource", func(t *testing.T) {
+		source, target := newSource(), &fakeBackend{revision: 1}
+
+		snapshot, err := TakeSnapshot(t.Context(), source, KeyPrefix)
+		require.NoError(t, err)
+		result, err := Copy(t.Context(), snapshot, target, KeyPrefix)
+		require.NoError(t, err)
+
+		assert.EqualError(t, Verify(t.Context(), snapshot, target, KeyPrefix, result),
+			"target revision 1 is below source revision 1336, resource versions would go backwards")
+	})
+
+	t.Run("CreateFails", func(t *testing.T) {
+		source, target := newSource(), &fakeBackend{createErr: errors.New("database is locked")}
+
+		snapshot, err := TakeSnapshot(t.Context(), source, KeyPrefix)
+		require.NoError(t, err)
+		_, err = Copy(t.Context(), snapshot, target, KeyPrefix)
+		assert.EqualError(t, err, "failed to create key /registry/events/default/pod-0000.1: database is locked")
+	})
+}
+
+func TestCheckSingleMember(t *testing.T) {
+	assert.NoError(t, checkSingleMember([]*etcdserverpb.Member{{ID: 0x1f, Name: "controller-1"}}))
+
+	err := checkSingleMember([]*etcdserverpb.Member{{ID: 0x2a, Name: "controller-2"}, {ID: 0x1f, Name: "controller-1"}, {ID: 0x3c}})
+	assert.ErrorIs(t, err, ErrMultipleMembers)
+	assert.EqualError(t, err, "etcd cluster has multiple members: 3c, controller-1, controller-2")
+}
+
+// An in-memory backend. Doesn't keep any history, so listing at older
+// revisions only works as long as there are no writes in between.
+type fakeBackend struct {
+	revision  int64
+	kvs       map[string]*KeyValue
+	createErr error
+}
+
+func (b *fakeBackend) put(kv KeyValue) {
+	if b.kvs == nil {
+		b.kvs = make(map[string]*KeyValue)
+	}
+	b.revision++
+	b.kvs[kv.Key] = &kv
+}
+
+func (b *fakeBackend) List(_ context.Context, prefix, start string, limit, rev int64) ([]KeyValue, int64, bool, error) {
+	if rev != 0 && rev != b.revision {
+		return nil, 0, false, fmt.Errorf("revision %d is gone", rev)
+	}
+
+	var keys []string
+	for key := range b.kvs {
+		if strings.HasPrefix(key, prefix) && key >= start {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	more := int64(len(keys)) > limit
+	if more {
+		keys = keys[:limit]
+	}
+	kvs := make([]KeyValue, len(keys))
+	for i, key := range keys {
+		kvs[i] = *b.kvs[key]
+	}
+	return kvs, b.revision, more, nil
+}
+
+func (b *fakeBackend) Create(_ context.Context, kv *KeyValue) error {
+	if b.createErr != nil {
+		return b.createErr
+	}
+	if _, exists := b.kvs[kv.Key]; exists {
+		return errors.New("key already exists")
+	}
+	b.put(*kv)
+	return nil
+}
+
+func (b *fakeBackend) Revision(context.Context) (int64, error) {
+	return b.revision, nil
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"github.com/k0sproject/k0s/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	processRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "supervisor",
+		Name:      "process_running",
+		Help:      "Whether a supervised process is currently running.",
+	}, []string{"process"})
+
+	processRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "supervisor",
+		Name:      "process_restarts_total",
+		Help:      "Number of times a supervised process has been restarted after it terminated.",
+	}, []string{"process"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(processRunning, processRestarts)
+}
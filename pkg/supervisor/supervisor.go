@@ -254,11 +254,7 @@ func (s *Supervisor) Supervise(ctx context.Context) error {
 				// get signals sent directly to parent.
 				s.cmd.SysProcAttr = DetachAttr(s.UID, s.GID, s.RequiredPrivileges)
 
-				const maxLogChunkLen = 16 * 1024
-				s.cmd.Stdout = log.NewWriter(s.log.WithField("stream", "stdout"), logrus.InfoLevel, maxLogChunkLen)
-				s.cmd.Stderr = log.NewWriter(s.log.WithField("stream", "stderr"), logrus.InfoLevel, maxLogChunkLen)
-
-				err = s.cmd.Start()
+				err = s.startProcess()
 			}
 			s.mutex.Unlock()
 			if err != nil {
@@ -277,9 +273,13 @@ func (s *Supervisor) Supervise(ctx context.Context) error {
 					started <- nil
 				} else {
 					s.log.Infof("Restarted (%d)", restarts)
+					processRestarts.WithLabelValues(s.Name).Inc()
 				}
 				restarts++
-				if s.processWaitQuit(ctx, s.cmd) {
+				processRunning.WithLabelValues(s.Name).Set(1)
+				quit := s.processWaitQuit(ctx, s.cmd)
+				processRunning.WithLabelValues(s.Name).Set(0)
+				if quit {
 					return
 				}
 			}
@@ -310,6 +310,50 @@ func (s *Supervisor) Supervise(ctx context.Context) error {
 	return nil
 }
 
+// Starts the process and forwards its output to the log. The output is
+// annotated with the PID of the process, which is why the output pipes are
+// created here instead of letting exec.Cmd create them.
+func (s *Supervisor) startProcess() (err error) {
+	var readers, writers []*os.File
+	defer func() {
+		// The process has its own copies of the pipes' write ends.
+		for _, w := range writers {
+			_ = w.Close()
+		}
+		if err != nil {
+			for _, r := range readers {
+				_ = r.Close()
+			}
+		}
+	}()
+
+	for range 2 {
+		r, w, err := os.Pipe()
+		if err != nil {
+			return err
+		}
+		readers, writers = append(readers, r), append(writers, w)
+	}
+
+	s.cmd.Stdout, s.cmd.Stderr = writers[0], writers[1]
+	if err := s.cmd.Start(); err != nil {
+		return err
+	}
+
+	processLog := s.log.WithField("pid", s.cmd.Process.Pid)
+	for i, stream := range []string{"stdout", "stderr"} {
+		go forwardOutput(readers[i], processLog.WithField("stream", stream))
+	}
+
+	return nil
+}
+
+func forwardOutput(r *os.File, streamLog *logrus.Entry) {
+	defer r.Close()
+	const maxLogChunkLen = 16 * 1024
+	_, _ = io.Copy(log.NewWriter(streamLog, logrus.InfoLevel, maxLogChunkLen).ParseLevels(), r)
+}
+
 // Stops the supervised process using the default stop behavior.
 func (s *Supervisor) Stop() error {
 	return s.StopWith(StopOpts{})
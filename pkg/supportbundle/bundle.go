@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package supportbundle collects diagnostic information about a k0s node into
+// a single tarball that can be attached to bug reports.
+//
+// Collection is best effort: each piece of information is gathered by a
+// separate collector, and failing collectors don't prevent the others from
+// running. This way, a bundle can be created even if k0s or the Kubernetes API
+// server is down. The outcome of all collectors is recorded in the bundle's
+// collection.log file.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Options configure what is collected into a support bundle.
+type Options struct {
+	K0sVars *config.CfgVars
+
+	// The path of the k0s executable, used to run k0s subcommands.
+	Executable string
+
+	// Logs are collected starting from this long ago.
+	Since time.Duration
+
+	// The maximum time a single collector may take.
+	Timeout time.Duration
+}
+
+// Write collects a support bundle and writes it as a gzipped tarball to w. All
+// files are placed into a top-level directory with the given name.
+func Write(ctx context.Context, w io.Writer, name string, opts Options) error {
+	return write(ctx, w, name, opts.Timeout, collectors(&opts))
+}
+
+// A collector gathers a specific piece of information and adds it to a bundle.
+type collector struct {
+	name    string
+	collect func(context.Context, *bundle) error
+}
+
+func write(ctx context.Context, w io.Writer, name string, timeout time.Duration, collectors []collector) (err error) {
+	gz := gzip.NewWriter(w)
+	b := bundle{tw: tar.NewWriter(gz), dir: name, modTime: time.Now()}
+	defer func() {
+		if err == nil {
+			err = b.tw.Close()
+			err = errors.Join(err, gz.Close())
+		}
+	}()
+
+	var collectionLog []byte
+	for _, c := range collectors {
+		log := logrus.WithField("collector", c.name)
+		log.Debug("Collecting")
+
+		start := time.Now()
+		collectCtx, cancel := context.WithTimeout(ctx, timeout)
+		collectErr := c.collect(collectCtx, &b)
+		cancel()
+		if b.err != nil {
+			return b.err
+		}
+
+		took := time.Since(start).Round(time.Millisecond)
+		var skip skipped
+		if errors.As(collectErr, &skip) {
+			log.Debug("Skipped: ", skip)
+			collectionLog = fmt.Appendf(collectionLog, "%s: skipped: %s\n", c.name, skip)
+		} else if collectErr != nil {
+			log.WithError(collectErr).Warn("Collection failed")
+			collectionLog = fmt.Appendf(collectionLog, "%s: failed after %s: %v\n", c.name, took, collectErr)
+		} else {
+			collectionLog = fmt.Appendf(collectionLog, "%s: ok after %s\n", c.name, took)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	b.add("collection.log", collectionLog)
+	return b.err
+}
+
+// A support bundle that's being written.
+type bundle struct {
+	tw      *tar.Writer
+	dir     string
+	modTime time.Time
+	err     error
+}
+
+// Adds a file with the given name and contents to the bundle. Any write errors
+// are remembered and abort the bundle creation once the current collector
+// returns, since the tarball is most probably unusable afterwards.
+func (b *bundle) add(name string, data []byte) {
+	if b.err != nil {
+		return
+	}
+
+	if err := b.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join(b.dir, name),
+		Size:     int64(len(data)),
+		Mode:     0600,
+		ModTime:  b.modTime,
+	}); err != nil {
+		b.err = err
+		return
+	}
+
+	if _, err := b.tw.Write(data); err != nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/k0sproject/k0s/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := write(t.Context(), &buf, "bundle", time.Minute, []collector{
+		{"ok", func(_ context.Context, b *bundle) error {
+			b.add("ok.txt", []byte("ok"))
+			return nil
+		}},
+		{"partial", func(_ context.Context, b *bundle) error {
+			b.add("partial.txt", []byte("partial"))
+			return errors.New("something went wrong")
+		}},
+		{"skip", func(context.Context, *bundle) error {
+			return skipped("nothing to do")
+		}},
+		{"timeout", func(ctx context.Context, _ *bundle) error {
+			deadline, ok := ctx.Deadline()
+			if assert.True(t, ok, "No deadline") {
+				assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 10*time.Second)
+			}
+			return nil
+		}},
+	})
+	require.NoError(t, err)
+
+	files := readBundle(t, &buf)
+	assert.Equal(t, "ok", files["bundle/ok.txt"])
+	assert.Equal(t, "partial", files["bundle/partial.txt"])
+	assert.Regexp(t, `^ok: ok after \S+
+partial: failed after \S+: something went wrong
+skip: skipped: nothing to do
+timeout: ok after \S+
+$`, files["bundle/collection.log"])
+	assert.Len(t, files, 3)
+}
+
+func TestCollectSupervisor(t *testing.T) {
+	runDir := t.TempDir()
+	pid := strconv.Itoa(os.Getpid())
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "self.pid"), []byte(pid+"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(runDir, "broken.pid"), []byte("foo"), 0644))
+
+	opts := Options{K0sVars: &config.CfgVars{RunDir: runDir}}
+	files := collect(t, opts.collectSupervisor)
+
+	supervisor := files["bundle/supervisor.txt"]
+	assert.Regexp(t, `(?m)^broken\s+-\s+invalid PID file\s+-$`, supervisor)
+	if _, err := os.Stat("/proc/self/status"); err == nil {
+		assert.Regexp(t, `(?m)^self\s+`+pid+`\s+[RS] \(\w+\)\s+\S+`, supervisor)
+	}
+}
+
+func TestCollectCertificates(t *testing.T) {
+	certDir := t.TempDir()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	notAfter := time.Now().Add(72*time.Hour + 30*time.Minute)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(certDir, "etcd"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "etcd", "ca.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "ca.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(certDir, "client.pem"), slices.Concat(
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	), 0600))
+
+	opts := Options{K0sVars: &config.CfgVars{
+		CertRootDir:    certDir,
+		KubeletRootDir: filepath.Join(t.TempDir(), "nonexistent"),
+	}}
+	files := collect(t, opts.collectCertificates)
+
+	certificates := files["bundle/certificates.txt"]
+	for _, name := range []string{"etcd/ca.crt", "client.pem"} {
+		assert.Regexp(t, `(?m)^`+filepath.Join(certDir, name)+`\s+CN=test\s+CN=test\s+`+notAfter.UTC().Format(time.RFC3339)+`\s+3d0h$`, certificates)
+	}
+	assert.NotContains(t, certificates, "ca.key")
+	assert.NotContains(t, certificates, "PRIVATE KEY")
+}
+
+func collect(t *testing.T, collect func(context.Context, *bundle) error) map[string]string {
+	var buf bytes.Buffer
+	require.NoError(t, write(t.Context(), &buf, "bundle", time.Minute, []collector{
+		{"test", collect},
+	}))
+	files := readBundle(t, &buf)
+	assert.Regexp(t, `^test: ok after \S+\n$`, files["bundle/collection.log"])
+	return files
+}
+
+func readBundle(t *testing.T, r io.Reader) map[string]string {
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, int64(0600), hdr.Mode, "For %s", hdr.Name)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+
+	return files
+}
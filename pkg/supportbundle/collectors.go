@@ -0,0 +1,504 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supportbundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/k0sproject/k0s/internal/pkg/dir"
+	"github.com/k0sproject/k0s/internal/pkg/file"
+	autopilotv1beta2 "github.com/k0sproject/k0s/pkg/apis/autopilot/v1beta2"
+	etcdv1beta1 "github.com/k0sproject/k0s/pkg/apis/etcd/v1beta1"
+	helmv1beta1 "github.com/k0sproject/k0s/pkg/apis/helm/v1beta1"
+	k0sv1beta1 "github.com/k0sproject/k0s/pkg/apis/k0s/v1beta1"
+	"github.com/k0sproject/k0s/pkg/build"
+	"github.com/k0sproject/k0s/pkg/component/status"
+	"github.com/k0sproject/k0s/pkg/component/worker/containerd"
+	kubeutil "github.com/k0sproject/k0s/pkg/kubernetes"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// The maximum number of bytes collected from a single log file. Larger files
+// are truncated at the beginning.
+const maxLogFileSize = 10 * 1024 * 1024
+
+// Indicates that a collector had nothing to collect.
+type skipped string
+
+func (s skipped) Error() string { return string(s) }
+
+func collectors(opts *Options) []collector {
+	return []collector{
+		{"version", collectVersion},
+		{"config", opts.collectConfig},
+		{"status", opts.collectStatus},
+		{"sysinfo", opts.collectSysinfo},
+		{"supervisor", opts.collectSupervisor},
+		{"certificates", opts.collectCertificates},
+		{"etcd", opts.collectEtcdStatus},
+		{"containerd", collectContainerdConfig},
+		{"kubelet", opts.collectKubeletConfig},
+		{"logs", opts.collectLogs},
+		{"kubernetes", opts.collectKubernetesObjects},
+	}
+}
+
+func collectVersion(_ context.Context, b *bundle) error {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, v := range []struct{ name, version string }{
+		{"k0s", build.Version},
+		{"runc", build.RuncVersion},
+		{"containerd", build.ContainerdVersion},
+		{"kubernetes", build.KubernetesVersion},
+		{"kine", build.KineVersion},
+		{"etcd", build.EtcdVersion},
+		{"konnectivity", build.KonnectivityVersion},
+	} {
+		fmt.Fprintf(w, "%s\t%s\n", v.name, v.version)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	b.add("version.txt", buf.Bytes())
+	return nil
+}
+
+// Collects the k0s configuration, both the one given at startup and the one
+// that k0s is actually running with.
+func (o *Options) collectConfig(_ context.Context, b *bundle) error {
+	var collected bool
+	var errs []error
+	for _, f := range []struct{ name, path string }{
+		{"config/k0s.yaml", o.K0sVars.StartupConfigPath},
+		{"config/runtime.yaml", o.K0sVars.RuntimeConfigPath},
+	} {
+		if f.path == "" {
+			continue
+		}
+		if err := addRedactedFile(b, f.name, f.path, RedactYAML); err == nil {
+			collected = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	if !collected && errs == nil {
+		return skipped("no configuration files found")
+	}
+	return errors.Join(errs...)
+}
+
+// Collects the output of "k0s status" and the component status. This requires
+// k0s to be running.
+func (o *Options) collectStatus(ctx context.Context, b *bundle) error {
+	statusInfo, err := status.GetStatusInfo(ctx, o.K0sVars.StatusSocketPath)
+	if err != nil {
+		return err
+	}
+	if err := addRedactedYAML(b, "status/status.yaml", statusInfo); err != nil {
+		return err
+	}
+
+	componentStatus, err := status.GetComponentStatus(ctx, o.K0sVars.StatusSocketPath, 10)
+	if err != nil {
+		return err
+	}
+	return addRedactedYAML(b, "status/components.yaml", componentStatus)
+}
+
+func (o *Options) collectSysinfo(ctx context.Context, b *bundle) error {
+	out, err := o.runK0s(ctx, "sysinfo", "--data-dir", o.K0sVars.DataDir)
+	b.add("sysinfo.txt", out)
+	return err
+}
+
+// Collects the state of the processes supervised by k0s, based on their PID
+// files.
+func (o *Options) collectSupervisor(_ context.Context, b *bundle) error {
+	pidFiles, err := filepath.Glob(filepath.Join(o.K0sVars.RunDir, "*.pid"))
+	if err != nil {
+		return err
+	}
+	if len(pidFiles) < 1 {
+		return skipped("no PID files found in " + o.K0sVars.RunDir)
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROCESS\tPID\tSTATE\tCOMMAND")
+	for _, pidFile := range pidFiles {
+		name := strings.TrimSuffix(filepath.Base(pidFile), ".pid")
+		pid, state, command := "-", "unknown", "-"
+
+		if data, err := os.ReadFile(pidFile); err != nil {
+			state = err.Error()
+		} else if pidNum, err := strconv.Atoi(strings.TrimSpace(string(data))); err != nil {
+			state = "invalid PID file"
+		} else {
+			pid = strconv.Itoa(pidNum)
+			state, command = processState(pidNum)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, pid, state, command)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	b.add("supervisor.txt", buf.Bytes())
+	return nil
+}
+
+// Looks up the state of a process in the proc filesystem.
+func processState(pid int) (state, command string) {
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	data, err := os.ReadFile(filepath.Join(procDir, "status"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "not running", "-"
+	} else if err != nil {
+		return err.Error(), "-"
+	}
+
+	state = "unknown"
+	for line := range strings.Lines(string(data)) {
+		if value, ok := strings.CutPrefix(line, "State:"); ok {
+			state = strings.TrimSpace(value)
+			break
+		}
+	}
+
+	command = "-"
+	if data, err := os.ReadFile(filepath.Join(procDir, "cmdline")); err == nil {
+		command = strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+	}
+
+	return state, command
+}
+
+// Collects the expiry dates of all certificates in the k0s and kubelet PKI
+// directories. Only metadata is collected, never any key material.
+func (o *Options) collectCertificates(_ context.Context, b *bundle) error {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tSUBJECT\tISSUER\tNOT AFTER\tEXPIRES IN")
+
+	var errs []error
+	now := time.Now()
+	for _, root := range []string{o.K0sVars.CertRootDir, filepath.Join(o.K0sVars.KubeletRootDir, "pki")} {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				if path == root && errors.Is(err, os.ErrNotExist) {
+					return nil
+				}
+				return err
+			case d.IsDir():
+				return nil
+			case filepath.Ext(path) != ".crt" && filepath.Ext(path) != ".pem":
+				return nil
+			}
+
+			certs, err := readCertificates(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", path, err))
+				return nil
+			}
+			for _, cert := range certs {
+				expiresIn := "expired"
+				if remaining := cert.NotAfter.Sub(now); remaining > 0 {
+					expiresIn = fmt.Sprintf("%dd%dh", int(remaining.Hours())/24, int(remaining.Hours())%24)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					path, cert.Subject, cert.Issuer,
+					cert.NotAfter.UTC().Format(time.RFC3339), expiresIn,
+				)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	b.add("certificates.txt", buf.Bytes())
+	return errors.Join(errs...)
+}
+
+// Parses all certificates from the given PEM file, ignoring any other blocks.
+func readCertificates(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
+
+// Collects the status of the etcd cluster by running "k0s etcd status". This
+// works as long as the local etcd member is reachable, even if the Kubernetes
+// API server is down.
+func (o *Options) collectEtcdStatus(ctx context.Context, b *bundle) error {
+	if !dir.IsDirectory(o.K0sVars.EtcdCertDir) {
+		return skipped("not an etcd-backed controller")
+	}
+
+	out, err := o.runK0s(ctx, "etcd", "status", "-o", "json", "--data-dir", o.K0sVars.DataDir)
+	if len(out) > 0 {
+		b.add("etcd/status.json", out)
+	}
+	return err
+}
+
+func collectContainerdConfig(_ context.Context, b *bundle) error {
+	paths := []string{containerd.DefaultConfPath}
+	if imports, err := filepath.Glob(filepath.Join(containerd.DefaultImportsPath, "*.toml")); err == nil {
+		paths = append(paths, imports...)
+	}
+
+	var collected bool
+	var errs []error
+	for _, path := range paths {
+		name := "containerd/" + filepath.Base(path)
+		if path != containerd.DefaultConfPath {
+			name = "containerd/containerd.d/" + filepath.Base(path)
+		}
+		if err := addRedactedFile(b, name, path, RedactTOML); err == nil {
+			collected = true
+		} else if !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	if !collected && errs == nil {
+		return skipped("no containerd configuration found")
+	}
+	return errors.Join(errs...)
+}
+
+func (o *Options) collectKubeletConfig(_ context.Context, b *bundle) error {
+	err := addRedactedFile(b, "kubelet/config.yaml", filepath.Join(o.K0sVars.RunDir, "kubelet", "config.yaml"), RedactYAML)
+	if errors.Is(err, os.ErrNotExist) {
+		return skipped("no kubelet configuration found")
+	}
+	return err
+}
+
+// Collects the k0s logs from journald, or from the log files of the other
+// service managers, as well as the per-component log files.
+func (o *Options) collectLogs(ctx context.Context, b *bundle) error {
+	var collected bool
+	var errs []error
+
+	if journalctl, err := exec.LookPath("journalctl"); err == nil {
+		out, err := runCommand(ctx, journalctl,
+			"--no-pager", "--output", "short-iso",
+			"--since", fmt.Sprintf("-%ds", int(o.Since.Seconds())),
+			"--unit", "k0scontroller", "--unit", "k0sworker",
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("journalctl: %w", err))
+		} else {
+			b.add("logs/journal.log", out)
+			collected = true
+		}
+	}
+
+	// The log files written by the service managers other than systemd.
+	serviceLogs, _ := filepath.Glob("/var/log/k0s*")
+	componentLogs, _ := filepath.Glob(filepath.Join(o.K0sVars.DataDir, "logs", "*"))
+	for _, f := range []struct {
+		dir   string
+		paths []string
+	}{
+		{"logs", serviceLogs},
+		{"logs/components", componentLogs},
+	} {
+		for _, path := range f.paths {
+			if !file.Exists(path) {
+				continue
+			}
+			data, err := readTail(path, maxLogFileSize)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			b.add(f.dir+"/"+filepath.Base(path), data)
+			collected = true
+		}
+	}
+
+	if !collected && errs == nil {
+		return skipped("no logs found")
+	}
+	return errors.Join(errs...)
+}
+
+// Reads at most the last limit bytes of the given file.
+func readTail(path string, limit int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if offset := stat.Size() - limit; offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	return io.ReadAll(io.LimitReader(f, limit))
+}
+
+// The Kubernetes objects to be collected. Secrets are never collected.
+var kubernetesObjects = []struct {
+	resource  schema.GroupVersionResource
+	namespace string
+}{
+	{corev1.SchemeGroupVersion.WithResource("nodes"), ""},
+	{corev1.SchemeGroupVersion.WithResource("pods"), metav1.NamespaceSystem},
+	{corev1.SchemeGroupVersion.WithResource("events"), metav1.NamespaceSystem},
+	{coordinationv1.SchemeGroupVersion.WithResource("leases"), corev1.NamespaceNodeLease},
+	{k0sv1beta1.SchemeGroupVersion.WithResource("clusterconfigs"), metav1.NamespaceSystem},
+	{etcdv1beta1.SchemeGroupVersion.WithResource("etcdmembers"), ""},
+	{helmv1beta1.SchemeGroupVersion.WithResource("charts"), metav1.NamespaceSystem},
+	{autopilotv1beta2.SchemeGroupVersion.WithResource("controlnodes"), ""},
+	{autopilotv1beta2.SchemeGroupVersion.WithResource("plans"), ""},
+}
+
+// Collects Kubernetes objects that are relevant for k0s. Uses the admin
+// kubeconfig on controllers and the kubelet's kubeconfig on workers.
+func (o *Options) collectKubernetesObjects(ctx context.Context, b *bundle) error {
+	kubeconfig := o.K0sVars.AdminKubeConfigPath
+	if !file.Exists(kubeconfig) {
+		kubeconfig = o.K0sVars.KubeletAuthConfigPath
+		if !file.Exists(kubeconfig) {
+			return skipped("no kubeconfig found")
+		}
+	}
+
+	restConfig, err := kubeutil.ClientConfig(kubeutil.KubeconfigFromFile(kubeconfig))
+	if err != nil {
+		return err
+	}
+	restConfig.Timeout = 10 * time.Second
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, obj := range kubernetesObjects {
+		list, err := client.Resource(obj.resource).Namespace(obj.namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", obj.resource.GroupResource(), err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		name := obj.resource.GroupResource().String()
+		if obj.namespace != "" {
+			name = obj.namespace + "/" + name
+		}
+		if err := addRedactedYAML(b, "kubernetes/"+name+".yaml", list.UnstructuredContent()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Runs a k0s subcommand and returns its standard output.
+func (o *Options) runK0s(ctx context.Context, args ...string) ([]byte, error) {
+	return runCommand(ctx, o.Executable, args...)
+}
+
+// Runs a command and returns its standard output. The standard error is
+// included in the returned error, if the command fails.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.Bytes(), fmt.Errorf("%w: %s", err, msg)
+		}
+		return stdout.Bytes(), err
+	}
+	return stdout.Bytes(), nil
+}
+
+func addRedactedFile(b *bundle, name, path string, redact func([]byte) ([]byte, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err = redact(data)
+	if err != nil {
+		return fmt.Errorf("failed to redact %s: %w", path, err)
+	}
+	b.add(name, data)
+	return nil
+}
+
+func addRedactedYAML(b *bundle, name string, v any) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if data, err = RedactYAML(data); err != nil {
+		return err
+	}
+	b.add(name, data)
+	return nil
+}
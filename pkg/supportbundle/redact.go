@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supportbundle
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"sigs.k8s.io/yaml"
+)
+
+// The value that replaces redacted secrets.
+const Redacted = "<redacted>"
+
+// Substrings of keys whose values are considered to be secret. Keys are
+// compared case-insensitively, ignoring dashes and underscores.
+var secretKeySubstrings = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"credential",
+	"privatekey",
+	"apikey",
+	"datasource",
+}
+
+// Keys whose values are considered to be secret, if they match exactly.
+var secretKeys = []string{
+	"auth",
+	"identitytoken",
+}
+
+// RedactYAML replaces the values of all secret-looking keys in the given YAML
+// or JSON document with [Redacted]. The document is re-encoded as YAML.
+func RedactYAML(data []byte) ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(redact(doc))
+}
+
+// RedactTOML replaces the values of all secret-looking keys in the given TOML
+// document with [Redacted]. The document is re-encoded as TOML.
+func RedactTOML(data []byte) ([]byte, error) {
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(redact(doc)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "env" {
+				// Container environment variables: the names don't tell
+				// anything about the secrecy of the values.
+				v[key] = redactEnv(value)
+			} else if values, ok := value.(string); ok && key == "values" {
+				v[key] = redactValues(values)
+			} else if isSecretKey(key) {
+				if value != nil {
+					v[key] = Redacted
+				}
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	case []map[string]any:
+		for _, item := range v {
+			redact(item)
+		}
+	}
+	return v
+}
+
+func redactEnv(v any) any {
+	vars, ok := v.([]any)
+	if !ok {
+		return redact(v)
+	}
+	for _, envVar := range vars {
+		if envVar, ok := envVar.(map[string]any); ok {
+			if _, ok := envVar["value"]; ok {
+				envVar["value"] = Redacted
+			}
+		}
+	}
+	return vars
+}
+
+// Redacts Helm chart values, which are embedded as a YAML string. Values that
+// can't be parsed are redacted as a whole.
+func redactValues(values string) string {
+	var doc any
+	if err := yaml.Unmarshal([]byte(values), &doc); err != nil {
+		return Redacted
+	}
+	if doc == nil {
+		return values
+	}
+	redacted, err := yaml.Marshal(redact(doc))
+	if err != nil {
+		return Redacted
+	}
+	return string(redacted)
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+	for _, secretKey := range secretKeys {
+		if key == secretKey {
+			return true
+		}
+	}
+	for _, substring := range secretKeySubstrings {
+		if strings.Contains(key, substring) {
+			return true
+		}
+	}
+	return false
+}
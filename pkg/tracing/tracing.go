@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing sets up OpenTelemetry tracing for the k0s process.
+//
+// Packages create their spans using [otel.Tracer]. As long as [Setup] hasn't
+// been called, the global tracer provider is a no-op, so spans come at almost
+// no cost when tracing is disabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/k0sproject/k0s/pkg/build"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options configure where traces are exported to.
+type Options struct {
+	// The URL of an OTLP/gRPC endpoint, e.g. http://localhost:4317.
+	Endpoint string
+	// The path of a file to which spans are written as JSON, one per line.
+	File string
+}
+
+// Enabled returns true if any exporter has been configured.
+func (o *Options) Enabled() bool {
+	return o.Endpoint != "" || o.File != ""
+}
+
+// Setup installs a global tracer provider that exports traces as configured by
+// opts. The returned function flushes any pending spans and shuts down the
+// tracer provider. If no exporter has been configured, Setup does nothing.
+func Setup(ctx context.Context, role string, opts Options) (shutdown func(context.Context) error, _ error) {
+	if !opts.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var (
+		exporters []sdktrace.SpanExporter
+		closers   []func() error
+	)
+	cleanup := func() {
+		for _, exporter := range exporters {
+			_ = exporter.Shutdown(context.Background())
+		}
+		for _, close := range closers {
+			_ = close()
+		}
+	}
+
+	if opts.Endpoint != "" {
+		exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(opts.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		closers = append(closers, f.Close)
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to create file trace exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("k0s"),
+		semconv.ServiceVersion(build.Version),
+		attribute.String("k0s.role", role),
+	))
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	for _, exporter := range exporters {
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		for _, close := range closers {
+			err = errors.Join(err, close())
+		}
+		return err
+	}, nil
+}
+
+// RecordError records err in span and marks it as failed, if err isn't nil.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// End records err in span, if any, and ends it.
+func End(span trace.Span, err error) {
+	RecordError(span, err)
+	span.End()
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 k0s authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(t.Context(), "test", Options{})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(t.Context()))
+
+	_, span := otel.Tracer("test").Start(t.Context(), "test")
+	defer span.End()
+	assert.False(t, span.IsRecording())
+}
+
+func TestSetup_File(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	traceFile := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(t.Context(), "test", Options{File: traceFile})
+	require.NoError(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(t.Context(), "parent")
+	_, child := otel.Tracer("test").Start(ctx, "child")
+	End(child, assert.AnError)
+	End(parent, nil)
+
+	require.NoError(t, shutdown(t.Context()))
+
+	data, err := os.ReadFile(traceFile)
+	require.NoError(t, err)
+
+	type span struct {
+		Name        string
+		SpanContext struct{ TraceID string }
+		Parent      struct{ SpanID string }
+		Status      struct{ Code string }
+		Resource    []struct {
+			Key   string
+			Value struct{ Value any }
+		}
+	}
+
+	var spans []span
+	for line := range bytes.Lines(data) {
+		var s span
+		require.NoError(t, json.Unmarshal(line, &s), "%s", line)
+		spans = append(spans, s)
+	}
+
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "Error", spans[0].Status.Code)
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, "Unset", spans[1].Status.Code)
+	assert.Equal(t, spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, trace.SpanID{}.String(), spans[1].Parent.SpanID)
+
+	resource := make(map[string]any)
+	for _, attr := range spans[0].Resource {
+		resource[attr.Key] = attr.Value.Value
+	}
+	assert.Equal(t, "k0s", resource["service.name"])
+	assert.Equal(t, "test", resource["k0s.role"])
+}
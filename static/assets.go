@@ -21,6 +21,12 @@ var (
 	CalicoManifests fs.FS = subFS(calicoManifests, "manifests", "calico")
 )
 
+var (
+	//go:embed manifests/cilium
+	ciliumManifests embed.FS
+	CiliumManifests fs.FS = subFS(ciliumManifests, "manifests", "cilium")
+)
+
 var (
 	//go:embed manifests/windows
 	windowsManifests embed.FS